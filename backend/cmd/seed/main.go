@@ -1,19 +1,37 @@
 package main
 
 import (
+	"flag"
+	"fmt"
 	"log"
-	"os"
 
 	"github.com/bbp/backend/config"
 	"github.com/bbp/backend/internal/repository/sqlite"
+	"github.com/bbp/backend/pkg/catalog"
 	"github.com/bbp/backend/pkg/database"
 	"github.com/bbp/backend/pkg/seed"
 	"github.com/bbp/backend/internal/repository/models"
 )
 
 func main() {
+	catalogPath := flag.String("catalog", "", "path to a catalog file or directory (default: built-in catalog)")
+	dryRun := flag.Bool("dry-run", false, "print the changes without writing them")
+	flag.Parse()
+
 	log.Println("Starting seed command...")
 
+	// Загружаем каталог игр и карт
+	var cat *catalog.Catalog
+	var err error
+	if *catalogPath != "" {
+		cat, err = catalog.Load(*catalogPath)
+	} else {
+		cat, err = catalog.LoadDefault()
+	}
+	if err != nil {
+		log.Fatalf("Failed to load catalog: %v", err)
+	}
+
 	// Загружаем конфигурацию
 	cfg := config.Load()
 
@@ -35,20 +53,33 @@ func main() {
 		&models.VetoActionModel{},
 		&models.RoomModel{},
 		&models.RoomParticipantModel{},
+		&models.MapRotationModel{},
+		&models.VetoSessionMapModel{},
 	); err != nil {
 		log.Fatalf("Failed to run migrations: %v", err)
 	}
 
 	// Инициализируем репозитории
-	gameRepo := sqlite.NewGameRepository(db)
-	mapRepo := sqlite.NewMapRepository(db)
-	mapPoolRepo := sqlite.NewMapPoolRepository(db)
+	repos := seed.Repositories{
+		Games:     sqlite.NewGameRepository(db),
+		Maps:      sqlite.NewMapRepository(db),
+		MapPools:  sqlite.NewMapPoolRepository(db),
+		Rotations: sqlite.NewMapRotationRepository(db),
+	}
 
-	// Выполняем seed
-	if err := seed.Seed(gameRepo, mapRepo, mapPoolRepo); err != nil {
+	// Выполняем импорт каталога
+	report, err := seed.Import(cat, repos, seed.Options{DryRun: *dryRun})
+	if err != nil {
 		log.Fatalf("Failed to seed database: %v", err)
-		os.Exit(1)
 	}
 
-	log.Println("Seed completed successfully!")
+	for _, change := range report.Changes {
+		fmt.Println(change.String())
+	}
+
+	if report.DryRun {
+		log.Printf("Dry run: %d changes would be applied", len(report.Changes))
+		return
+	}
+	log.Printf("Seed completed successfully! %d changes applied", len(report.Changes))
 }
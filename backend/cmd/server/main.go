@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
 	"os/signal"
@@ -10,15 +11,22 @@ import (
 	"github.com/bbp/backend/config"
 	"github.com/bbp/backend/pkg/database"
 	"github.com/bbp/backend/pkg/jwt"
+	"github.com/bbp/backend/pkg/mailer"
+	"github.com/bbp/backend/pkg/oauth"
+	"github.com/bbp/backend/pkg/resultcard"
 	"github.com/bbp/backend/internal/handler/http"
 	"github.com/bbp/backend/internal/middleware"
 	"github.com/bbp/backend/internal/repository/models"
 	"github.com/bbp/backend/internal/repository/sqlite"
+	"github.com/bbp/backend/internal/usecase/apikey"
 	"github.com/bbp/backend/internal/usecase/auth"
 	"github.com/bbp/backend/internal/usecase/user"
 	"github.com/bbp/backend/internal/usecase/veto"
 	"github.com/bbp/backend/internal/usecase/map_pool"
 	"github.com/bbp/backend/internal/usecase/room"
+	"github.com/bbp/backend/internal/usecase/team"
+	"github.com/bbp/backend/internal/usecase/tournament"
+	"github.com/bbp/backend/internal/usecase/webhook"
 	"github.com/bbp/backend/internal/handler/websocket"
 	ws "github.com/bbp/backend/pkg/websocket"
 	"github.com/gin-gonic/gin"
@@ -45,6 +53,38 @@ func main() {
 		&models.VetoActionModel{},
 		&models.RoomModel{},
 		&models.RoomParticipantModel{},
+		&models.RoomMatchModel{},
+		&models.MapRotationModel{},
+		&models.VetoSessionMapModel{},
+		&models.VetoSessionConstraintModel{},
+		&models.RefreshTokenModel{},
+		&models.RevokedTokenModel{},
+		&models.PasswordResetTokenModel{},
+		&models.EmailVerificationTokenModel{},
+		&models.UserIdentityModel{},
+		&models.OAuthStateModel{},
+		&models.UserTOTPModel{},
+		&models.RecoveryCodeModel{},
+		&models.LoginChallengeModel{},
+		&models.LoginThrottleModel{},
+		&models.LockoutEventModel{},
+		&models.TeamModel{},
+		&models.TeamMemberModel{},
+		&models.TeamInviteModel{},
+		&models.MatchResultModel{},
+		&models.MapResultModel{},
+		&models.MatchResultPlayerModel{},
+		&models.TournamentModel{},
+		&models.TournamentTeamModel{},
+		&models.TournamentMatchModel{},
+		&models.TournamentStageRuleModel{},
+		&models.TournamentStageMapModel{},
+		&models.WebhookModel{},
+		&models.WebhookEventModel{},
+		&models.WebhookDeliveryModel{},
+		&models.APIKeyModel{},
+		&models.APIKeyScopeModel{},
+		&models.APIKeyRoomModel{},
 	); err != nil {
 		log.Fatalf("Failed to run migrations: %v", err)
 	}
@@ -58,20 +98,16 @@ func main() {
 
 	router := gin.Default()
 
+	// IP клиента (лимиты, блокировка входа, журнал ключей) берется из заголовков прокси только для доверенных адресов,
+	// иначе любой клиент мог бы подставить себе X-Forwarded-For
+	if err := router.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+		log.Fatalf("Invalid TRUSTED_PROXIES: %v", err)
+	}
+
 	// Подключаем middleware
 	router.Use(middleware.CORSMiddleware(cfg.CORSOrigin))
 	router.Use(middleware.ErrorHandlerMiddleware())
 	
-	// Rate limiting для всех endpoints
-	// В development используем более мягкие лимиты, в production - строгие
-	if cfg.Environment == "development" {
-		// Для development: 500 запросов в минуту (более мягкие лимиты)
-		router.Use(middleware.RateLimitMiddleware(500, time.Minute))
-	} else {
-		// Для production: 100 запросов в минуту (стандартные лимиты)
-		router.Use(middleware.DefaultRateLimitMiddleware())
-	}
-	
 	// Строгий rate limiting для auth endpoints
 	// В development используем более мягкие лимиты
 	var authRateLimit gin.HandlerFunc
@@ -98,61 +134,298 @@ func main() {
 	userRepo := sqlite.NewUserRepository(db)
 	vetoSessionRepo := sqlite.NewVetoSessionRepository(db)
 	vetoActionRepo := sqlite.NewVetoActionRepository(db)
+	vetoStatsRepo := sqlite.NewVetoStatsRepository(db)
+	matchResultRepo := sqlite.NewMatchResultRepository(db)
+	teamRepo := sqlite.NewTeamRepository(db)
+	teamInviteRepo := sqlite.NewTeamInviteRepository(db)
+	tournamentRepo := sqlite.NewTournamentRepository(db)
+	webhookRepo := sqlite.NewWebhookRepository(db)
+	webhookDeliveryRepo := sqlite.NewWebhookDeliveryRepository(db)
+	apiKeyRepo := sqlite.NewAPIKeyRepository(db)
 	roomRepo := sqlite.NewRoomRepository(db)
 	mapRepo := sqlite.NewMapRepository(db)
 	mapPoolRepo := sqlite.NewMapPoolRepository(db)
 	gameRepo := sqlite.NewGameRepository(db)
+	mapRotationRepo := sqlite.NewMapRotationRepository(db)
+	refreshTokenRepo := sqlite.NewRefreshTokenRepository(db)
+	revokedTokenRepo := sqlite.NewRevokedTokenRepository(db)
+	passwordResetTokenRepo := sqlite.NewPasswordResetTokenRepository(db)
+	emailVerificationTokenRepo := sqlite.NewEmailVerificationTokenRepository(db)
+	userIdentityRepo := sqlite.NewUserIdentityRepository(db)
+	oauthStateRepo := sqlite.NewOAuthStateRepository(db)
+	twoFactorRepo := sqlite.NewTwoFactorRepository(db)
+	recoveryCodeRepo := sqlite.NewRecoveryCodeRepository(db)
+	loginChallengeRepo := sqlite.NewLoginChallengeRepository(db)
+	loginThrottleRepo := sqlite.NewLoginThrottleRepository(db)
+	lockoutEventRepo := sqlite.NewLockoutEventRepository(db)
+
+	// Отозванные access токены проверяются при каждой валидации
+	jwtService.SetDenylist(revokedTokenRepo)
+
+	// Персональные API ключи: у запросов по ключу свой лимит, поэтому ключ проверяется до общего rate limiting
+	apiKeyAuthenticator := apikey.NewAuthenticator(apiKeyRepo, userRepo, roomRepo)
+	router.Use(middleware.APIKeyMiddleware(apiKeyAuthenticator, cfg.APIKeyRateLimit, time.Minute))
+
+	// Rate limiting для всех endpoints
+	// В development используем более мягкие лимиты, в production - строгие
+	if cfg.Environment == "development" {
+		// Для development: 500 запросов в минуту (более мягкие лимиты)
+		router.Use(middleware.RateLimitMiddleware(500, time.Minute))
+	} else {
+		// Для production: 100 запросов в минуту (стандартные лимиты)
+		router.Use(middleware.DefaultRateLimitMiddleware())
+	}
+	
+	// Инициализируем отправку писем
+	appMailer, err := mailer.New(cfg.Mailer, cfg.MailerDir)
+	if err != nil {
+		log.Fatalf("Failed to initialize mailer: %v", err)
+	}
 
 	// Инициализируем use cases для авторизации
-	registerUseCase := auth.NewRegisterUseCase(userRepo, jwtService)
-	loginUseCase := auth.NewLoginUseCase(userRepo, jwtService)
+	tokenIssuer := auth.NewTokenIssuer(jwtService, refreshTokenRepo, cfg.RefreshTokenExpiry)
+	emailVerifier := auth.NewEmailVerifier(
+		emailVerificationTokenRepo,
+		appMailer,
+		cfg.AppURL+"/verify-email",
+		cfg.EmailVerificationExpiry,
+	)
+	// Challenge второго шага входа живет 5 минут
+	twoFactorVerifier := auth.NewTwoFactorVerifier(twoFactorRepo, recoveryCodeRepo, loginChallengeRepo, 5*time.Minute)
+	registerUseCase := auth.NewRegisterUseCase(userRepo, tokenIssuer, emailVerifier)
+	// Блокировка входа по аккаунту и IP после неудачных попыток
+	loginThrottlePolicy := auth.DefaultLoginThrottlePolicy()
+	loginThrottlePolicy.AccountMaxFailures = cfg.LoginMaxFailures
+	loginThrottlePolicy.IPMaxFailures = cfg.LoginIPMaxFailures
+	loginThrottlePolicy.BaseLockout = cfg.LoginLockout
+	loginThrottlePolicy.MaxLockout = cfg.LoginMaxLockout
+	loginThrottler := auth.NewLoginThrottler(loginThrottleRepo, lockoutEventRepo, loginThrottlePolicy)
+	loginUseCase := auth.NewLoginUseCase(userRepo, tokenIssuer, twoFactorVerifier, loginThrottler)
 	getCurrentUserUseCase := auth.NewGetCurrentUserUseCase(userRepo)
+	refreshUseCase := auth.NewRefreshUseCase(userRepo, refreshTokenRepo, revokedTokenRepo, tokenIssuer)
+	logoutUseCase := auth.NewLogoutUseCase(refreshTokenRepo, revokedTokenRepo)
+	logoutAllUseCase := auth.NewLogoutAllUseCase(refreshTokenRepo, revokedTokenRepo)
+	requestResetUseCase := auth.NewRequestPasswordResetUseCase(
+		userRepo,
+		passwordResetTokenRepo,
+		appMailer,
+		cfg.AppURL+"/reset-password",
+		cfg.PasswordResetExpiry,
+	)
+	resetPasswordUseCase := auth.NewResetPasswordUseCase(userRepo, passwordResetTokenRepo, refreshTokenRepo, revokedTokenRepo)
+	verifyEmailUseCase := auth.NewVerifyEmailUseCase(userRepo, emailVerificationTokenRepo)
+	resendVerificationUseCase := auth.NewResendVerificationUseCase(userRepo, emailVerifier)
+
+	// Гостевые аккаунты для быстрого входа в комнату
+	createGuestUseCase := auth.NewCreateGuestUseCase(userRepo, tokenIssuer, cfg.GuestSessionExpiry)
+	upgradeGuestUseCase := auth.NewUpgradeGuestUseCase(userRepo, refreshTokenRepo, revokedTokenRepo, tokenIssuer, emailVerifier)
+
+	// Двухфакторная аутентификация (TOTP)
+	getTwoFactorStatusUseCase := auth.NewGetTwoFactorStatusUseCase(twoFactorRepo, recoveryCodeRepo)
+	setupTwoFactorUseCase := auth.NewSetupTwoFactorUseCase(userRepo, twoFactorRepo, cfg.TOTPIssuer)
+	confirmTwoFactorUseCase := auth.NewConfirmTwoFactorUseCase(twoFactorRepo, recoveryCodeRepo)
+	disableTwoFactorUseCase := auth.NewDisableTwoFactorUseCase(userRepo, twoFactorRepo, recoveryCodeRepo, twoFactorVerifier)
+	verifyLoginChallengeUseCase := auth.NewVerifyLoginChallengeUseCase(userRepo, loginChallengeRepo, twoFactorVerifier, tokenIssuer, loginThrottler)
+
+	// Администрирование
+	unlockAccountUseCase := auth.NewUnlockAccountUseCase(userRepo, loginThrottler)
+	getLockoutEventsUseCase := auth.NewGetLockoutEventsUseCase(lockoutEventRepo)
+
+	// Вход через OAuth2/OIDC провайдеров
+	oauthProviders := buildOAuthProviders(cfg)
+	oauthStartUseCase := auth.NewOAuthStartUseCase(oauthProviders, oauthStateRepo)
+	oauthCallbackUseCase := auth.NewOAuthCallbackUseCase(oauthProviders, oauthStateRepo, userIdentityRepo, userRepo, tokenIssuer, twoFactorVerifier)
 
 	// Инициализируем use cases для пользователя
 	getProfileUseCase := user.NewGetProfileUseCase(userRepo)
-	updateProfileUseCase := user.NewUpdateProfileUseCase(userRepo)
+	updateProfileUseCase := user.NewUpdateProfileUseCase(userRepo, passwordResetTokenRepo, emailVerifier)
 	getSessionsUseCase := user.NewGetSessionsUseCase(vetoSessionRepo)
 	getRoomsUseCase := user.NewGetRoomsUseCase(roomRepo)
+	changePasswordUseCase := user.NewChangePasswordUseCase(userRepo)
+	deleteAccountUseCase := user.NewDeleteAccountUseCase(userRepo, roomRepo, mapPoolRepo, vetoSessionRepo, teamRepo, logoutAllUseCase)
+	cleanupGuestsUseCase := user.NewCleanupGuestsUseCase(userRepo, deleteAccountUseCase, cfg.GuestSessionExpiry)
+	exportDataUseCase := user.NewExportDataUseCase(userRepo, vetoSessionRepo, roomRepo, mapPoolRepo, userIdentityRepo, teamRepo)
+	getPublicProfileUseCase := user.NewGetPublicProfileUseCase(userRepo, vetoStatsRepo)
 
 	// Инициализируем VetoLogicService
 	vetoLogicService := veto.NewVetoLogicService()
 
 	// Инициализируем use cases для veto
-	createSessionUseCase := veto.NewCreateSessionUseCase(vetoSessionRepo, mapPoolRepo, gameRepo, vetoLogicService)
+	createSessionUseCase := veto.NewCreateSessionUseCase(vetoSessionRepo, mapPoolRepo, gameRepo, mapRotationRepo, teamRepo, vetoLogicService)
 	getSessionUseCase := veto.NewGetSessionUseCase(vetoSessionRepo)
 	getNextActionUseCase := veto.NewGetNextActionUseCase(vetoSessionRepo, mapPoolRepo, vetoLogicService)
 	banMapUseCase := veto.NewBanMapUseCase(vetoSessionRepo, vetoActionRepo, mapRepo, mapPoolRepo, vetoLogicService)
 	pickMapUseCase := veto.NewPickMapUseCase(vetoSessionRepo, vetoActionRepo, mapRepo, mapPoolRepo, vetoLogicService)
 	selectSideUseCase := veto.NewSelectSideUseCase(vetoSessionRepo, vetoActionRepo, mapPoolRepo, vetoLogicService)
-	resetSessionUseCase := veto.NewResetSessionUseCase(vetoSessionRepo, vetoActionRepo)
+	resetSessionUseCase := veto.NewResetSessionUseCase(vetoSessionRepo, vetoActionRepo, matchResultRepo)
 	startSessionUseCase := veto.NewStartSessionUseCase(vetoSessionRepo)
+	getOverlayUseCase := veto.NewGetOverlayUseCase(vetoSessionRepo, mapPoolRepo, vetoLogicService)
+	exportSessionUseCase := veto.NewExportSessionUseCase(vetoSessionRepo, gameRepo, getOverlayUseCase)
+
+	// Инициализируем use cases для результатов серий
+	reportResultUseCase := veto.NewReportResultUseCase(vetoSessionRepo, matchResultRepo, teamRepo)
+	confirmResultUseCase := veto.NewConfirmResultUseCase(vetoSessionRepo, matchResultRepo, teamRepo)
+	disputeResultUseCase := veto.NewDisputeResultUseCase(vetoSessionRepo, matchResultRepo, teamRepo)
+	getResultUseCase := veto.NewGetResultUseCase(vetoSessionRepo, matchResultRepo)
 
 	// Инициализируем use cases для map pools
-	getPoolsUseCase := map_pool.NewGetPoolsUseCase(mapPoolRepo, gameRepo)
+	getPoolsUseCase := map_pool.NewGetPoolsUseCase(mapPoolRepo, gameRepo, vetoLogicService)
 	getPoolUseCase := map_pool.NewGetPoolUseCase(mapPoolRepo)
 	createCustomPoolUseCase := map_pool.NewCreateCustomPoolUseCase(mapPoolRepo, mapRepo, gameRepo)
 	deletePoolUseCase := map_pool.NewDeletePoolUseCase(mapPoolRepo)
+	updatePoolUseCase := map_pool.NewUpdatePoolUseCase(mapPoolRepo, vetoSessionRepo)
+	addMapUseCase := map_pool.NewAddMapUseCase(mapPoolRepo, mapRepo, vetoSessionRepo)
+	removeMapUseCase := map_pool.NewRemoveMapUseCase(mapPoolRepo, vetoSessionRepo)
+	duplicatePoolUseCase := map_pool.NewDuplicatePoolUseCase(mapPoolRepo)
+	getPublicPoolsUseCase := map_pool.NewGetPublicPoolsUseCase(mapPoolRepo)
+	getSharedPoolUseCase := map_pool.NewGetSharedPoolUseCase(mapPoolRepo)
+	forkPoolUseCase := map_pool.NewForkPoolUseCase(mapPoolRepo)
 
 	// Инициализируем use cases для rooms
-	createRoomUseCase := room.NewCreateRoomUseCase(roomRepo, gameRepo, mapPoolRepo)
+	createRoomUseCase := room.NewCreateRoomUseCase(roomRepo, gameRepo, mapPoolRepo, teamRepo, vetoLogicService)
 	getRoomUseCase := room.NewGetRoomUseCase(roomRepo)
 	getRoomBySessionUseCase := room.NewGetRoomBySessionUseCase(roomRepo)
 	getRoomsListUseCase := room.NewGetRoomsListUseCase(roomRepo)
-	joinRoomUseCase := room.NewJoinRoomUseCase(roomRepo)
+	joinRoomUseCase := room.NewJoinRoomUseCase(roomRepo, teamRepo)
 	leaveRoomUseCase := room.NewLeaveRoomUseCase(roomRepo)
 	deleteRoomUseCase := room.NewDeleteRoomUseCase(roomRepo)
-	updateRoomUseCase := room.NewUpdateRoomUseCase(roomRepo)
+	updateRoomUseCase := room.NewUpdateRoomUseCase(roomRepo, mapPoolRepo, teamRepo, vetoLogicService)
+	startVetoUseCase := room.NewStartVetoUseCase(roomRepo, vetoSessionRepo, createSessionUseCase, startSessionUseCase)
+	getRoomMatchesUseCase := room.NewGetRoomMatchesUseCase(roomRepo, vetoSessionRepo)
+
+	// Инициализируем use cases для команд
+	createTeamUseCase := team.NewCreateTeamUseCase(teamRepo)
+	getTeamUseCase := team.NewGetTeamUseCase(teamRepo)
+	getUserTeamsUseCase := team.NewGetUserTeamsUseCase(teamRepo)
+	updateTeamUseCase := team.NewUpdateTeamUseCase(teamRepo)
+	deleteTeamUseCase := team.NewDeleteTeamUseCase(teamRepo)
+	inviteMemberUseCase := team.NewInviteMemberUseCase(teamRepo, teamInviteRepo, userRepo)
+	getInvitesUseCase := team.NewGetInvitesUseCase(teamInviteRepo)
+	respondInviteUseCase := team.NewRespondInviteUseCase(teamRepo, teamInviteRepo)
+	removeMemberUseCase := team.NewRemoveMemberUseCase(teamRepo)
+	updateMemberUseCase := team.NewUpdateMemberUseCase(teamRepo)
+	getTeamSessionsUseCase := team.NewGetTeamSessionsUseCase(teamRepo, vetoSessionRepo)
+	getTeamStatsUseCase := team.NewGetTeamStatsUseCase(teamRepo, vetoStatsRepo)
+
+	// Инициализируем use cases для турниров; подтвержденные результаты матчей продвигают сетку
+	createMatchRoomUseCase := room.NewCreateMatchRoomUseCase(roomRepo, vetoSessionRepo, createSessionUseCase)
+	stageConstraintsService := tournament.NewStageConstraintsService(vetoSessionRepo, matchResultRepo, mapPoolRepo, vetoLogicService)
+	createTournamentUseCase := tournament.NewCreateTournamentUseCase(tournamentRepo, gameRepo, mapPoolRepo, vetoLogicService)
+	getTournamentUseCase := tournament.NewGetTournamentUseCase(tournamentRepo)
+	getTournamentsUseCase := tournament.NewGetTournamentsUseCase(tournamentRepo)
+	deleteTournamentUseCase := tournament.NewDeleteTournamentUseCase(tournamentRepo)
+	registerTournamentTeamUseCase := tournament.NewRegisterTeamUseCase(tournamentRepo, teamRepo)
+	withdrawTournamentTeamUseCase := tournament.NewWithdrawTeamUseCase(tournamentRepo, teamRepo)
+	seedTournamentTeamsUseCase := tournament.NewSeedTeamsUseCase(tournamentRepo)
+	startTournamentUseCase := tournament.NewStartTournamentUseCase(tournamentRepo, createMatchRoomUseCase, stageConstraintsService)
+	scheduleTournamentMatchUseCase := tournament.NewScheduleMatchUseCase(tournamentRepo)
+	advanceBracketUseCase := tournament.NewAdvanceBracketUseCase(tournamentRepo, createMatchRoomUseCase, stageConstraintsService)
+	reportResultUseCase.SetResultListener(advanceBracketUseCase)
+	confirmResultUseCase.SetResultListener(advanceBracketUseCase)
+
+	// Исходящие webhooks: доставки хранятся в журнале и отправляются в фоне с повторами
+	// Получатели на локальных и внутренних адресах запрещены, иначе подписка позволяла бы обращаться к внутренней сети
+	webhookPolicy := webhook.DefaultDeliveryPolicy()
+	webhookPolicy.AllowPrivateNetworks = cfg.WebhookAllowPrivateNetworks
+	webhookDispatcher := webhook.NewDispatcher(webhookRepo, webhookDeliveryRepo, webhookPolicy)
+	go webhookDispatcher.Run(context.Background())
+	createWebhookUseCase := webhook.NewCreateWebhookUseCase(webhookRepo, roomRepo)
+	getWebhooksUseCase := webhook.NewGetWebhooksUseCase(webhookRepo)
+	updateWebhookUseCase := webhook.NewUpdateWebhookUseCase(webhookRepo)
+	deleteWebhookUseCase := webhook.NewDeleteWebhookUseCase(webhookRepo)
+	getWebhookDeliveriesUseCase := webhook.NewGetDeliveriesUseCase(webhookRepo, webhookDeliveryRepo)
+	redeliverWebhookUseCase := webhook.NewRedeliverUseCase(webhookRepo, webhookDeliveryRepo, webhookDispatcher)
+	pingWebhookUseCase := webhook.NewPingWebhookUseCase(webhookRepo, webhookDispatcher)
+
+	// Инициализируем use cases для API ключей
+	createAPIKeyUseCase := apikey.NewCreateAPIKeyUseCase(apiKeyRepo, roomRepo)
+	getAPIKeysUseCase := apikey.NewGetAPIKeysUseCase(apiKeyRepo)
+	revokeAPIKeyUseCase := apikey.NewRevokeAPIKeyUseCase(apiKeyRepo)
 
 	// Инициализируем handlers
-	authHandler := http.NewAuthHandler(registerUseCase, loginUseCase, getCurrentUserUseCase)
-	userHandler := http.NewUserHandler(getProfileUseCase, updateProfileUseCase, getSessionsUseCase, getRoomsUseCase)
+	authHandler := http.NewAuthHandler(
+		registerUseCase,
+		loginUseCase,
+		getCurrentUserUseCase,
+		refreshUseCase,
+		logoutUseCase,
+		logoutAllUseCase,
+		requestResetUseCase,
+		resetPasswordUseCase,
+		verifyEmailUseCase,
+		resendVerificationUseCase,
+	)
+	guestHandler := http.NewGuestHandler(createGuestUseCase, upgradeGuestUseCase)
+	twoFactorHandler := http.NewTwoFactorHandler(
+		getTwoFactorStatusUseCase,
+		setupTwoFactorUseCase,
+		confirmTwoFactorUseCase,
+		disableTwoFactorUseCase,
+		verifyLoginChallengeUseCase,
+	)
+	adminHandler := http.NewAdminHandler(unlockAccountUseCase, getLockoutEventsUseCase)
+	oauthHandler := http.NewOAuthHandler(oauthStartUseCase, oauthCallbackUseCase, cfg.AppURL)
+	userHandler := http.NewUserHandler(
+		getProfileUseCase,
+		updateProfileUseCase,
+		getSessionsUseCase,
+		getRoomsUseCase,
+		changePasswordUseCase,
+		deleteAccountUseCase,
+		exportDataUseCase,
+		getPublicProfileUseCase,
+	)
 	// Инициализируем WebSocket manager (нужен для RoomHandler)
 	wsManager := ws.NewManager()
 	go wsManager.Run()
 
+	matchResultHandler := http.NewMatchResultHandler(reportResultUseCase, confirmResultUseCase, disputeResultUseCase, getResultUseCase)
 	vetoHandler := http.NewVetoHandler(createSessionUseCase, getSessionUseCase, getNextActionUseCase, banMapUseCase, pickMapUseCase, selectSideUseCase, resetSessionUseCase, startSessionUseCase, mapPoolRepo, roomRepo, wsManager)
-	mapPoolHandler := http.NewMapPoolHandler(getPoolsUseCase, getPoolUseCase, createCustomPoolUseCase, deletePoolUseCase)
-	roomHandler := http.NewRoomHandler(createRoomUseCase, getRoomUseCase, getRoomBySessionUseCase, getRoomsListUseCase, joinRoomUseCase, leaveRoomUseCase, deleteRoomUseCase, updateRoomUseCase, wsManager)
+	vetoHandler.SetWebhookDispatcher(webhookDispatcher)
+	overlayHandler := http.NewOverlayHandler(getOverlayUseCase)
+	mapImageLoader, err := resultcard.NewImageLoader(cfg.MapImageBaseURL)
+	if err != nil {
+		log.Fatalf("Invalid MAP_IMAGE_BASE_URL: %v", err)
+	}
+	exportHandler := http.NewExportHandler(exportSessionUseCase, mapImageLoader)
+	mapPoolHandler := http.NewMapPoolHandler(getPoolsUseCase, getPoolUseCase, createCustomPoolUseCase, deletePoolUseCase, updatePoolUseCase, addMapUseCase, removeMapUseCase, duplicatePoolUseCase, getPublicPoolsUseCase, getSharedPoolUseCase, forkPoolUseCase)
+	teamHandler := http.NewTeamHandler(
+		createTeamUseCase,
+		getTeamUseCase,
+		getUserTeamsUseCase,
+		updateTeamUseCase,
+		deleteTeamUseCase,
+		inviteMemberUseCase,
+		getInvitesUseCase,
+		respondInviteUseCase,
+		removeMemberUseCase,
+		updateMemberUseCase,
+		getTeamSessionsUseCase,
+		getTeamStatsUseCase,
+	)
+	tournamentHandler := http.NewTournamentHandler(
+		createTournamentUseCase,
+		getTournamentUseCase,
+		getTournamentsUseCase,
+		deleteTournamentUseCase,
+		registerTournamentTeamUseCase,
+		withdrawTournamentTeamUseCase,
+		seedTournamentTeamsUseCase,
+		startTournamentUseCase,
+		scheduleTournamentMatchUseCase,
+	)
+	webhookHandler := http.NewWebhookHandler(
+		createWebhookUseCase,
+		getWebhooksUseCase,
+		updateWebhookUseCase,
+		deleteWebhookUseCase,
+		getWebhookDeliveriesUseCase,
+		redeliverWebhookUseCase,
+		pingWebhookUseCase,
+	)
+	apiKeyHandler := http.NewAPIKeyHandler(createAPIKeyUseCase, getAPIKeysUseCase, revokeAPIKeyUseCase)
+	roomHandler := http.NewRoomHandler(createRoomUseCase, getRoomUseCase, getRoomBySessionUseCase, getRoomsListUseCase, joinRoomUseCase, leaveRoomUseCase, deleteRoomUseCase, updateRoomUseCase, startVetoUseCase, getRoomMatchesUseCase, wsManager)
+	roomHandler.SetWebhookDispatcher(webhookDispatcher, mapPoolRepo)
 
 	// Инициализируем WebSocket handler
 	roomWebSocketHandler := websocket.NewRoomWebSocketHandler(
@@ -168,6 +441,7 @@ func main() {
 		resetSessionUseCase,
 		startSessionUseCase,
 	)
+	roomWebSocketHandler.SetWebhookDispatcher(webhookDispatcher)
 
 	// API routes
 	api := router.Group("/api")
@@ -178,7 +452,24 @@ func main() {
 		{
 			auth.POST("/register", authHandler.Register)
 			auth.POST("/login", authHandler.Login)
+			auth.POST("/login/2fa", twoFactorHandler.VerifyLogin)
 			auth.GET("/me", middleware.AuthMiddleware(jwtService), authHandler.GetCurrentUser)
+			auth.POST("/refresh", authHandler.Refresh)
+			auth.POST("/logout", middleware.AuthMiddleware(jwtService), authHandler.Logout)
+			auth.POST("/logout-all", middleware.AuthMiddleware(jwtService), authHandler.LogoutAll)
+			auth.POST("/password/forgot", authHandler.ForgotPassword)
+			auth.POST("/password/reset", authHandler.ResetPassword)
+			auth.POST("/verify", authHandler.VerifyEmail)
+			auth.POST("/verify/resend", middleware.AuthMiddleware(jwtService), authHandler.ResendVerification)
+			auth.POST("/guest", guestHandler.CreateGuest)
+			auth.POST("/guest/upgrade", middleware.AuthMiddleware(jwtService), guestHandler.UpgradeGuest)
+			auth.GET("/oauth/providers", oauthHandler.GetProviders)
+			auth.GET("/oauth/:provider/start", oauthHandler.Start)
+			auth.GET("/oauth/:provider/callback", oauthHandler.Callback)
+			auth.GET("/2fa", middleware.AuthMiddleware(jwtService), twoFactorHandler.GetStatus)
+			auth.POST("/2fa/setup", middleware.AuthMiddleware(jwtService), middleware.RejectGuests(), twoFactorHandler.Setup)
+			auth.POST("/2fa/confirm", middleware.AuthMiddleware(jwtService), middleware.RejectGuests(), twoFactorHandler.Confirm)
+			auth.POST("/2fa/disable", middleware.AuthMiddleware(jwtService), twoFactorHandler.Disable)
 		}
 
 		// Protected routes (требуют авторизации)
@@ -186,9 +477,23 @@ func main() {
 		users.Use(middleware.AuthMiddleware(jwtService))
 		{
 			users.GET("/profile", userHandler.GetProfile)
-			users.PUT("/profile", userHandler.UpdateProfile)
+			users.PUT("/profile", middleware.RejectGuests(), userHandler.UpdateProfile)
 			users.GET("/sessions", userHandler.GetSessions)
 			users.GET("/rooms", userHandler.GetRooms)
+			users.PUT("/password", middleware.RejectGuests(), userHandler.ChangePassword)
+			users.DELETE("/me", userHandler.DeleteAccount)
+			users.GET("/me/export", userHandler.ExportData)
+		}
+		// Публичный профиль доступен без авторизации, владелец видит статистику скрытого профиля
+		api.GET("/profiles/:username", middleware.OptionalAuthMiddleware(jwtService), userHandler.GetPublicProfile)
+
+		// Admin routes (администраторы из ADMIN_EMAILS)
+		admin := api.Group("/admin")
+		admin.Use(middleware.AuthMiddleware(jwtService), middleware.RequireAdmin(userRepo, cfg.AdminEmails))
+		{
+			admin.GET("/lockouts", adminHandler.GetLockoutEvents)
+			admin.POST("/users/:id/unlock", adminHandler.UnlockUser)
+			admin.PUT("/veto/sessions/:id/result", matchResultHandler.ResolveResult)
 		}
 
 		// Veto routes (публичные, но могут быть созданы с авторизацией)
@@ -197,28 +502,55 @@ func main() {
 		{
 			sessions := vetoGroup.Group("/sessions")
 			{
-				sessions.POST("", vetoHandler.CreateSession)
+				// Авторизация опциональна - нужна для доступа к приватным пулам
+				sessions.POST("", middleware.OptionalAuthMiddleware(jwtService), vetoHandler.CreateSession)
 				// Специфичные маршруты идут первыми
 				sessions.GET("/share/:token", vetoHandler.GetSessionByShareToken)
+				sessions.GET("/share/:token/events", roomWebSocketHandler.StreamSessionEvents)
 				sessions.GET("/:id/next-action", vetoHandler.GetNextAction)
+				sessions.GET("/:id/export", exportHandler.ExportSession)
 				sessions.POST("/:id/start", vetoHandler.StartSession)
 				sessions.POST("/:id/ban", vetoHandler.BanMap)
 				sessions.POST("/:id/pick", vetoHandler.PickMap)
 				sessions.POST("/:id/select-side", vetoHandler.SelectSide)
 				sessions.POST("/:id/reset", vetoHandler.ResetSession)
+				// Результат серии: сообщают и подтверждают капитаны команд сессии
+				sessions.GET("/:id/result", matchResultHandler.GetResult)
+				sessions.POST("/:id/result", middleware.AuthMiddleware(jwtService), matchResultHandler.ReportResult)
+				sessions.POST("/:id/result/confirm", middleware.AuthMiddleware(jwtService), matchResultHandler.ConfirmResult)
+				sessions.POST("/:id/result/dispute", middleware.AuthMiddleware(jwtService), matchResultHandler.DisputeResult)
 				// Общий маршрут GET /:id должен быть последним
 				sessions.GET("/:id", vetoHandler.GetSession)
 			}
 		}
 
+		// Overlay routes: компактное состояние сессии по share token для стрим-оверлеев (ETag и Server-Sent Events)
+		api.GET("/overlay/:token", overlayHandler.GetOverlay)
+		api.GET("/overlay/:token/stream", overlayHandler.StreamOverlay)
+
+		// Создание комнат и пулов карт можно ограничить подтвержденными аккаунтами
+		verifiedOnly := gin.HandlerFunc(func(c *gin.Context) { c.Next() })
+		if cfg.RequireEmailVerification {
+			verifiedOnly = middleware.RequireVerifiedEmail(userRepo)
+		}
+
+		// Библиотека публичных пулов и пулы по ссылке (без авторизации)
+		api.GET("/map-pools/public", mapPoolHandler.GetPublicPools)
+		api.GET("/map-pools/shared/:slug", mapPoolHandler.GetSharedPool)
+
 		// Map Pools routes (требуют авторизации)
 		mapPools := api.Group("/map-pools")
 		mapPools.Use(middleware.AuthMiddleware(jwtService))
 		{
 			mapPools.GET("/games/:gameId", mapPoolHandler.GetPools)
 			mapPools.GET("/:id", mapPoolHandler.GetPool)
-			mapPools.POST("", mapPoolHandler.CreateCustomPool)
+			mapPools.POST("", middleware.RejectGuests(), verifiedOnly, mapPoolHandler.CreateCustomPool)
 			mapPools.DELETE("/:id", mapPoolHandler.DeletePool)
+			mapPools.PUT("/:id", mapPoolHandler.UpdatePool)
+			mapPools.POST("/:id/maps/:mapId", mapPoolHandler.AddMap)
+			mapPools.DELETE("/:id/maps/:mapId", mapPoolHandler.RemoveMap)
+			mapPools.POST("/:id/duplicate", middleware.RejectGuests(), verifiedOnly, mapPoolHandler.DuplicatePool)
+			mapPools.POST("/shared/:slug/fork", middleware.RejectGuests(), verifiedOnly, mapPoolHandler.ForkPool)
 		}
 
 		// Rooms routes
@@ -226,20 +558,109 @@ func main() {
 		rooms := api.Group("/rooms")
 		rooms.Use(middleware.AuthMiddleware(jwtService))
 		{
-			rooms.POST("", roomHandler.CreateRoom)
+			rooms.POST("", verifiedOnly, roomHandler.CreateRoom)
 			rooms.GET("/by-session/:sessionId", roomHandler.GetRoomBySession)
 			rooms.GET("/:id", roomHandler.GetRoom)
 			rooms.POST("/:id/join", roomHandler.JoinRoom)
 			rooms.POST("/:id/leave", roomHandler.LeaveRoom)
 			rooms.PUT("/:id", roomHandler.UpdateRoom)
+			rooms.POST("/:id/veto", roomHandler.StartVeto)
+			rooms.GET("/:id/matches", roomHandler.GetRoomMatches)
 			rooms.DELETE("/:id", roomHandler.DeleteRoom)
 			rooms.GET("/:id/participants", roomHandler.GetParticipants)
 		}
 
+		// Teams routes: карточка команды, история и статистика публичные
+		api.GET("/teams/:id", teamHandler.GetTeam)
+		api.GET("/teams/:id/sessions", teamHandler.GetTeamSessions)
+		api.GET("/teams/:id/stats", teamHandler.GetTeamStats)
+		teams := api.Group("/teams")
+		teams.Use(middleware.AuthMiddleware(jwtService))
+		{
+			teams.GET("", teamHandler.GetMyTeams)
+			teams.POST("", middleware.RejectGuests(), verifiedOnly, teamHandler.CreateTeam)
+			teams.GET("/invites", teamHandler.GetInvites)
+			teams.POST("/invites/:inviteId/accept", teamHandler.AcceptInvite)
+			teams.POST("/invites/:inviteId/decline", teamHandler.DeclineInvite)
+			teams.PUT("/:id", teamHandler.UpdateTeam)
+			teams.DELETE("/:id", teamHandler.DeleteTeam)
+			teams.POST("/:id/invites", teamHandler.InviteMember)
+			teams.PUT("/:id/members/:userId", teamHandler.UpdateMember)
+			teams.DELETE("/:id/members/:userId", teamHandler.RemoveMember)
+		}
+
+		// Tournaments routes: список и сетка публичные, управляет организатор, регистрирует капитан
+		api.GET("/tournaments", tournamentHandler.GetTournaments)
+		api.GET("/tournaments/:id", tournamentHandler.GetTournament)
+		tournaments := api.Group("/tournaments")
+		tournaments.Use(middleware.AuthMiddleware(jwtService))
+		{
+			tournaments.POST("", middleware.RejectGuests(), verifiedOnly, tournamentHandler.CreateTournament)
+			tournaments.DELETE("/:id", tournamentHandler.DeleteTournament)
+			tournaments.POST("/:id/teams", tournamentHandler.RegisterTeam)
+			tournaments.DELETE("/:id/teams/:teamId", tournamentHandler.WithdrawTeam)
+			tournaments.PUT("/:id/seeds", tournamentHandler.SeedTeams)
+			tournaments.POST("/:id/start", tournamentHandler.StartTournament)
+			tournaments.PUT("/:id/matches/:matchId", tournamentHandler.ScheduleMatch)
+		}
+
+		// Webhooks routes: подписки на события вето своих комнат и сессий
+		webhooks := api.Group("/webhooks")
+		webhooks.Use(middleware.AuthMiddleware(jwtService), middleware.RejectGuests())
+		{
+			webhooks.GET("", webhookHandler.GetWebhooks)
+			webhooks.POST("", webhookHandler.CreateWebhook)
+			webhooks.PUT("/:id", webhookHandler.UpdateWebhook)
+			webhooks.DELETE("/:id", webhookHandler.DeleteWebhook)
+			webhooks.POST("/:id/ping", webhookHandler.PingWebhook)
+			webhooks.GET("/:id/deliveries", webhookHandler.GetDeliveries)
+			webhooks.POST("/:id/deliveries/:deliveryId/redeliver", webhookHandler.Redeliver)
+		}
+
+		// API keys routes: ключи для ботов и интеграций, управляются только из аккаунта пользователя
+		apiKeys := api.Group("/api-keys")
+		apiKeys.Use(middleware.AuthMiddleware(jwtService), middleware.RejectGuests())
+		{
+			apiKeys.GET("", apiKeyHandler.GetAPIKeys)
+			apiKeys.POST("", apiKeyHandler.CreateAPIKey)
+			apiKeys.DELETE("/:id", apiKeyHandler.RevokeAPIKey)
+		}
+
 		// WebSocket routes (auth handled in handler via query param)
 		router.GET("/ws/room/:roomId", roomWebSocketHandler.HandleWebSocket)
+		// Server-Sent Events вместо websocket для сетей, где upgrade заблокирован (авторизация как у websocket)
+		api.GET("/rooms/:id/events", roomWebSocketHandler.StreamRoomEvents)
 	}
 
+	// Периодически очищаем denylist от истёкших access токенов, истекшие refresh токены, незавершенные входы через OAuth
+	// и гостевые аккаунты с истекшей сессией вместе с их комнатами
+	go func() {
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+		for range ticker.C {
+			if err := revokedTokenRepo.DeleteExpired(time.Now()); err != nil {
+				log.Printf("Failed to clean up revoked tokens: %v", err)
+			}
+			if err := refreshTokenRepo.DeleteExpired(time.Now()); err != nil {
+				log.Printf("Failed to clean up refresh tokens: %v", err)
+			}
+			if err := oauthStateRepo.DeleteExpired(time.Now()); err != nil {
+				log.Printf("Failed to clean up oauth states: %v", err)
+			}
+			if err := loginChallengeRepo.DeleteExpired(time.Now()); err != nil {
+				log.Printf("Failed to clean up login challenges: %v", err)
+			}
+			if err := loginThrottler.DeleteStale(time.Now()); err != nil {
+				log.Printf("Failed to clean up login throttles: %v", err)
+			}
+			if result, err := cleanupGuestsUseCase.Execute(user.CleanupGuestsInput{Now: time.Now()}); err != nil {
+				log.Printf("Failed to clean up expired guests: %v", err)
+			} else if result.Deleted > 0 {
+				log.Printf("Deleted %d expired guest accounts", result.Deleted)
+			}
+		}
+	}()
+
 	// Запускаем сервер
 	serverAddr := ":" + cfg.Port
 	log.Printf("Server starting on %s", serverAddr)
@@ -258,3 +679,38 @@ func main() {
 
 	log.Println("Shutting down server...")
 }
+
+// buildOAuthProviders создает провайдеров входа, для которых задан client id
+func buildOAuthProviders(cfg *config.Config) []oauth.Provider {
+	callbackURL := func(name string) string {
+		return cfg.APIURL + "/api/auth/oauth/" + name + "/callback"
+	}
+
+	var providers []oauth.Provider
+	if cfg.OAuth.DiscordClientID != "" {
+		providers = append(providers, oauth.NewDiscordProvider(
+			cfg.OAuth.DiscordClientID, cfg.OAuth.DiscordClientSecret, callbackURL("discord"),
+		))
+	}
+	if cfg.OAuth.GoogleClientID != "" {
+		providers = append(providers, oauth.NewGoogleProvider(
+			cfg.OAuth.GoogleClientID, cfg.OAuth.GoogleClientSecret, callbackURL("google"),
+		))
+	}
+	if cfg.OAuth.OIDCClientID != "" && cfg.OAuth.OIDCIssuer != "" {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		provider, err := oauth.DiscoverOIDCProvider(
+			ctx, cfg.OAuth.OIDCName, cfg.OAuth.OIDCIssuer,
+			cfg.OAuth.OIDCClientID, cfg.OAuth.OIDCClientSecret, callbackURL(cfg.OAuth.OIDCName),
+		)
+		if err != nil {
+			log.Printf("OIDC provider %s disabled: %v", cfg.OAuth.OIDCName, err)
+		} else {
+			providers = append(providers, provider)
+		}
+	}
+
+	return providers
+}
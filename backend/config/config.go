@@ -2,16 +2,62 @@ package config
 
 import (
 	"os"
+	"strconv"
+	"strings"
 	"time"
 )
 
 type Config struct {
-	Port        string
-	JWTSecret   string
-	JWTExpiry   time.Duration
-	DBPath      string
-	CORSOrigin  string
-	Environment string
+	Port                string
+	JWTSecret           string
+	JWTExpiry           time.Duration
+	RefreshTokenExpiry  time.Duration
+	DBPath              string
+	CORSOrigin          string
+	Environment         string
+	AppURL              string // Публичный URL фронтенда, используется в ссылках из писем
+	Mailer              string // Драйвер отправки писем: log или file
+	MailerDir           string // Директория для писем при MAILER=file
+	PasswordResetExpiry time.Duration
+	// Требовать подтвержденный email для создания комнат и пулов карт
+	RequireEmailVerification bool
+	EmailVerificationExpiry  time.Duration
+	// Публичный URL backend, используется в redirect_uri провайдеров OAuth
+	APIURL string
+	OAuth  OAuthConfig
+	// Время жизни гостевой сессии (не продлевается)
+	GuestSessionExpiry time.Duration
+	// Название сервиса в приложении-аутентификаторе
+	TOTPIssuer string
+	// Email администраторов (через запятую); доступ только при подтвержденном email
+	AdminEmails []string
+	// Блокировка входа после неудачных попыток
+	LoginMaxFailures   int
+	LoginIPMaxFailures int
+	LoginLockout       time.Duration
+	LoginMaxLockout    time.Duration
+	// Лимит запросов в минуту на один API ключ, отдельно от лимитов интерактивных пользователей
+	APIKeyRateLimit int
+	// Откуда загружать картинки карт для карточек с итогом вето; относительные Map.ImageURL раздает фронтенд
+	MapImageBaseURL string
+	// Разрешить доставку webhooks на loopback и адреса частных сетей (только для локальной разработки)
+	WebhookAllowPrivateNetworks bool
+	// Адреса или подсети reverse proxy, чьим X-Forwarded-For/X-Real-IP можно доверять; пусто - не доверять никому
+	TrustedProxies []string
+}
+
+// OAuthConfig настройки входа через внешних провайдеров
+// Провайдер включается, если задан его client id
+type OAuthConfig struct {
+	DiscordClientID     string
+	DiscordClientSecret string
+	GoogleClientID      string
+	GoogleClientSecret  string
+	// Произвольный OIDC провайдер, endpoints берутся через discovery
+	OIDCName         string
+	OIDCIssuer       string
+	OIDCClientID     string
+	OIDCClientSecret string
 }
 
 func Load() *Config {
@@ -25,13 +71,23 @@ func Load() *Config {
 		jwtSecret = "your-secret-key-change-in-production"
 	}
 
+	// Access токены короткоживущие, сессия продлевается через refresh токен
 	jwtExpiryStr := os.Getenv("JWT_EXPIRY")
 	if jwtExpiryStr == "" {
-		jwtExpiryStr = "24h"
+		jwtExpiryStr = "15m"
 	}
 	jwtExpiry, _ := time.ParseDuration(jwtExpiryStr)
 	if jwtExpiry == 0 {
-		jwtExpiry, _ = time.ParseDuration("24h") // Default 24h
+		jwtExpiry, _ = time.ParseDuration("15m") // Default 15m
+	}
+
+	refreshExpiryStr := os.Getenv("REFRESH_TOKEN_EXPIRY")
+	if refreshExpiryStr == "" {
+		refreshExpiryStr = "720h"
+	}
+	refreshExpiry, _ := time.ParseDuration(refreshExpiryStr)
+	if refreshExpiry == 0 {
+		refreshExpiry, _ = time.ParseDuration("720h") // Default 30 days
 	}
 
 	dbPath := os.Getenv("DB_PATH")
@@ -45,17 +101,140 @@ func Load() *Config {
 		corsOrigin = "*"
 	}
 
+	var trustedProxies []string
+	for _, proxy := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			trustedProxies = append(trustedProxies, proxy)
+		}
+	}
+
 	env := os.Getenv("ENVIRONMENT")
 	if env == "" {
 		env = "development"
 	}
 
+	appURL := strings.TrimRight(os.Getenv("APP_URL"), "/")
+	if appURL == "" {
+		appURL = "http://localhost:5173"
+	}
+
+	mailerDriver := os.Getenv("MAILER")
+	if mailerDriver == "" {
+		mailerDriver = "log"
+	}
+
+	mailerDir := os.Getenv("MAILER_DIR")
+	if mailerDir == "" {
+		mailerDir = "./data/mail"
+	}
+
+	resetExpiry, _ := time.ParseDuration(os.Getenv("PASSWORD_RESET_EXPIRY"))
+	if resetExpiry == 0 {
+		resetExpiry = time.Hour // Default 1h
+	}
+
+	requireVerification, _ := strconv.ParseBool(os.Getenv("REQUIRE_EMAIL_VERIFICATION"))
+
+	verificationExpiry, _ := time.ParseDuration(os.Getenv("EMAIL_VERIFICATION_EXPIRY"))
+	if verificationExpiry == 0 {
+		verificationExpiry = 48 * time.Hour // Default 48h
+	}
+
+	apiURL := strings.TrimRight(os.Getenv("API_URL"), "/")
+	if apiURL == "" {
+		apiURL = "http://localhost:" + port
+	}
+
+	guestExpiry, _ := time.ParseDuration(os.Getenv("GUEST_SESSION_EXPIRY"))
+	if guestExpiry == 0 {
+		guestExpiry = 24 * time.Hour // Default 24h
+	}
+
+	totpIssuer := os.Getenv("TOTP_ISSUER")
+	if totpIssuer == "" {
+		totpIssuer = "MapBan"
+	}
+
+	var adminEmails []string
+	for _, email := range strings.Split(os.Getenv("ADMIN_EMAILS"), ",") {
+		if email = strings.TrimSpace(email); email != "" {
+			adminEmails = append(adminEmails, email)
+		}
+	}
+
+	loginMaxFailures, _ := strconv.Atoi(os.Getenv("LOGIN_MAX_FAILURES"))
+	if loginMaxFailures <= 0 {
+		loginMaxFailures = 5
+	}
+
+	loginIPMaxFailures, _ := strconv.Atoi(os.Getenv("LOGIN_IP_MAX_FAILURES"))
+	if loginIPMaxFailures <= 0 {
+		loginIPMaxFailures = 20
+	}
+
+	loginLockout, _ := time.ParseDuration(os.Getenv("LOGIN_LOCKOUT"))
+	if loginLockout == 0 {
+		loginLockout = time.Minute // Default 1m
+	}
+
+	loginMaxLockout, _ := time.ParseDuration(os.Getenv("LOGIN_MAX_LOCKOUT"))
+	if loginMaxLockout == 0 {
+		loginMaxLockout = time.Hour // Default 1h
+	}
+
+	apiKeyRateLimit, _ := strconv.Atoi(os.Getenv("API_KEY_RATE_LIMIT"))
+	if apiKeyRateLimit <= 0 {
+		apiKeyRateLimit = 120
+	}
+
+	mapImageBaseURL := strings.TrimRight(os.Getenv("MAP_IMAGE_BASE_URL"), "/")
+	if mapImageBaseURL == "" {
+		mapImageBaseURL = appURL
+	}
+
+	webhookAllowPrivate, _ := strconv.ParseBool(os.Getenv("WEBHOOK_ALLOW_PRIVATE_NETWORKS"))
+
+	oidcName := os.Getenv("OAUTH_OIDC_NAME")
+	if oidcName == "" {
+		oidcName = "oidc"
+	}
+
 	return &Config{
-		Port:        port,
-		JWTSecret:   jwtSecret,
-		JWTExpiry:   jwtExpiry,
-		DBPath:      dbPath,
-		CORSOrigin:  corsOrigin,
-		Environment: env,
+		Port:                     port,
+		JWTSecret:                jwtSecret,
+		JWTExpiry:                jwtExpiry,
+		RefreshTokenExpiry:       refreshExpiry,
+		DBPath:                   dbPath,
+		CORSOrigin:               corsOrigin,
+		Environment:              env,
+		AppURL:                   appURL,
+		Mailer:                   mailerDriver,
+		MailerDir:                mailerDir,
+		PasswordResetExpiry:      resetExpiry,
+		RequireEmailVerification: requireVerification,
+		EmailVerificationExpiry:  verificationExpiry,
+		APIURL:                   apiURL,
+		OAuth: OAuthConfig{
+			DiscordClientID:     os.Getenv("OAUTH_DISCORD_CLIENT_ID"),
+			DiscordClientSecret: os.Getenv("OAUTH_DISCORD_CLIENT_SECRET"),
+			GoogleClientID:      os.Getenv("OAUTH_GOOGLE_CLIENT_ID"),
+			GoogleClientSecret:  os.Getenv("OAUTH_GOOGLE_CLIENT_SECRET"),
+			OIDCName:            oidcName,
+			OIDCIssuer:          os.Getenv("OAUTH_OIDC_ISSUER"),
+			OIDCClientID:        os.Getenv("OAUTH_OIDC_CLIENT_ID"),
+			OIDCClientSecret:    os.Getenv("OAUTH_OIDC_CLIENT_SECRET"),
+		},
+		GuestSessionExpiry: guestExpiry,
+		TOTPIssuer:         totpIssuer,
+		AdminEmails:        adminEmails,
+		LoginMaxFailures:   loginMaxFailures,
+		LoginIPMaxFailures: loginIPMaxFailures,
+		LoginLockout:       loginLockout,
+		LoginMaxLockout:    loginMaxLockout,
+		APIKeyRateLimit:    apiKeyRateLimit,
+		MapImageBaseURL:    mapImageBaseURL,
+
+		WebhookAllowPrivateNetworks: webhookAllowPrivate,
+		TrustedProxies:              trustedProxies,
 	}
 }
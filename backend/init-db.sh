@@ -47,9 +47,9 @@ if [ $? -eq 0 ]; then
     echo "📁 База данных находится в: ./data/app.db"
     echo ""
     echo "Создано:"
-    echo "  - Игра Valorant"
-    echo "  - 12 карт Valorant"
-    echo "  - 2 системных пула карт (All Maps, Competitive Maps)"
+    echo "  - Игры из каталога (Valorant, CS2, Overwatch 2, Rainbow Six Siege)"
+    echo "  - Карты каждой игры"
+    echo "  - Системные пулы карт (All Maps, Competitive Maps)"
     echo ""
 else
     echo "❌ Ошибка при инициализации базы данных"
@@ -0,0 +1,66 @@
+package entities
+
+import "time"
+
+// APIKeyScope набор действий, доступных по API ключу
+type APIKeyScope string
+
+const (
+	APIKeyScopeRead APIKeyScope = "read" // GET запросы от имени владельца ключа
+	APIKeyScopeVeto APIKeyScope = "veto" // Создание и проведение вето: старт, баны, пики, выбор стороны, сброс
+)
+
+// IsValid проверяет, что scope существует
+func (s APIKeyScope) IsValid() bool {
+	return s == APIKeyScopeRead || s == APIKeyScopeVeto
+}
+
+// APIKey персональный ключ для ботов и интеграций
+// В БД хранится только хэш ключа, сам ключ показывается один раз при создании
+type APIKey struct {
+	ID         uint          `json:"id"`
+	UserID     uint          `json:"user_id"`
+	Name       string        `json:"name"`
+	Prefix     string        `json:"prefix"` // Начало ключа, чтобы отличать ключи в списке
+	KeyHash    string        `json:"-"`
+	Scopes     []APIKeyScope `json:"scopes"`
+	RoomIDs    []uint        `json:"room_ids,omitempty"` // Комнаты, в которых ключ управляет вето; пустой список - любые
+	LastUsedAt *time.Time    `json:"last_used_at,omitempty"`
+	LastUsedIP string        `json:"last_used_ip,omitempty"`
+	ExpiresAt  *time.Time    `json:"expires_at,omitempty"`
+	RevokedAt  *time.Time    `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time     `json:"created_at"`
+}
+
+// IsActive проверяет, что ключ не отозван и не истек
+func (k *APIKey) IsActive(now time.Time) bool {
+	return k.RevokedAt == nil && (k.ExpiresAt == nil || now.Before(*k.ExpiresAt))
+}
+
+// HasScope проверяет, что ключ выдан со scope
+func (k *APIKey) HasScope(scope APIKeyScope) bool {
+	for _, s := range k.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// IsRoomRestricted проверяет, что ключ управляет вето только в выбранных комнатах
+func (k *APIKey) IsRoomRestricted() bool {
+	return len(k.RoomIDs) > 0
+}
+
+// AllowsRoom проверяет, что ключ может управлять вето в комнате
+func (k *APIKey) AllowsRoom(roomID uint) bool {
+	if !k.IsRoomRestricted() {
+		return true
+	}
+	for _, id := range k.RoomIDs {
+		if id == roomID {
+			return true
+		}
+	}
+	return false
+}
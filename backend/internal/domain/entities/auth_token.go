@@ -0,0 +1,30 @@
+package entities
+
+import "time"
+
+// RefreshToken долгоживущий токен для получения новых access токенов
+// В БД хранится только хэш токена
+type RefreshToken struct {
+	ID           uint       `json:"id"`
+	UserID       uint       `json:"user_id"`
+	TokenHash    string     `json:"-"`
+	AccessJTI    string     `json:"-"` // jti access токена, выданного вместе с refresh токеном
+	ExpiresAt    time.Time  `json:"expires_at"`
+	RevokedAt    *time.Time `json:"revoked_at,omitempty"`
+	ReplacedByID *uint      `json:"replaced_by_id,omitempty"` // Токен, выданный при ротации
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// IsActive проверяет, что токен не отозван и не истек
+func (t *RefreshToken) IsActive(now time.Time) bool {
+	return t.RevokedAt == nil && now.Before(t.ExpiresAt)
+}
+
+// RevokedToken отозванный access токен (denylist по jti)
+// Запись нужна только до истечения срока действия токена
+type RevokedToken struct {
+	JTI       string    `json:"jti"`
+	UserID    uint      `json:"user_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
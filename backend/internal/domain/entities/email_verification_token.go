@@ -0,0 +1,20 @@
+package entities
+
+import "time"
+
+// EmailVerificationToken одноразовый токен подтверждения email
+// Привязан к конкретному адресу: после смены email старые токены недействительны
+type EmailVerificationToken struct {
+	ID        uint       `json:"id"`
+	UserID    uint       `json:"user_id"`
+	Email     string     `json:"email"`
+	TokenHash string     `json:"-"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// IsUsable проверяет, что токен не использован и не истек
+func (t *EmailVerificationToken) IsUsable(now time.Time) bool {
+	return t.UsedAt == nil && now.Before(t.ExpiresAt)
+}
@@ -0,0 +1,47 @@
+package entities
+
+import "time"
+
+// LoginThrottleScope объект, по которому считаются неудачные попытки входа
+type LoginThrottleScope string
+
+const (
+	LoginThrottleScopeAccount LoginThrottleScope = "account" // Ключ - email
+	LoginThrottleScopeIP      LoginThrottleScope = "ip"
+)
+
+// LoginThrottle счетчик неудачных попыток входа для аккаунта или IP
+type LoginThrottle struct {
+	Scope         LoginThrottleScope `json:"scope"`
+	Key           string             `json:"key"`
+	Failures      int                `json:"failures"`
+	LastFailureAt time.Time          `json:"last_failure_at"`
+	LockedUntil   *time.Time         `json:"locked_until,omitempty"`
+}
+
+// IsLocked проверяет, действует ли блокировка в момент now
+func (t *LoginThrottle) IsLocked(now time.Time) bool {
+	return t != nil && t.LockedUntil != nil && now.Before(*t.LockedUntil)
+}
+
+// LockoutAction тип записи в журнале блокировок
+type LockoutAction string
+
+const (
+	LockoutActionLocked   LockoutAction = "locked"
+	LockoutActionUnlocked LockoutAction = "unlocked"
+)
+
+// LockoutEvent запись журнала блокировок входа
+type LockoutEvent struct {
+	ID          uint               `json:"id"`
+	Action      LockoutAction      `json:"action"`
+	Scope       LoginThrottleScope `json:"scope"`
+	Key         string             `json:"key"`
+	UserID      *uint              `json:"user_id,omitempty"` // Заблокированный аккаунт, если он существует
+	IP          string             `json:"ip,omitempty"`      // IP последней неудачной попытки
+	Failures    int                `json:"failures"`
+	LockedUntil *time.Time         `json:"locked_until,omitempty"`
+	ActorID     *uint              `json:"actor_id,omitempty"` // Администратор, снявший блокировку
+	CreatedAt   time.Time          `json:"created_at"`
+}
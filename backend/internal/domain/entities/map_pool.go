@@ -13,16 +13,27 @@ const (
 	MapPoolTypeCustom      MapPoolType = "custom"
 )
 
+type MapPoolVisibility string
+
+const (
+	MapPoolVisibilityPrivate  MapPoolVisibility = "private"  // Доступен только владельцу
+	MapPoolVisibilityUnlisted MapPoolVisibility = "unlisted" // Доступен по ссылке, не показывается в библиотеке
+	MapPoolVisibilityPublic   MapPoolVisibility = "public"   // Показывается в библиотеке пулов
+)
+
 type MapPool struct {
-	ID        uint         `json:"id"`
-	GameID    uint         `json:"game_id"`
-	UserID    *uint        `json:"user_id,omitempty"`
-	Name      string       `json:"name"`
-	Type      MapPoolType  `json:"type"`
-	IsSystem  bool         `json:"is_system"`
-	CreatedAt time.Time    `json:"created_at"`
-	UpdatedAt time.Time    `json:"updated_at"`
-	Maps      []Map        `json:"maps,omitempty"`
+	ID           uint              `json:"id"`
+	GameID       uint              `json:"game_id"`
+	UserID       *uint             `json:"user_id,omitempty"`
+	Name         string            `json:"name"`
+	Type         MapPoolType       `json:"type"`
+	IsSystem     bool              `json:"is_system"`
+	Visibility   MapPoolVisibility `json:"visibility"`
+	ShareSlug    *string           `json:"share_slug,omitempty"`     // Slug для ссылки на пул
+	ForkedFromID *uint             `json:"forked_from_id,omitempty"` // Пул, с которого сделана копия
+	CreatedAt    time.Time         `json:"created_at"`
+	UpdatedAt    time.Time         `json:"updated_at"`
+	Maps         []Map             `json:"maps,omitempty"`
 }
 
 // Validate проверяет валидность данных пула карт
@@ -42,5 +53,26 @@ func (mp *MapPool) Validate() error {
 	if mp.Type == MapPoolTypeCustom && len(mp.Maps) == 0 {
 		return errors.New("custom map pool must have at least one map")
 	}
+	if mp.Visibility != "" && !mp.Visibility.IsValid() {
+		return errors.New("invalid map pool visibility")
+	}
 	return nil
 }
+
+// IsValid проверяет, что значение видимости известно
+func (v MapPoolVisibility) IsValid() bool {
+	return v == MapPoolVisibilityPrivate || v == MapPoolVisibilityUnlisted || v == MapPoolVisibilityPublic
+}
+
+// IsAccessibleBy проверяет, может ли пользователь использовать пул по id
+// Системные и публичные пулы доступны всем, остальные - только владельцу.
+// Пул по ссылке (unlisted) другие пользователи получают только через /map-pools/shared/:slug
+func (mp *MapPool) IsAccessibleBy(userID *uint) bool {
+	if mp.IsSystem || mp.UserID == nil {
+		return true
+	}
+	if mp.Visibility == MapPoolVisibilityPublic {
+		return true
+	}
+	return userID != nil && *mp.UserID == *userID
+}
@@ -0,0 +1,40 @@
+package entities
+
+import (
+	"errors"
+	"time"
+)
+
+// MapRotation версия соревновательного пула карт игры, действующая с указанной даты
+type MapRotation struct {
+	ID            uint      `json:"id"`
+	GameID        uint      `json:"game_id"`
+	EffectiveFrom time.Time `json:"effective_from"`
+	MapIDs        []uint    `json:"map_ids"`
+	CreatedAt     time.Time `json:"created_at"`
+	Maps          []Map     `json:"maps,omitempty"`
+}
+
+// Validate проверяет валидность данных ротации
+func (r *MapRotation) Validate() error {
+	if r.GameID == 0 {
+		return errors.New("game_id is required")
+	}
+	if r.EffectiveFrom.IsZero() {
+		return errors.New("effective_from is required")
+	}
+	if len(r.MapIDs) == 0 {
+		return errors.New("map rotation must have at least one map")
+	}
+	return nil
+}
+
+// Contains проверяет, входит ли карта в ротацию
+func (r *MapRotation) Contains(mapID uint) bool {
+	for _, id := range r.MapIDs {
+		if id == mapID {
+			return true
+		}
+	}
+	return false
+}
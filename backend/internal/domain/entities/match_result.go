@@ -0,0 +1,60 @@
+package entities
+
+import "time"
+
+type MatchResultStatus string
+
+const (
+	MatchResultStatusPending   MatchResultStatus = "pending"   // Сообщен капитаном одной стороны и ждет подтверждения соперника
+	MatchResultStatusConfirmed MatchResultStatus = "confirmed" // Подтвержден соперником или выставлен администратором
+	MatchResultStatusDisputed  MatchResultStatus = "disputed"  // Соперник оспорил результат
+)
+
+// MatchResult - итог серии, сыгранной по результатам вето
+type MatchResult struct {
+	ID             uint                `json:"id"`
+	VetoSessionID  uint                `json:"veto_session_id"`
+	Status         MatchResultStatus   `json:"status"`
+	ReportedByID   uint                `json:"reported_by_id"`
+	ReportedByTeam string              `json:"reported_by_team,omitempty"` // "A" или "B"; пусто, если результат выставил администратор
+	ConfirmedByID  *uint               `json:"confirmed_by_id,omitempty"`
+	DisputedByID   *uint               `json:"disputed_by_id,omitempty"`
+	DisputeReason  *string             `json:"dispute_reason,omitempty"`
+	ScoreA         int                 `json:"score_a"` // Выигранные карты
+	ScoreB         int                 `json:"score_b"`
+	WinnerTeam     string              `json:"winner_team"` // "A" или "B"
+	Maps           []MapResult         `json:"maps"`
+	Players        []MatchResultPlayer `json:"-"` // Составы команд на момент подтверждения
+	CreatedAt      time.Time           `json:"created_at"`
+	UpdatedAt      time.Time           `json:"updated_at"`
+	ConfirmedAt    *time.Time          `json:"confirmed_at,omitempty"`
+}
+
+// IsConfirmed проверяет, подтвержден ли результат
+func (r *MatchResult) IsConfirmed() bool {
+	return r.Status == MatchResultStatusConfirmed
+}
+
+// MapResult - счет одной карты серии
+type MapResult struct {
+	ID           uint  `json:"id"`
+	VetoActionID *uint `json:"veto_action_id,omitempty"` // Пик карты; для десайдера не задан
+	MapID        uint  `json:"map_id"`
+	Order        int   `json:"order"` // Порядок карты в серии, начиная с 1
+	ScoreA       int   `json:"score_a"`
+	ScoreB       int   `json:"score_b"`
+}
+
+// Winner возвращает сторону, выигравшую карту
+func (m *MapResult) Winner() string {
+	if m.ScoreA > m.ScoreB {
+		return "A"
+	}
+	return "B"
+}
+
+// MatchResultPlayer - участник команды, за которую он играл серию
+type MatchResultPlayer struct {
+	UserID uint   `json:"user_id"`
+	Team   string `json:"team"` // "A" или "B"
+}
@@ -32,10 +32,13 @@ type Room struct {
 	MapPoolID       *uint        `json:"map_pool_id,omitempty"`
 	VetoType        *VetoType    `json:"veto_type,omitempty"` // Тип вето (bo1, bo3, bo5)
 	VetoSessionID   *uint        `json:"veto_session_id,omitempty"`
+	TeamAID         *uint        `json:"team_a_id,omitempty"` // Команды, между которыми проходит вето (опционально)
+	TeamBID         *uint        `json:"team_b_id,omitempty"`
 	MaxParticipants int          `json:"max_participants"`
 	CreatedAt       time.Time    `json:"created_at"`
 	UpdatedAt       time.Time    `json:"updated_at"`
 	Participants    []RoomParticipant `json:"participants,omitempty"`
+	Matches         []RoomMatch       `json:"matches,omitempty"` // Серия матчей комнаты по порядку; текущий - с VetoSessionID
 }
 
 // Validate проверяет валидность данных комнаты
@@ -67,6 +70,24 @@ func (r *Room) Validate() error {
 	return nil
 }
 
+// HasTeam проверяет, играет ли команда в комнате
+func (r *Room) HasTeam(teamID uint) bool {
+	return (r.TeamAID != nil && *r.TeamAID == teamID) || (r.TeamBID != nil && *r.TeamBID == teamID)
+}
+
+// CurrentMatch возвращает матч, сессия которого сейчас привязана к комнате
+func (r *Room) CurrentMatch() *RoomMatch {
+	if r.VetoSessionID == nil {
+		return nil
+	}
+	for i := range r.Matches {
+		if r.Matches[i].VetoSessionID == *r.VetoSessionID {
+			return &r.Matches[i]
+		}
+	}
+	return nil
+}
+
 // CanJoin проверяет, можно ли присоединиться к комнате
 func (r *Room) CanJoin() bool {
 	return r.Status == RoomStatusWaiting && len(r.Participants) < r.MaxParticipants
@@ -0,0 +1,14 @@
+package entities
+
+import "time"
+
+// RoomMatch - матч серии, сыгранной в комнате; у каждого матча своя сессия вето
+type RoomMatch struct {
+	ID            uint         `json:"id"`
+	RoomID        uint         `json:"room_id"`
+	VetoSessionID uint         `json:"veto_session_id"`
+	Number        int          `json:"number"`        // Порядковый номер матча в комнате, начиная с 1
+	SidesSwapped  bool         `json:"sides_swapped"` // Стороны A и B поменяны относительно настроек комнаты
+	CreatedAt     time.Time    `json:"created_at"`
+	Session       *VetoSession `json:"session,omitempty"` // Сессия вето (загружается отдельно)
+}
@@ -18,6 +18,7 @@ type RoomParticipant struct {
 	UserID   uint             `json:"user_id"`
 	Username *string          `json:"username,omitempty"` // Никнейм пользователя (загружается через JOIN)
 	Role     ParticipantRole  `json:"role"`
+	TeamID   *uint            `json:"team_id,omitempty"` // Команда комнаты, за которую играет участник
 	JoinedAt time.Time        `json:"joined_at"`
 }
 
@@ -0,0 +1,115 @@
+package entities
+
+import (
+	"errors"
+	"net/url"
+	"time"
+)
+
+type TeamRole string
+
+const (
+	TeamRoleCaptain TeamRole = "captain" // Управляет составом и настройками команды
+	TeamRolePlayer  TeamRole = "player"
+	TeamRoleCoach   TeamRole = "coach"
+)
+
+// IsValid проверяет, что роль в команде известна
+func (r TeamRole) IsValid() bool {
+	return r == TeamRoleCaptain || r == TeamRolePlayer || r == TeamRoleCoach
+}
+
+type Team struct {
+	ID        uint         `json:"id"`
+	Name      string       `json:"name"`
+	Tag       string       `json:"tag"`
+	LogoURL   string       `json:"logo_url"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
+	Members   []TeamMember `json:"members,omitempty"`
+}
+
+// Validate проверяет валидность данных команды
+func (t *Team) Validate() error {
+	if t.Name == "" {
+		return errors.New("name is required")
+	}
+	if len(t.Name) > 100 {
+		return errors.New("name must be no more than 100 characters")
+	}
+	if len(t.Tag) < 2 || len(t.Tag) > 10 {
+		return errors.New("tag must be between 2 and 10 characters")
+	}
+	if len(t.LogoURL) > 255 {
+		return errors.New("logo_url must be no more than 255 characters")
+	}
+	if t.LogoURL != "" {
+		u, err := url.Parse(t.LogoURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errors.New("logo_url must be an http(s) URL")
+		}
+	}
+	return nil
+}
+
+// GetMember возвращает участника состава или nil
+func (t *Team) GetMember(userID uint) *TeamMember {
+	for i := range t.Members {
+		if t.Members[i].UserID == userID {
+			return &t.Members[i]
+		}
+	}
+	return nil
+}
+
+// IsCaptain проверяет, является ли пользователь капитаном команды
+func (t *Team) IsCaptain(userID uint) bool {
+	member := t.GetMember(userID)
+	return member != nil && member.Role == TeamRoleCaptain
+}
+
+// CountCaptains возвращает количество капитанов в составе
+func (t *Team) CountCaptains() int {
+	count := 0
+	for _, member := range t.Members {
+		if member.Role == TeamRoleCaptain {
+			count++
+		}
+	}
+	return count
+}
+
+type TeamMember struct {
+	ID       uint      `json:"id"`
+	TeamID   uint      `json:"team_id"`
+	UserID   uint      `json:"user_id"`
+	Username *string   `json:"username,omitempty"` // Никнейм пользователя (загружается через JOIN)
+	Role     TeamRole  `json:"role"`
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+type TeamInviteStatus string
+
+const (
+	TeamInviteStatusPending  TeamInviteStatus = "pending"
+	TeamInviteStatusAccepted TeamInviteStatus = "accepted"
+	TeamInviteStatusDeclined TeamInviteStatus = "declined"
+)
+
+// TeamInvite приглашение пользователя в состав команды
+type TeamInvite struct {
+	ID          uint             `json:"id"`
+	TeamID      uint             `json:"team_id"`
+	UserID      uint             `json:"user_id"`
+	InvitedByID uint             `json:"invited_by_id"`
+	Role        TeamRole         `json:"role"`
+	Status      TeamInviteStatus `json:"status"`
+	CreatedAt   time.Time        `json:"created_at"`
+	RespondedAt *time.Time       `json:"responded_at,omitempty"`
+	Team        *Team            `json:"team,omitempty"` // Загружается для списка приглашений пользователя
+}
+
+// IsPending проверяет, ожидает ли приглашение ответа
+func (i *TeamInvite) IsPending() bool {
+	return i.Status == TeamInviteStatusPending
+}
@@ -0,0 +1,251 @@
+package entities
+
+import (
+	"errors"
+	"time"
+)
+
+type TournamentFormat string
+
+const (
+	TournamentFormatSingleElimination TournamentFormat = "single_elimination"
+	TournamentFormatDoubleElimination TournamentFormat = "double_elimination"
+	TournamentFormatSwiss             TournamentFormat = "swiss" // Групповой этап по швейцарской системе
+)
+
+// IsValid проверяет, что формат турнира известен
+func (f TournamentFormat) IsValid() bool {
+	return f == TournamentFormatSingleElimination ||
+		f == TournamentFormatDoubleElimination ||
+		f == TournamentFormatSwiss
+}
+
+type TournamentStatus string
+
+const (
+	TournamentStatusRegistration TournamentStatus = "registration"
+	TournamentStatusInProgress   TournamentStatus = "in_progress"
+	TournamentStatusFinished     TournamentStatus = "finished"
+)
+
+type Tournament struct {
+	ID            uint                  `json:"id"`
+	OwnerID       uint                  `json:"owner_id"` // Организатор
+	Name          string                `json:"name"`
+	GameID        uint                  `json:"game_id"`
+	MapPoolID     uint                  `json:"map_pool_id"`
+	Format        TournamentFormat      `json:"format"`
+	Status        TournamentStatus      `json:"status"`
+	MaxTeams      int                   `json:"max_teams"`
+	SwissRounds   int                   `json:"swiss_rounds,omitempty"`    // Количество раундов швейцарской системы
+	FinalVetoType VetoType              `json:"final_veto_type,omitempty"` // Формат финала и гранд-финала сетки на выбывание
+	WinnerTeamID  *uint                 `json:"winner_team_id,omitempty"`
+	StartedAt     *time.Time            `json:"started_at,omitempty"`
+	CreatedAt     time.Time             `json:"created_at"`
+	UpdatedAt     time.Time             `json:"updated_at"`
+	Teams         []TournamentTeam      `json:"teams,omitempty"`       // Зарегистрированные команды по посеву
+	Matches       []TournamentMatch     `json:"matches,omitempty"`     // Матчи сетки по сетке, раунду и позиции
+	StageRules    []TournamentStageRule `json:"stage_rules,omitempty"` // Ограничения пула карт по стадиям
+}
+
+// Validate проверяет валидность данных турнира
+func (t *Tournament) Validate() error {
+	if t.OwnerID == 0 {
+		return errors.New("owner_id is required")
+	}
+	if t.Name == "" {
+		return errors.New("name is required")
+	}
+	if len(t.Name) > 100 {
+		return errors.New("name must be no more than 100 characters")
+	}
+	if t.GameID == 0 {
+		return errors.New("game_id is required")
+	}
+	if t.MapPoolID == 0 {
+		return errors.New("map_pool_id is required")
+	}
+	if !t.Format.IsValid() {
+		return errors.New("invalid tournament format")
+	}
+	if t.MaxTeams < 2 || t.MaxTeams > 64 {
+		return errors.New("max_teams must be between 2 and 64")
+	}
+	if t.SwissRounds < 0 || t.SwissRounds > 10 {
+		return errors.New("swiss_rounds must be between 0 and 10")
+	}
+	if t.FinalVetoType != "" && t.FinalVetoType != VetoTypeBo3 && t.FinalVetoType != VetoTypeBo5 {
+		return errors.New("final_veto_type must be bo3 or bo5")
+	}
+	return nil
+}
+
+// IsOwner проверяет, является ли пользователь организатором
+func (t *Tournament) IsOwner(userID uint) bool {
+	return t.OwnerID == userID
+}
+
+// GetTeam возвращает зарегистрированную команду или nil
+func (t *Tournament) GetTeam(teamID uint) *TournamentTeam {
+	for i := range t.Teams {
+		if t.Teams[i].TeamID == teamID {
+			return &t.Teams[i]
+		}
+	}
+	return nil
+}
+
+// StageRule возвращает правила пула карт стадии или nil
+func (t *Tournament) StageRule(stage TournamentStage) *TournamentStageRule {
+	for i := range t.StageRules {
+		if t.StageRules[i].Stage == stage {
+			return &t.StageRules[i]
+		}
+	}
+	return nil
+}
+
+// GetMatch возвращает матч сетки или nil
+func (t *Tournament) GetMatch(matchID uint) *TournamentMatch {
+	for i := range t.Matches {
+		if t.Matches[i].ID == matchID {
+			return &t.Matches[i]
+		}
+	}
+	return nil
+}
+
+type TournamentStage string
+
+const (
+	TournamentStageSwiss    TournamentStage = "swiss"    // Групповой этап по швейцарской системе
+	TournamentStagePlayoffs TournamentStage = "playoffs" // Сетка на выбывание, включая финал
+)
+
+// TournamentStageRule ограничения пула карт для матчей стадии
+type TournamentStageRule struct {
+	Stage          TournamentStage `json:"stage"`
+	NoRepeatPicks  bool            `json:"no_repeat_picks"`            // Команда не может пикнуть карту, которую уже играла на этой стадии
+	CarryOverBans  bool            `json:"carry_over_bans"`            // Баны предыдущей серии каждой команды на стадии исключаются из пула
+	ExcludedMapIDs []uint          `json:"excluded_map_ids,omitempty"` // Карты, исключенные на всей стадии
+	DeciderMapID   *uint           `json:"decider_map_id,omitempty"`   // Десайдер всех Bo3 и Bo5 стадии
+}
+
+// TournamentTeam команда, зарегистрированная на турнир
+type TournamentTeam struct {
+	ID           uint      `json:"id"`
+	TournamentID uint      `json:"tournament_id"`
+	TeamID       uint      `json:"team_id"`
+	Seed         int       `json:"seed"` // Посев, начиная с 1
+	RegisteredAt time.Time `json:"registered_at"`
+	Team         *Team     `json:"team,omitempty"` // Загружается вместе с турниром
+}
+
+type TournamentBracket string
+
+const (
+	TournamentBracketUpper      TournamentBracket = "upper" // Основная сетка (и единственная при single elimination)
+	TournamentBracketLower      TournamentBracket = "lower" // Сетка проигравших
+	TournamentBracketGrandFinal TournamentBracket = "grand_final"
+	TournamentBracketSwiss      TournamentBracket = "swiss"
+)
+
+type TournamentMatchStatus string
+
+const (
+	TournamentMatchStatusPending  TournamentMatchStatus = "pending"  // Ждет победителей предыдущих матчей
+	TournamentMatchStatusReady    TournamentMatchStatus = "ready"    // Команды известны, комната и сессия созданы
+	TournamentMatchStatusFinished TournamentMatchStatus = "finished" // Сыгран или завершен без игры
+)
+
+// TournamentMatch матч турнирной сетки
+// Победитель переходит в слот NextSlot матча NextMatchID, проигравший (double elimination) - в LoserNextMatchID
+type TournamentMatch struct {
+	ID               uint                  `json:"id"`
+	TournamentID     uint                  `json:"tournament_id"`
+	Bracket          TournamentBracket     `json:"bracket"`
+	Round            int                   `json:"round"`    // Раунд внутри сетки, начиная с 1
+	Position         int                   `json:"position"` // Позиция в раунде, начиная с 1
+	TeamAID          *uint                 `json:"team_a_id,omitempty"`
+	TeamBID          *uint                 `json:"team_b_id,omitempty"`
+	WinnerTeamID     *uint                 `json:"winner_team_id,omitempty"` // Без победителя матч завершается, только если в нем нет ни одной команды
+	Status           TournamentMatchStatus `json:"status"`
+	VetoType         VetoType              `json:"veto_type"`
+	ScheduledAt      *time.Time            `json:"scheduled_at,omitempty"`
+	RoomID           *uint                 `json:"room_id,omitempty"`
+	VetoSessionID    *uint                 `json:"veto_session_id,omitempty"`
+	NextMatchID      *uint                 `json:"next_match_id,omitempty"`
+	NextSlot         string                `json:"next_slot,omitempty"` // "A" или "B"
+	LoserNextMatchID *uint                 `json:"loser_next_match_id,omitempty"`
+	LoserNextSlot    string                `json:"loser_next_slot,omitempty"`
+	CreatedAt        time.Time             `json:"created_at"`
+	UpdatedAt        time.Time             `json:"updated_at"`
+
+	// Связи между матчами при генерации сетки, до назначения ID
+	NextMatch      *TournamentMatch `json:"-"`
+	LoserNextMatch *TournamentMatch `json:"-"`
+}
+
+// IsFinished проверяет, завершен ли матч
+func (m *TournamentMatch) IsFinished() bool {
+	return m.Status == TournamentMatchStatusFinished
+}
+
+// Stage возвращает стадию турнира, к которой относится матч
+func (m *TournamentMatch) Stage() TournamentStage {
+	if m.Bracket == TournamentBracketSwiss {
+		return TournamentStageSwiss
+	}
+	return TournamentStagePlayoffs
+}
+
+// IsBye проверяет, прошла ли команда дальше без игры
+func (m *TournamentMatch) IsBye() bool {
+	return m.IsFinished() && m.VetoSessionID == nil
+}
+
+// Slot возвращает команду в слоте "A" или "B"
+func (m *TournamentMatch) Slot(slot string) *uint {
+	if slot == "A" {
+		return m.TeamAID
+	}
+	return m.TeamBID
+}
+
+// SetSlot ставит команду в слот "A" или "B"
+func (m *TournamentMatch) SetSlot(slot string, teamID *uint) {
+	if slot == "A" {
+		m.TeamAID = teamID
+	} else {
+		m.TeamBID = teamID
+	}
+}
+
+// HasTeam проверяет, играет ли команда в матче
+func (m *TournamentMatch) HasTeam(teamID uint) bool {
+	return (m.TeamAID != nil && *m.TeamAID == teamID) || (m.TeamBID != nil && *m.TeamBID == teamID)
+}
+
+// LoserTeamID возвращает проигравшую команду завершенного матча или nil
+func (m *TournamentMatch) LoserTeamID() *uint {
+	if m.WinnerTeamID == nil {
+		return nil
+	}
+	if m.TeamAID != nil && *m.TeamAID != *m.WinnerTeamID {
+		return m.TeamAID
+	}
+	if m.TeamBID != nil && *m.TeamBID != *m.WinnerTeamID {
+		return m.TeamBID
+	}
+	return nil
+}
+
+// TournamentStanding строка таблицы швейцарской системы
+type TournamentStanding struct {
+	TeamID   uint `json:"team_id"`
+	Seed     int  `json:"seed"`
+	Wins     int  `json:"wins"` // Включая пропуски раунда
+	Losses   int  `json:"losses"`
+	Byes     int  `json:"byes"`
+	Buchholz int  `json:"buchholz"` // Сумма побед соперников
+}
@@ -0,0 +1,39 @@
+package entities
+
+import "time"
+
+// UserTOTP настройки двухфакторной аутентификации пользователя (TOTP)
+// До подтверждения кодом из приложения 2FA не включена
+type UserTOTP struct {
+	UserID       uint       `json:"user_id"`
+	Secret       string     `json:"-"`
+	ConfirmedAt  *time.Time `json:"confirmed_at,omitempty"`
+	LastUsedStep int64      `json:"-"` // Последний принятый шаг TOTP, защищает от повторного использования кода
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// IsEnabled проверяет, что 2FA подтверждена и действует
+func (t *UserTOTP) IsEnabled() bool {
+	return t != nil && t.ConfirmedAt != nil
+}
+
+// RecoveryCode одноразовый код восстановления доступа при потере устройства
+// В БД хранится только хэш кода
+type RecoveryCode struct {
+	ID        uint       `json:"id"`
+	UserID    uint       `json:"user_id"`
+	CodeHash  string     `json:"-"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// LoginChallenge промежуточный шаг входа: пароль проверен, ожидается код 2FA
+type LoginChallenge struct {
+	ID        uint       `json:"id"`
+	UserID    uint       `json:"user_id"`
+	TokenHash string     `json:"-"`
+	Attempts  int        `json:"attempts"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
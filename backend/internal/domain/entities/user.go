@@ -5,13 +5,25 @@ import (
 	"time"
 )
 
+type ProfileVisibility string
+
+const (
+	ProfileVisibilityPublic  ProfileVisibility = "public"  // Публичный профиль показывает статистику вето
+	ProfileVisibilityPrivate ProfileVisibility = "private" // Виден только username
+)
+
 type User struct {
-	ID        uint      `json:"id"`
-	Email     string    `json:"email"`
-	Username  string    `json:"username"`
-	Password  string    `json:"-"` // не возвращается в JSON
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID       uint   `json:"id"`
+	Email    string `json:"email"`
+	Username string `json:"username"`
+	Password string `json:"-"` // не возвращается в JSON
+	// EmailVerified подтвержден ли текущий email; сбрасывается при смене email
+	EmailVerified bool `json:"email_verified"`
+	// IsGuest временный аккаунт без пароля и настоящего email
+	IsGuest           bool              `json:"is_guest"`
+	ProfileVisibility ProfileVisibility `json:"profile_visibility"`
+	CreatedAt         time.Time         `json:"created_at"`
+	UpdatedAt         time.Time         `json:"updated_at"`
 }
 
 // Validate проверяет валидность данных пользователя
@@ -28,5 +40,13 @@ func (u *User) Validate() error {
 	if u.Password == "" {
 		return errors.New("password is required")
 	}
+	if u.ProfileVisibility != "" && u.ProfileVisibility != ProfileVisibilityPublic && u.ProfileVisibility != ProfileVisibilityPrivate {
+		return errors.New("invalid profile visibility")
+	}
 	return nil
 }
+
+// IsProfilePublic проверяет, показывается ли статистика пользователя другим
+func (u *User) IsProfilePublic() bool {
+	return !u.IsGuest && u.ProfileVisibility != ProfileVisibilityPrivate
+}
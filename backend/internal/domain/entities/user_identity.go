@@ -0,0 +1,23 @@
+package entities
+
+import "time"
+
+// UserIdentity привязка пользователя к аккаунту внешнего провайдера (OAuth2/OIDC)
+type UserIdentity struct {
+	ID        uint      `json:"id"`
+	UserID    uint      `json:"user_id"`
+	Provider  string    `json:"provider"`
+	Subject   string    `json:"subject"` // Идентификатор пользователя у провайдера
+	Email     string    `json:"email"`   // Email на момент привязки
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// OAuthState состояние начатого входа через провайдера (state + PKCE verifier)
+// Используется однократно при обработке callback
+type OAuthState struct {
+	State        string    `json:"-"`
+	Provider     string    `json:"provider"`
+	CodeVerifier string    `json:"-"`
+	ExpiresAt    time.Time `json:"expires_at"`
+	CreatedAt    time.Time `json:"created_at"`
+}
@@ -0,0 +1,54 @@
+package entities
+
+// VetoConstraints ограничения пула карт сессии поверх ее собственных действий:
+// карты, сыгранные или забаненные в предыдущих сериях, и заранее назначенный десайдер
+type VetoConstraints struct {
+	ExcludedMapIDs      []uint `json:"excluded_map_ids,omitempty"`        // Недоступны обеим командам (например, перенесенные баны)
+	TeamAExcludedMapIDs []uint `json:"team_a_excluded_map_ids,omitempty"` // Команда A не может их пикнуть (например, уже сыграны)
+	TeamBExcludedMapIDs []uint `json:"team_b_excluded_map_ids,omitempty"`
+	DeciderMapID        *uint  `json:"decider_map_id,omitempty"` // Десайдер назначен заранее и не участвует в банах и пиках
+}
+
+// IsEmpty проверяет, что ограничений нет
+func (c *VetoConstraints) IsEmpty() bool {
+	return c == nil || (len(c.ExcludedMapIDs) == 0 &&
+		len(c.TeamAExcludedMapIDs) == 0 &&
+		len(c.TeamBExcludedMapIDs) == 0 &&
+		c.DeciderMapID == nil)
+}
+
+// IsExcluded проверяет, что карта недоступна для банов и пиков обеих команд
+func (c *VetoConstraints) IsExcluded(mapID uint) bool {
+	if c == nil {
+		return false
+	}
+	if c.DeciderMapID != nil && *c.DeciderMapID == mapID {
+		return true
+	}
+	return containsMapID(c.ExcludedMapIDs, mapID)
+}
+
+// TeamExcludedMapIDs возвращает карты, которые команда "A" или "B" не может пикнуть
+func (c *VetoConstraints) TeamExcludedMapIDs(team string) []uint {
+	if c == nil {
+		return nil
+	}
+	if team == "A" {
+		return c.TeamAExcludedMapIDs
+	}
+	return c.TeamBExcludedMapIDs
+}
+
+// IsExcludedForTeam проверяет, что команда не может пикнуть карту
+func (c *VetoConstraints) IsExcludedForTeam(mapID uint, team string) bool {
+	return containsMapID(c.TeamExcludedMapIDs(team), mapID)
+}
+
+func containsMapID(mapIDs []uint, mapID uint) bool {
+	for _, id := range mapIDs {
+		if id == mapID {
+			return true
+		}
+	}
+	return false
+}
@@ -27,10 +27,13 @@ type VetoSession struct {
 	UserID        *uint       `json:"user_id,omitempty"`
 	GameID        uint        `json:"game_id"`
 	MapPoolID     uint        `json:"map_pool_id"`
+	MapRotationID *uint       `json:"map_rotation_id,omitempty"` // Ротация, действовавшая на момент создания
 	Type          VetoType    `json:"type"`
 	Status        VetoStatus  `json:"status"`
 	TeamAName     string      `json:"team_a_name"`
 	TeamBName     string      `json:"team_b_name"`
+	TeamAID       *uint       `json:"team_a_id,omitempty"` // Зарегистрированная команда A (опционально)
+	TeamBID       *uint       `json:"team_b_id,omitempty"`
 	CurrentTeam   string      `json:"current_team"`
 	SelectedMapID *uint       `json:"selected_map_id,omitempty"`
 	SelectedSide  *string     `json:"selected_side,omitempty"`
@@ -40,6 +43,8 @@ type VetoSession struct {
 	UpdatedAt     time.Time   `json:"updated_at"`
 	FinishedAt    *time.Time  `json:"finished_at,omitempty"`
 	Actions       []VetoAction `json:"actions,omitempty"`
+	MapSnapshot   []Map        `json:"map_snapshot,omitempty"` // Карты пула на момент создания сессии
+	Constraints   *VetoConstraints `json:"constraints,omitempty"` // Ограничения пула из предыдущих серий
 }
 
 // Validate проверяет валидность данных сессии вето
@@ -71,6 +76,17 @@ func (vs *VetoSession) Validate() error {
 	return nil
 }
 
+// GetTeamSide возвращает сторону сессии ("A" или "B"), за которую играет команда
+func (vs *VetoSession) GetTeamSide(teamID uint) string {
+	if vs.TeamAID != nil && *vs.TeamAID == teamID {
+		return "A"
+	}
+	if vs.TeamBID != nil && *vs.TeamBID == teamID {
+		return "B"
+	}
+	return ""
+}
+
 // CanBan проверяет, можно ли забанить карту
 func (vs *VetoSession) CanBan() bool {
 	return vs.Status == VetoStatusInProgress && !vs.IsFinished()
@@ -0,0 +1,40 @@
+package entities
+
+// UserVetoStats агрегированная статистика вето по сессиям пользователя или команды
+type UserVetoStats struct {
+	SessionsTotal    int64           `json:"sessions_total"`
+	SessionsFinished int64           `json:"sessions_finished"`
+	Formats          []VetoTypeCount `json:"formats"`
+	MostBanned       []MapCount      `json:"most_banned"`
+	MostPicked       []MapCount      `json:"most_picked"`
+	Sides            []SideCount     `json:"sides"`
+	Results          ResultStats     `json:"results"` // Итоги серий по подтвержденным результатам
+}
+
+// ResultStats итоги сыгранных серий
+type ResultStats struct {
+	SeriesPlayed int64 `json:"series_played"`
+	SeriesWon    int64 `json:"series_won"`
+	SeriesLost   int64 `json:"series_lost"`
+	MapsWon      int64 `json:"maps_won"`
+	MapsLost     int64 `json:"maps_lost"`
+}
+
+// VetoTypeCount количество сессий одного формата
+type VetoTypeCount struct {
+	Type  VetoType `json:"type"`
+	Count int64    `json:"count"`
+}
+
+// MapCount сколько раз карта была забанена или выбрана
+type MapCount struct {
+	MapID   uint   `json:"map_id"`
+	MapName string `json:"map_name"`
+	Count   int64  `json:"count"`
+}
+
+// SideCount сколько раз сторона была выбрана после пика
+type SideCount struct {
+	Side  string `json:"side"`
+	Count int64  `json:"count"`
+}
@@ -0,0 +1,90 @@
+package entities
+
+import "time"
+
+// WebhookEvent событие вето, на которое можно подписаться
+type WebhookEvent string
+
+const (
+	WebhookEventVetoStarted  WebhookEvent = "veto.started"
+	WebhookEventMapBanned    WebhookEvent = "veto.map_banned"
+	WebhookEventMapPicked    WebhookEvent = "veto.map_picked"
+	WebhookEventSideSelected WebhookEvent = "veto.side_selected"
+	WebhookEventVetoFinished WebhookEvent = "veto.finished"
+	WebhookEventVetoReset    WebhookEvent = "veto.reset"
+	WebhookEventPing         WebhookEvent = "ping" // Тестовая доставка, отправляется только вручную
+)
+
+// WebhookEvents события, на которые можно подписаться
+var WebhookEvents = []WebhookEvent{
+	WebhookEventVetoStarted,
+	WebhookEventMapBanned,
+	WebhookEventMapPicked,
+	WebhookEventSideSelected,
+	WebhookEventVetoFinished,
+	WebhookEventVetoReset,
+}
+
+// IsValid проверяет, что на событие можно подписаться
+func (e WebhookEvent) IsValid() bool {
+	for _, event := range WebhookEvents {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// Webhook подписка на события вето
+// Подписка комнаты (RoomID задан) получает события только этой комнаты,
+// подписка пользователя - события его комнат и созданных им сессий
+type Webhook struct {
+	ID        uint           `json:"id"`
+	UserID    uint           `json:"user_id"`
+	RoomID    *uint          `json:"room_id,omitempty"`
+	URL       string         `json:"url"`
+	Secret    string         `json:"-"`      // Ключ подписи HMAC-SHA256
+	Events    []WebhookEvent `json:"events"` // Пустой список - все события
+	IsActive  bool           `json:"is_active"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+}
+
+// Subscribes проверяет, что подписка получает событие
+func (w *Webhook) Subscribes(event WebhookEvent) bool {
+	if len(w.Events) == 0 {
+		return true
+	}
+	for _, e := range w.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryStatusPending   WebhookDeliveryStatus = "pending" // Ждет первой или повторной попытки
+	WebhookDeliveryStatusSucceeded WebhookDeliveryStatus = "succeeded"
+	WebhookDeliveryStatusFailed    WebhookDeliveryStatus = "failed" // Попытки исчерпаны
+)
+
+// WebhookDelivery доставка события подписке и результат последней попытки
+type WebhookDelivery struct {
+	ID             uint                  `json:"id"`
+	WebhookID      uint                  `json:"webhook_id"`
+	Event          WebhookEvent          `json:"event"`
+	Payload        string                `json:"payload"` // Тело запроса, при повторной доставке отправляется без изменений
+	Status         WebhookDeliveryStatus `json:"status"`
+	Attempts       int                   `json:"attempts"`
+	ResponseStatus *int                  `json:"response_status,omitempty"`
+	ResponseBody   string                `json:"response_body,omitempty"` // Начало ответа получателя
+	LastError      string                `json:"last_error,omitempty"`
+	NextAttemptAt  *time.Time            `json:"next_attempt_at,omitempty"`
+	DeliveredAt    *time.Time            `json:"delivered_at,omitempty"`
+	RedeliveryOf   *uint                 `json:"redelivery_of,omitempty"` // Доставка, повторенная вручную
+	CreatedAt      time.Time             `json:"created_at"`
+	UpdatedAt      time.Time             `json:"updated_at"`
+}
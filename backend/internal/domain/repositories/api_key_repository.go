@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/bbp/backend/internal/domain/entities"
+)
+
+type APIKeyRepository interface {
+	Create(key *entities.APIKey) error
+	// GetByID и GetByHash возвращают nil, если ключа нет
+	GetByID(id uint) (*entities.APIKey, error)
+	GetByHash(keyHash string) (*entities.APIKey, error)
+	// Ключи пользователя, включая отозванные, новые первыми
+	GetByUserID(userID uint) ([]entities.APIKey, error)
+	Revoke(id uint, revokedAt time.Time) error
+	UpdateLastUsed(id uint, usedAt time.Time, ip string) error
+}
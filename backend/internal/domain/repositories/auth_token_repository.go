@@ -0,0 +1,25 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/bbp/backend/internal/domain/entities"
+)
+
+type RefreshTokenRepository interface {
+	Create(token *entities.RefreshToken) error
+	GetByHash(tokenHash string) (*entities.RefreshToken, error)
+	// Получение неотозванных и неистекших токенов пользователя
+	GetActiveByUserID(userID uint) ([]entities.RefreshToken, error)
+	// Отзыв действующего токена; false - токен уже был отозван (например, параллельным запросом)
+	Revoke(id uint, replacedByID *uint) (bool, error)
+	RevokeAllByUserID(userID uint) error
+	// Удаление истекших токенов, в том числе отозванных при ротации
+	DeleteExpired(now time.Time) error
+}
+
+type RevokedTokenRepository interface {
+	Create(token *entities.RevokedToken) error
+	IsRevoked(jti string) (bool, error)
+	DeleteExpired(now time.Time) error
+}
@@ -0,0 +1,12 @@
+package repositories
+
+import "github.com/bbp/backend/internal/domain/entities"
+
+type EmailVerificationTokenRepository interface {
+	Create(token *entities.EmailVerificationToken) error
+	GetByHash(tokenHash string) (*entities.EmailVerificationToken, error)
+	// Помечает токен использованным; возвращает false, если токен уже был использован
+	MarkUsed(id uint) (bool, error)
+	// Помечает использованными все неиспользованные токены пользователя
+	InvalidateByUserID(userID uint) error
+}
@@ -0,0 +1,22 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/bbp/backend/internal/domain/entities"
+)
+
+type LoginThrottleRepository interface {
+	// Get возвращает nil, если неудачных попыток не было
+	Get(scope entities.LoginThrottleScope, key string) (*entities.LoginThrottle, error)
+	Save(throttle *entities.LoginThrottle) error
+	Delete(scope entities.LoginThrottleScope, key string) error
+	// DeleteStale удаляет счетчики без неудачных попыток и активной блокировки с момента before
+	DeleteStale(before time.Time) error
+}
+
+type LockoutEventRepository interface {
+	Create(event *entities.LockoutEvent) error
+	// List возвращает записи журнала, новые первыми
+	List(limit, offset int) ([]entities.LockoutEvent, error)
+}
@@ -2,6 +2,12 @@ package repositories
 
 import "github.com/bbp/backend/internal/domain/entities"
 
+// MapPoolFilter фильтр для библиотеки публичных пулов
+type MapPoolFilter struct {
+	GameID *uint   // Опционально: только пулы игры
+	Query  *string // Опционально: поиск по названию
+}
+
 type MapPoolRepository interface {
 	Create(pool *entities.MapPool) error
 	GetByID(id uint) (*entities.MapPool, error)
@@ -13,4 +19,8 @@ type MapPoolRepository interface {
 	Delete(id uint) error
 	AddMap(poolID, mapID uint) error
 	RemoveMap(poolID, mapID uint) error
+	GetByShareSlug(slug string) (*entities.MapPool, error)
+	// Библиотека публичных пулов
+	GetPublic(filter *MapPoolFilter, limit, offset int) ([]entities.MapPool, error)
+	CountPublic(filter *MapPoolFilter) (int64, error)
 }
@@ -7,6 +7,7 @@ type MapRepository interface {
 	GetByID(id uint) (*entities.Map, error)
 	GetByGameID(gameID uint) ([]entities.Map, error)
 	GetBySlug(slug string) (*entities.Map, error)
+	GetByGameIDAndSlug(gameID uint, slug string) (*entities.Map, error)
 	Update(m *entities.Map) error
 	Delete(id uint) error
 }
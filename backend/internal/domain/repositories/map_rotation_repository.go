@@ -0,0 +1,14 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/bbp/backend/internal/domain/entities"
+)
+
+type MapRotationRepository interface {
+	Create(rotation *entities.MapRotation) error
+	GetByGameID(gameID uint) ([]entities.MapRotation, error) // Сначала самые новые
+	// Получение ротации, действующей на указанный момент
+	GetActive(gameID uint, at time.Time) (*entities.MapRotation, error)
+}
@@ -0,0 +1,12 @@
+package repositories
+
+import "github.com/bbp/backend/internal/domain/entities"
+
+type MatchResultRepository interface {
+	Create(result *entities.MatchResult) error
+	// Результат сессии вместе со счетом карт и составами; nil, если результата нет
+	GetBySessionID(sessionID uint) (*entities.MatchResult, error)
+	// Обновляет результат, заменяя счет карт и составы
+	Update(result *entities.MatchResult) error
+	DeleteBySessionID(sessionID uint) error
+}
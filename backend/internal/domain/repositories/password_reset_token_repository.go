@@ -0,0 +1,12 @@
+package repositories
+
+import "github.com/bbp/backend/internal/domain/entities"
+
+type PasswordResetTokenRepository interface {
+	Create(token *entities.PasswordResetToken) error
+	GetByHash(tokenHash string) (*entities.PasswordResetToken, error)
+	// Помечает токен использованным; возвращает false, если токен уже был использован
+	MarkUsed(id uint) (bool, error)
+	// Помечает использованными все неиспользованные токены пользователя
+	InvalidateByUserID(userID uint) error
+}
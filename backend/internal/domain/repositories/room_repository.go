@@ -18,12 +18,21 @@ type RoomRepository interface {
 	Delete(id uint) error
 	AddParticipant(participant *entities.RoomParticipant) error
 	RemoveParticipant(roomID, userID uint) error
+	UpdateParticipantRole(roomID, userID uint, role entities.ParticipantRole) error
 	GetParticipants(roomID uint) ([]entities.RoomParticipant, error)
 	GetParticipant(roomID, userID uint) (*entities.RoomParticipant, error)
 	// Получение комнаты, в которой участвует пользователь
 	GetUserRoom(userID uint) (*entities.Room, error)
-	// Получение комнаты по veto_session_id
+	// Получение всех комнат, в которых участвует пользователь (включая свои)
+	GetByParticipantID(userID uint) ([]entities.Room, error)
+	// Получение комнаты по veto_session_id (текущей или одной из сессий серии)
 	GetByVetoSessionID(sessionID uint) (*entities.Room, error)
+	// Добавляет матч в серию комнаты и делает его сессию текущей; номер матча назначается автоматически.
+	// Матч добавляется, только если текущая сессия комнаты все еще expectedSessionID;
+	// false - ее уже сменил параллельный запрос
+	AddMatch(match *entities.RoomMatch, expectedSessionID *uint) (bool, error)
+	// Матчи комнаты по порядку
+	GetMatches(roomID uint) ([]entities.RoomMatch, error)
 	// Подсчет количества комнат с фильтром
 	Count(filter *RoomFilter) (int64, error)
 }
@@ -0,0 +1,30 @@
+package repositories
+
+import "github.com/bbp/backend/internal/domain/entities"
+
+type TeamRepository interface {
+	Create(team *entities.Team) error
+	GetByID(id uint) (*entities.Team, error)
+	GetByName(name string) (*entities.Team, error)
+	// Получение команд, в составе которых состоит пользователь
+	GetByMemberID(userID uint) ([]entities.Team, error)
+	Update(team *entities.Team) error
+	// Удаление команды вместе с составом и приглашениями; сессии сохраняют ссылку на команду
+	Delete(id uint) error
+	AddMember(member *entities.TeamMember) error
+	RemoveMember(teamID, userID uint) error
+	UpdateMemberRole(teamID, userID uint, role entities.TeamRole) error
+	GetMember(teamID, userID uint) (*entities.TeamMember, error)
+}
+
+type TeamInviteRepository interface {
+	Create(invite *entities.TeamInvite) error
+	GetByID(id uint) (*entities.TeamInvite, error)
+	// Ожидающее ответа приглашение пользователя в команду
+	GetPending(teamID, userID uint) (*entities.TeamInvite, error)
+	// Ожидающие ответа приглашения пользователя вместе с командами
+	GetPendingByUserID(userID uint) ([]entities.TeamInvite, error)
+	GetPendingByTeamID(teamID uint) ([]entities.TeamInvite, error)
+	Update(invite *entities.TeamInvite) error
+	Delete(id uint) error
+}
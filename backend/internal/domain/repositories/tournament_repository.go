@@ -0,0 +1,24 @@
+package repositories
+
+import "github.com/bbp/backend/internal/domain/entities"
+
+type TournamentRepository interface {
+	Create(tournament *entities.Tournament) error
+	// Получение турнира вместе с командами и матчами сетки
+	GetByID(id uint) (*entities.Tournament, error)
+	// Список турниров без матчей, новые первыми; status опционален
+	GetList(status *entities.TournamentStatus, limit, offset int) ([]entities.Tournament, error)
+	Count(status *entities.TournamentStatus) (int64, error)
+	Update(tournament *entities.Tournament) error
+	// Удаление турнира вместе с регистрациями и матчами; комнаты и сессии матчей остаются
+	Delete(id uint) error
+	AddTeam(team *entities.TournamentTeam) error
+	RemoveTeam(tournamentID, teamID uint) error
+	// Сохранение посева зарегистрированных команд
+	UpdateSeeds(tournamentID uint, teams []entities.TournamentTeam) error
+	// Создание матчей сетки в одной транзакции; связи NextMatch и LoserNextMatch превращаются в ID
+	CreateMatches(matches []*entities.TournamentMatch) error
+	UpdateMatch(match *entities.TournamentMatch) error
+	// Матч турнира, для которого создана сессия вето, или nil
+	GetMatchBySessionID(sessionID uint) (*entities.TournamentMatch, error)
+}
@@ -0,0 +1,36 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/bbp/backend/internal/domain/entities"
+)
+
+type TwoFactorRepository interface {
+	// GetByUserID возвращает nil, если пользователь не начинал настройку 2FA
+	GetByUserID(userID uint) (*entities.UserTOTP, error)
+	// Save создает или заменяет настройки пользователя
+	Save(totp *entities.UserTOTP) error
+	Confirm(userID uint, step int64) error
+	// UseStep сохраняет шаг TOTP; возвращает false, если шаг не новее уже использованного
+	UseStep(userID uint, step int64) (bool, error)
+	Delete(userID uint) error
+}
+
+type RecoveryCodeRepository interface {
+	// ReplaceForUser удаляет старые коды пользователя и сохраняет новые
+	ReplaceForUser(userID uint, codeHashes []string) error
+	// UseCode помечает неиспользованный код использованным; false, если кода нет
+	UseCode(userID uint, codeHash string) (bool, error)
+	CountUnused(userID uint) (int64, error)
+	DeleteByUserID(userID uint) error
+}
+
+type LoginChallengeRepository interface {
+	Create(challenge *entities.LoginChallenge) error
+	GetByHash(tokenHash string) (*entities.LoginChallenge, error)
+	IncrementAttempts(id uint) error
+	// MarkUsed возвращает false, если challenge уже был использован
+	MarkUsed(id uint) (bool, error)
+	DeleteExpired(now time.Time) error
+}
@@ -0,0 +1,20 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/bbp/backend/internal/domain/entities"
+)
+
+type UserIdentityRepository interface {
+	Create(identity *entities.UserIdentity) error
+	GetByProviderSubject(provider, subject string) (*entities.UserIdentity, error)
+	GetByUserID(userID uint) ([]entities.UserIdentity, error)
+}
+
+type OAuthStateRepository interface {
+	Create(state *entities.OAuthState) error
+	// Consume возвращает состояние и удаляет его; nil, если состояние не найдено
+	Consume(state string) (*entities.OAuthState, error)
+	DeleteExpired(now time.Time) error
+}
@@ -1,12 +1,19 @@
 package repositories
 
-import "github.com/bbp/backend/internal/domain/entities"
+import (
+	"time"
+
+	"github.com/bbp/backend/internal/domain/entities"
+)
 
 type UserRepository interface {
 	Create(user *entities.User) error
 	GetByID(id uint) (*entities.User, error)
 	GetByEmail(email string) (*entities.User, error)
 	GetByUsername(username string) (*entities.User, error)
+	// GetGuestsCreatedBefore возвращает гостевые аккаунты, созданные раньше before
+	GetGuestsCreatedBefore(before time.Time) ([]entities.User, error)
 	Update(user *entities.User) error
+	// Delete окончательно удаляет пользователя вместе с токенами, привязками OAuth и настройками 2FA
 	Delete(id uint) error
 }
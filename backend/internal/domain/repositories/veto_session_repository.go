@@ -7,6 +7,11 @@ type VetoSessionRepository interface {
 	GetByID(id uint) (*entities.VetoSession, error)
 	GetByShareToken(token string) (*entities.VetoSession, error)
 	GetByUserID(userID uint) ([]entities.VetoSession, error)
+	// История сессий, в которых участвовала команда (за любую из сторон)
+	GetByTeamID(teamID uint) ([]entities.VetoSession, error)
+	CountInProgressByMapPoolID(mapPoolID uint) (int64, error) // Количество идущих сессий, использующих пул
 	Update(session *entities.VetoSession) error
+	// Отвязывает сессии от пользователя; история сессий и действий сохраняется
+	AnonymizeByUserID(userID uint) error
 	Delete(id uint) error
 }
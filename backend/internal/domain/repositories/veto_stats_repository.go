@@ -0,0 +1,11 @@
+package repositories
+
+import "github.com/bbp/backend/internal/domain/entities"
+
+type VetoStatsRepository interface {
+	// GetUserStats считает статистику по сессиям, которые пользователь создал или в которых играл,
+	// и по действиям его команды в них; mapLimit ограничивает топы карт
+	GetUserStats(userID uint, mapLimit int) (*entities.UserVetoStats, error)
+	// GetTeamStats считает статистику команды по её действиям во всех сессиях, где она участвовала
+	GetTeamStats(teamID uint, mapLimit int) (*entities.UserVetoStats, error)
+}
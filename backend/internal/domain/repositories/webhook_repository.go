@@ -0,0 +1,33 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/bbp/backend/internal/domain/entities"
+)
+
+type WebhookRepository interface {
+	Create(webhook *entities.Webhook) error
+	// GetByID возвращает nil, если подписки нет
+	GetByID(id uint) (*entities.Webhook, error)
+	// Подписки пользователя, включая подписки его комнат
+	GetByUserID(userID uint) ([]entities.Webhook, error)
+	// Активные подписки комнаты
+	GetActiveByRoomID(roomID uint) ([]entities.Webhook, error)
+	// Активные подписки пользователя без привязки к комнате
+	GetActiveByUser(userID uint) ([]entities.Webhook, error)
+	Update(webhook *entities.Webhook) error
+	// Удаление подписки вместе с журналом доставок
+	Delete(id uint) error
+}
+
+type WebhookDeliveryRepository interface {
+	Create(delivery *entities.WebhookDelivery) error
+	// GetByID возвращает nil, если доставки нет
+	GetByID(id uint) (*entities.WebhookDelivery, error)
+	// Журнал доставок подписки, новые первыми
+	GetByWebhookID(webhookID uint, limit, offset int) ([]entities.WebhookDelivery, error)
+	// Доставки в статусе pending, время попытки которых наступило к now, старые первыми
+	GetDue(now time.Time, limit int) ([]entities.WebhookDelivery, error)
+	Update(delivery *entities.WebhookDelivery) error
+}
@@ -0,0 +1,41 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/bbp/backend/internal/domain/entities"
+)
+
+// LockoutEventResponse DTO записи журнала блокировок входа
+type LockoutEventResponse struct {
+	ID          uint    `json:"id"`
+	Action      string  `json:"action"`
+	Scope       string  `json:"scope"`
+	Key         string  `json:"key"`
+	UserID      *uint   `json:"user_id,omitempty"`
+	IP          string  `json:"ip,omitempty"`
+	Failures    int     `json:"failures"`
+	LockedUntil *string `json:"locked_until,omitempty"`
+	ActorID     *uint   `json:"actor_id,omitempty"`
+	CreatedAt   string  `json:"created_at"`
+}
+
+// ToLockoutEventResponse конвертирует entity LockoutEvent в LockoutEventResponse
+func ToLockoutEventResponse(event *entities.LockoutEvent) LockoutEventResponse {
+	response := LockoutEventResponse{
+		ID:        event.ID,
+		Action:    string(event.Action),
+		Scope:     string(event.Scope),
+		Key:       event.Key,
+		UserID:    event.UserID,
+		IP:        event.IP,
+		Failures:  event.Failures,
+		ActorID:   event.ActorID,
+		CreatedAt: event.CreatedAt.Format(time.RFC3339),
+	}
+	if event.LockedUntil != nil {
+		lockedUntil := event.LockedUntil.Format(time.RFC3339)
+		response.LockedUntil = &lockedUntil
+	}
+	return response
+}
@@ -0,0 +1,75 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/bbp/backend/internal/domain/entities"
+)
+
+// CreateAPIKeyRequest DTO для создания API ключа
+type CreateAPIKeyRequest struct {
+	Name      string     `json:"name" binding:"required,max=100"`
+	Scopes    []string   `json:"scopes" binding:"required"` // read и/или veto
+	RoomIDs   []uint     `json:"room_ids"`                  // Ограничить управление вето комнатами; пустой список - без ограничения
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// APIKeyResponse DTO для API ключа
+type APIKeyResponse struct {
+	ID         uint     `json:"id"`
+	Name       string   `json:"name"`
+	Prefix     string   `json:"prefix"`
+	Key        string   `json:"key,omitempty"` // Только при создании
+	Scopes     []string `json:"scopes"`
+	RoomIDs    []uint   `json:"room_ids"`
+	LastUsedAt *string  `json:"last_used_at,omitempty"`
+	LastUsedIP string   `json:"last_used_ip,omitempty"`
+	ExpiresAt  *string  `json:"expires_at,omitempty"`
+	RevokedAt  *string  `json:"revoked_at,omitempty"`
+	CreatedAt  string   `json:"created_at"`
+}
+
+// ToAPIKeyScopes конвертирует scopes запроса
+func ToAPIKeyScopes(scopes []string) []entities.APIKeyScope {
+	result := make([]entities.APIKeyScope, len(scopes))
+	for i, scope := range scopes {
+		result[i] = entities.APIKeyScope(scope)
+	}
+	return result
+}
+
+// ToAPIKeyResponse конвертирует entity APIKey в APIKeyResponse; сам ключ передается только при создании
+func ToAPIKeyResponse(key *entities.APIKey, rawKey string) APIKeyResponse {
+	scopes := make([]string, len(key.Scopes))
+	for i, scope := range key.Scopes {
+		scopes[i] = string(scope)
+	}
+	roomIDs := key.RoomIDs
+	if roomIDs == nil {
+		roomIDs = []uint{}
+	}
+
+	response := APIKeyResponse{
+		ID:         key.ID,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Key:        rawKey,
+		Scopes:     scopes,
+		RoomIDs:    roomIDs,
+		LastUsedIP: key.LastUsedIP,
+		CreatedAt:  key.CreatedAt.Format(time.RFC3339),
+	}
+	if key.LastUsedAt != nil {
+		lastUsedAt := key.LastUsedAt.Format(time.RFC3339)
+		response.LastUsedAt = &lastUsedAt
+	}
+	if key.ExpiresAt != nil {
+		expiresAt := key.ExpiresAt.Format(time.RFC3339)
+		response.ExpiresAt = &expiresAt
+	}
+	if key.RevokedAt != nil {
+		revokedAt := key.RevokedAt.Format(time.RFC3339)
+		response.RevokedAt = &revokedAt
+	}
+	return response
+}
@@ -15,26 +15,118 @@ type LoginRequest struct {
 	Password string `json:"password" binding:"required"`
 }
 
+// RefreshRequest DTO для обновления токенов
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// ForgotPasswordRequest DTO для запроса сброса пароля
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// ResetPasswordRequest DTO для установки нового пароля по токену сброса
+type ResetPasswordRequest struct {
+	Token       string `json:"token" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required,min=6"`
+}
+
+// VerifyEmailRequest DTO для подтверждения email
+type VerifyEmailRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// UpgradeGuestRequest DTO для превращения гостевого аккаунта в полноценный
+type UpgradeGuestRequest struct {
+	Email    string  `json:"email" binding:"required,email"`
+	Username *string `json:"username,omitempty" binding:"omitempty,min=3,max=50"`
+	Password string  `json:"password" binding:"required,min=6"`
+}
+
+// TwoFactorCodeRequest DTO для подтверждения настройки 2FA
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+// DisableTwoFactorRequest DTO для отключения 2FA
+type DisableTwoFactorRequest struct {
+	Password string `json:"password" binding:"required"`
+	Code     string `json:"code" binding:"required"`
+}
+
+// LoginTwoFactorRequest DTO для второго шага входа
+type LoginTwoFactorRequest struct {
+	ChallengeToken string `json:"challenge_token" binding:"required"`
+	Code           string `json:"code" binding:"required"`
+}
+
+// LogoutRequest DTO для выхода (refresh токен опционален)
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
 // AuthResponse DTO для ответов авторизации
 type AuthResponse struct {
-	Token string      `json:"token"`
-	User  UserResponse `json:"user"`
+	Token            string       `json:"token"`
+	ExpiresAt        string       `json:"expires_at"`
+	RefreshToken     string       `json:"refresh_token"`
+	RefreshExpiresAt string       `json:"refresh_expires_at"`
+	User             UserResponse `json:"user"`
+}
+
+// TwoFactorChallengeResponse DTO ответа на вход, когда требуется код 2FA
+type TwoFactorChallengeResponse struct {
+	TwoFactorRequired  bool   `json:"two_factor_required"`
+	ChallengeToken     string `json:"challenge_token"`
+	ChallengeExpiresAt string `json:"challenge_expires_at"`
+}
+
+// TwoFactorSetupResponse DTO с секретом для приложения-аутентификатора
+type TwoFactorSetupResponse struct {
+	Secret     string `json:"secret"`
+	OtpauthURI string `json:"otpauth_uri"`
+}
+
+// TwoFactorStatusResponse DTO состояния 2FA пользователя
+type TwoFactorStatusResponse struct {
+	Enabled                bool    `json:"enabled"`
+	EnabledAt              *string `json:"enabled_at,omitempty"`
+	RecoveryCodesRemaining int64   `json:"recovery_codes_remaining"`
+}
+
+// TokenResponse DTO для ответа на обновление токенов
+type TokenResponse struct {
+	Token            string `json:"token"`
+	ExpiresAt        string `json:"expires_at"`
+	RefreshToken     string `json:"refresh_token"`
+	RefreshExpiresAt string `json:"refresh_expires_at"`
 }
 
 // UserResponse DTO для данных пользователя в ответах
 type UserResponse struct {
-	ID        uint   `json:"id"`
-	Email     string `json:"email"`
-	Username  string `json:"username"`
-	CreatedAt string `json:"created_at"`
+	ID                uint   `json:"id"`
+	Email             string `json:"email"`
+	Username          string `json:"username"`
+	EmailVerified     bool   `json:"email_verified"`
+	IsGuest           bool   `json:"is_guest"`
+	ProfileVisibility string `json:"profile_visibility"`
+	CreatedAt         string `json:"created_at"`
 }
 
 // ToUserResponse конвертирует entity User в UserResponse
 func ToUserResponse(user *entities.User) UserResponse {
+	email := user.Email
+	if user.IsGuest {
+		// У гостя служебный email, наружу его не отдаем
+		email = ""
+	}
 	return UserResponse{
-		ID:        user.ID,
-		Email:     user.Email,
-		Username:  user.Username,
-		CreatedAt: user.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		ID:                user.ID,
+		Email:             email,
+		Username:          user.Username,
+		EmailVerified:     user.EmailVerified,
+		IsGuest:           user.IsGuest,
+		ProfileVisibility: string(user.ProfileVisibility),
+		CreatedAt:         user.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 	}
-}
\ No newline at end of file
+}
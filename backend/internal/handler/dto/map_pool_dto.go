@@ -8,21 +8,53 @@ import (
 
 // MapPoolResponse DTO для ответа с пулом карт
 type MapPoolResponse struct {
-	ID        uint          `json:"id"`
-	GameID    uint          `json:"game_id"`
-	UserID    *uint         `json:"user_id,omitempty"`
-	Name      string        `json:"name"`
-	Type      string        `json:"type"`
-	IsSystem  bool          `json:"is_system"`
-	Maps      []MapResponse `json:"maps"`
-	CreatedAt string        `json:"created_at"`
-	UpdatedAt string        `json:"updated_at"`
+	ID           uint          `json:"id"`
+	GameID       uint          `json:"game_id"`
+	UserID       *uint         `json:"user_id,omitempty"`
+	Name         string        `json:"name"`
+	Type         string        `json:"type"`
+	IsSystem     bool          `json:"is_system"`
+	Visibility   string        `json:"visibility"`
+	ShareSlug    *string       `json:"share_slug,omitempty"`
+	ForkedFromID *uint         `json:"forked_from_id,omitempty"`
+	Maps         []MapResponse `json:"maps"`
+	CreatedAt    string        `json:"created_at"`
+	UpdatedAt    string        `json:"updated_at"`
+
+	Compatibility []PoolCompatibilityResponse `json:"compatibility,omitempty"`
+}
+
+// PoolCompatibilityResponse DTO совместимости пула с форматом вето
+type PoolCompatibilityResponse struct {
+	VetoType   string `json:"veto_type"`
+	MinSize    int    `json:"min_size"`
+	ExactSize  int    `json:"exact_size,omitempty"`
+	Compatible bool   `json:"compatible"`
+	Exact      bool   `json:"exact"`
 }
 
 // CreateCustomMapPoolRequest DTO для создания кастомного пула
 type CreateCustomMapPoolRequest struct {
-	Name   string `json:"name" binding:"required,min=1,max=255"`
-	MapIDs []uint `json:"map_ids" binding:"required,min=1"`
+	Name       string `json:"name" binding:"required,min=1,max=255"`
+	MapIDs     []uint `json:"map_ids" binding:"required,min=1"`
+	Visibility string `json:"visibility" binding:"omitempty,oneof=private unlisted public"`
+}
+
+// UpdateMapPoolRequest DTO для переименования пула
+type UpdateMapPoolRequest struct {
+	Name       string  `json:"name" binding:"required,min=1,max=255"`
+	Visibility *string `json:"visibility" binding:"omitempty,oneof=private unlisted public"`
+}
+
+// PublicMapPoolListResponse DTO для библиотеки публичных пулов
+type PublicMapPoolListResponse struct {
+	Data  []MapPoolResponse `json:"data"`
+	Total int64             `json:"total"`
+}
+
+// DuplicateMapPoolRequest DTO для копирования пула
+type DuplicateMapPoolRequest struct {
+	Name string `json:"name" binding:"omitempty,max=255"`
 }
 
 // MapResponse DTO для карты
@@ -48,15 +80,18 @@ func ToMapPoolResponse(pool *entities.MapPool) MapPoolResponse {
 	}
 
 	return MapPoolResponse{
-		ID:        pool.ID,
-		GameID:    pool.GameID,
-		UserID:    pool.UserID,
-		Name:      pool.Name,
-		Type:      string(pool.Type),
-		IsSystem:  pool.IsSystem,
-		Maps:      maps,
-		CreatedAt: pool.CreatedAt.Format(time.RFC3339),
-		UpdatedAt: pool.UpdatedAt.Format(time.RFC3339),
+		ID:           pool.ID,
+		GameID:       pool.GameID,
+		UserID:       pool.UserID,
+		Name:         pool.Name,
+		Type:         string(pool.Type),
+		IsSystem:     pool.IsSystem,
+		Visibility:   string(pool.Visibility),
+		ShareSlug:    pool.ShareSlug,
+		ForkedFromID: pool.ForkedFromID,
+		Maps:         maps,
+		CreatedAt:    pool.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:    pool.UpdatedAt.Format(time.RFC3339),
 	}
 }
 
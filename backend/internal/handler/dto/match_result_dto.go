@@ -0,0 +1,97 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/bbp/backend/internal/domain/entities"
+)
+
+// MapScoreRequest DTO счета одной карты серии
+type MapScoreRequest struct {
+	MapID  uint `json:"map_id" binding:"required"`
+	ScoreA int  `json:"score_a" binding:"min=0"`
+	ScoreB int  `json:"score_b" binding:"min=0"`
+}
+
+// ReportMatchResultRequest DTO для отчета о результате серии
+// Карты указываются в порядке игры: пики, затем десайдер; несыгранные карты не указываются
+type ReportMatchResultRequest struct {
+	Maps []MapScoreRequest `json:"maps" binding:"required,min=1,dive"`
+}
+
+// DisputeMatchResultRequest DTO для оспаривания результата
+type DisputeMatchResultRequest struct {
+	Reason string `json:"reason" binding:"required,max=500"`
+}
+
+// MapResultResponse DTO для счета карты
+type MapResultResponse struct {
+	VetoActionID *uint  `json:"veto_action_id,omitempty"`
+	MapID        uint   `json:"map_id"`
+	Order        int    `json:"order"`
+	ScoreA       int    `json:"score_a"`
+	ScoreB       int    `json:"score_b"`
+	Winner       string `json:"winner"`
+}
+
+// MatchResultResponse DTO для результата серии
+type MatchResultResponse struct {
+	ID             uint                `json:"id"`
+	VetoSessionID  uint                `json:"veto_session_id"`
+	Status         string              `json:"status"`
+	ReportedByID   uint                `json:"reported_by_id"`
+	ReportedByTeam string              `json:"reported_by_team,omitempty"`
+	ConfirmedByID  *uint               `json:"confirmed_by_id,omitempty"`
+	DisputedByID   *uint               `json:"disputed_by_id,omitempty"`
+	DisputeReason  *string             `json:"dispute_reason,omitempty"`
+	ScoreA         int                 `json:"score_a"`
+	ScoreB         int                 `json:"score_b"`
+	WinnerTeam     string              `json:"winner_team"`
+	WinnerName     string              `json:"winner_name,omitempty"`
+	Maps           []MapResultResponse `json:"maps"`
+	CreatedAt      string              `json:"created_at"`
+	UpdatedAt      string              `json:"updated_at"`
+	ConfirmedAt    *string             `json:"confirmed_at,omitempty"`
+}
+
+// ToMatchResultResponse конвертирует результат серии; session нужна для имени победителя
+func ToMatchResultResponse(result *entities.MatchResult, session *entities.VetoSession) MatchResultResponse {
+	response := MatchResultResponse{
+		ID:             result.ID,
+		VetoSessionID:  result.VetoSessionID,
+		Status:         string(result.Status),
+		ReportedByID:   result.ReportedByID,
+		ReportedByTeam: result.ReportedByTeam,
+		ConfirmedByID:  result.ConfirmedByID,
+		DisputedByID:   result.DisputedByID,
+		DisputeReason:  result.DisputeReason,
+		ScoreA:         result.ScoreA,
+		ScoreB:         result.ScoreB,
+		WinnerTeam:     result.WinnerTeam,
+		Maps:           make([]MapResultResponse, len(result.Maps)),
+		CreatedAt:      result.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:      result.UpdatedAt.Format(time.RFC3339),
+	}
+	if session != nil {
+		if result.WinnerTeam == "A" {
+			response.WinnerName = session.TeamAName
+		} else {
+			response.WinnerName = session.TeamBName
+		}
+	}
+	for i, m := range result.Maps {
+		response.Maps[i] = MapResultResponse{
+			VetoActionID: m.VetoActionID,
+			MapID:        m.MapID,
+			Order:        m.Order,
+			ScoreA:       m.ScoreA,
+			ScoreB:       m.ScoreB,
+			Winner:       m.Winner(),
+		}
+	}
+	if result.ConfirmedAt != nil {
+		confirmedAt := result.ConfirmedAt.Format(time.RFC3339)
+		response.ConfirmedAt = &confirmedAt
+	}
+	return response
+}
@@ -0,0 +1,47 @@
+package dto
+
+// OverlayResponse DTO для стрим-оверлея: плоское состояние сессии, готовое к отображению
+type OverlayResponse struct {
+	SessionID uint                    `json:"session_id"`
+	Type      string                  `json:"type"`
+	Status    string                  `json:"status"`
+	TeamA     string                  `json:"team_a"`
+	TeamB     string                  `json:"team_b"`
+	Steps     []OverlayStepResponse   `json:"steps"`
+	Turn      *OverlayTurnResponse    `json:"turn"`
+	Decider   *OverlayDeciderResponse `json:"decider"`
+	UpdatedAt string                  `json:"updated_at"`
+}
+
+// OverlayStepResponse DTO для выполненного шага вето
+type OverlayStepResponse struct {
+	Step         int     `json:"step"`
+	Team         string  `json:"team"`
+	TeamName     string  `json:"team_name"`
+	Action       string  `json:"action"`
+	MapID        uint    `json:"map_id"`
+	MapName      string  `json:"map_name"`
+	MapImageURL  string  `json:"map_image_url"`
+	Side         *string `json:"side,omitempty"`
+	SideTeam     string  `json:"side_team,omitempty"`
+	SideTeamName string  `json:"side_team_name,omitempty"`
+}
+
+// OverlayTurnResponse DTO для текущего хода
+type OverlayTurnResponse struct {
+	Step         int     `json:"step"`
+	Team         string  `json:"team"`
+	TeamName     string  `json:"team_name"`
+	Action       string  `json:"action"` // ban, pick, ban_or_pick или side
+	TimerSeconds int     `json:"timer_seconds"`
+	StartedAt    string  `json:"started_at"`
+	Deadline     *string `json:"deadline,omitempty"` // Отсчет считает оверлей, чтобы ответ не менялся каждую секунду
+}
+
+// OverlayDeciderResponse DTO для десайдера
+type OverlayDeciderResponse struct {
+	MapID       uint    `json:"map_id"`
+	MapName     string  `json:"map_name"`
+	MapImageURL string  `json:"map_image_url"`
+	Side        *string `json:"side,omitempty"`
+}
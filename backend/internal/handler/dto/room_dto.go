@@ -12,17 +12,26 @@ type CreateRoomRequest struct {
 	VetoType        *string `json:"veto_type" binding:"omitempty,oneof=bo1 bo3 bo5"` // Тип вето (bo1, bo3, bo5)
 	MaxParticipants *int    `json:"max_participants" binding:"omitempty,min=2,max=20"`
 	Password        *string `json:"password" binding:"omitempty,min=4,max=50"` // Пароль для приватных комнат (опционально)
+	TeamAID         *uint   `json:"team_a_id"` // Команды, между которыми проходит вето (опционально)
+	TeamBID         *uint   `json:"team_b_id"`
 }
 
 // JoinRoomRequest DTO для присоединения к комнате
 type JoinRoomRequest struct {
 	Password string `json:"password" binding:"omitempty,min=4,max=50"` // Пароль для приватных комнат
+	TeamID   *uint  `json:"team_id"` // Команда комнаты, за которую играет участник
 }
 
 // UpdateRoomRequest DTO для обновления комнаты
 type UpdateRoomRequest struct {
-	MapPoolID     *uint   `json:"map_pool_id"` // ID пула карт
-	VetoType      *string `json:"veto_type" binding:"omitempty,oneof=bo1 bo3 bo5"` // Тип вето (bo1, bo3, bo5)
-	VetoSessionID *uint   `json:"veto_session_id"` // ID сессии вето
-	Status        *string `json:"status" binding:"omitempty,oneof=waiting active finished"` // Статус комнаты
+	MapPoolID *uint   `json:"map_pool_id"` // ID пула карт
+	VetoType  *string `json:"veto_type" binding:"omitempty,oneof=bo1 bo3 bo5"` // Тип вето (bo1, bo3, bo5)
+	Status    *string `json:"status" binding:"omitempty,oneof=waiting active finished"` // Статус комнаты
+	TeamAID   *uint   `json:"team_a_id"` // Команды комнаты
+	TeamBID   *uint   `json:"team_b_id"`
+}
+
+// StartVetoRequest DTO для запуска вето в комнате (тело запроса необязательно)
+type StartVetoRequest struct {
+	SwapSides bool `json:"swap_sides"` // Поменять стороны A и B относительно предыдущего матча
 }
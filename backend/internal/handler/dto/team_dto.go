@@ -0,0 +1,133 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/bbp/backend/internal/domain/entities"
+)
+
+// CreateTeamRequest DTO для создания команды
+type CreateTeamRequest struct {
+	Name    string `json:"name" binding:"required,min=1,max=100"`
+	Tag     string `json:"tag" binding:"required,min=2,max=10"`
+	LogoURL string `json:"logo_url" binding:"omitempty,url,max=255"`
+}
+
+// UpdateTeamRequest DTO для обновления команды
+type UpdateTeamRequest struct {
+	Name    *string `json:"name" binding:"omitempty,min=1,max=100"`
+	Tag     *string `json:"tag" binding:"omitempty,min=2,max=10"`
+	LogoURL *string `json:"logo_url" binding:"omitempty,max=255"` // Пустая строка убирает логотип
+}
+
+// InviteTeamMemberRequest DTO для приглашения в состав
+type InviteTeamMemberRequest struct {
+	Username string `json:"username" binding:"required"`
+	Role     string `json:"role" binding:"required,oneof=captain player coach"`
+}
+
+// UpdateTeamMemberRequest DTO для смены роли участника
+type UpdateTeamMemberRequest struct {
+	Role string `json:"role" binding:"required,oneof=captain player coach"`
+}
+
+// TeamResponse DTO для команды
+type TeamResponse struct {
+	ID        uint                 `json:"id"`
+	Name      string               `json:"name"`
+	Tag       string               `json:"tag"`
+	LogoURL   string               `json:"logo_url"`
+	CreatedAt string               `json:"created_at"`
+	UpdatedAt string               `json:"updated_at"`
+	Members   []TeamMemberResponse `json:"members"`
+}
+
+// TeamMemberResponse DTO для участника состава
+type TeamMemberResponse struct {
+	UserID   uint    `json:"user_id"`
+	Username *string `json:"username,omitempty"`
+	Role     string  `json:"role"`
+	JoinedAt string  `json:"joined_at"`
+}
+
+// TeamInviteResponse DTO для приглашения в команду
+type TeamInviteResponse struct {
+	ID          uint          `json:"id"`
+	TeamID      uint          `json:"team_id"`
+	UserID      uint          `json:"user_id"`
+	InvitedByID uint          `json:"invited_by_id"`
+	Role        string        `json:"role"`
+	Status      string        `json:"status"`
+	CreatedAt   string        `json:"created_at"`
+	Team        *TeamResponse `json:"team,omitempty"`
+}
+
+// TeamSessionsResponse DTO для истории вето команды
+type TeamSessionsResponse struct {
+	Sessions []VetoSessionResponse `json:"sessions"`
+}
+
+// TeamStatsResponse DTO для статистики команды
+type TeamStatsResponse struct {
+	TeamID uint                    `json:"team_id"`
+	Stats  *entities.UserVetoStats `json:"stats"`
+}
+
+// ToTeamResponse конвертирует entity Team в TeamResponse
+func ToTeamResponse(team *entities.Team) TeamResponse {
+	members := make([]TeamMemberResponse, len(team.Members))
+	for i, member := range team.Members {
+		members[i] = TeamMemberResponse{
+			UserID:   member.UserID,
+			Username: member.Username,
+			Role:     string(member.Role),
+			JoinedAt: member.JoinedAt.Format(time.RFC3339),
+		}
+	}
+
+	return TeamResponse{
+		ID:        team.ID,
+		Name:      team.Name,
+		Tag:       team.Tag,
+		LogoURL:   team.LogoURL,
+		CreatedAt: team.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: team.UpdatedAt.Format(time.RFC3339),
+		Members:   members,
+	}
+}
+
+// ToTeamResponseList конвертирует список команд
+func ToTeamResponseList(teams []entities.Team) []TeamResponse {
+	response := make([]TeamResponse, len(teams))
+	for i, team := range teams {
+		response[i] = ToTeamResponse(&team)
+	}
+	return response
+}
+
+// ToTeamInviteResponse конвертирует entity TeamInvite в TeamInviteResponse
+func ToTeamInviteResponse(invite *entities.TeamInvite) TeamInviteResponse {
+	response := TeamInviteResponse{
+		ID:          invite.ID,
+		TeamID:      invite.TeamID,
+		UserID:      invite.UserID,
+		InvitedByID: invite.InvitedByID,
+		Role:        string(invite.Role),
+		Status:      string(invite.Status),
+		CreatedAt:   invite.CreatedAt.Format(time.RFC3339),
+	}
+	if invite.Team != nil {
+		team := ToTeamResponse(invite.Team)
+		response.Team = &team
+	}
+	return response
+}
+
+// ToTeamInviteResponseList конвертирует список приглашений
+func ToTeamInviteResponseList(invites []entities.TeamInvite) []TeamInviteResponse {
+	response := make([]TeamInviteResponse, len(invites))
+	for i, invite := range invites {
+		response[i] = ToTeamInviteResponse(&invite)
+	}
+	return response
+}
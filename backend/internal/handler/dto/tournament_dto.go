@@ -0,0 +1,192 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/bbp/backend/internal/domain/entities"
+)
+
+// CreateTournamentRequest DTO для создания турнира
+type CreateTournamentRequest struct {
+	Name          string                       `json:"name" binding:"required,min=1,max=100"`
+	GameID        uint                         `json:"game_id" binding:"required"`
+	MapPoolID     uint                         `json:"map_pool_id" binding:"required"`
+	Format        string                       `json:"format" binding:"required,oneof=single_elimination double_elimination swiss"`
+	MaxTeams      int                          `json:"max_teams" binding:"omitempty,min=2,max=64"`
+	SwissRounds   int                          `json:"swiss_rounds" binding:"omitempty,min=1,max=10"`     // Только для swiss, по умолчанию по количеству команд
+	FinalVetoType string                       `json:"final_veto_type" binding:"omitempty,oneof=bo3 bo5"` // Только для сетки на выбывание, по умолчанию bo5
+	StageRules    []TournamentStageRuleRequest `json:"stage_rules" binding:"omitempty,dive"`
+}
+
+// TournamentStageRuleRequest DTO для ограничений пула карт стадии
+type TournamentStageRuleRequest struct {
+	Stage          string `json:"stage" binding:"required,oneof=swiss playoffs"`
+	NoRepeatPicks  bool   `json:"no_repeat_picks"` // Команда не может пикнуть карту, сыгранную ею на стадии
+	CarryOverBans  bool   `json:"carry_over_bans"` // Баны предыдущей серии команд исключаются из пула
+	ExcludedMapIDs []uint `json:"excluded_map_ids"`
+	DeciderMapID   *uint  `json:"decider_map_id"`
+}
+
+// RegisterTournamentTeamRequest DTO для регистрации команды
+type RegisterTournamentTeamRequest struct {
+	TeamID uint `json:"team_id" binding:"required"`
+}
+
+// SeedTournamentRequest DTO для посева: все зарегистрированные команды от первого посева к последнему
+type SeedTournamentRequest struct {
+	TeamIDs []uint `json:"team_ids" binding:"required,min=1"`
+}
+
+// ScheduleTournamentMatchRequest DTO для назначения времени матча (null снимает время)
+type ScheduleTournamentMatchRequest struct {
+	ScheduledAt *time.Time `json:"scheduled_at"`
+}
+
+// TournamentResponse DTO для турнира
+type TournamentResponse struct {
+	ID            uint                           `json:"id"`
+	OwnerID       uint                           `json:"owner_id"`
+	Name          string                         `json:"name"`
+	GameID        uint                           `json:"game_id"`
+	MapPoolID     uint                           `json:"map_pool_id"`
+	Format        string                         `json:"format"`
+	Status        string                         `json:"status"`
+	MaxTeams      int                            `json:"max_teams"`
+	SwissRounds   int                            `json:"swiss_rounds,omitempty"`
+	FinalVetoType string                         `json:"final_veto_type,omitempty"`
+	WinnerTeamID  *uint                          `json:"winner_team_id,omitempty"`
+	StartedAt     *string                        `json:"started_at,omitempty"`
+	CreatedAt     string                         `json:"created_at"`
+	UpdatedAt     string                         `json:"updated_at"`
+	Teams         []TournamentTeamResponse       `json:"teams"`
+	Matches       []TournamentMatchResponse      `json:"matches,omitempty"`
+	Standings     []entities.TournamentStanding  `json:"standings,omitempty"` // Таблица швейцарской системы
+	StageRules    []entities.TournamentStageRule `json:"stage_rules,omitempty"`
+}
+
+// TournamentTeamResponse DTO для зарегистрированной команды
+type TournamentTeamResponse struct {
+	TeamID       uint   `json:"team_id"`
+	Name         string `json:"name"`
+	Tag          string `json:"tag"`
+	LogoURL      string `json:"logo_url,omitempty"`
+	Seed         int    `json:"seed"`
+	RegisteredAt string `json:"registered_at"`
+}
+
+// TournamentMatchResponse DTO для матча сетки
+type TournamentMatchResponse struct {
+	ID               uint    `json:"id"`
+	Bracket          string  `json:"bracket"`
+	Round            int     `json:"round"`
+	Position         int     `json:"position"`
+	TeamAID          *uint   `json:"team_a_id,omitempty"`
+	TeamBID          *uint   `json:"team_b_id,omitempty"`
+	WinnerTeamID     *uint   `json:"winner_team_id,omitempty"`
+	Status           string  `json:"status"`
+	Bye              bool    `json:"bye"` // Матч завершен без игры
+	VetoType         string  `json:"veto_type"`
+	ScheduledAt      *string `json:"scheduled_at,omitempty"`
+	RoomID           *uint   `json:"room_id,omitempty"`
+	VetoSessionID    *uint   `json:"veto_session_id,omitempty"`
+	NextMatchID      *uint   `json:"next_match_id,omitempty"`
+	NextSlot         string  `json:"next_slot,omitempty"`
+	LoserNextMatchID *uint   `json:"loser_next_match_id,omitempty"`
+	LoserNextSlot    string  `json:"loser_next_slot,omitempty"`
+}
+
+// TournamentListResponse DTO для списка турниров
+type TournamentListResponse struct {
+	Tournaments []TournamentResponse `json:"tournaments"`
+	Total       int64                `json:"total"`
+	Limit       int                  `json:"limit"`
+	Offset      int                  `json:"offset"`
+}
+
+// ToTournamentResponse конвертирует entity Tournament в TournamentResponse
+func ToTournamentResponse(tournament *entities.Tournament, standings []entities.TournamentStanding) TournamentResponse {
+	teams := make([]TournamentTeamResponse, len(tournament.Teams))
+	for i, team := range tournament.Teams {
+		teams[i] = TournamentTeamResponse{
+			TeamID:       team.TeamID,
+			Seed:         team.Seed,
+			RegisteredAt: team.RegisteredAt.Format(time.RFC3339),
+		}
+		if team.Team != nil {
+			teams[i].Name = team.Team.Name
+			teams[i].Tag = team.Team.Tag
+			teams[i].LogoURL = team.Team.LogoURL
+		}
+	}
+
+	matches := make([]TournamentMatchResponse, len(tournament.Matches))
+	for i := range tournament.Matches {
+		matches[i] = ToTournamentMatchResponse(&tournament.Matches[i])
+	}
+
+	response := TournamentResponse{
+		ID:            tournament.ID,
+		OwnerID:       tournament.OwnerID,
+		Name:          tournament.Name,
+		GameID:        tournament.GameID,
+		MapPoolID:     tournament.MapPoolID,
+		Format:        string(tournament.Format),
+		Status:        string(tournament.Status),
+		MaxTeams:      tournament.MaxTeams,
+		SwissRounds:   tournament.SwissRounds,
+		FinalVetoType: string(tournament.FinalVetoType),
+		WinnerTeamID:  tournament.WinnerTeamID,
+		CreatedAt:     tournament.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:     tournament.UpdatedAt.Format(time.RFC3339),
+		Teams:         teams,
+		Matches:       matches,
+		Standings:     standings,
+		StageRules:    tournament.StageRules,
+	}
+	if tournament.StartedAt != nil {
+		startedAt := tournament.StartedAt.Format(time.RFC3339)
+		response.StartedAt = &startedAt
+	}
+	return response
+}
+
+// ToTournamentMatchResponse конвертирует entity TournamentMatch в TournamentMatchResponse
+func ToTournamentMatchResponse(match *entities.TournamentMatch) TournamentMatchResponse {
+	response := TournamentMatchResponse{
+		ID:               match.ID,
+		Bracket:          string(match.Bracket),
+		Round:            match.Round,
+		Position:         match.Position,
+		TeamAID:          match.TeamAID,
+		TeamBID:          match.TeamBID,
+		WinnerTeamID:     match.WinnerTeamID,
+		Status:           string(match.Status),
+		Bye:              match.IsBye(),
+		VetoType:         string(match.VetoType),
+		RoomID:           match.RoomID,
+		VetoSessionID:    match.VetoSessionID,
+		NextMatchID:      match.NextMatchID,
+		NextSlot:         match.NextSlot,
+		LoserNextMatchID: match.LoserNextMatchID,
+		LoserNextSlot:    match.LoserNextSlot,
+	}
+	if match.ScheduledAt != nil {
+		scheduledAt := match.ScheduledAt.Format(time.RFC3339)
+		response.ScheduledAt = &scheduledAt
+	}
+	return response
+}
+
+// ToTournamentListResponse конвертирует список турниров (без матчей сетки)
+func ToTournamentListResponse(tournaments []entities.Tournament, total int64, limit, offset int) TournamentListResponse {
+	response := TournamentListResponse{
+		Tournaments: make([]TournamentResponse, len(tournaments)),
+		Total:       total,
+		Limit:       limit,
+		Offset:      offset,
+	}
+	for i := range tournaments {
+		response.Tournaments[i] = ToTournamentResponse(&tournaments[i], nil)
+	}
+	return response
+}
@@ -10,6 +10,61 @@ import (
 type UpdateProfileRequest struct {
 	Email    *string `json:"email,omitempty" binding:"omitempty,email"`
 	Username *string `json:"username,omitempty" binding:"omitempty,min=3,max=50"`
+	// Видимость статистики в публичном профиле: public или private
+	ProfileVisibility *string `json:"profile_visibility,omitempty" binding:"omitempty,oneof=public private"`
+	// Текущий пароль, обязателен при смене email
+	CurrentPassword string `json:"current_password,omitempty"`
+}
+
+// ChangePasswordRequest DTO для смены пароля
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required,min=6"`
+}
+
+// DeleteAccountRequest DTO для удаления аккаунта
+// Пароль обязателен для всех, кроме гостевых аккаунтов
+type DeleteAccountRequest struct {
+	Password string `json:"password"`
+}
+
+// UserIdentityResponse DTO привязанного аккаунта внешнего провайдера
+type UserIdentityResponse struct {
+	Provider string `json:"provider"`
+	Email    string `json:"email"`
+	LinkedAt string `json:"linked_at"`
+}
+
+// UserDataExportResponse DTO архива персональных данных пользователя
+type UserDataExportResponse struct {
+	ExportedAt string                 `json:"exported_at"`
+	Profile    UserResponse           `json:"profile"`
+	Identities []UserIdentityResponse `json:"identities"`
+	Sessions   []VetoSessionResponse  `json:"sessions"`
+	Rooms      []RoomResponse         `json:"rooms"`
+	MapPools   []MapPoolResponse      `json:"map_pools"`
+	Teams      []TeamResponse         `json:"teams"`
+}
+
+// PublicProfileResponse DTO для публичного профиля пользователя
+type PublicProfileResponse struct {
+	ID        uint                    `json:"id"`
+	Username  string                  `json:"username"`
+	CreatedAt string                  `json:"created_at"`
+	IsPrivate bool                    `json:"is_private"`
+	Stats     *entities.UserVetoStats `json:"stats,omitempty"`
+}
+
+// ToPublicProfileResponse конвертирует профиль в PublicProfileResponse
+// Email и прочие личные данные в публичный профиль не попадают
+func ToPublicProfileResponse(user *entities.User, stats *entities.UserVetoStats) PublicProfileResponse {
+	return PublicProfileResponse{
+		ID:        user.ID,
+		Username:  user.Username,
+		CreatedAt: user.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		IsPrivate: !user.IsProfilePublic(),
+		Stats:     stats,
+	}
 }
 
 // UserSessionsResponse DTO для ответа с сессиями пользователя
@@ -34,10 +89,14 @@ type RoomResponse struct {
 	MapPoolID       *uint                `json:"map_pool_id,omitempty"`
 	VetoType        *string              `json:"veto_type,omitempty"` // Тип вето (bo1, bo3, bo5)
 	VetoSessionID   *uint                `json:"veto_session_id,omitempty"`
+	TeamAID         *uint                `json:"team_a_id,omitempty"`
+	TeamBID         *uint                `json:"team_b_id,omitempty"`
 	MaxParticipants int                  `json:"max_participants"`
 	CreatedAt       string               `json:"created_at"`
 	UpdatedAt       string               `json:"updated_at"`
 	Participants    []RoomParticipantResponse `json:"participants,omitempty"`
+	CurrentMatch    int                  `json:"current_match,omitempty"` // Номер текущего матча серии
+	Matches         []RoomMatchResponse  `json:"matches,omitempty"`
 }
 
 // RoomParticipantResponse DTO для участника комнаты
@@ -47,9 +106,27 @@ type RoomParticipantResponse struct {
 	UserID   uint   `json:"user_id"`
 	Username *string `json:"username,omitempty"` // Никнейм пользователя
 	Role     string `json:"role"`
+	TeamID   *uint  `json:"team_id,omitempty"`
 	JoinedAt string `json:"joined_at"`
 }
 
+// RoomMatchResponse DTO для матча серии комнаты
+type RoomMatchResponse struct {
+	ID            uint                 `json:"id"`
+	Number        int                  `json:"number"`
+	VetoSessionID uint                 `json:"veto_session_id"`
+	SidesSwapped  bool                 `json:"sides_swapped"`
+	CreatedAt     string               `json:"created_at"`
+	Session       *VetoSessionResponse `json:"session,omitempty"`
+}
+
+// RoomMatchesResponse DTO для истории матчей комнаты
+type RoomMatchesResponse struct {
+	RoomID       uint                `json:"room_id"`
+	CurrentMatch int                 `json:"current_match,omitempty"`
+	Matches      []RoomMatchResponse `json:"matches"`
+}
+
 
 // ToRoomResponse конвертирует entity Room в RoomResponse
 func ToRoomResponse(room *entities.Room) RoomResponse {
@@ -70,6 +147,8 @@ func ToRoomResponse(room *entities.Room) RoomResponse {
 		MapPoolID:       room.MapPoolID,
 		VetoType:        vetoTypeStr,
 		VetoSessionID:   room.VetoSessionID,
+		TeamAID:         room.TeamAID,
+		TeamBID:         room.TeamBID,
 		MaxParticipants: room.MaxParticipants,
 		CreatedAt:       room.CreatedAt.Format(time.RFC3339),
 		UpdatedAt:       room.UpdatedAt.Format(time.RFC3339),
@@ -77,6 +156,49 @@ func ToRoomResponse(room *entities.Room) RoomResponse {
 	if room.Participants != nil {
 		response.Participants = ToRoomParticipantResponseList(room.Participants)
 	}
+	if len(room.Matches) > 0 {
+		response.Matches = ToRoomMatchResponseList(room.Matches)
+	}
+	if current := room.CurrentMatch(); current != nil {
+		response.CurrentMatch = current.Number
+	}
+	return response
+}
+
+// ToRoomMatchResponse конвертирует entity RoomMatch в RoomMatchResponse
+func ToRoomMatchResponse(match *entities.RoomMatch) RoomMatchResponse {
+	response := RoomMatchResponse{
+		ID:            match.ID,
+		Number:        match.Number,
+		VetoSessionID: match.VetoSessionID,
+		SidesSwapped:  match.SidesSwapped,
+		CreatedAt:     match.CreatedAt.Format(time.RFC3339),
+	}
+	if match.Session != nil {
+		session := ToVetoSessionResponse(match.Session)
+		response.Session = &session
+	}
+	return response
+}
+
+// ToRoomMatchResponseList конвертирует список матчей комнаты
+func ToRoomMatchResponseList(matches []entities.RoomMatch) []RoomMatchResponse {
+	response := make([]RoomMatchResponse, len(matches))
+	for i, match := range matches {
+		response[i] = ToRoomMatchResponse(&match)
+	}
+	return response
+}
+
+// ToRoomMatchesResponse собирает историю матчей комнаты
+func ToRoomMatchesResponse(room *entities.Room, matches []entities.RoomMatch) RoomMatchesResponse {
+	response := RoomMatchesResponse{
+		RoomID:  room.ID,
+		Matches: ToRoomMatchResponseList(matches),
+	}
+	if current := room.CurrentMatch(); current != nil {
+		response.CurrentMatch = current.Number
+	}
 	return response
 }
 
@@ -97,6 +219,7 @@ func ToRoomParticipantResponse(participant *entities.RoomParticipant) RoomPartic
 		UserID:   participant.UserID,
 		Username: participant.Username,
 		Role:     string(participant.Role),
+		TeamID:   participant.TeamID,
 		JoinedAt: participant.JoinedAt.Format(time.RFC3339),
 	}
 }
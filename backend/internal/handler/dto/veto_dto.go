@@ -8,46 +8,63 @@ import (
 
 // CreateVetoSessionRequest DTO для создания сессии вето
 type CreateVetoSessionRequest struct {
-	GameID       uint   `json:"game_id" binding:"required"`
-	MapPoolID    uint   `json:"map_pool_id" binding:"required"`
-	Type         string `json:"type" binding:"required,oneof=bo1 bo3 bo5"`
-	TeamAName    string `json:"team_a_name" binding:"required,min=1,max=100"`
-	TeamBName    string `json:"team_b_name" binding:"required,min=1,max=100"`
-	TimerSeconds int    `json:"timer_seconds" binding:"min=0,max=300"`
+	GameID       uint                    `json:"game_id" binding:"required"`
+	MapPoolID    uint                    `json:"map_pool_id" binding:"required"`
+	Type         string                  `json:"type" binding:"required,oneof=bo1 bo3 bo5"`
+	TeamAName    string                  `json:"team_a_name" binding:"required_without=TeamAID,max=100"`
+	TeamBName    string                  `json:"team_b_name" binding:"required_without=TeamBID,max=100"`
+	TeamAID      *uint                   `json:"team_a_id"` // Зарегистрированная команда; без имени используется её название
+	TeamBID      *uint                   `json:"team_b_id"`
+	TimerSeconds int                     `json:"timer_seconds" binding:"min=0,max=300"`
+	Constraints  *VetoConstraintsRequest `json:"constraints"` // Ограничения пула из предыдущих серий (опционально)
+}
+
+// VetoConstraintsRequest DTO для ограничений пула сессии
+type VetoConstraintsRequest struct {
+	ExcludedMapIDs      []uint `json:"excluded_map_ids"`        // Недоступны обеим командам
+	TeamAExcludedMapIDs []uint `json:"team_a_excluded_map_ids"` // Команда A не может их пикнуть
+	TeamBExcludedMapIDs []uint `json:"team_b_excluded_map_ids"`
+	DeciderMapID        *uint  `json:"decider_map_id"` // Десайдер Bo3/Bo5, назначенный заранее
 }
 
 // VetoSessionResponse DTO для ответа с сессией
 type VetoSessionResponse struct {
-	ID            uint                 `json:"id"`
-	UserID        *uint                `json:"user_id,omitempty"`
-	GameID        uint                 `json:"game_id"`
-	MapPoolID     uint                 `json:"map_pool_id"`
-	Type          string               `json:"type"`
-	Status        string               `json:"status"`
-	TeamAName     string               `json:"team_a_name"`
-	TeamBName     string               `json:"team_b_name"`
-	CurrentTeam   string               `json:"current_team"`
-	SelectedMapID *uint                `json:"selected_map_id,omitempty"`
-	SelectedSide  *string              `json:"selected_side,omitempty"`
-	TimerSeconds  int                  `json:"timer_seconds"`
-	ShareToken    string               `json:"share_token"`
-	CreatedAt     string               `json:"created_at"`
-	UpdatedAt     string               `json:"updated_at"`
-	FinishedAt    *string              `json:"finished_at,omitempty"`
-	MapPool       *MapPoolResponse     `json:"map_pool,omitempty"`
-	Actions       []VetoActionResponse `json:"actions,omitempty"`
+	ID            uint                      `json:"id"`
+	UserID        *uint                     `json:"user_id,omitempty"`
+	GameID        uint                      `json:"game_id"`
+	MapPoolID     uint                      `json:"map_pool_id"`
+	MapRotationID *uint                     `json:"map_rotation_id,omitempty"`
+	Type          string                    `json:"type"`
+	Status        string                    `json:"status"`
+	TeamAName     string                    `json:"team_a_name"`
+	TeamBName     string                    `json:"team_b_name"`
+	TeamAID       *uint                     `json:"team_a_id,omitempty"`
+	TeamBID       *uint                     `json:"team_b_id,omitempty"`
+	CurrentTeam   string                    `json:"current_team"`
+	SelectedMapID *uint                     `json:"selected_map_id,omitempty"`
+	SelectedSide  *string                   `json:"selected_side,omitempty"`
+	TimerSeconds  int                       `json:"timer_seconds"`
+	ShareToken    string                    `json:"share_token"`
+	CreatedAt     string                    `json:"created_at"`
+	UpdatedAt     string                    `json:"updated_at"`
+	FinishedAt    *string                   `json:"finished_at,omitempty"`
+	MapPool       *MapPoolResponse          `json:"map_pool,omitempty"`
+	Actions       []VetoActionResponse      `json:"actions,omitempty"`
+	Constraints   *entities.VetoConstraints `json:"constraints,omitempty"`
 }
 
 // NextActionResponse DTO для следующего действия
 type NextActionResponse struct {
-	ActionType         string `json:"action_type"` // "ban", "pick", "both"
-	CurrentStep        int    `json:"current_step"`
-	CurrentTeam        string `json:"current_team"` // "A" или "B"
-	CanBan             bool   `json:"can_ban"`
-	CanPick            bool   `json:"can_pick"`
-	NeedsSideSelection bool   `json:"needs_side_selection"`          // Нужен ли выбор стороны после последнего действия
-	SideSelectionTeam  string `json:"side_selection_team,omitempty"` // Какая команда должна выбрать сторону
-	Message            string `json:"message,omitempty"`
+	ActionType         string                    `json:"action_type"` // "ban", "pick", "both"
+	CurrentStep        int                       `json:"current_step"`
+	CurrentTeam        string                    `json:"current_team"` // "A" или "B"
+	CanBan             bool                      `json:"can_ban"`
+	CanPick            bool                      `json:"can_pick"`
+	NeedsSideSelection bool                      `json:"needs_side_selection"`          // Нужен ли выбор стороны после последнего действия
+	SideSelectionTeam  string                    `json:"side_selection_team,omitempty"` // Какая команда должна выбрать сторону
+	Message            string                    `json:"message,omitempty"`
+	Constraints        *entities.VetoConstraints `json:"constraints,omitempty"`      // Ограничения пула сессии
+	PickableMapIDs     []uint                    `json:"pickable_map_ids,omitempty"` // Карты, которые текущая команда может пикнуть
 }
 
 // BanMapRequest DTO для бана карты
@@ -87,10 +104,13 @@ func ToVetoSessionResponse(session *entities.VetoSession) VetoSessionResponse {
 		UserID:        session.UserID,
 		GameID:        session.GameID,
 		MapPoolID:     session.MapPoolID,
+		MapRotationID: session.MapRotationID,
 		Type:          string(session.Type),
 		Status:        string(session.Status),
 		TeamAName:     session.TeamAName,
 		TeamBName:     session.TeamBName,
+		TeamAID:       session.TeamAID,
+		TeamBID:       session.TeamBID,
 		CurrentTeam:   session.CurrentTeam,
 		SelectedMapID: session.SelectedMapID,
 		SelectedSide:  session.SelectedSide,
@@ -98,6 +118,7 @@ func ToVetoSessionResponse(session *entities.VetoSession) VetoSessionResponse {
 		ShareToken:    session.ShareToken,
 		CreatedAt:     session.CreatedAt.Format(time.RFC3339),
 		UpdatedAt:     session.UpdatedAt.Format(time.RFC3339),
+		Constraints:   session.Constraints,
 	}
 
 	if session.FinishedAt != nil {
@@ -112,6 +133,19 @@ func ToVetoSessionResponse(session *entities.VetoSession) VetoSessionResponse {
 	return response
 }
 
+// ToVetoConstraints конвертирует VetoConstraintsRequest в entity VetoConstraints
+func ToVetoConstraints(req *VetoConstraintsRequest) *entities.VetoConstraints {
+	if req == nil {
+		return nil
+	}
+	return &entities.VetoConstraints{
+		ExcludedMapIDs:      req.ExcludedMapIDs,
+		TeamAExcludedMapIDs: req.TeamAExcludedMapIDs,
+		TeamBExcludedMapIDs: req.TeamBExcludedMapIDs,
+		DeciderMapID:        req.DeciderMapID,
+	}
+}
+
 // ToVetoSessionResponseList конвертирует список сессий
 func ToVetoSessionResponseList(sessions []entities.VetoSession) []VetoSessionResponse {
 	response := make([]VetoSessionResponse, len(sessions))
@@ -0,0 +1,18 @@
+package dto
+
+// VetoExportResponse DTO канонического экспорта итога вето (format=json)
+// Шаги и десайдер в том же виде, что и в состоянии оверлея
+type VetoExportResponse struct {
+	SessionID  uint                    `json:"session_id"`
+	Game       string                  `json:"game"`
+	Type       string                  `json:"type"`
+	Status     string                  `json:"status"`
+	TeamA      string                  `json:"team_a"`
+	TeamB      string                  `json:"team_b"`
+	TeamAID    *uint                   `json:"team_a_id,omitempty"`
+	TeamBID    *uint                   `json:"team_b_id,omitempty"`
+	Steps      []OverlayStepResponse   `json:"steps"`
+	Decider    *OverlayDeciderResponse `json:"decider"`
+	CreatedAt  string                  `json:"created_at"`
+	FinishedAt *string                 `json:"finished_at,omitempty"`
+}
@@ -0,0 +1,108 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/bbp/backend/internal/domain/entities"
+)
+
+// CreateWebhookRequest DTO для создания подписки
+type CreateWebhookRequest struct {
+	URL    string   `json:"url" binding:"required,max=2048"`
+	RoomID *uint    `json:"room_id"` // Подписка на события одной комнаты; без него - на все комнаты и сессии пользователя
+	Events []string `json:"events"`  // Пустой список - все события
+}
+
+// UpdateWebhookRequest DTO для изменения подписки; незаданные поля не меняются
+type UpdateWebhookRequest struct {
+	URL          *string   `json:"url" binding:"omitempty,max=2048"`
+	Events       *[]string `json:"events"`
+	IsActive     *bool     `json:"is_active"`
+	RotateSecret bool      `json:"rotate_secret"`
+}
+
+// WebhookResponse DTO для подписки
+type WebhookResponse struct {
+	ID        uint     `json:"id"`
+	RoomID    *uint    `json:"room_id,omitempty"`
+	URL       string   `json:"url"`
+	Events    []string `json:"events"`
+	IsActive  bool     `json:"is_active"`
+	Secret    string   `json:"secret,omitempty"` // Только при создании и смене ключа
+	CreatedAt string   `json:"created_at"`
+	UpdatedAt string   `json:"updated_at"`
+}
+
+// WebhookDeliveryResponse DTO для записи журнала доставок
+type WebhookDeliveryResponse struct {
+	ID             uint    `json:"id"`
+	WebhookID      uint    `json:"webhook_id"`
+	Event          string  `json:"event"`
+	Payload        string  `json:"payload"`
+	Status         string  `json:"status"`
+	Attempts       int     `json:"attempts"`
+	ResponseStatus *int    `json:"response_status,omitempty"`
+	ResponseBody   string  `json:"response_body,omitempty"`
+	LastError      string  `json:"last_error,omitempty"`
+	NextAttemptAt  *string `json:"next_attempt_at,omitempty"`
+	DeliveredAt    *string `json:"delivered_at,omitempty"`
+	RedeliveryOf   *uint   `json:"redelivery_of,omitempty"`
+	CreatedAt      string  `json:"created_at"`
+}
+
+// ToWebhookEvents конвертирует события запроса
+func ToWebhookEvents(events []string) []entities.WebhookEvent {
+	result := make([]entities.WebhookEvent, len(events))
+	for i, event := range events {
+		result[i] = entities.WebhookEvent(event)
+	}
+	return result
+}
+
+// ToWebhookResponse конвертирует entity Webhook в WebhookResponse; ключ подписи включается только если withSecret
+func ToWebhookResponse(webhook *entities.Webhook, withSecret bool) WebhookResponse {
+	events := make([]string, len(webhook.Events))
+	for i, event := range webhook.Events {
+		events[i] = string(event)
+	}
+
+	response := WebhookResponse{
+		ID:        webhook.ID,
+		RoomID:    webhook.RoomID,
+		URL:       webhook.URL,
+		Events:    events,
+		IsActive:  webhook.IsActive,
+		CreatedAt: webhook.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: webhook.UpdatedAt.Format(time.RFC3339),
+	}
+	if withSecret {
+		response.Secret = webhook.Secret
+	}
+	return response
+}
+
+// ToWebhookDeliveryResponse конвертирует entity WebhookDelivery в WebhookDeliveryResponse
+func ToWebhookDeliveryResponse(delivery *entities.WebhookDelivery) WebhookDeliveryResponse {
+	response := WebhookDeliveryResponse{
+		ID:             delivery.ID,
+		WebhookID:      delivery.WebhookID,
+		Event:          string(delivery.Event),
+		Payload:        delivery.Payload,
+		Status:         string(delivery.Status),
+		Attempts:       delivery.Attempts,
+		ResponseStatus: delivery.ResponseStatus,
+		ResponseBody:   delivery.ResponseBody,
+		LastError:      delivery.LastError,
+		RedeliveryOf:   delivery.RedeliveryOf,
+		CreatedAt:      delivery.CreatedAt.Format(time.RFC3339),
+	}
+	if delivery.NextAttemptAt != nil {
+		nextAttemptAt := delivery.NextAttemptAt.Format(time.RFC3339)
+		response.NextAttemptAt = &nextAttemptAt
+	}
+	if delivery.DeliveredAt != nil {
+		deliveredAt := delivery.DeliveredAt.Format(time.RFC3339)
+		response.DeliveredAt = &deliveredAt
+	}
+	return response
+}
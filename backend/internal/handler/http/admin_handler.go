@@ -0,0 +1,85 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/bbp/backend/internal/handler/dto"
+	"github.com/bbp/backend/internal/middleware"
+	"github.com/bbp/backend/internal/usecase/auth"
+	"github.com/gin-gonic/gin"
+)
+
+type AdminHandler struct {
+	unlockAccountUseCase    *auth.UnlockAccountUseCase
+	getLockoutEventsUseCase *auth.GetLockoutEventsUseCase
+}
+
+func NewAdminHandler(
+	unlockAccountUseCase *auth.UnlockAccountUseCase,
+	getLockoutEventsUseCase *auth.GetLockoutEventsUseCase,
+) *AdminHandler {
+	return &AdminHandler{
+		unlockAccountUseCase:    unlockAccountUseCase,
+		getLockoutEventsUseCase: getLockoutEventsUseCase,
+	}
+}
+
+// UnlockUser обрабатывает POST /api/admin/users/:id/unlock
+func (h *AdminHandler) UnlockUser(c *gin.Context) {
+	admin, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return
+	}
+
+	err = h.unlockAccountUseCase.Execute(auth.UnlockAccountInput{
+		UserID:  uint(id),
+		ActorID: admin.ID,
+	})
+	if err != nil {
+		switch err {
+		case auth.ErrUserNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "account unlocked"})
+}
+
+// GetLockoutEvents обрабатывает GET /api/admin/lockouts
+func (h *AdminHandler) GetLockoutEvents(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil || limit <= 0 {
+		limit = 50
+	}
+
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+
+	events, err := h.getLockoutEventsUseCase.Execute(auth.GetLockoutEventsInput{
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	response := make([]dto.LockoutEventResponse, len(events))
+	for i := range events {
+		response[i] = dto.ToLockoutEventResponse(&events[i])
+	}
+	c.JSON(http.StatusOK, gin.H{"events": response})
+}
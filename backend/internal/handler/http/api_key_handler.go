@@ -0,0 +1,123 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/bbp/backend/internal/handler/dto"
+	"github.com/bbp/backend/internal/middleware"
+	"github.com/bbp/backend/internal/usecase/apikey"
+	"github.com/gin-gonic/gin"
+)
+
+type APIKeyHandler struct {
+	createAPIKeyUseCase *apikey.CreateAPIKeyUseCase
+	getAPIKeysUseCase   *apikey.GetAPIKeysUseCase
+	revokeAPIKeyUseCase *apikey.RevokeAPIKeyUseCase
+}
+
+func NewAPIKeyHandler(
+	createAPIKeyUseCase *apikey.CreateAPIKeyUseCase,
+	getAPIKeysUseCase *apikey.GetAPIKeysUseCase,
+	revokeAPIKeyUseCase *apikey.RevokeAPIKeyUseCase,
+) *APIKeyHandler {
+	return &APIKeyHandler{
+		createAPIKeyUseCase: createAPIKeyUseCase,
+		getAPIKeysUseCase:   getAPIKeysUseCase,
+		revokeAPIKeyUseCase: revokeAPIKeyUseCase,
+	}
+}
+
+// GetAPIKeys обрабатывает GET /api/api-keys
+func (h *APIKeyHandler) GetAPIKeys(c *gin.Context) {
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	result, err := h.getAPIKeysUseCase.Execute(user.ID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	keys := make([]dto.APIKeyResponse, len(result.APIKeys))
+	for i := range result.APIKeys {
+		keys[i] = dto.ToAPIKeyResponse(&result.APIKeys[i], "")
+	}
+	c.JSON(http.StatusOK, gin.H{"api_keys": keys})
+}
+
+// CreateAPIKey обрабатывает POST /api/api-keys
+func (h *APIKeyHandler) CreateAPIKey(c *gin.Context) {
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req dto.CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.createAPIKeyUseCase.Execute(apikey.CreateAPIKeyInput{
+		UserID:    user.ID,
+		Name:      req.Name,
+		Scopes:    dto.ToAPIKeyScopes(req.Scopes),
+		RoomIDs:   req.RoomIDs,
+		ExpiresAt: req.ExpiresAt,
+	})
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	// Ключ показывается один раз, в БД хранится только его хэш
+	c.JSON(http.StatusCreated, dto.ToAPIKeyResponse(result.APIKey, result.Key))
+}
+
+// RevokeAPIKey обрабатывает DELETE /api/api-keys/:id
+func (h *APIKeyHandler) RevokeAPIKey(c *gin.Context) {
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	keyID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid api key id"})
+		return
+	}
+
+	key, err := h.revokeAPIKeyUseCase.Execute(apikey.RevokeAPIKeyInput{
+		APIKeyID: uint(keyID),
+		UserID:   user.ID,
+	})
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToAPIKeyResponse(key, ""))
+}
+
+func (h *APIKeyHandler) handleError(c *gin.Context, err error) {
+	switch err {
+	case apikey.ErrAPIKeyNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "api key not found"})
+	case apikey.ErrRoomNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "room not found"})
+	case apikey.ErrUnauthorized:
+		c.JSON(http.StatusForbidden, gin.H{"error": "unauthorized"})
+	case apikey.ErrInvalidName, apikey.ErrInvalidScope, apikey.ErrInvalidExpiry:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case apikey.ErrTooManyAPIKeys:
+		c.JSON(http.StatusConflict, gin.H{"error": "api key limit reached"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+	}
+}
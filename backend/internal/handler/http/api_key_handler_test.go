@@ -0,0 +1,228 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/handler/dto"
+	"github.com/bbp/backend/internal/middleware"
+	"github.com/bbp/backend/internal/repository/models"
+	"github.com/bbp/backend/internal/repository/sqlite"
+	"github.com/bbp/backend/internal/usecase/apikey"
+	"github.com/bbp/backend/internal/usecase/veto"
+	"github.com/bbp/backend/pkg/database"
+	"github.com/bbp/backend/pkg/jwt"
+	ws "github.com/bbp/backend/pkg/websocket"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIKeyHandler_ScopedKeys(t *testing.T) {
+	db, cleanup := setupVetoTestDB(t)
+	defer cleanup()
+	require.NoError(t, database.Migrate(db,
+		&models.RevokedTokenModel{},
+		&models.APIKeyModel{},
+		&models.APIKeyScopeModel{},
+		&models.APIKeyRoomModel{},
+	))
+
+	userRepo := sqlite.NewUserRepository(db)
+	gameRepo := sqlite.NewGameRepository(db)
+	mapRepo := sqlite.NewMapRepository(db)
+	mapPoolRepo := sqlite.NewMapPoolRepository(db)
+	roomRepo := sqlite.NewRoomRepository(db)
+	vetoSessionRepo := sqlite.NewVetoSessionRepository(db)
+	apiKeyRepo := sqlite.NewAPIKeyRepository(db)
+	vetoLogicService := veto.NewVetoLogicService()
+	wsManager := ws.NewManager()
+	go wsManager.Run()
+
+	jwtService := jwt.NewJWTService("test-secret", time.Hour)
+	jwtService.SetDenylist(sqlite.NewRevokedTokenRepository(db))
+
+	apiKeyHandler := NewAPIKeyHandler(
+		apikey.NewCreateAPIKeyUseCase(apiKeyRepo, roomRepo),
+		apikey.NewGetAPIKeysUseCase(apiKeyRepo),
+		apikey.NewRevokeAPIKeyUseCase(apiKeyRepo),
+	)
+	vetoHandler := NewVetoHandler(
+		veto.NewCreateSessionUseCase(vetoSessionRepo, mapPoolRepo, gameRepo, sqlite.NewMapRotationRepository(db), sqlite.NewTeamRepository(db), vetoLogicService),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		veto.NewStartSessionUseCase(vetoSessionRepo),
+		mapPoolRepo,
+		roomRepo,
+		wsManager,
+	)
+
+	// Как в main: ключ проверяется до общего лимита, у ключей свой лимит
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.APIKeyMiddleware(apikey.NewAuthenticator(apiKeyRepo, userRepo, roomRepo), 10, time.Minute))
+	router.Use(middleware.RateLimitMiddleware(1000, time.Minute))
+	router.GET("/api/auth/me", middleware.AuthMiddleware(jwtService), func(c *gin.Context) {
+		user, _ := middleware.GetUserFromContext(c)
+		c.JSON(http.StatusOK, gin.H{"id": user.ID, "username": user.Username})
+	})
+	router.POST("/api/veto/sessions", middleware.OptionalAuthMiddleware(jwtService), vetoHandler.CreateSession)
+	router.POST("/api/veto/sessions/:id/start", vetoHandler.StartSession)
+	apiKeys := router.Group("/api/api-keys", middleware.AuthMiddleware(jwtService), middleware.RejectGuests())
+	apiKeys.GET("", apiKeyHandler.GetAPIKeys)
+	apiKeys.POST("", apiKeyHandler.CreateAPIKey)
+	apiKeys.DELETE("/:id", apiKeyHandler.RevokeAPIKey)
+
+	createUser := func(username string) (*entities.User, string) {
+		u := &entities.User{Email: username + "@example.com", Username: username, Password: "hashed"}
+		require.NoError(t, userRepo.Create(u))
+		token, err := jwtService.GenerateToken(u.ID, u.Username)
+		require.NoError(t, err)
+		return u, token
+	}
+	request := func(method, path, token, key string, payload interface{}) *httptest.ResponseRecorder {
+		var body bytes.Buffer
+		if payload != nil {
+			json.NewEncoder(&body).Encode(payload)
+		}
+		req := httptest.NewRequest(method, path, &body)
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		if key != "" {
+			req.Header.Set(middleware.APIKeyHeader, key)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	createKey := func(token string, req dto.CreateAPIKeyRequest) dto.APIKeyResponse {
+		w := request(http.MethodPost, "/api/api-keys", token, "", req)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		var resp dto.APIKeyResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		require.NotEmpty(t, resp.Key)
+		return resp
+	}
+
+	owner, ownerToken := createUser("owner")
+	stranger, strangerToken := createUser("stranger")
+
+	game := &entities.Game{Name: "CS2", Slug: "cs2", IsActive: true}
+	require.NoError(t, gameRepo.Create(game))
+	var maps []entities.Map
+	for _, slug := range []string{"mirage", "inferno", "nuke"} {
+		m := &entities.Map{GameID: game.ID, Name: slug, Slug: slug, IsActive: true}
+		require.NoError(t, mapRepo.Create(m))
+		maps = append(maps, *m)
+	}
+	pool := &entities.MapPool{GameID: game.ID, Name: "Pool", Type: entities.MapPoolTypeAll, IsSystem: true, Maps: maps}
+	require.NoError(t, mapPoolRepo.Create(pool))
+
+	// Две комнаты владельца со своими сессиями и комната другого пользователя
+	var rooms []*entities.Room
+	for i, code := range []string{"ROOMA1", "ROOMB1"} {
+		w := request(http.MethodPost, "/api/veto/sessions", ownerToken, "", dto.CreateVetoSessionRequest{
+			GameID: game.ID, MapPoolID: pool.ID, Type: "bo1", TeamAName: "A", TeamBName: "B",
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		var session dto.VetoSessionResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &session))
+
+		room := &entities.Room{OwnerID: owner.ID, Name: fmt.Sprintf("Room %d", i), Code: code, Type: entities.RoomTypePublic, Status: entities.RoomStatusWaiting, GameID: game.ID, MaxParticipants: 2, VetoSessionID: &session.ID}
+		require.NoError(t, roomRepo.Create(room))
+		rooms = append(rooms, room)
+	}
+	strangerRoom := &entities.Room{OwnerID: stranger.ID, Name: "Other", Code: "OTHER1", Type: entities.RoomTypePublic, Status: entities.RoomStatusWaiting, GameID: game.ID, MaxParticipants: 2}
+	require.NoError(t, roomRepo.Create(strangerRoom))
+
+	// Некорректные ключи отклоняются
+	w := request(http.MethodPost, "/api/api-keys", ownerToken, "", dto.CreateAPIKeyRequest{Name: "bot", Scopes: []string{"admin"}})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = request(http.MethodPost, "/api/api-keys", ownerToken, "", dto.CreateAPIKeyRequest{Name: "bot", Scopes: []string{"veto"}, RoomIDs: []uint{strangerRoom.ID}})
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	past := time.Now().Add(-time.Hour)
+	w = request(http.MethodPost, "/api/api-keys", ownerToken, "", dto.CreateAPIKeyRequest{Name: "bot", Scopes: []string{"read"}, ExpiresAt: &past})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	readKey := createKey(ownerToken, dto.CreateAPIKeyRequest{Name: "Discord bot", Scopes: []string{"read"}})
+	vetoKey := createKey(ownerToken, dto.CreateAPIKeyRequest{Name: "Room A control", Scopes: []string{"veto"}, RoomIDs: []uint{rooms[0].ID}})
+	assert.Equal(t, readKey.Key[:len(readKey.Prefix)], readKey.Prefix)
+
+	// Ключ показывается только при создании
+	w = request(http.MethodGet, "/api/api-keys", ownerToken, "", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), readKey.Key)
+	assert.NotContains(t, w.Body.String(), vetoKey.Key)
+
+	// Ключ на чтение аутентифицирует GET запросы от имени владельца
+	w = request(http.MethodGet, "/api/auth/me", "", readKey.Key, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), `"username":"owner"`)
+	w = request(http.MethodGet, "/api/auth/me", "", "bbp_unknown", nil)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	// Управлять ключами и вето ключом на чтение нельзя
+	w = request(http.MethodGet, "/api/api-keys", "", readKey.Key, nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = request(http.MethodPost, fmt.Sprintf("/api/veto/sessions/%d/start", *rooms[0].VetoSessionID), "", readKey.Key, nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	// Ключ вето работает только в своих комнатах и не дает чтения
+	w = request(http.MethodPost, fmt.Sprintf("/api/veto/sessions/%d/start", *rooms[1].VetoSessionID), "", vetoKey.Key, nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = request(http.MethodPost, "/api/veto/sessions", "", vetoKey.Key, dto.CreateVetoSessionRequest{
+		GameID: game.ID, MapPoolID: pool.ID, Type: "bo1", TeamAName: "A", TeamBName: "B",
+	})
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = request(http.MethodGet, "/api/auth/me", "", vetoKey.Key, nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = request(http.MethodPost, fmt.Sprintf("/api/veto/sessions/%d/start", *rooms[0].VetoSessionID), "", vetoKey.Key, nil)
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	// Использование ключа видно в списке
+	w = request(http.MethodGet, "/api/api-keys", ownerToken, "", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var list struct {
+		APIKeys []dto.APIKeyResponse `json:"api_keys"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	require.Len(t, list.APIKeys, 2)
+	for _, key := range list.APIKeys {
+		assert.NotNil(t, key.LastUsedAt, key.Name)
+	}
+
+	// У ключа свой лимит запросов, интерактивный лимит пользователя он не расходует
+	limited := false
+	for i := 0; i < 10; i++ {
+		if request(http.MethodGet, "/api/auth/me", "", readKey.Key, nil).Code == http.StatusTooManyRequests {
+			limited = true
+			break
+		}
+	}
+	assert.True(t, limited)
+	w = request(http.MethodGet, "/api/auth/me", ownerToken, "", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// Отозвать ключ может только владелец; отозванный ключ перестает работать
+	w = request(http.MethodDelete, fmt.Sprintf("/api/api-keys/%d", vetoKey.ID), strangerToken, "", nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = request(http.MethodDelete, fmt.Sprintf("/api/api-keys/%d", vetoKey.ID), ownerToken, "", nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var revoked dto.APIKeyResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &revoked))
+	assert.NotNil(t, revoked.RevokedAt)
+	w = request(http.MethodPost, fmt.Sprintf("/api/veto/sessions/%d/start", *rooms[0].VetoSessionID), "", vetoKey.Key, nil)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
@@ -1,7 +1,9 @@
 package http
 
 import (
+	"log"
 	"net/http"
+	"time"
 
 	"github.com/bbp/backend/internal/handler/dto"
 	"github.com/bbp/backend/internal/middleware"
@@ -10,20 +12,52 @@ import (
 )
 
 type AuthHandler struct {
-	registerUseCase      *auth.RegisterUseCase
-	loginUseCase         *auth.LoginUseCase
-	getCurrentUserUseCase *auth.GetCurrentUserUseCase
+	registerUseCase           *auth.RegisterUseCase
+	loginUseCase              *auth.LoginUseCase
+	getCurrentUserUseCase     *auth.GetCurrentUserUseCase
+	refreshUseCase            *auth.RefreshUseCase
+	logoutUseCase             *auth.LogoutUseCase
+	logoutAllUseCase          *auth.LogoutAllUseCase
+	requestResetUseCase       *auth.RequestPasswordResetUseCase
+	resetPasswordUseCase      *auth.ResetPasswordUseCase
+	verifyEmailUseCase        *auth.VerifyEmailUseCase
+	resendVerificationUseCase *auth.ResendVerificationUseCase
 }
 
 func NewAuthHandler(
 	registerUseCase *auth.RegisterUseCase,
 	loginUseCase *auth.LoginUseCase,
 	getCurrentUserUseCase *auth.GetCurrentUserUseCase,
+	refreshUseCase *auth.RefreshUseCase,
+	logoutUseCase *auth.LogoutUseCase,
+	logoutAllUseCase *auth.LogoutAllUseCase,
+	requestResetUseCase *auth.RequestPasswordResetUseCase,
+	resetPasswordUseCase *auth.ResetPasswordUseCase,
+	verifyEmailUseCase *auth.VerifyEmailUseCase,
+	resendVerificationUseCase *auth.ResendVerificationUseCase,
 ) *AuthHandler {
 	return &AuthHandler{
-		registerUseCase:       registerUseCase,
-		loginUseCase:          loginUseCase,
-		getCurrentUserUseCase: getCurrentUserUseCase,
+		registerUseCase:           registerUseCase,
+		loginUseCase:              loginUseCase,
+		getCurrentUserUseCase:     getCurrentUserUseCase,
+		refreshUseCase:            refreshUseCase,
+		logoutUseCase:             logoutUseCase,
+		logoutAllUseCase:          logoutAllUseCase,
+		requestResetUseCase:       requestResetUseCase,
+		resetPasswordUseCase:      resetPasswordUseCase,
+		verifyEmailUseCase:        verifyEmailUseCase,
+		resendVerificationUseCase: resendVerificationUseCase,
+	}
+}
+
+// toAuthResponse собирает ответ авторизации из пары токенов
+func toAuthResponse(tokens *auth.TokenPair, user dto.UserResponse) dto.AuthResponse {
+	return dto.AuthResponse{
+		Token:            tokens.AccessToken,
+		ExpiresAt:        tokens.AccessExpiresAt.Format(time.RFC3339),
+		RefreshToken:     tokens.RefreshToken,
+		RefreshExpiresAt: tokens.RefreshExpiresAt.Format(time.RFC3339),
+		User:             user,
 	}
 }
 
@@ -53,10 +87,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusCreated, dto.AuthResponse{
-		Token: result.Token,
-		User:  dto.ToUserResponse(result.User),
-	})
+	c.JSON(http.StatusCreated, toAuthResponse(result.Tokens, dto.ToUserResponse(result.User)))
 }
 
 // Login обрабатывает POST /api/auth/login
@@ -70,27 +101,39 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	result, err := h.loginUseCase.Execute(auth.LoginInput{
 		Email:    req.Email,
 		Password: req.Password,
+		IP:       c.ClientIP(),
 	})
 
 	if err != nil {
 		switch err {
 		case auth.ErrInvalidCredentials:
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid email or password"})
+		case auth.ErrTooManyLoginAttempts:
+			// Одинаковый ответ для существующих и несуществующих аккаунтов
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": "too many login attempts, try again later"})
 		default:
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
 		}
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.AuthResponse{
-		Token: result.Token,
-		User: dto.UserResponse{
-			ID:        result.User.ID,
-			Email:     result.User.Email,
-			Username:  result.User.Username,
-			CreatedAt: result.User.CreatedAt,
-		},
-	})
+	// Пароль верный, но токены будут выданы только после кода 2FA
+	if result.Challenge != nil {
+		c.JSON(http.StatusOK, dto.TwoFactorChallengeResponse{
+			TwoFactorRequired:  true,
+			ChallengeToken:     result.Challenge.Token,
+			ChallengeExpiresAt: result.Challenge.ExpiresAt.Format(time.RFC3339),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, toAuthResponse(result.Tokens, dto.UserResponse{
+		ID:            result.User.ID,
+		Email:         result.User.Email,
+		Username:      result.User.Username,
+		EmailVerified: result.User.EmailVerified,
+		CreatedAt:     result.User.CreatedAt,
+	}))
 }
 
 // GetCurrentUser обрабатывает GET /api/auth/me
@@ -112,10 +155,189 @@ func (h *AuthHandler) GetCurrentUser(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.UserResponse{
-		ID:        result.User.ID,
-		Email:     result.User.Email,
-		Username:  result.User.Username,
-		CreatedAt: result.User.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	c.JSON(http.StatusOK, dto.ToUserResponse(result.User))
+}
+
+// Refresh обрабатывает POST /api/auth/refresh
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	var req dto.RefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.refreshUseCase.Execute(auth.RefreshInput{
+		RefreshToken: req.RefreshToken,
+	})
+	if err != nil {
+		switch err {
+		case auth.ErrInvalidRefreshToken:
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid or expired refresh token"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.TokenResponse{
+		Token:            result.Tokens.AccessToken,
+		ExpiresAt:        result.Tokens.AccessExpiresAt.Format(time.RFC3339),
+		RefreshToken:     result.Tokens.RefreshToken,
+		RefreshExpiresAt: result.Tokens.RefreshExpiresAt.Format(time.RFC3339),
+	})
+}
+
+// Logout обрабатывает POST /api/auth/logout
+// Отзывает текущий access токен и, если передан, refresh токен этой сессии
+func (h *AuthHandler) Logout(c *gin.Context) {
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	// Тело запроса необязательно
+	var req dto.LogoutRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	input := auth.LogoutInput{
+		UserID:       user.ID,
+		RefreshToken: req.RefreshToken,
+	}
+	if claims, ok := middleware.GetTokenClaimsFromContext(c); ok {
+		input.AccessJTI = claims.ID
+		if claims.ExpiresAt != nil {
+			input.AccessExpiresAt = claims.ExpiresAt.Time
+		}
+	}
+
+	if err := h.logoutUseCase.Execute(input); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// LogoutAll обрабатывает POST /api/auth/logout-all
+// Завершает все сессии пользователя на всех устройствах
+func (h *AuthHandler) LogoutAll(c *gin.Context) {
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	input := auth.LogoutAllInput{
+		UserID: user.ID,
+	}
+	if claims, ok := middleware.GetTokenClaimsFromContext(c); ok {
+		input.AccessJTI = claims.ID
+		if claims.ExpiresAt != nil {
+			input.AccessExpiresAt = claims.ExpiresAt.Time
+		}
+	}
+
+	if err := h.logoutAllUseCase.Execute(input); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ForgotPassword обрабатывает POST /api/auth/password/forgot
+// Всегда отвечает 202, чтобы не раскрывать наличие аккаунта с этим email:
+// ошибки (в том числе отправки письма, которая бывает только для существующего аккаунта) только логируются
+func (h *AuthHandler) ForgotPassword(c *gin.Context) {
+	var req dto.ForgotPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.requestResetUseCase.Execute(auth.RequestPasswordResetInput{
+		Email: req.Email,
+	}); err != nil {
+		log.Printf("Failed to request password reset: %v", err)
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"message": "if the account exists, a password reset email has been sent"})
+}
+
+// ResetPassword обрабатывает POST /api/auth/password/reset
+func (h *AuthHandler) ResetPassword(c *gin.Context) {
+	var req dto.ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err := h.resetPasswordUseCase.Execute(auth.ResetPasswordInput{
+		Token:       req.Token,
+		NewPassword: req.NewPassword,
+	})
+	if err != nil {
+		switch err {
+		case auth.ErrInvalidResetToken:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid or expired password reset token"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// VerifyEmail обрабатывает POST /api/auth/verify
+func (h *AuthHandler) VerifyEmail(c *gin.Context) {
+	var req dto.VerifyEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.verifyEmailUseCase.Execute(auth.VerifyEmailInput{
+		Token: req.Token,
 	})
-}
\ No newline at end of file
+	if err != nil {
+		switch err {
+		case auth.ErrInvalidVerificationToken:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid or expired email verification token"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToUserResponse(result.User))
+}
+
+// ResendVerification обрабатывает POST /api/auth/verify/resend
+func (h *AuthHandler) ResendVerification(c *gin.Context) {
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	if err := h.resendVerificationUseCase.Execute(user.ID); err != nil {
+		switch err {
+		case auth.ErrUserNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		case auth.ErrEmailAlreadyVerified:
+			c.JSON(http.StatusConflict, gin.H{"error": "email already verified"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"message": "verification email sent"})
+}
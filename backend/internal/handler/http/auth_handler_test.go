@@ -3,16 +3,26 @@ package http
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
 	"github.com/bbp/backend/internal/handler/dto"
+	"github.com/bbp/backend/internal/middleware"
 	"github.com/bbp/backend/internal/repository/models"
 	"github.com/bbp/backend/internal/repository/sqlite"
 	"github.com/bbp/backend/internal/usecase/auth"
+	"github.com/bbp/backend/internal/usecase/user"
 	"github.com/bbp/backend/pkg/database"
 	"github.com/bbp/backend/pkg/jwt"
+	"github.com/bbp/backend/pkg/mailer"
+	"github.com/bbp/backend/pkg/totp"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"gorm.io/gorm"
@@ -27,6 +37,17 @@ func setupTestDB(t *testing.T) (*gorm.DB, func()) {
 	// Миграции
 	if err := database.Migrate(db,
 		&models.UserModel{},
+		&models.RoomParticipantModel{},
+		&models.RoomMatchModel{},
+		&models.RefreshTokenModel{},
+		&models.RevokedTokenModel{},
+		&models.PasswordResetTokenModel{},
+		&models.EmailVerificationTokenModel{},
+		&models.UserTOTPModel{},
+		&models.RecoveryCodeModel{},
+		&models.LoginChallengeModel{},
+		&models.LoginThrottleModel{},
+		&models.LockoutEventModel{},
 	); err != nil {
 		t.Fatalf("Failed to run migrations: %v", err)
 	}
@@ -38,25 +59,99 @@ func setupTestDB(t *testing.T) (*gorm.DB, func()) {
 	return db, cleanup
 }
 
+// recordingMailer сохраняет отправленные письма для проверки в тестах; с err отправка не удается
+type recordingMailer struct {
+	messages []mailer.Message
+	err      error
+}
+
+func (m *recordingMailer) Send(msg mailer.Message) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.messages = append(m.messages, msg)
+	return nil
+}
+
 func setupTestRouter(t *testing.T) (*gin.Engine, func()) {
+	router, _, cleanup := setupTestRouterWithMailer(t)
+	return router, cleanup
+}
+
+func setupTestRouterWithMailer(t *testing.T) (*gin.Engine, *recordingMailer, func()) {
 	db, cleanup := setupTestDB(t)
+	testMailer := &recordingMailer{}
 
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
 
 	// Инициализируем репозитории
 	userRepo := sqlite.NewUserRepository(db)
+	refreshTokenRepo := sqlite.NewRefreshTokenRepository(db)
+	revokedTokenRepo := sqlite.NewRevokedTokenRepository(db)
+	passwordResetTokenRepo := sqlite.NewPasswordResetTokenRepository(db)
+	emailVerificationTokenRepo := sqlite.NewEmailVerificationTokenRepository(db)
+	twoFactorRepo := sqlite.NewTwoFactorRepository(db)
+	recoveryCodeRepo := sqlite.NewRecoveryCodeRepository(db)
+	loginChallengeRepo := sqlite.NewLoginChallengeRepository(db)
+	lockoutEventRepo := sqlite.NewLockoutEventRepository(db)
 
 	// Инициализируем JWT сервис
 	jwtService := jwt.NewJWTService("test-secret", 24*60*60*1000*1000000) // 24 часа в наносекундах
+	jwtService.SetDenylist(revokedTokenRepo)
 
 	// Инициализируем use cases
-	registerUseCase := auth.NewRegisterUseCase(userRepo, jwtService)
-	loginUseCase := auth.NewLoginUseCase(userRepo, jwtService)
+	tokenIssuer := auth.NewTokenIssuer(jwtService, refreshTokenRepo, 30*24*time.Hour)
+	emailVerifier := auth.NewEmailVerifier(emailVerificationTokenRepo, testMailer, "http://localhost/verify-email", time.Hour)
+	registerUseCase := auth.NewRegisterUseCase(userRepo, tokenIssuer, emailVerifier)
+	twoFactorVerifier := auth.NewTwoFactorVerifier(twoFactorRepo, recoveryCodeRepo, loginChallengeRepo, 5*time.Minute)
+	loginThrottler := auth.NewLoginThrottler(sqlite.NewLoginThrottleRepository(db), lockoutEventRepo, auth.DefaultLoginThrottlePolicy())
+	loginUseCase := auth.NewLoginUseCase(userRepo, tokenIssuer, twoFactorVerifier, loginThrottler)
 	getCurrentUserUseCase := auth.NewGetCurrentUserUseCase(userRepo)
+	refreshUseCase := auth.NewRefreshUseCase(userRepo, refreshTokenRepo, revokedTokenRepo, tokenIssuer)
+	logoutUseCase := auth.NewLogoutUseCase(refreshTokenRepo, revokedTokenRepo)
+	logoutAllUseCase := auth.NewLogoutAllUseCase(refreshTokenRepo, revokedTokenRepo)
+	requestResetUseCase := auth.NewRequestPasswordResetUseCase(
+		userRepo, passwordResetTokenRepo, testMailer, "http://localhost/reset-password", time.Hour,
+	)
+	resetPasswordUseCase := auth.NewResetPasswordUseCase(userRepo, passwordResetTokenRepo, refreshTokenRepo, revokedTokenRepo)
+	verifyEmailUseCase := auth.NewVerifyEmailUseCase(userRepo, emailVerificationTokenRepo)
+	resendVerificationUseCase := auth.NewResendVerificationUseCase(userRepo, emailVerifier)
 
 	// Инициализируем handler
-	authHandler := NewAuthHandler(registerUseCase, loginUseCase, getCurrentUserUseCase)
+	authHandler := NewAuthHandler(
+		registerUseCase,
+		loginUseCase,
+		getCurrentUserUseCase,
+		refreshUseCase,
+		logoutUseCase,
+		logoutAllUseCase,
+		requestResetUseCase,
+		resetPasswordUseCase,
+		verifyEmailUseCase,
+		resendVerificationUseCase,
+	)
+	twoFactorHandler := NewTwoFactorHandler(
+		auth.NewGetTwoFactorStatusUseCase(twoFactorRepo, recoveryCodeRepo),
+		auth.NewSetupTwoFactorUseCase(userRepo, twoFactorRepo, "MapBan"),
+		auth.NewConfirmTwoFactorUseCase(twoFactorRepo, recoveryCodeRepo),
+		auth.NewDisableTwoFactorUseCase(userRepo, twoFactorRepo, recoveryCodeRepo, twoFactorVerifier),
+		auth.NewVerifyLoginChallengeUseCase(userRepo, loginChallengeRepo, twoFactorVerifier, tokenIssuer, loginThrottler),
+	)
+	userHandler := NewUserHandler(
+		nil,
+		user.NewUpdateProfileUseCase(userRepo, passwordResetTokenRepo, emailVerifier),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+	adminHandler := NewAdminHandler(
+		auth.NewUnlockAccountUseCase(userRepo, loginThrottler),
+		auth.NewGetLockoutEventsUseCase(lockoutEventRepo),
+	)
 
 	// Настраиваем роуты
 	api := router.Group("/api")
@@ -66,10 +161,33 @@ func setupTestRouter(t *testing.T) (*gin.Engine, func()) {
 			authGroup.POST("/register", authHandler.Register)
 			authGroup.POST("/login", authHandler.Login)
 			authGroup.GET("/me", authHandler.GetCurrentUser)
+			authGroup.GET("/session", middleware.AuthMiddleware(jwtService), authHandler.GetCurrentUser)
+			authGroup.POST("/refresh", authHandler.Refresh)
+			authGroup.POST("/logout", middleware.AuthMiddleware(jwtService), authHandler.Logout)
+			authGroup.POST("/logout-all", middleware.AuthMiddleware(jwtService), authHandler.LogoutAll)
+			authGroup.POST("/password/forgot", authHandler.ForgotPassword)
+			authGroup.POST("/password/reset", authHandler.ResetPassword)
+			authGroup.POST("/verify", authHandler.VerifyEmail)
+			authGroup.POST("/verify/resend", middleware.AuthMiddleware(jwtService), authHandler.ResendVerification)
+			authGroup.POST("/login/2fa", twoFactorHandler.VerifyLogin)
+			authGroup.GET("/2fa", middleware.AuthMiddleware(jwtService), twoFactorHandler.GetStatus)
+			authGroup.POST("/2fa/setup", middleware.AuthMiddleware(jwtService), twoFactorHandler.Setup)
+			authGroup.POST("/2fa/confirm", middleware.AuthMiddleware(jwtService), twoFactorHandler.Confirm)
+			authGroup.POST("/2fa/disable", middleware.AuthMiddleware(jwtService), twoFactorHandler.Disable)
+			authGroup.GET("/verified-only", middleware.AuthMiddleware(jwtService), middleware.RequireVerifiedEmail(userRepo), authHandler.GetCurrentUser)
+		}
+
+		api.PUT("/users/profile", middleware.AuthMiddleware(jwtService), userHandler.UpdateProfile)
+
+		adminGroup := api.Group("/admin")
+		adminGroup.Use(middleware.AuthMiddleware(jwtService), middleware.RequireAdmin(userRepo, []string{"admin@example.com"}))
+		{
+			adminGroup.GET("/lockouts", adminHandler.GetLockoutEvents)
+			adminGroup.POST("/users/:id/unlock", adminHandler.UnlockUser)
 		}
 	}
 
-	return router, cleanup
+	return router, testMailer, cleanup
 }
 
 func TestAuthHandler_Register(t *testing.T) {
@@ -266,3 +384,548 @@ func TestAuthHandler_GetCurrentUser(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthHandler_RefreshAndLogout(t *testing.T) {
+	router, cleanup := setupTestRouter(t)
+	defer cleanup()
+
+	postJSON := func(path, token string, payload interface{}) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(payload)
+		req := httptest.NewRequest(http.MethodPost, path, bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	getSession := func(token string) int {
+		req := httptest.NewRequest(http.MethodGet, "/api/auth/session", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	registerW := postJSON("/api/auth/register", "", dto.RegisterRequest{
+		Email:    "test@example.com",
+		Username: "testuser",
+		Password: "password123",
+	})
+	assert.Equal(t, http.StatusCreated, registerW.Code)
+
+	var registered dto.AuthResponse
+	assert.NoError(t, json.Unmarshal(registerW.Body.Bytes(), &registered))
+	assert.NotEmpty(t, registered.RefreshToken)
+	assert.NotEmpty(t, registered.ExpiresAt)
+
+	// Ротация refresh токена
+	refreshW := postJSON("/api/auth/refresh", "", dto.RefreshRequest{RefreshToken: registered.RefreshToken})
+	assert.Equal(t, http.StatusOK, refreshW.Code)
+
+	var refreshed dto.TokenResponse
+	assert.NoError(t, json.Unmarshal(refreshW.Body.Bytes(), &refreshed))
+	assert.NotEqual(t, registered.RefreshToken, refreshed.RefreshToken)
+	assert.Equal(t, http.StatusOK, getSession(refreshed.Token))
+
+	// Повторное использование старого refresh токена завершает все сессии
+	reuseW := postJSON("/api/auth/refresh", "", dto.RefreshRequest{RefreshToken: registered.RefreshToken})
+	assert.Equal(t, http.StatusUnauthorized, reuseW.Code)
+
+	reuseAfterW := postJSON("/api/auth/refresh", "", dto.RefreshRequest{RefreshToken: refreshed.RefreshToken})
+	assert.Equal(t, http.StatusUnauthorized, reuseAfterW.Code)
+
+	// Logout отзывает access токен
+	loginW := postJSON("/api/auth/login", "", dto.LoginRequest{Email: "test@example.com", Password: "password123"})
+	assert.Equal(t, http.StatusOK, loginW.Code)
+
+	var loggedIn dto.AuthResponse
+	assert.NoError(t, json.Unmarshal(loginW.Body.Bytes(), &loggedIn))
+	assert.Equal(t, http.StatusOK, getSession(loggedIn.Token))
+
+	logoutW := postJSON("/api/auth/logout", loggedIn.Token, dto.LogoutRequest{RefreshToken: loggedIn.RefreshToken})
+	assert.Equal(t, http.StatusNoContent, logoutW.Code)
+	assert.Equal(t, http.StatusUnauthorized, getSession(loggedIn.Token))
+
+	afterLogoutW := postJSON("/api/auth/refresh", "", dto.RefreshRequest{RefreshToken: loggedIn.RefreshToken})
+	assert.Equal(t, http.StatusUnauthorized, afterLogoutW.Code)
+}
+
+// racingRefreshTokenRepository отзывает токен "параллельным запросом" между проверкой и ротацией
+type racingRefreshTokenRepository struct {
+	repositories.RefreshTokenRepository
+}
+
+func (r *racingRefreshTokenRepository) Revoke(id uint, replacedByID *uint) (bool, error) {
+	if _, err := r.RefreshTokenRepository.Revoke(id, nil); err != nil {
+		return false, err
+	}
+	return r.RefreshTokenRepository.Revoke(id, replacedByID)
+}
+
+func TestRefreshTokenRepository_DeleteExpired(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := sqlite.NewRefreshTokenRepository(db)
+	now := time.Now()
+	expired := &entities.RefreshToken{UserID: 1, TokenHash: "expired", ExpiresAt: now.Add(-time.Minute)}
+	active := &entities.RefreshToken{UserID: 1, TokenHash: "active", ExpiresAt: now.Add(time.Hour)}
+	assert.NoError(t, repo.Create(expired))
+	assert.NoError(t, repo.Create(active))
+	// Отозванный при ротации токен удаляется тоже только после истечения
+	revoked, err := repo.Revoke(active.ID, nil)
+	assert.NoError(t, err)
+	assert.True(t, revoked)
+
+	assert.NoError(t, repo.DeleteExpired(now))
+
+	stored, err := repo.GetByHash("expired")
+	assert.NoError(t, err)
+	assert.Nil(t, stored)
+	stored, err = repo.GetByHash("active")
+	assert.NoError(t, err)
+	assert.NotNil(t, stored)
+}
+
+func TestRefreshUseCase_ConcurrentRotation(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	userRepo := sqlite.NewUserRepository(db)
+	refreshTokenRepo := sqlite.NewRefreshTokenRepository(db)
+	revokedTokenRepo := sqlite.NewRevokedTokenRepository(db)
+	jwtService := jwt.NewJWTService("test-secret", time.Hour)
+	tokenIssuer := auth.NewTokenIssuer(jwtService, refreshTokenRepo, time.Hour)
+
+	user := &entities.User{Email: "race@example.com", Username: "race", Password: "hashed"}
+	assert.NoError(t, userRepo.Create(user))
+	tokens, err := tokenIssuer.Issue(user)
+	assert.NoError(t, err)
+
+	// Токен уже ротирован другим запросом: новая пара не выдается, все сессии отзываются
+	refreshUseCase := auth.NewRefreshUseCase(userRepo, &racingRefreshTokenRepository{refreshTokenRepo}, revokedTokenRepo, tokenIssuer)
+	_, err = refreshUseCase.Execute(auth.RefreshInput{RefreshToken: tokens.RefreshToken})
+	assert.Equal(t, auth.ErrInvalidRefreshToken, err)
+
+	active, err := refreshTokenRepo.GetActiveByUserID(user.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, active)
+}
+
+func TestAuthHandler_PasswordReset(t *testing.T) {
+	router, testMailer, cleanup := setupTestRouterWithMailer(t)
+	defer cleanup()
+
+	postJSON := func(path string, payload interface{}) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(payload)
+		req := httptest.NewRequest(http.MethodPost, path, bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	registerW := postJSON("/api/auth/register", dto.RegisterRequest{
+		Email:    "test@example.com",
+		Username: "testuser",
+		Password: "password123",
+	})
+	assert.Equal(t, http.StatusCreated, registerW.Code)
+
+	var registered dto.AuthResponse
+	assert.NoError(t, json.Unmarshal(registerW.Body.Bytes(), &registered))
+	// Письмо подтверждения email после регистрации здесь не проверяем
+	testMailer.messages = nil
+
+	// Для неизвестного email ответ такой же, но письмо не отправляется
+	unknownW := postJSON("/api/auth/password/forgot", dto.ForgotPasswordRequest{Email: "nobody@example.com"})
+	assert.Equal(t, http.StatusAccepted, unknownW.Code)
+	assert.Empty(t, testMailer.messages)
+
+	// Сбой почты тоже не раскрывает, что аккаунт существует
+	testMailer.err = errors.New("smtp unavailable")
+	failedW := postJSON("/api/auth/password/forgot", dto.ForgotPasswordRequest{Email: "test@example.com"})
+	assert.Equal(t, http.StatusAccepted, failedW.Code)
+	assert.Equal(t, unknownW.Body.String(), failedW.Body.String())
+	testMailer.err = nil
+
+	forgotW := postJSON("/api/auth/password/forgot", dto.ForgotPasswordRequest{Email: "test@example.com"})
+	assert.Equal(t, http.StatusAccepted, forgotW.Code)
+	if !assert.Len(t, testMailer.messages, 1) {
+		return
+	}
+	assert.Equal(t, "test@example.com", testMailer.messages[0].To)
+
+	token := extractMailToken(t, testMailer.messages[0])
+
+	resetW := postJSON("/api/auth/password/reset", dto.ResetPasswordRequest{Token: token, NewPassword: "newpassword456"})
+	assert.Equal(t, http.StatusNoContent, resetW.Code)
+
+	// Токен одноразовый
+	reuseW := postJSON("/api/auth/password/reset", dto.ResetPasswordRequest{Token: token, NewPassword: "another789"})
+	assert.Equal(t, http.StatusBadRequest, reuseW.Code)
+
+	// Сброс пароля завершает существующие сессии
+	refreshW := postJSON("/api/auth/refresh", dto.RefreshRequest{RefreshToken: registered.RefreshToken})
+	assert.Equal(t, http.StatusUnauthorized, refreshW.Code)
+
+	oldLoginW := postJSON("/api/auth/login", dto.LoginRequest{Email: "test@example.com", Password: "password123"})
+	assert.Equal(t, http.StatusUnauthorized, oldLoginW.Code)
+
+	newLoginW := postJSON("/api/auth/login", dto.LoginRequest{Email: "test@example.com", Password: "newpassword456"})
+	assert.Equal(t, http.StatusOK, newLoginW.Code)
+}
+
+func TestAuthHandler_ChangeEmailRequiresPassword(t *testing.T) {
+	router, testMailer, cleanup := setupTestRouterWithMailer(t)
+	defer cleanup()
+
+	sendJSON := func(method, path, token string, payload interface{}) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(payload)
+		req := httptest.NewRequest(method, path, bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	registerW := sendJSON(http.MethodPost, "/api/auth/register", "", dto.RegisterRequest{
+		Email:    "test@example.com",
+		Username: "testuser",
+		Password: "password123",
+	})
+	assert.Equal(t, http.StatusCreated, registerW.Code)
+	var registered dto.AuthResponse
+	assert.NoError(t, json.Unmarshal(registerW.Body.Bytes(), &registered))
+
+	// Ссылка сброса пароля, выданная до смены email
+	testMailer.messages = nil
+	assert.Equal(t, http.StatusAccepted, sendJSON(http.MethodPost, "/api/auth/password/forgot", "", dto.ForgotPasswordRequest{Email: "test@example.com"}).Code)
+	if !assert.Len(t, testMailer.messages, 1) {
+		return
+	}
+	resetToken := extractMailToken(t, testMailer.messages[0])
+
+	// Одного access токена для смены email недостаточно
+	newEmail := "attacker@example.com"
+	w := sendJSON(http.MethodPut, "/api/users/profile", registered.Token, dto.UpdateProfileRequest{Email: &newEmail})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = sendJSON(http.MethodPut, "/api/users/profile", registered.Token, dto.UpdateProfileRequest{Email: &newEmail, CurrentPassword: "wrong-password"})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// Username меняется без пароля
+	username := "renamed"
+	w = sendJSON(http.MethodPut, "/api/users/profile", registered.Token, dto.UpdateProfileRequest{Username: &username})
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = sendJSON(http.MethodPut, "/api/users/profile", registered.Token, dto.UpdateProfileRequest{Email: &newEmail, CurrentPassword: "password123"})
+	assert.Equal(t, http.StatusOK, w.Code)
+	var updated dto.UserResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &updated))
+	assert.Equal(t, newEmail, updated.Email)
+	assert.False(t, updated.EmailVerified)
+
+	// Старая ссылка сброса пароля больше не действует
+	resetW := sendJSON(http.MethodPost, "/api/auth/password/reset", "", dto.ResetPasswordRequest{Token: resetToken, NewPassword: "newpassword456"})
+	assert.Equal(t, http.StatusBadRequest, resetW.Code)
+}
+
+// extractMailToken достает токен из ссылки в письме
+func extractMailToken(t *testing.T, msg mailer.Message) string {
+	idx := strings.Index(msg.Body, "?token=")
+	if idx < 0 {
+		t.Fatalf("no token link in mail body: %s", msg.Body)
+	}
+	return strings.Fields(msg.Body[idx+len("?token="):])[0]
+}
+
+func TestAuthHandler_VerifyEmail(t *testing.T) {
+	router, testMailer, cleanup := setupTestRouterWithMailer(t)
+	defer cleanup()
+
+	body, _ := json.Marshal(dto.RegisterRequest{
+		Email:    "test@example.com",
+		Username: "testuser",
+		Password: "password123",
+	})
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/register", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	registerW := httptest.NewRecorder()
+	router.ServeHTTP(registerW, req)
+	assert.Equal(t, http.StatusCreated, registerW.Code)
+
+	var registered dto.AuthResponse
+	assert.NoError(t, json.Unmarshal(registerW.Body.Bytes(), &registered))
+	assert.False(t, registered.User.EmailVerified)
+	if !assert.Len(t, testMailer.messages, 1) {
+		return
+	}
+	assert.Equal(t, "test@example.com", testMailer.messages[0].To)
+
+	verifiedOnly := func() int {
+		req := httptest.NewRequest(http.MethodGet, "/api/auth/verified-only", nil)
+		req.Header.Set("Authorization", "Bearer "+registered.Token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+	verify := func(token string) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(dto.VerifyEmailRequest{Token: token})
+		req := httptest.NewRequest(http.MethodPost, "/api/auth/verify", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	assert.Equal(t, http.StatusForbidden, verifiedOnly())
+
+	token := extractMailToken(t, testMailer.messages[0])
+	verifyW := verify(token)
+	assert.Equal(t, http.StatusOK, verifyW.Code)
+
+	var verified dto.UserResponse
+	assert.NoError(t, json.Unmarshal(verifyW.Body.Bytes(), &verified))
+	assert.True(t, verified.EmailVerified)
+	assert.Equal(t, http.StatusOK, verifiedOnly())
+
+	// Токен одноразовый
+	assert.Equal(t, http.StatusBadRequest, verify(token).Code)
+	assert.Equal(t, http.StatusBadRequest, verify("unknown").Code)
+}
+
+func TestAuthHandler_TwoFactorLogin(t *testing.T) {
+	router, cleanup := setupTestRouter(t)
+	defer cleanup()
+
+	postJSON := func(path, token string, payload interface{}) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(payload)
+		req := httptest.NewRequest(http.MethodPost, path, bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	login := func() dto.TwoFactorChallengeResponse {
+		w := postJSON("/api/auth/login", "", dto.LoginRequest{Email: "test@example.com", Password: "password123"})
+		assert.Equal(t, http.StatusOK, w.Code)
+		var challenge dto.TwoFactorChallengeResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &challenge))
+		return challenge
+	}
+
+	registerW := postJSON("/api/auth/register", "", dto.RegisterRequest{
+		Email:    "test@example.com",
+		Username: "testuser",
+		Password: "password123",
+	})
+	assert.Equal(t, http.StatusCreated, registerW.Code)
+	var registered dto.AuthResponse
+	assert.NoError(t, json.Unmarshal(registerW.Body.Bytes(), &registered))
+
+	// Настройка: секрет и otpauth URI, без подтверждения 2FA не включена
+	setupW := postJSON("/api/auth/2fa/setup", registered.Token, nil)
+	assert.Equal(t, http.StatusOK, setupW.Code)
+	var setup dto.TwoFactorSetupResponse
+	assert.NoError(t, json.Unmarshal(setupW.Body.Bytes(), &setup))
+	assert.True(t, strings.HasPrefix(setup.OtpauthURI, "otpauth://totp/"))
+	assert.False(t, login().TwoFactorRequired)
+
+	badConfirmW := postJSON("/api/auth/2fa/confirm", registered.Token, dto.TwoFactorCodeRequest{Code: "000000"})
+	assert.Equal(t, http.StatusBadRequest, badConfirmW.Code)
+
+	step := totp.Step(time.Now())
+	code, err := totp.Code(setup.Secret, step)
+	assert.NoError(t, err)
+	confirmW := postJSON("/api/auth/2fa/confirm", registered.Token, dto.TwoFactorCodeRequest{Code: code})
+	assert.Equal(t, http.StatusOK, confirmW.Code)
+	var confirmed struct {
+		RecoveryCodes []string `json:"recovery_codes"`
+	}
+	assert.NoError(t, json.Unmarshal(confirmW.Body.Bytes(), &confirmed))
+	assert.Len(t, confirmed.RecoveryCodes, 10)
+
+	// Вход по паролю теперь возвращает challenge вместо токенов
+	challenge := login()
+	assert.True(t, challenge.TwoFactorRequired)
+	assert.NotEmpty(t, challenge.ChallengeToken)
+
+	// Код, использованный при подтверждении, повторно не принимается
+	replayW := postJSON("/api/auth/login/2fa", "", dto.LoginTwoFactorRequest{ChallengeToken: challenge.ChallengeToken, Code: code})
+	assert.Equal(t, http.StatusUnauthorized, replayW.Code)
+
+	nextCode, err := totp.Code(setup.Secret, step+1)
+	assert.NoError(t, err)
+	verifyW := postJSON("/api/auth/login/2fa", "", dto.LoginTwoFactorRequest{ChallengeToken: challenge.ChallengeToken, Code: nextCode})
+	assert.Equal(t, http.StatusOK, verifyW.Code)
+	var loggedIn dto.AuthResponse
+	assert.NoError(t, json.Unmarshal(verifyW.Body.Bytes(), &loggedIn))
+	assert.NotEmpty(t, loggedIn.Token)
+
+	// Challenge одноразовый
+	reuseW := postJSON("/api/auth/login/2fa", "", dto.LoginTwoFactorRequest{ChallengeToken: challenge.ChallengeToken, Code: nextCode})
+	assert.Equal(t, http.StatusUnauthorized, reuseW.Code)
+
+	// Код восстановления работает один раз
+	recoveryW := postJSON("/api/auth/login/2fa", "", dto.LoginTwoFactorRequest{
+		ChallengeToken: login().ChallengeToken,
+		Code:           strings.ToUpper(confirmed.RecoveryCodes[0]),
+	})
+	assert.Equal(t, http.StatusOK, recoveryW.Code)
+	recoveryReuseW := postJSON("/api/auth/login/2fa", "", dto.LoginTwoFactorRequest{
+		ChallengeToken: login().ChallengeToken,
+		Code:           confirmed.RecoveryCodes[0],
+	})
+	assert.Equal(t, http.StatusUnauthorized, recoveryReuseW.Code)
+
+	// Отключение требует пароль и код
+	wrongPasswordW := postJSON("/api/auth/2fa/disable", loggedIn.Token, dto.DisableTwoFactorRequest{
+		Password: "wrong-password",
+		Code:     confirmed.RecoveryCodes[1],
+	})
+	assert.Equal(t, http.StatusForbidden, wrongPasswordW.Code)
+
+	disableW := postJSON("/api/auth/2fa/disable", loggedIn.Token, dto.DisableTwoFactorRequest{
+		Password: "password123",
+		Code:     confirmed.RecoveryCodes[1],
+	})
+	assert.Equal(t, http.StatusOK, disableW.Code)
+	assert.False(t, login().TwoFactorRequired)
+}
+
+func TestAuthHandler_TwoFactorLoginLockout(t *testing.T) {
+	router, cleanup := setupTestRouter(t)
+	defer cleanup()
+
+	postJSON := func(path, token string, payload interface{}) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(payload)
+		req := httptest.NewRequest(http.MethodPost, path, bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	login := func(password string) *httptest.ResponseRecorder {
+		return postJSON("/api/auth/login", "", dto.LoginRequest{Email: "test@example.com", Password: password})
+	}
+
+	registerW := postJSON("/api/auth/register", "", dto.RegisterRequest{
+		Email:    "test@example.com",
+		Username: "testuser",
+		Password: "password123",
+	})
+	assert.Equal(t, http.StatusCreated, registerW.Code)
+	var registered dto.AuthResponse
+	assert.NoError(t, json.Unmarshal(registerW.Body.Bytes(), &registered))
+
+	setupW := postJSON("/api/auth/2fa/setup", registered.Token, nil)
+	assert.Equal(t, http.StatusOK, setupW.Code)
+	var setup dto.TwoFactorSetupResponse
+	assert.NoError(t, json.Unmarshal(setupW.Body.Bytes(), &setup))
+	step := totp.Step(time.Now())
+	code, err := totp.Code(setup.Secret, step)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, postJSON("/api/auth/2fa/confirm", registered.Token, dto.TwoFactorCodeRequest{Code: code}).Code)
+
+	// Верный пароль при включенной 2FA не сбрасывает счетчик неудачных попыток
+	for i := 0; i < 4; i++ {
+		assert.Equal(t, http.StatusUnauthorized, login("wrong-password").Code)
+	}
+	loginW := login("password123")
+	assert.Equal(t, http.StatusOK, loginW.Code)
+	var challenge dto.TwoFactorChallengeResponse
+	assert.NoError(t, json.Unmarshal(loginW.Body.Bytes(), &challenge))
+	assert.True(t, challenge.TwoFactorRequired)
+
+	// Неверный код - пятая неудачная попытка: аккаунт блокируется, верный код уже не принимается
+	wrongW := postJSON("/api/auth/login/2fa", "", dto.LoginTwoFactorRequest{ChallengeToken: challenge.ChallengeToken, Code: "000000"})
+	assert.Equal(t, http.StatusUnauthorized, wrongW.Code)
+
+	nextCode, err := totp.Code(setup.Secret, step+1)
+	assert.NoError(t, err)
+	lockedW := postJSON("/api/auth/login/2fa", "", dto.LoginTwoFactorRequest{ChallengeToken: challenge.ChallengeToken, Code: nextCode})
+	assert.Equal(t, http.StatusTooManyRequests, lockedW.Code)
+	assert.Equal(t, http.StatusTooManyRequests, login("password123").Code)
+}
+
+func TestAuthHandler_LoginLockout(t *testing.T) {
+	router, testMailer, cleanup := setupTestRouterWithMailer(t)
+	defer cleanup()
+
+	postJSON := func(path, token string, payload interface{}) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(payload)
+		req := httptest.NewRequest(http.MethodPost, path, bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	register := func(email, username string) dto.AuthResponse {
+		w := postJSON("/api/auth/register", "", dto.RegisterRequest{Email: email, Username: username, Password: "password123"})
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var response dto.AuthResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response
+	}
+	login := func(email, password string) int {
+		return postJSON("/api/auth/login", "", dto.LoginRequest{Email: email, Password: password}).Code
+	}
+
+	// Администратор должен подтвердить email
+	admin := register("admin@example.com", "admin")
+	if !assert.Len(t, testMailer.messages, 1) {
+		return
+	}
+	verifyW := postJSON("/api/auth/verify", "", dto.VerifyEmailRequest{Token: extractMailToken(t, testMailer.messages[0])})
+	assert.Equal(t, http.StatusOK, verifyW.Code)
+
+	user := register("test@example.com", "testuser")
+
+	for i := 0; i < 5; i++ {
+		assert.Equal(t, http.StatusUnauthorized, login("test@example.com", "wrong-password"))
+	}
+	// Во время блокировки не принимается и верный пароль
+	assert.Equal(t, http.StatusTooManyRequests, login("test@example.com", "password123"))
+
+	// Несуществующий аккаунт блокируется так же
+	for i := 0; i < 5; i++ {
+		assert.Equal(t, http.StatusUnauthorized, login("ghost@example.com", "wrong-password"))
+	}
+	assert.Equal(t, http.StatusTooManyRequests, login("ghost@example.com", "password123"))
+
+	unlockPath := fmt.Sprintf("/api/admin/users/%d/unlock", user.User.ID)
+	assert.Equal(t, http.StatusForbidden, postJSON(unlockPath, user.Token, nil).Code)
+	assert.Equal(t, http.StatusOK, postJSON(unlockPath, admin.Token, nil).Code)
+	assert.Equal(t, http.StatusOK, login("test@example.com", "password123"))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/lockouts", nil)
+	req.Header.Set("Authorization", "Bearer "+admin.Token)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var lockouts struct {
+		Events []dto.LockoutEventResponse `json:"events"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &lockouts))
+	if assert.Len(t, lockouts.Events, 3) {
+		assert.Equal(t, "unlocked", lockouts.Events[0].Action)
+		assert.Equal(t, admin.User.ID, *lockouts.Events[0].ActorID)
+		assert.Equal(t, "locked", lockouts.Events[2].Action)
+		assert.Equal(t, user.User.ID, *lockouts.Events[2].UserID)
+	}
+}
@@ -0,0 +1,293 @@
+package http
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"image"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/handler/dto"
+	"github.com/bbp/backend/internal/usecase/veto"
+	"github.com/bbp/backend/pkg/resultcard"
+	"github.com/gin-gonic/gin"
+)
+
+// Форматы экспорта итога вето
+const (
+	ExportFormatJSON     = "json"
+	ExportFormatCSV      = "csv"
+	ExportFormatMarkdown = "md"
+	ExportFormatSVG      = "svg"
+	ExportFormatPNG      = "png"
+)
+
+// ExportHandler отдает итог сессии вето файлом: JSON, CSV действий, Markdown или карточкой PNG/SVG
+type ExportHandler struct {
+	exportSessionUseCase *veto.ExportSessionUseCase
+	imageLoader          *resultcard.ImageLoader
+}
+
+func NewExportHandler(exportSessionUseCase *veto.ExportSessionUseCase, imageLoader *resultcard.ImageLoader) *ExportHandler {
+	return &ExportHandler{
+		exportSessionUseCase: exportSessionUseCase,
+		imageLoader:          imageLoader,
+	}
+}
+
+// ExportSession обрабатывает GET /api/veto/sessions/:id/export?format=json|csv|md|svg|png
+func (h *ExportHandler) ExportSession(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid session id"})
+		return
+	}
+
+	format := strings.ToLower(c.DefaultQuery("format", ExportFormatJSON))
+	if format == "markdown" {
+		format = ExportFormatMarkdown
+	}
+	var contentType string
+	switch format {
+	case ExportFormatJSON:
+		contentType = "application/json; charset=utf-8"
+	case ExportFormatCSV:
+		contentType = "text/csv; charset=utf-8"
+	case ExportFormatMarkdown:
+		contentType = "text/markdown; charset=utf-8"
+	case ExportFormatSVG:
+		contentType = "image/svg+xml"
+	case ExportFormatPNG:
+		contentType = "image/png"
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "unsupported export format"})
+		return
+	}
+
+	result, err := h.exportSessionUseCase.Execute(uint(id))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	var body []byte
+	switch format {
+	case ExportFormatJSON:
+		body, err = json.MarshalIndent(toVetoExportResponse(result), "", "  ")
+	case ExportFormatCSV:
+		body, err = exportCSV(result)
+	case ExportFormatMarkdown:
+		body = exportMarkdown(result)
+	case ExportFormatSVG:
+		body, err = resultcard.RenderSVG(h.toResultCard(result))
+	case ExportFormatPNG:
+		body, err = resultcard.RenderPNG(h.toResultCard(result))
+	}
+	if err != nil {
+		log.Printf("Failed to export veto session %d as %s: %v", id, format, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`inline; filename="veto-%d.%s"`, id, format))
+	c.Data(http.StatusOK, contentType, body)
+}
+
+func (h *ExportHandler) handleError(c *gin.Context, err error) {
+	switch err {
+	case veto.ErrSessionNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "session not found"})
+	case veto.ErrMapPoolNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "map pool not found"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+	}
+}
+
+// toVetoExportResponse конвертирует итог вето в канонический JSON
+func toVetoExportResponse(result *veto.ExportSessionOutput) dto.VetoExportResponse {
+	overlay := toOverlayResponse(result.Result)
+	response := dto.VetoExportResponse{
+		SessionID: result.Session.ID,
+		Game:      result.GameName,
+		Type:      overlay.Type,
+		Status:    overlay.Status,
+		TeamA:     overlay.TeamA,
+		TeamB:     overlay.TeamB,
+		TeamAID:   result.Session.TeamAID,
+		TeamBID:   result.Session.TeamBID,
+		Steps:     overlay.Steps,
+		Decider:   overlay.Decider,
+		CreatedAt: result.Session.CreatedAt.UTC().Format(time.RFC3339),
+	}
+	if result.Session.FinishedAt != nil {
+		finishedAt := result.Session.FinishedAt.UTC().Format(time.RFC3339)
+		response.FinishedAt = &finishedAt
+	}
+	return response
+}
+
+// exportCSV пишет действия вето по строке на шаг; десайдер - последней строкой с action=decider
+func exportCSV(result *veto.ExportSessionOutput) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write([]string{"step", "team", "team_name", "action", "map_id", "map_name", "side", "side_team_name"}); err != nil {
+		return nil, err
+	}
+	for _, step := range result.Result.Steps {
+		if err := w.Write([]string{
+			strconv.Itoa(step.Step),
+			step.Team,
+			csvCell(step.TeamName),
+			string(step.Action),
+			strconv.FormatUint(uint64(step.Map.ID), 10),
+			csvCell(step.Map.Name),
+			stringValue(step.Side),
+			csvCell(step.SideTeamName),
+		}); err != nil {
+			return nil, err
+		}
+	}
+	if decider := result.Result.Decider; decider != nil {
+		if err := w.Write([]string{
+			"", "", "", "decider",
+			strconv.FormatUint(uint64(decider.Map.ID), 10),
+			csvCell(decider.Map.Name),
+			stringValue(decider.Side),
+			"",
+		}); err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+// csvCell экранирует значения, которые табличные редакторы приняли бы за формулу
+func csvCell(value string) string {
+	if value != "" && strings.ContainsRune("=+-@", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// exportMarkdown собирает сводку для чатов и форумов: заголовок, таблица шагов и десайдер
+func exportMarkdown(result *veto.ExportSessionOutput) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "# %s vs %s\n\n", markdownEscape(result.Result.TeamAName), markdownEscape(result.Result.TeamBName))
+	fmt.Fprintf(&buf, "%s\n\n", markdownEscape(exportSubtitle(result)))
+
+	if len(result.Result.Steps) > 0 {
+		buf.WriteString("| # | Team | Action | Map | Side |\n")
+		buf.WriteString("| --- | --- | --- | --- | --- |\n")
+		for _, step := range result.Result.Steps {
+			fmt.Fprintf(&buf, "| %d | %s | %s | %s | %s |\n",
+				step.Step, markdownEscape(step.TeamName), step.Action, markdownEscape(step.Map.Name), markdownEscape(sideDetail(step)))
+		}
+		buf.WriteString("\n")
+	}
+
+	if decider := result.Result.Decider; decider != nil {
+		fmt.Fprintf(&buf, "**Decider:** %s", markdownEscape(decider.Map.Name))
+		if decider.Side != nil {
+			fmt.Fprintf(&buf, " (%s)", markdownEscape(*decider.Side))
+		}
+		buf.WriteString("\n")
+	}
+	return buf.Bytes()
+}
+
+var markdownReplacer = strings.NewReplacer(
+	`\`, `\\`, "|", `\|`, "*", `\*`, "_", `\_`, "`", "\\`",
+	"[", `\[`, "]", `\]`, "<", `\<`, ">", `\>`, "#", `\#`, "\n", " ",
+)
+
+func markdownEscape(value string) string {
+	return markdownReplacer.Replace(value)
+}
+
+// toResultCard собирает карточку итога вето; картинки, которые не удалось загрузить, заменяются пустыми плитками
+func (h *ExportHandler) toResultCard(result *veto.ExportSessionOutput) resultcard.Card {
+	card := resultcard.Card{
+		TeamA:    result.Result.TeamAName,
+		TeamB:    result.Result.TeamBName,
+		Subtitle: exportSubtitle(result),
+	}
+
+	for _, step := range result.Result.Steps {
+		tile := resultcard.Tile{
+			MapName: step.Map.Name,
+			Team:    step.Team,
+			Image:   h.loadMapImage(step.Map.ImageURL),
+		}
+		switch step.Action {
+		case entities.VetoActionTypePick:
+			tile.Label = "Pick · " + step.TeamName
+			tile.Detail = sideDetail(step)
+			card.Picks = append(card.Picks, tile)
+		default:
+			tile.Label = "Ban · " + step.TeamName
+			card.Bans = append(card.Bans, tile)
+		}
+	}
+
+	if decider := result.Result.Decider; decider != nil {
+		card.Decider = &resultcard.Tile{
+			MapName: decider.Map.Name,
+			Label:   "Decider",
+			Image:   h.loadMapImage(decider.Map.ImageURL),
+		}
+		if decider.Side != nil {
+			card.Decider.Detail = "Side · " + *decider.Side
+		}
+	}
+	return card
+}
+
+func (h *ExportHandler) loadMapImage(imageURL string) image.Image {
+	if imageURL == "" || h.imageLoader == nil {
+		return nil
+	}
+	img, err := h.imageLoader.Load(imageURL)
+	if err != nil {
+		return nil
+	}
+	return img
+}
+
+// exportSubtitle подпись под названиями команд: игра, формат и статус незавершенной сессии
+func exportSubtitle(result *veto.ExportSessionOutput) string {
+	parts := []string{}
+	if result.GameName != "" {
+		parts = append(parts, result.GameName)
+	}
+	parts = append(parts, strings.ToUpper(string(result.Result.Type)))
+	if result.Result.Status != entities.VetoStatusFinished {
+		parts = append(parts, strings.ReplaceAll(string(result.Result.Status), "_", " "))
+	}
+	return strings.Join(parts, " · ")
+}
+
+// sideDetail описывает выбор стороны на пикнутой карте, например "Bravo · CT"
+func sideDetail(step veto.OverlayStep) string {
+	if step.Side == nil {
+		return ""
+	}
+	if step.SideTeamName == "" {
+		return *step.Side
+	}
+	return step.SideTeamName + " · " + *step.Side
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
@@ -0,0 +1,142 @@
+package http
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/handler/dto"
+	"github.com/bbp/backend/internal/repository/sqlite"
+	"github.com/bbp/backend/internal/usecase/veto"
+	"github.com/bbp/backend/pkg/resultcard"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportHandler_Formats(t *testing.T) {
+	db, cleanup := setupVetoTestDB(t)
+	defer cleanup()
+
+	gameRepo := sqlite.NewGameRepository(db)
+	mapRepo := sqlite.NewMapRepository(db)
+	mapPoolRepo := sqlite.NewMapPoolRepository(db)
+	vetoSessionRepo := sqlite.NewVetoSessionRepository(db)
+	vetoActionRepo := sqlite.NewVetoActionRepository(db)
+	vetoLogicService := veto.NewVetoLogicService()
+
+	// Картинки карт отдает фронтенд
+	var mapImage bytes.Buffer
+	img := image.NewRGBA(image.Rect(0, 0, 32, 18))
+	img.Set(0, 0, color.RGBA{0xff, 0, 0, 0xff})
+	require.NoError(t, png.Encode(&mapImage, img))
+	frontend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(mapImage.Bytes())
+	}))
+	defer frontend.Close()
+
+	game := &entities.Game{Name: "CS2", Slug: "cs2", IsActive: true}
+	require.NoError(t, gameRepo.Create(game))
+	var maps []entities.Map
+	for _, name := range []string{"Mirage", "Inferno", "Nuke"} {
+		slug := strings.ToLower(name)
+		m := &entities.Map{GameID: game.ID, Name: name, Slug: slug, ImageURL: "/images/" + slug + ".png", IsActive: true}
+		require.NoError(t, mapRepo.Create(m))
+		maps = append(maps, *m)
+	}
+	pool := &entities.MapPool{GameID: game.ID, Name: "Pool", Type: entities.MapPoolTypeAll, IsSystem: true, Maps: maps}
+	require.NoError(t, mapPoolRepo.Create(pool))
+
+	created, err := veto.NewCreateSessionUseCase(vetoSessionRepo, mapPoolRepo, gameRepo, sqlite.NewMapRotationRepository(db), sqlite.NewTeamRepository(db), vetoLogicService).
+		Execute(veto.CreateSessionInput{GameID: game.ID, MapPoolID: pool.ID, Type: entities.VetoTypeBo1, TeamAName: "=Alpha|1", TeamBName: "Bravo"})
+	require.NoError(t, err)
+	session := created.Session
+	_, err = veto.NewStartSessionUseCase(vetoSessionRepo).Execute(veto.StartSessionInput{SessionID: session.ID})
+	require.NoError(t, err)
+	banMap := veto.NewBanMapUseCase(vetoSessionRepo, vetoActionRepo, mapRepo, mapPoolRepo, vetoLogicService)
+	_, err = banMap.Execute(veto.BanMapInput{SessionID: session.ID, MapID: maps[0].ID, Team: "A"})
+	require.NoError(t, err)
+	_, err = banMap.Execute(veto.BanMapInput{SessionID: session.ID, MapID: maps[1].ID, Team: "B"})
+	require.NoError(t, err)
+
+	imageLoader, err := resultcard.NewImageLoader(frontend.URL)
+	require.NoError(t, err)
+	exportUseCase := veto.NewExportSessionUseCase(vetoSessionRepo, gameRepo, veto.NewGetOverlayUseCase(vetoSessionRepo, mapPoolRepo, vetoLogicService))
+	handler := NewExportHandler(exportUseCase, imageLoader)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/api/veto/sessions/:id/export", handler.ExportSession)
+
+	export := func(id uint, format string) *httptest.ResponseRecorder {
+		path := fmt.Sprintf("/api/veto/sessions/%d/export", id)
+		if format != "" {
+			path += "?format=" + format
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+
+	assert.Equal(t, http.StatusNotFound, export(session.ID+100, "").Code)
+	assert.Equal(t, http.StatusBadRequest, export(session.ID, "pdf").Code)
+
+	// JSON по умолчанию
+	w := export(session.ID, "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, fmt.Sprintf(`inline; filename="veto-%d.json"`, session.ID), w.Header().Get("Content-Disposition"))
+	var response dto.VetoExportResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "CS2", response.Game)
+	assert.Equal(t, "finished", response.Status)
+	require.Len(t, response.Steps, 2)
+	assert.Equal(t, "Mirage", response.Steps[0].MapName)
+	assert.Equal(t, "/images/mirage.png", response.Steps[0].MapImageURL)
+	require.NotNil(t, response.Decider)
+	assert.Equal(t, "Nuke", response.Decider.MapName)
+	assert.NotNil(t, response.FinishedAt)
+
+	// CSV: строка на действие и десайдер, значения-формулы экранированы
+	w = export(session.ID, "csv")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+	rows, err := csv.NewReader(w.Body).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 4)
+	assert.Equal(t, []string{"step", "team", "team_name", "action", "map_id", "map_name", "side", "side_team_name"}, rows[0])
+	assert.Equal(t, "'=Alpha|1", rows[1][2])
+	assert.Equal(t, "Inferno", rows[2][5])
+	assert.Equal(t, "decider", rows[3][3])
+	assert.Equal(t, "Nuke", rows[3][5])
+
+	// Markdown
+	w = export(session.ID, "markdown")
+	require.Equal(t, http.StatusOK, w.Code)
+	markdown := w.Body.String()
+	assert.True(t, strings.HasPrefix(markdown, "# =Alpha\\|1 vs Bravo\n"), markdown)
+	assert.Contains(t, markdown, "| 2 | Bravo | ban | Inferno |  |")
+	assert.Contains(t, markdown, "**Decider:** Nuke")
+
+	// Карточка с картинками карт
+	w = export(session.ID, "svg")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "image/svg+xml", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), "Decider")
+	assert.Equal(t, 3, strings.Count(w.Body.String(), "data:image/jpeg;base64,"))
+
+	w = export(session.ID, "png")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "image/png", w.Header().Get("Content-Type"))
+	card, err := png.Decode(w.Body)
+	require.NoError(t, err)
+	assert.Equal(t, 1200, card.Bounds().Dx())
+}
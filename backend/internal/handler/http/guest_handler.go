@@ -0,0 +1,75 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/bbp/backend/internal/handler/dto"
+	"github.com/bbp/backend/internal/middleware"
+	"github.com/bbp/backend/internal/usecase/auth"
+	"github.com/gin-gonic/gin"
+)
+
+type GuestHandler struct {
+	createGuestUseCase  *auth.CreateGuestUseCase
+	upgradeGuestUseCase *auth.UpgradeGuestUseCase
+}
+
+func NewGuestHandler(
+	createGuestUseCase *auth.CreateGuestUseCase,
+	upgradeGuestUseCase *auth.UpgradeGuestUseCase,
+) *GuestHandler {
+	return &GuestHandler{
+		createGuestUseCase:  createGuestUseCase,
+		upgradeGuestUseCase: upgradeGuestUseCase,
+	}
+}
+
+// CreateGuest обрабатывает POST /api/auth/guest
+func (h *GuestHandler) CreateGuest(c *gin.Context) {
+	result, err := h.createGuestUseCase.Execute()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, toAuthResponse(result.Tokens, dto.ToUserResponse(result.User)))
+}
+
+// UpgradeGuest обрабатывает POST /api/auth/guest/upgrade
+func (h *GuestHandler) UpgradeGuest(c *gin.Context) {
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req dto.UpgradeGuestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.upgradeGuestUseCase.Execute(auth.UpgradeGuestInput{
+		UserID:   user.ID,
+		Email:    req.Email,
+		Username: req.Username,
+		Password: req.Password,
+	})
+	if err != nil {
+		switch err {
+		case auth.ErrUserNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		case auth.ErrNotGuest:
+			c.JSON(http.StatusConflict, gin.H{"error": "account is not a guest account"})
+		case auth.ErrEmailAlreadyExists:
+			c.JSON(http.StatusConflict, gin.H{"error": "email already exists"})
+		case auth.ErrUsernameAlreadyExists:
+			c.JSON(http.StatusConflict, gin.H{"error": "username already exists"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, toAuthResponse(result.Tokens, dto.ToUserResponse(result.User)))
+}
@@ -0,0 +1,124 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bbp/backend/internal/handler/dto"
+	"github.com/bbp/backend/internal/middleware"
+	"github.com/bbp/backend/internal/repository/sqlite"
+	"github.com/bbp/backend/internal/usecase/auth"
+	"github.com/bbp/backend/pkg/jwt"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupGuestTestRouter(t *testing.T) *gin.Engine {
+	db, cleanup := setupTestDB(t)
+	t.Cleanup(cleanup)
+
+	userRepo := sqlite.NewUserRepository(db)
+	refreshTokenRepo := sqlite.NewRefreshTokenRepository(db)
+	revokedTokenRepo := sqlite.NewRevokedTokenRepository(db)
+
+	jwtService := jwt.NewJWTService("test-secret", time.Hour)
+	jwtService.SetDenylist(revokedTokenRepo)
+
+	tokenIssuer := auth.NewTokenIssuer(jwtService, refreshTokenRepo, 30*24*time.Hour)
+	emailVerifier := auth.NewEmailVerifier(
+		sqlite.NewEmailVerificationTokenRepository(db), &recordingMailer{}, "http://localhost/verify-email", time.Hour,
+	)
+
+	handler := NewGuestHandler(
+		auth.NewCreateGuestUseCase(userRepo, tokenIssuer, 2*time.Hour),
+		auth.NewUpgradeGuestUseCase(userRepo, refreshTokenRepo, revokedTokenRepo, tokenIssuer, emailVerifier),
+	)
+	refreshHandler := NewAuthHandler(
+		nil, nil, auth.NewGetCurrentUserUseCase(userRepo),
+		auth.NewRefreshUseCase(userRepo, refreshTokenRepo, revokedTokenRepo, tokenIssuer),
+		nil, nil, nil, nil, nil, nil,
+	)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/api/auth/guest", handler.CreateGuest)
+	router.POST("/api/auth/guest/upgrade", middleware.AuthMiddleware(jwtService), handler.UpgradeGuest)
+	router.POST("/api/auth/refresh", refreshHandler.Refresh)
+	router.GET("/api/auth/me", middleware.AuthMiddleware(jwtService), refreshHandler.GetCurrentUser)
+	// Маршрут, закрытый для гостей, как создание пулов карт
+	router.POST("/api/map-pools", middleware.AuthMiddleware(jwtService), middleware.RejectGuests(), func(c *gin.Context) {
+		c.Status(http.StatusCreated)
+	})
+
+	return router
+}
+
+func TestGuestHandler_CreateAndUpgrade(t *testing.T) {
+	router := setupGuestTestRouter(t)
+
+	request := func(method, path, token string, payload interface{}) *httptest.ResponseRecorder {
+		var body bytes.Buffer
+		if payload != nil {
+			json.NewEncoder(&body).Encode(payload)
+		}
+		req := httptest.NewRequest(method, path, &body)
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	guestW := request(http.MethodPost, "/api/auth/guest", "", nil)
+	assert.Equal(t, http.StatusCreated, guestW.Code)
+
+	var guest dto.AuthResponse
+	assert.NoError(t, json.Unmarshal(guestW.Body.Bytes(), &guest))
+	assert.True(t, guest.User.IsGuest)
+	assert.Empty(t, guest.User.Email)
+	assert.Regexp(t, `^Guest-\d{6}$`, guest.User.Username)
+
+	// Гостевая сессия ограничена и не продлевается при обновлении токенов
+	guestRefreshExpiry, _ := time.Parse(time.RFC3339, guest.RefreshExpiresAt)
+	assert.WithinDuration(t, time.Now().Add(2*time.Hour), guestRefreshExpiry, time.Minute)
+
+	refreshW := request(http.MethodPost, "/api/auth/refresh", "", dto.RefreshRequest{RefreshToken: guest.RefreshToken})
+	assert.Equal(t, http.StatusOK, refreshW.Code)
+	var refreshed dto.TokenResponse
+	assert.NoError(t, json.Unmarshal(refreshW.Body.Bytes(), &refreshed))
+	assert.Equal(t, guest.RefreshExpiresAt, refreshed.RefreshExpiresAt)
+
+	// Гость не может создавать пулы карт
+	assert.Equal(t, http.StatusForbidden, request(http.MethodPost, "/api/map-pools", refreshed.Token, nil).Code)
+
+	username := "upgraded"
+	upgradeW := request(http.MethodPost, "/api/auth/guest/upgrade", refreshed.Token, dto.UpgradeGuestRequest{
+		Email:    "upgraded@example.com",
+		Username: &username,
+		Password: "password123",
+	})
+	assert.Equal(t, http.StatusOK, upgradeW.Code)
+
+	var upgraded dto.AuthResponse
+	assert.NoError(t, json.Unmarshal(upgradeW.Body.Bytes(), &upgraded))
+	assert.False(t, upgraded.User.IsGuest)
+	assert.Equal(t, guest.User.ID, upgraded.User.ID)
+	assert.Equal(t, "upgraded@example.com", upgraded.User.Email)
+
+	// Старые гостевые токены отозваны, новые работают без ограничений
+	assert.Equal(t, http.StatusUnauthorized, request(http.MethodGet, "/api/auth/me", refreshed.Token, nil).Code)
+	assert.Equal(t, http.StatusCreated, request(http.MethodPost, "/api/map-pools", upgraded.Token, nil).Code)
+
+	// Повторный апгрейд невозможен
+	repeatW := request(http.MethodPost, "/api/auth/guest/upgrade", upgraded.Token, dto.UpgradeGuestRequest{
+		Email:    "other@example.com",
+		Password: "password123",
+	})
+	assert.Equal(t, http.StatusConflict, repeatW.Code)
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/bbp/backend/internal/domain/entities"
 	"github.com/bbp/backend/internal/handler/dto"
 	"github.com/bbp/backend/internal/middleware"
 	"github.com/bbp/backend/internal/usecase/map_pool"
@@ -15,6 +16,13 @@ type MapPoolHandler struct {
 	getPoolUseCase         *map_pool.GetPoolUseCase
 	createCustomPoolUseCase *map_pool.CreateCustomPoolUseCase
 	deletePoolUseCase      *map_pool.DeletePoolUseCase
+	updatePoolUseCase      *map_pool.UpdatePoolUseCase
+	addMapUseCase          *map_pool.AddMapUseCase
+	removeMapUseCase       *map_pool.RemoveMapUseCase
+	duplicatePoolUseCase   *map_pool.DuplicatePoolUseCase
+	getPublicPoolsUseCase  *map_pool.GetPublicPoolsUseCase
+	getSharedPoolUseCase   *map_pool.GetSharedPoolUseCase
+	forkPoolUseCase        *map_pool.ForkPoolUseCase
 }
 
 func NewMapPoolHandler(
@@ -22,12 +30,26 @@ func NewMapPoolHandler(
 	getPoolUseCase *map_pool.GetPoolUseCase,
 	createCustomPoolUseCase *map_pool.CreateCustomPoolUseCase,
 	deletePoolUseCase *map_pool.DeletePoolUseCase,
+	updatePoolUseCase *map_pool.UpdatePoolUseCase,
+	addMapUseCase *map_pool.AddMapUseCase,
+	removeMapUseCase *map_pool.RemoveMapUseCase,
+	duplicatePoolUseCase *map_pool.DuplicatePoolUseCase,
+	getPublicPoolsUseCase *map_pool.GetPublicPoolsUseCase,
+	getSharedPoolUseCase *map_pool.GetSharedPoolUseCase,
+	forkPoolUseCase *map_pool.ForkPoolUseCase,
 ) *MapPoolHandler {
 	return &MapPoolHandler{
 		getPoolsUseCase:         getPoolsUseCase,
 		getPoolUseCase:          getPoolUseCase,
 		createCustomPoolUseCase: createCustomPoolUseCase,
 		deletePoolUseCase:       deletePoolUseCase,
+		updatePoolUseCase:       updatePoolUseCase,
+		addMapUseCase:           addMapUseCase,
+		removeMapUseCase:        removeMapUseCase,
+		duplicatePoolUseCase:    duplicatePoolUseCase,
+		getPublicPoolsUseCase:   getPublicPoolsUseCase,
+		getSharedPoolUseCase:    getSharedPoolUseCase,
+		forkPoolUseCase:         forkPoolUseCase,
 	}
 }
 
@@ -62,7 +84,20 @@ func (h *MapPoolHandler) GetPools(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.ToMapPoolResponseList(result.Pools))
+	response := dto.ToMapPoolResponseList(result.Pools)
+	for i := range response {
+		for _, compat := range result.Compatibility[response[i].ID] {
+			response[i].Compatibility = append(response[i].Compatibility, dto.PoolCompatibilityResponse{
+				VetoType:   string(compat.VetoType),
+				MinSize:    compat.MinSize,
+				ExactSize:  compat.ExactSize,
+				Compatible: compat.Compatible,
+				Exact:      compat.Exact,
+			})
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
 }
 
 // GetPool обрабатывает GET /api/map-pools/:id
@@ -127,10 +162,11 @@ func (h *MapPoolHandler) CreateCustomPool(c *gin.Context) {
 	}
 
 	result, err := h.createCustomPoolUseCase.Execute(map_pool.CreateCustomPoolInput{
-		UserID:  user.ID,
-		GameID:  gameID,
-		Name:    req.Name,
-		MapIDs:  req.MapIDs,
+		UserID:     user.ID,
+		GameID:     gameID,
+		Name:       req.Name,
+		MapIDs:     req.MapIDs,
+		Visibility: entities.MapPoolVisibility(req.Visibility),
 	})
 
 	if err != nil {
@@ -189,3 +225,274 @@ func (h *MapPoolHandler) DeletePool(c *gin.Context) {
 
 	c.JSON(http.StatusNoContent, nil)
 }
+
+// UpdatePool обрабатывает PUT /api/map-pools/:id
+func (h *MapPoolHandler) UpdatePool(c *gin.Context) {
+	// Получаем пользователя из контекста (требует авторизации)
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid pool id"})
+		return
+	}
+
+	var req dto.UpdateMapPoolRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var visibility *entities.MapPoolVisibility
+	if req.Visibility != nil {
+		v := entities.MapPoolVisibility(*req.Visibility)
+		visibility = &v
+	}
+
+	result, err := h.updatePoolUseCase.Execute(map_pool.UpdatePoolInput{
+		PoolID:     uint(id),
+		UserID:     user.ID,
+		Name:       req.Name,
+		Visibility: visibility,
+	})
+	if err != nil {
+		h.handleEditError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToMapPoolResponse(result.Pool))
+}
+
+// AddMap обрабатывает POST /api/map-pools/:id/maps/:mapId
+func (h *MapPoolHandler) AddMap(c *gin.Context) {
+	// Получаем пользователя из контекста (требует авторизации)
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	poolID, mapID, ok := parsePoolMapParams(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.addMapUseCase.Execute(map_pool.AddMapInput{
+		PoolID: poolID,
+		MapID:  mapID,
+		UserID: user.ID,
+	})
+	if err != nil {
+		h.handleEditError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToMapPoolResponse(result.Pool))
+}
+
+// RemoveMap обрабатывает DELETE /api/map-pools/:id/maps/:mapId
+func (h *MapPoolHandler) RemoveMap(c *gin.Context) {
+	// Получаем пользователя из контекста (требует авторизации)
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	poolID, mapID, ok := parsePoolMapParams(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.removeMapUseCase.Execute(map_pool.RemoveMapInput{
+		PoolID: poolID,
+		MapID:  mapID,
+		UserID: user.ID,
+	})
+	if err != nil {
+		h.handleEditError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToMapPoolResponse(result.Pool))
+}
+
+// DuplicatePool обрабатывает POST /api/map-pools/:id/duplicate
+func (h *MapPoolHandler) DuplicatePool(c *gin.Context) {
+	// Получаем пользователя из контекста (требует авторизации)
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid pool id"})
+		return
+	}
+
+	// Тело запроса необязательно
+	var req dto.DuplicateMapPoolRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	result, err := h.duplicatePoolUseCase.Execute(map_pool.DuplicatePoolInput{
+		PoolID: uint(id),
+		UserID: user.ID,
+		Name:   req.Name,
+	})
+	if err != nil {
+		h.handleEditError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ToMapPoolResponse(result.Pool))
+}
+
+// GetPublicPools обрабатывает GET /api/map-pools/public
+// Доступен без авторизации - библиотека опубликованных пулов
+func (h *MapPoolHandler) GetPublicPools(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit <= 0 {
+		limit = 20
+	}
+
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+
+	input := map_pool.GetPublicPoolsInput{
+		Limit:  limit,
+		Offset: offset,
+	}
+
+	// Опциональные фильтры: game_id и поиск по названию
+	if gameIDStr := c.Query("game_id"); gameIDStr != "" {
+		gameID, err := strconv.ParseUint(gameIDStr, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid game id"})
+			return
+		}
+		id := uint(gameID)
+		input.GameID = &id
+	}
+	if query := c.Query("q"); query != "" {
+		input.Query = &query
+	}
+
+	result, err := h.getPublicPoolsUseCase.Execute(input)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.PublicMapPoolListResponse{
+		Data:  dto.ToMapPoolResponseList(result.Pools),
+		Total: result.Total,
+	})
+}
+
+// GetSharedPool обрабатывает GET /api/map-pools/shared/:slug
+// Доступен без авторизации - пул по ссылке
+func (h *MapPoolHandler) GetSharedPool(c *gin.Context) {
+	result, err := h.getSharedPoolUseCase.Execute(map_pool.GetSharedPoolInput{
+		ShareSlug: c.Param("slug"),
+	})
+	if err != nil {
+		switch err {
+		case map_pool.ErrMapPoolNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "map pool not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToMapPoolResponse(result.Pool))
+}
+
+// ForkPool обрабатывает POST /api/map-pools/shared/:slug/fork
+func (h *MapPoolHandler) ForkPool(c *gin.Context) {
+	// Получаем пользователя из контекста (требует авторизации)
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	// Тело запроса необязательно
+	var req dto.DuplicateMapPoolRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	result, err := h.forkPoolUseCase.Execute(map_pool.ForkPoolInput{
+		ShareSlug: c.Param("slug"),
+		UserID:    user.ID,
+		Name:      req.Name,
+	})
+	if err != nil {
+		h.handleEditError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ToMapPoolResponse(result.Pool))
+}
+
+// handleEditError преобразует ошибки редактирования пула в HTTP ответ
+func (h *MapPoolHandler) handleEditError(c *gin.Context, err error) {
+	switch err {
+	case map_pool.ErrMapPoolNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "map pool not found"})
+	case map_pool.ErrMapNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "map not found"})
+	case map_pool.ErrUnauthorized:
+		c.JSON(http.StatusForbidden, gin.H{"error": "unauthorized"})
+	case map_pool.ErrCannotEditSystem:
+		c.JSON(http.StatusForbidden, gin.H{"error": "cannot edit system map pool"})
+	case map_pool.ErrPoolInUse:
+		c.JSON(http.StatusConflict, gin.H{"error": "map pool is used by an in-progress veto session"})
+	case map_pool.ErrMapAlreadyInPool:
+		c.JSON(http.StatusConflict, gin.H{"error": "map is already in the pool"})
+	case map_pool.ErrMapNotInPool:
+		c.JSON(http.StatusNotFound, gin.H{"error": "map is not in the pool"})
+	case map_pool.ErrInvalidMapPool:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid map pool"})
+	case map_pool.ErrPoolHasNoMaps:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "map pool must have at least one map"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+	}
+}
+
+// parsePoolMapParams разбирает параметры :id и :mapId
+func parsePoolMapParams(c *gin.Context) (uint, uint, bool) {
+	poolID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid pool id"})
+		return 0, 0, false
+	}
+
+	mapID, err := strconv.ParseUint(c.Param("mapId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid map id"})
+		return 0, 0, false
+	}
+
+	return uint(poolID), uint(mapID), true
+}
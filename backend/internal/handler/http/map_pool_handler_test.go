@@ -0,0 +1,257 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/handler/dto"
+	"github.com/bbp/backend/internal/middleware"
+	"github.com/bbp/backend/internal/repository/sqlite"
+	"github.com/bbp/backend/internal/usecase/map_pool"
+	"github.com/bbp/backend/internal/usecase/veto"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+type mapPoolTestEnv struct {
+	router *gin.Engine
+	db     *gorm.DB
+	poolID uint
+	mapIDs []uint
+}
+
+// setupMapPoolTestRouter создает роутер с пулом пользователя 1 из двух карт и третьей свободной картой
+func setupMapPoolTestRouter(t *testing.T) (*mapPoolTestEnv, func()) {
+	db, cleanup := setupVetoTestDB(t)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	mapPoolRepo := sqlite.NewMapPoolRepository(db)
+	mapRepo := sqlite.NewMapRepository(db)
+	gameRepo := sqlite.NewGameRepository(db)
+	vetoSessionRepo := sqlite.NewVetoSessionRepository(db)
+
+	game := &entities.Game{Name: "Valorant", Slug: "valorant", IsActive: true}
+	if err := gameRepo.Create(game); err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+
+	var maps []entities.Map
+	var mapIDs []uint
+	for _, slug := range []string{"bind", "haven", "lotus"} {
+		m := &entities.Map{GameID: game.ID, Name: slug, Slug: slug, IsActive: true}
+		if err := mapRepo.Create(m); err != nil {
+			t.Fatalf("Failed to create map: %v", err)
+		}
+		maps = append(maps, *m)
+		mapIDs = append(mapIDs, m.ID)
+	}
+
+	userID := uint(1)
+	pool := &entities.MapPool{
+		GameID: game.ID,
+		UserID: &userID,
+		Name:   "My Pool",
+		Type:   entities.MapPoolTypeCustom,
+		Maps:   maps[:2],
+	}
+	if err := mapPoolRepo.Create(pool); err != nil {
+		t.Fatalf("Failed to create map pool: %v", err)
+	}
+
+	handler := NewMapPoolHandler(
+		map_pool.NewGetPoolsUseCase(mapPoolRepo, gameRepo, veto.NewVetoLogicService()),
+		map_pool.NewGetPoolUseCase(mapPoolRepo),
+		map_pool.NewCreateCustomPoolUseCase(mapPoolRepo, mapRepo, gameRepo),
+		map_pool.NewDeletePoolUseCase(mapPoolRepo),
+		map_pool.NewUpdatePoolUseCase(mapPoolRepo, vetoSessionRepo),
+		map_pool.NewAddMapUseCase(mapPoolRepo, mapRepo, vetoSessionRepo),
+		map_pool.NewRemoveMapUseCase(mapPoolRepo, vetoSessionRepo),
+		map_pool.NewDuplicatePoolUseCase(mapPoolRepo),
+		map_pool.NewGetPublicPoolsUseCase(mapPoolRepo),
+		map_pool.NewGetSharedPoolUseCase(mapPoolRepo),
+		map_pool.NewForkPoolUseCase(mapPoolRepo),
+	)
+
+	router.GET("/api/map-pools/public", handler.GetPublicPools)
+	router.GET("/api/map-pools/shared/:slug", handler.GetSharedPool)
+
+	// Пользователь передается заголовком вместо JWT
+	mapPools := router.Group("/api/map-pools")
+	mapPools.Use(func(c *gin.Context) {
+		var id uint
+		fmt.Sscan(c.GetHeader("X-Test-User"), &id)
+		c.Set(middleware.UserContextKey, &entities.User{ID: id})
+		c.Next()
+	})
+	mapPools.GET("/games/:gameId", handler.GetPools)
+	mapPools.GET("/:id", handler.GetPool)
+	mapPools.PUT("/:id", handler.UpdatePool)
+	mapPools.POST("/:id/maps/:mapId", handler.AddMap)
+	mapPools.DELETE("/:id/maps/:mapId", handler.RemoveMap)
+	mapPools.POST("/:id/duplicate", handler.DuplicatePool)
+	mapPools.POST("/shared/:slug/fork", handler.ForkPool)
+
+	return &mapPoolTestEnv{router: router, db: db, poolID: pool.ID, mapIDs: mapIDs}, cleanup
+}
+
+func (env *mapPoolTestEnv) do(method, path string, userID uint, payload interface{}) *httptest.ResponseRecorder {
+	var body bytes.Buffer
+	if payload != nil {
+		json.NewEncoder(&body).Encode(payload)
+	}
+	req := httptest.NewRequest(method, path, &body)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Test-User", fmt.Sprint(userID))
+	w := httptest.NewRecorder()
+	env.router.ServeHTTP(w, req)
+	return w
+}
+
+func TestMapPoolHandler_EditPool(t *testing.T) {
+	env, cleanup := setupMapPoolTestRouter(t)
+	defer cleanup()
+
+	poolPath := fmt.Sprintf("/api/map-pools/%d", env.poolID)
+
+	// Переименование
+	w := env.do(http.MethodPut, poolPath, 1, dto.UpdateMapPoolRequest{Name: "Renamed"})
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// Добавление карты
+	w = env.do(http.MethodPost, fmt.Sprintf("%s/maps/%d", poolPath, env.mapIDs[2]), 1, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp dto.MapPoolResponse
+	json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.Equal(t, "Renamed", resp.Name)
+	assert.Len(t, resp.Maps, 3)
+
+	// Повторное добавление
+	w = env.do(http.MethodPost, fmt.Sprintf("%s/maps/%d", poolPath, env.mapIDs[2]), 1, nil)
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	// Удаление карты
+	w = env.do(http.MethodDelete, fmt.Sprintf("%s/maps/%d", poolPath, env.mapIDs[0]), 1, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.Len(t, resp.Maps, 2)
+
+	// Чужой пользователь не может менять пул
+	w = env.do(http.MethodPut, poolPath, 2, dto.UpdateMapPoolRequest{Name: "Hijacked"})
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestMapPoolHandler_EditBlockedDuringVeto(t *testing.T) {
+	env, cleanup := setupMapPoolTestRouter(t)
+	defer cleanup()
+
+	session := &entities.VetoSession{
+		GameID:     1,
+		MapPoolID:  env.poolID,
+		Type:       entities.VetoTypeBo1,
+		Status:     entities.VetoStatusInProgress,
+		TeamAName:  "Team A",
+		TeamBName:  "Team B",
+		ShareToken: "in-progress",
+	}
+	if err := sqlite.NewVetoSessionRepository(env.db).Create(session); err != nil {
+		t.Fatalf("Failed to create session: %v", err)
+	}
+
+	poolPath := fmt.Sprintf("/api/map-pools/%d", env.poolID)
+	w := env.do(http.MethodDelete, fmt.Sprintf("%s/maps/%d", poolPath, env.mapIDs[0]), 1, nil)
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	// Копию можно сделать и отредактировать
+	w = env.do(http.MethodPost, poolPath+"/duplicate", 1, nil)
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var copyResp dto.MapPoolResponse
+	json.Unmarshal(w.Body.Bytes(), &copyResp)
+	assert.Equal(t, "My Pool (copy)", copyResp.Name)
+	assert.Len(t, copyResp.Maps, 2)
+
+	w = env.do(http.MethodDelete, fmt.Sprintf("/api/map-pools/%d/maps/%d", copyResp.ID, env.mapIDs[0]), 1, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestMapPoolHandler_GetPoolsCompatibility(t *testing.T) {
+	env, cleanup := setupMapPoolTestRouter(t)
+	defer cleanup()
+
+	w := env.do(http.MethodGet, "/api/map-pools/games/1", 1, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var pools []dto.MapPoolResponse
+	json.Unmarshal(w.Body.Bytes(), &pools)
+	if assert.Len(t, pools, 1) && assert.Len(t, pools[0].Compatibility, 3) {
+		// Пул из двух карт подходит только для Bo1
+		assert.True(t, pools[0].Compatibility[0].Compatible)
+		assert.False(t, pools[0].Compatibility[1].Compatible)
+		assert.False(t, pools[0].Compatibility[2].Compatible)
+	}
+}
+
+func TestMapPoolHandler_PublishAndFork(t *testing.T) {
+	env, cleanup := setupMapPoolTestRouter(t)
+	defer cleanup()
+
+	// Приватный пул не виден в библиотеке
+	w := env.do(http.MethodGet, "/api/map-pools/public", 0, nil)
+	var list dto.PublicMapPoolListResponse
+	json.Unmarshal(w.Body.Bytes(), &list)
+	assert.Equal(t, int64(0), list.Total)
+
+	public := "public"
+	w = env.do(http.MethodPut, fmt.Sprintf("/api/map-pools/%d", env.poolID), 1, dto.UpdateMapPoolRequest{Name: "Official Pool", Visibility: &public})
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var published dto.MapPoolResponse
+	json.Unmarshal(w.Body.Bytes(), &published)
+	if !assert.NotNil(t, published.ShareSlug) {
+		return
+	}
+
+	w = env.do(http.MethodGet, "/api/map-pools/public?q=official", 0, nil)
+	json.Unmarshal(w.Body.Bytes(), &list)
+	assert.Equal(t, int64(1), list.Total)
+
+	w = env.do(http.MethodGet, "/api/map-pools/shared/"+*published.ShareSlug, 0, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// Другой пользователь копирует пул к себе
+	w = env.do(http.MethodPost, "/api/map-pools/shared/"+*published.ShareSlug+"/fork", 2, nil)
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var fork dto.MapPoolResponse
+	json.Unmarshal(w.Body.Bytes(), &fork)
+	assert.Equal(t, "private", fork.Visibility)
+	if assert.NotNil(t, fork.UserID) && assert.NotNil(t, fork.ForkedFromID) {
+		assert.Equal(t, uint(2), *fork.UserID)
+		assert.Equal(t, env.poolID, *fork.ForkedFromID)
+	}
+
+	// Пул по ссылке доступен другим только по slug, но не по id
+	unlisted := "unlisted"
+	w = env.do(http.MethodPut, fmt.Sprintf("/api/map-pools/%d", env.poolID), 1, dto.UpdateMapPoolRequest{Name: "Official Pool", Visibility: &unlisted})
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = env.do(http.MethodGet, "/api/map-pools/shared/"+*published.ShareSlug, 0, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, http.StatusForbidden, env.do(http.MethodGet, fmt.Sprintf("/api/map-pools/%d", env.poolID), 2, nil).Code)
+	assert.Equal(t, http.StatusForbidden, env.do(http.MethodPost, fmt.Sprintf("/api/map-pools/%d/duplicate", env.poolID), 2, nil).Code)
+	assert.Equal(t, http.StatusOK, env.do(http.MethodGet, fmt.Sprintf("/api/map-pools/%d", env.poolID), 1, nil).Code)
+
+	// После скрытия пул недоступен по ссылке
+	private := "private"
+	env.do(http.MethodPut, fmt.Sprintf("/api/map-pools/%d", env.poolID), 1, dto.UpdateMapPoolRequest{Name: "Official Pool", Visibility: &private})
+	w = env.do(http.MethodGet, "/api/map-pools/shared/"+*published.ShareSlug, 0, nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
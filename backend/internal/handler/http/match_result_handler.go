@@ -0,0 +1,188 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/bbp/backend/internal/handler/dto"
+	"github.com/bbp/backend/internal/middleware"
+	"github.com/bbp/backend/internal/usecase/veto"
+	"github.com/gin-gonic/gin"
+)
+
+type MatchResultHandler struct {
+	reportResultUseCase  *veto.ReportResultUseCase
+	confirmResultUseCase *veto.ConfirmResultUseCase
+	disputeResultUseCase *veto.DisputeResultUseCase
+	getResultUseCase     *veto.GetResultUseCase
+}
+
+func NewMatchResultHandler(
+	reportResultUseCase *veto.ReportResultUseCase,
+	confirmResultUseCase *veto.ConfirmResultUseCase,
+	disputeResultUseCase *veto.DisputeResultUseCase,
+	getResultUseCase *veto.GetResultUseCase,
+) *MatchResultHandler {
+	return &MatchResultHandler{
+		reportResultUseCase:  reportResultUseCase,
+		confirmResultUseCase: confirmResultUseCase,
+		disputeResultUseCase: disputeResultUseCase,
+		getResultUseCase:     getResultUseCase,
+	}
+}
+
+// GetResult обрабатывает GET /api/veto/sessions/:id/result
+func (h *MatchResultHandler) GetResult(c *gin.Context) {
+	sessionID, ok := parseSessionID(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.getResultUseCase.Execute(sessionID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToMatchResultResponse(result.Result, result.Session))
+}
+
+// ReportResult обрабатывает POST /api/veto/sessions/:id/result
+func (h *MatchResultHandler) ReportResult(c *gin.Context) {
+	h.report(c, false)
+}
+
+// ResolveResult обрабатывает PUT /api/admin/veto/sessions/:id/result
+func (h *MatchResultHandler) ResolveResult(c *gin.Context) {
+	h.report(c, true)
+}
+
+// report сохраняет результат от капитана или администратора
+func (h *MatchResultHandler) report(c *gin.Context, asAdmin bool) {
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	sessionID, ok := parseSessionID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.ReportMatchResultRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	maps := make([]veto.MapScoreInput, len(req.Maps))
+	for i, m := range req.Maps {
+		maps[i] = veto.MapScoreInput{MapID: m.MapID, ScoreA: m.ScoreA, ScoreB: m.ScoreB}
+	}
+
+	result, err := h.reportResultUseCase.Execute(veto.ReportResultInput{
+		SessionID: sessionID,
+		UserID:    user.ID,
+		Maps:      maps,
+		AsAdmin:   asAdmin,
+	})
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToMatchResultResponse(result.Result, result.Session))
+}
+
+// ConfirmResult обрабатывает POST /api/veto/sessions/:id/result/confirm
+func (h *MatchResultHandler) ConfirmResult(c *gin.Context) {
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	sessionID, ok := parseSessionID(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.confirmResultUseCase.Execute(veto.ConfirmResultInput{
+		SessionID: sessionID,
+		UserID:    user.ID,
+	})
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToMatchResultResponse(result.Result, result.Session))
+}
+
+// DisputeResult обрабатывает POST /api/veto/sessions/:id/result/dispute
+func (h *MatchResultHandler) DisputeResult(c *gin.Context) {
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	sessionID, ok := parseSessionID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.DisputeMatchResultRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.disputeResultUseCase.Execute(veto.DisputeResultInput{
+		SessionID: sessionID,
+		UserID:    user.ID,
+		Reason:    req.Reason,
+	})
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToMatchResultResponse(result.Result, result.Session))
+}
+
+func (h *MatchResultHandler) handleError(c *gin.Context, err error) {
+	switch err {
+	case veto.ErrSessionNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "session not found"})
+	case veto.ErrResultNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "match result not found"})
+	case veto.ErrNotCaptain:
+		c.JSON(http.StatusForbidden, gin.H{"error": "user is not a captain of a session team"})
+	case veto.ErrOwnResult:
+		c.JSON(http.StatusForbidden, gin.H{"error": "match result must be confirmed by the opposing team"})
+	case veto.ErrSessionNotFinished:
+		c.JSON(http.StatusConflict, gin.H{"error": "session is not finished"})
+	case veto.ErrResultConfirmed:
+		c.JSON(http.StatusConflict, gin.H{"error": "match result is already confirmed"})
+	case veto.ErrResultNotPending:
+		c.JSON(http.StatusConflict, gin.H{"error": "match result is not awaiting confirmation"})
+	case veto.ErrResultLocked:
+		c.JSON(http.StatusConflict, gin.H{"error": "match result can no longer be changed"})
+	case veto.ErrInvalidResult:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid match result"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+	}
+}
+
+// parseSessionID разбирает параметр :id
+func parseSessionID(c *gin.Context) (uint, bool) {
+	sessionID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid session id"})
+		return 0, false
+	}
+	return uint(sessionID), true
+}
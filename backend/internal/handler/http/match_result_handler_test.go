@@ -0,0 +1,196 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/handler/dto"
+	"github.com/bbp/backend/internal/middleware"
+	"github.com/bbp/backend/internal/repository/models"
+	"github.com/bbp/backend/internal/repository/sqlite"
+	"github.com/bbp/backend/internal/usecase/veto"
+	"github.com/bbp/backend/pkg/database"
+	"github.com/bbp/backend/pkg/jwt"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchResultHandler_ReportConfirmDispute(t *testing.T) {
+	db, err := database.NewDatabase(":memory:")
+	require.NoError(t, err)
+	defer database.Close(db)
+	require.NoError(t, database.Migrate(db,
+		&models.UserModel{},
+		&models.MapModel{},
+		&models.VetoSessionModel{},
+		&models.VetoActionModel{},
+		&models.VetoSessionMapModel{},
+		&models.VetoSessionConstraintModel{},
+		&models.RoomModel{},
+		&models.RoomParticipantModel{},
+		&models.RevokedTokenModel{},
+		&models.TeamModel{},
+		&models.TeamMemberModel{},
+		&models.MatchResultModel{},
+		&models.MapResultModel{},
+		&models.MatchResultPlayerModel{},
+	))
+
+	userRepo := sqlite.NewUserRepository(db)
+	teamRepo := sqlite.NewTeamRepository(db)
+	vetoSessionRepo := sqlite.NewVetoSessionRepository(db)
+	vetoActionRepo := sqlite.NewVetoActionRepository(db)
+	matchResultRepo := sqlite.NewMatchResultRepository(db)
+	statsRepo := sqlite.NewVetoStatsRepository(db)
+
+	jwtService := jwt.NewJWTService("test-secret", time.Hour)
+	jwtService.SetDenylist(sqlite.NewRevokedTokenRepository(db))
+
+	handler := NewMatchResultHandler(
+		veto.NewReportResultUseCase(vetoSessionRepo, matchResultRepo, teamRepo),
+		veto.NewConfirmResultUseCase(vetoSessionRepo, matchResultRepo, teamRepo),
+		veto.NewDisputeResultUseCase(vetoSessionRepo, matchResultRepo, teamRepo),
+		veto.NewGetResultUseCase(vetoSessionRepo, matchResultRepo),
+	)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/api/veto/sessions/:id/result", handler.GetResult)
+	auth := router.Group("/api", middleware.AuthMiddleware(jwtService))
+	auth.POST("/veto/sessions/:id/result", handler.ReportResult)
+	auth.POST("/veto/sessions/:id/result/confirm", handler.ConfirmResult)
+	auth.POST("/veto/sessions/:id/result/dispute", handler.DisputeResult)
+	auth.PUT("/admin/veto/sessions/:id/result", handler.ResolveResult)
+
+	createUser := func(username string) (*entities.User, string) {
+		u := &entities.User{Email: username + "@example.com", Username: username, Password: "hashed"}
+		require.NoError(t, userRepo.Create(u))
+		token, err := jwtService.GenerateToken(u.ID, u.Username)
+		require.NoError(t, err)
+		return u, token
+	}
+	createTeam := func(name string, members ...*entities.User) *entities.Team {
+		team := &entities.Team{Name: name, Tag: name[:3]}
+		require.NoError(t, teamRepo.Create(team))
+		for i, member := range members {
+			role := entities.TeamRolePlayer
+			if i == 0 {
+				role = entities.TeamRoleCaptain
+			}
+			require.NoError(t, teamRepo.AddMember(&entities.TeamMember{TeamID: team.ID, UserID: member.ID, Role: role}))
+		}
+		return team
+	}
+
+	captainA, captainAToken := createUser("captain_a")
+	captainB, captainBToken := createUser("captain_b")
+	playerB, playerBToken := createUser("player_b")
+	_, adminToken := createUser("admin")
+	teamA := createTeam("Falcons", captainA)
+	teamB := createTeam("Rivals", captainB, playerB)
+
+	// BO3: пики карт 1 и 2, десайдер - карта 3
+	decider := uint(3)
+	session := &entities.VetoSession{GameID: 1, MapPoolID: 1, Type: entities.VetoTypeBo3, Status: entities.VetoStatusInProgress, TeamAName: "Falcons", TeamBName: "Rivals", TeamAID: &teamA.ID, TeamBID: &teamB.ID, CurrentTeam: "A", ShareToken: "result-session", SelectedMapID: &decider}
+	require.NoError(t, vetoSessionRepo.Create(session))
+	require.NoError(t, vetoActionRepo.Create(&entities.VetoAction{VetoSessionID: session.ID, MapID: 1, Team: "A", ActionType: entities.VetoActionTypePick, StepNumber: 1}))
+	require.NoError(t, vetoActionRepo.Create(&entities.VetoAction{VetoSessionID: session.ID, MapID: 2, Team: "B", ActionType: entities.VetoActionTypePick, StepNumber: 2}))
+	resultPath := fmt.Sprintf("/api/veto/sessions/%d/result", session.ID)
+
+	request := func(method, path, token string, payload interface{}) *httptest.ResponseRecorder {
+		var body bytes.Buffer
+		if payload != nil {
+			json.NewEncoder(&body).Encode(payload)
+		}
+		req := httptest.NewRequest(method, path, &body)
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	report := func(token string, scores ...[3]int) *httptest.ResponseRecorder {
+		req := dto.ReportMatchResultRequest{}
+		for _, s := range scores {
+			req.Maps = append(req.Maps, dto.MapScoreRequest{MapID: uint(s[0]), ScoreA: s[1], ScoreB: s[2]})
+		}
+		return request(http.MethodPost, resultPath, token, req)
+	}
+
+	// Пока вето идет, результата нет
+	assert.Equal(t, http.StatusConflict, report(captainAToken, [3]int{1, 13, 5}, [3]int{2, 13, 10}).Code)
+	session.Status = entities.VetoStatusFinished
+	require.NoError(t, vetoSessionRepo.Update(session))
+
+	assert.Equal(t, http.StatusForbidden, report(playerBToken, [3]int{1, 13, 5}, [3]int{2, 13, 10}).Code)
+	// Карты не по порядку, ничья и лишняя карта после решенной серии
+	assert.Equal(t, http.StatusBadRequest, report(captainAToken, [3]int{2, 13, 5}, [3]int{1, 13, 10}).Code)
+	assert.Equal(t, http.StatusBadRequest, report(captainAToken, [3]int{1, 12, 12}, [3]int{2, 13, 10}).Code)
+	assert.Equal(t, http.StatusBadRequest, report(captainAToken, [3]int{1, 13, 5}, [3]int{2, 13, 10}, [3]int{3, 13, 1}).Code)
+	assert.Equal(t, http.StatusBadRequest, report(captainAToken, [3]int{1, 13, 5}).Code)
+
+	w := report(captainAToken, [3]int{1, 13, 5}, [3]int{2, 13, 10})
+	require.Equal(t, http.StatusOK, w.Code)
+	var result dto.MatchResultResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+	assert.Equal(t, "pending", result.Status)
+	assert.Equal(t, "A", result.WinnerTeam)
+	require.Len(t, result.Maps, 2)
+	assert.NotNil(t, result.Maps[0].VetoActionID)
+
+	// Подтвердить или оспорить может только соперник
+	assert.Equal(t, http.StatusForbidden, request(http.MethodPost, resultPath+"/confirm", captainAToken, nil).Code)
+	w = request(http.MethodPost, resultPath+"/dispute", captainBToken, dto.DisputeMatchResultRequest{Reason: "second map was 11-13"})
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+	assert.Equal(t, "disputed", result.Status)
+	assert.Equal(t, http.StatusConflict, request(http.MethodPost, resultPath+"/confirm", captainBToken, nil).Code)
+
+	// Встречный отчет снова ждет подтверждения
+	w = report(captainBToken, [3]int{1, 13, 5}, [3]int{2, 11, 13}, [3]int{3, 9, 13})
+	require.Equal(t, http.StatusOK, w.Code)
+	result = dto.MatchResultResponse{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+	assert.Equal(t, "pending", result.Status)
+	assert.Nil(t, result.DisputeReason)
+	assert.Nil(t, result.Maps[2].VetoActionID)
+
+	w = request(http.MethodPost, resultPath+"/confirm", captainAToken, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+	assert.Equal(t, "confirmed", result.Status)
+	assert.Equal(t, "B", result.WinnerTeam)
+	assert.Equal(t, "Rivals", result.WinnerName)
+	assert.Equal(t, 1, result.ScoreA)
+	assert.Equal(t, 2, result.ScoreB)
+
+	assert.Equal(t, http.StatusConflict, report(captainAToken, [3]int{1, 13, 5}, [3]int{2, 13, 10}).Code)
+	assert.Equal(t, http.StatusOK, request(http.MethodGet, resultPath, "", nil).Code)
+
+	// Подтвержденный результат попадает в статистику команд и игроков
+	teamStats, err := statsRepo.GetTeamStats(teamA.ID, 5)
+	require.NoError(t, err)
+	assert.Equal(t, entities.ResultStats{SeriesPlayed: 1, SeriesLost: 1, MapsWon: 1, MapsLost: 2}, teamStats.Results)
+	playerStats, err := statsRepo.GetUserStats(playerB.ID, 5)
+	require.NoError(t, err)
+	assert.Equal(t, entities.ResultStats{SeriesPlayed: 1, SeriesWon: 1, MapsWon: 2, MapsLost: 1}, playerStats.Results)
+
+	// Администратор может исправить подтвержденный результат
+	w = request(http.MethodPut, fmt.Sprintf("/api/admin/veto/sessions/%d/result", session.ID), adminToken, dto.ReportMatchResultRequest{Maps: []dto.MapScoreRequest{{MapID: 1, ScoreA: 13, ScoreB: 5}, {MapID: 2, ScoreA: 13, ScoreB: 10}}})
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+	assert.Equal(t, "confirmed", result.Status)
+	assert.Equal(t, "A", result.WinnerTeam)
+	teamStats, err = statsRepo.GetTeamStats(teamA.ID, 5)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), teamStats.Results.SeriesWon)
+}
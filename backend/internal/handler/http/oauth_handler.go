@@ -0,0 +1,138 @@
+package http
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/bbp/backend/internal/usecase/auth"
+	"github.com/gin-gonic/gin"
+)
+
+// oauthStateCookie cookie, которая привязывает state входа через провайдера к браузеру
+const oauthStateCookie = "oauth_state"
+
+type OAuthHandler struct {
+	startUseCase    *auth.OAuthStartUseCase
+	callbackUseCase *auth.OAuthCallbackUseCase
+	appURL          string
+}
+
+func NewOAuthHandler(
+	startUseCase *auth.OAuthStartUseCase,
+	callbackUseCase *auth.OAuthCallbackUseCase,
+	appURL string,
+) *OAuthHandler {
+	return &OAuthHandler{
+		startUseCase:    startUseCase,
+		callbackUseCase: callbackUseCase,
+		appURL:          appURL,
+	}
+}
+
+// GetProviders обрабатывает GET /api/auth/oauth/providers
+func (h *OAuthHandler) GetProviders(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"providers": h.startUseCase.Providers()})
+}
+
+// Start обрабатывает GET /api/auth/oauth/:provider/start
+// Перенаправляет пользователя на страницу авторизации провайдера
+func (h *OAuthHandler) Start(c *gin.Context) {
+	result, err := h.startUseCase.Execute(auth.OAuthStartInput{
+		Provider: c.Param("provider"),
+	})
+	if err != nil {
+		switch err {
+		case auth.ErrUnknownOAuthProvider:
+			c.JSON(http.StatusNotFound, gin.H{"error": "unknown oauth provider"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		}
+		return
+	}
+
+	// Lax: cookie отправляется при возврате с провайдера по ссылке верхнего уровня
+	http.SetCookie(c.Writer, &http.Cookie{
+		Name:     oauthStateCookie,
+		Value:    result.State,
+		Path:     "/api/auth/oauth",
+		Expires:  result.ExpiresAt,
+		HttpOnly: true,
+		Secure:   isSecureRequest(c),
+		SameSite: http.SameSiteLaxMode,
+	})
+	c.Redirect(http.StatusFound, result.AuthURL)
+}
+
+// Callback обрабатывает GET /api/auth/oauth/:provider/callback
+// Токены передаются фронтенду во fragment, чтобы не попадать в логи и Referer
+func (h *OAuthHandler) Callback(c *gin.Context) {
+	stateCookie, _ := c.Cookie(oauthStateCookie)
+	http.SetCookie(c.Writer, &http.Cookie{
+		Name:     oauthStateCookie,
+		Path:     "/api/auth/oauth",
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   isSecureRequest(c),
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	// Пользователь отказался от авторизации у провайдера
+	if providerError := c.Query("error"); providerError != "" {
+		h.redirectWithError(c, providerError)
+		return
+	}
+
+	result, err := h.callbackUseCase.Execute(auth.OAuthCallbackInput{
+		Provider:    c.Param("provider"),
+		State:       c.Query("state"),
+		StateCookie: stateCookie,
+		Code:        c.Query("code"),
+	})
+	if err != nil {
+		switch err {
+		case auth.ErrUnknownOAuthProvider:
+			c.JSON(http.StatusNotFound, gin.H{"error": "unknown oauth provider"})
+		case auth.ErrInvalidOAuthState:
+			h.redirectWithError(c, "invalid_state")
+		case auth.ErrOAuthExchangeFailed:
+			h.redirectWithError(c, "exchange_failed")
+		case auth.ErrOAuthEmailRequired:
+			h.redirectWithError(c, "email_required")
+		case auth.ErrOAuthAccountConflict:
+			h.redirectWithError(c, "account_exists")
+		default:
+			h.redirectWithError(c, "server_error")
+		}
+		return
+	}
+
+	// С включенной 2FA фронтенд завершает вход через POST /api/auth/login/2fa
+	if result.Challenge != nil {
+		fragment := url.Values{
+			"challenge_token":      {result.Challenge.Token},
+			"challenge_expires_at": {result.Challenge.ExpiresAt.Format(time.RFC3339)},
+		}
+		c.Redirect(http.StatusFound, h.appURL+"/oauth/callback#"+fragment.Encode())
+		return
+	}
+
+	fragment := url.Values{
+		"token":              {result.Tokens.AccessToken},
+		"expires_at":         {result.Tokens.AccessExpiresAt.Format(time.RFC3339)},
+		"refresh_token":      {result.Tokens.RefreshToken},
+		"refresh_expires_at": {result.Tokens.RefreshExpiresAt.Format(time.RFC3339)},
+		"created":            {strconv.FormatBool(result.Created)},
+	}
+	c.Redirect(http.StatusFound, h.appURL+"/oauth/callback#"+fragment.Encode())
+}
+
+// isSecureRequest сообщает, что запрос пришел по HTTPS напрямую или через прокси
+func isSecureRequest(c *gin.Context) bool {
+	return c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https"
+}
+
+func (h *OAuthHandler) redirectWithError(c *gin.Context, code string) {
+	c.Redirect(http.StatusFound, h.appURL+"/login?oauth_error="+url.QueryEscape(code))
+}
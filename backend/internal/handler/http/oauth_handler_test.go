@@ -0,0 +1,235 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+	"github.com/bbp/backend/internal/repository/models"
+	"github.com/bbp/backend/internal/repository/sqlite"
+	"github.com/bbp/backend/internal/usecase/auth"
+	"github.com/bbp/backend/pkg/database"
+	"github.com/bbp/backend/pkg/jwt"
+	"github.com/bbp/backend/pkg/oauth"
+	"github.com/bbp/backend/pkg/oauth/oauthtest"
+	"github.com/bbp/backend/pkg/password"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const oauthTestAppURL = "http://app.test"
+
+type oauthTestEnv struct {
+	server     *httptest.Server
+	fake       *oauthtest.Provider
+	jwtService *jwt.JWTService
+	userRepo   repositories.UserRepository
+}
+
+func setupOAuthTestEnv(t *testing.T) *oauthTestEnv {
+	db, err := database.NewDatabase(":memory:")
+	require.NoError(t, err)
+	require.NoError(t, database.Migrate(db,
+		&models.UserModel{},
+		&models.RoomParticipantModel{},
+		&models.RoomMatchModel{},
+		&models.RefreshTokenModel{},
+		&models.UserIdentityModel{},
+		&models.OAuthStateModel{},
+		&models.UserTOTPModel{},
+		&models.RecoveryCodeModel{},
+		&models.LoginChallengeModel{},
+	))
+
+	fake := oauthtest.NewProvider("client", "secret")
+	server := httptest.NewServer(nil)
+	t.Cleanup(func() {
+		server.Close()
+		fake.Close()
+		database.Close(db)
+	})
+
+	provider, err := oauth.DiscoverOIDCProvider(
+		context.Background(), "test", fake.Issuer(), "client", "secret",
+		server.URL+"/api/auth/oauth/test/callback",
+	)
+	require.NoError(t, err)
+	providers := []oauth.Provider{provider}
+
+	userRepo := sqlite.NewUserRepository(db)
+	stateRepo := sqlite.NewOAuthStateRepository(db)
+	jwtService := jwt.NewJWTService("test-secret", time.Hour)
+	tokenIssuer := auth.NewTokenIssuer(jwtService, sqlite.NewRefreshTokenRepository(db), 24*time.Hour)
+	twoFactorVerifier := auth.NewTwoFactorVerifier(
+		sqlite.NewTwoFactorRepository(db),
+		sqlite.NewRecoveryCodeRepository(db),
+		sqlite.NewLoginChallengeRepository(db),
+		5*time.Minute,
+	)
+
+	handler := NewOAuthHandler(
+		auth.NewOAuthStartUseCase(providers, stateRepo),
+		auth.NewOAuthCallbackUseCase(providers, stateRepo, sqlite.NewUserIdentityRepository(db), userRepo, tokenIssuer, twoFactorVerifier),
+		oauthTestAppURL,
+	)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/api/auth/oauth/providers", handler.GetProviders)
+	router.GET("/api/auth/oauth/:provider/start", handler.Start)
+	router.GET("/api/auth/oauth/:provider/callback", handler.Callback)
+	server.Config.Handler = router
+
+	return &oauthTestEnv{server: server, fake: fake, jwtService: jwtService, userRepo: userRepo}
+}
+
+// login проходит весь поток входа и возвращает итоговый редирект на фронтенд
+func (env *oauthTestEnv) login(t *testing.T) *url.URL {
+	jar, err := cookiejar.New(nil)
+	require.NoError(t, err)
+	client := &http.Client{Jar: jar, CheckRedirect: func(req *http.Request, _ []*http.Request) error {
+		if req.URL.Host == "app.test" {
+			return http.ErrUseLastResponse
+		}
+		return nil
+	}}
+
+	resp, err := client.Get(env.server.URL + "/api/auth/oauth/test/start")
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusFound, resp.StatusCode)
+
+	location, err := url.Parse(resp.Header.Get("Location"))
+	require.NoError(t, err)
+	return location
+}
+
+func TestOAuthHandler_LoginFlow(t *testing.T) {
+	env := setupOAuthTestEnv(t)
+
+	// Новый пользователь создается с подтвержденным провайдером email
+	env.fake.SetUser(oauthtest.User{Subject: "sub-1", Email: "player@example.com", EmailVerified: true, Username: "player"})
+	location := env.login(t)
+	assert.Equal(t, "/oauth/callback", location.Path)
+
+	fragment, err := url.ParseQuery(location.Fragment)
+	require.NoError(t, err)
+	assert.Equal(t, "true", fragment.Get("created"))
+	assert.NotEmpty(t, fragment.Get("refresh_token"))
+
+	claims, err := env.jwtService.ValidateToken(fragment.Get("token"))
+	require.NoError(t, err)
+	assert.Equal(t, "player", claims.Username)
+
+	created, err := env.userRepo.GetByEmail("player@example.com")
+	require.NoError(t, err)
+	require.NotNil(t, created)
+	assert.True(t, created.EmailVerified)
+
+	// Повторный вход находит пользователя по привязке
+	location = env.login(t)
+	fragment, _ = url.ParseQuery(location.Fragment)
+	assert.Equal(t, "false", fragment.Get("created"))
+	claims, err = env.jwtService.ValidateToken(fragment.Get("token"))
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, claims.UserID)
+}
+
+func TestOAuthHandler_AccountLinking(t *testing.T) {
+	env := setupOAuthTestEnv(t)
+
+	hashed, err := password.HashPassword("password123")
+	require.NoError(t, err)
+	existing := &entities.User{Email: "owner@example.com", Username: "owner", Password: hashed, EmailVerified: true}
+	require.NoError(t, env.userRepo.Create(existing))
+
+	// Неподтвержденный у провайдера email не привязывается к существующему аккаунту
+	env.fake.SetUser(oauthtest.User{Subject: "sub-2", Email: "owner@example.com", EmailVerified: false, Username: "attacker"})
+	location := env.login(t)
+	assert.Equal(t, "/login", location.Path)
+	assert.Equal(t, "account_exists", location.Query().Get("oauth_error"))
+
+	// Подтвержденный email связывает аккаунт
+	env.fake.SetUser(oauthtest.User{Subject: "sub-3", Email: "owner@example.com", EmailVerified: true, Username: "owner2"})
+	location = env.login(t)
+	fragment, _ := url.ParseQuery(location.Fragment)
+	assert.Equal(t, "false", fragment.Get("created"))
+
+	claims, err := env.jwtService.ValidateToken(fragment.Get("token"))
+	require.NoError(t, err)
+	assert.Equal(t, existing.ID, claims.UserID)
+}
+
+func TestOAuthHandler_UnverifiedAccountNotLinked(t *testing.T) {
+	env := setupOAuthTestEnv(t)
+
+	// Аккаунт заранее зарегистрирован чужим человеком на email владельца и не подтвержден
+	hashed, err := password.HashPassword("password123")
+	require.NoError(t, err)
+	squatter := &entities.User{Email: "victim@example.com", Username: "squatter", Password: hashed}
+	require.NoError(t, env.userRepo.Create(squatter))
+
+	// Вход настоящего владельца email не связывается с этим аккаунтом
+	env.fake.SetUser(oauthtest.User{Subject: "sub-4", Email: "victim@example.com", EmailVerified: true, Username: "victim"})
+	location := env.login(t)
+	assert.Equal(t, "/login", location.Path)
+	assert.Equal(t, "account_exists", location.Query().Get("oauth_error"))
+	assert.Empty(t, location.Fragment)
+
+	stored, err := env.userRepo.GetByID(squatter.ID)
+	require.NoError(t, err)
+	assert.False(t, stored.EmailVerified)
+}
+
+func TestOAuthHandler_InvalidState(t *testing.T) {
+	env := setupOAuthTestEnv(t)
+
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	resp, err := client.Get(env.server.URL + "/api/auth/oauth/test/callback?code=abc&state=forged")
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	location, err := url.Parse(resp.Header.Get("Location"))
+	require.NoError(t, err)
+	assert.Equal(t, "invalid_state", location.Query().Get("oauth_error"))
+
+	// Настоящий state без cookie браузера, начавшего вход, не принимается (login CSRF)
+	resp, err = client.Get(env.server.URL + "/api/auth/oauth/test/start")
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusFound, resp.StatusCode)
+	var stateCookie *http.Cookie
+	for _, cookie := range resp.Cookies() {
+		if cookie.Name == "oauth_state" {
+			stateCookie = cookie
+		}
+	}
+	require.NotNil(t, stateCookie)
+	assert.True(t, stateCookie.HttpOnly)
+	assert.Equal(t, http.SameSiteLaxMode, stateCookie.SameSite)
+	authURL, err := url.Parse(resp.Header.Get("Location"))
+	require.NoError(t, err)
+	state := authURL.Query().Get("state")
+	assert.Equal(t, state, stateCookie.Value)
+
+	resp, err = client.Get(env.server.URL + "/api/auth/oauth/test/callback?code=abc&state=" + url.QueryEscape(state))
+	require.NoError(t, err)
+	resp.Body.Close()
+	location, err = url.Parse(resp.Header.Get("Location"))
+	require.NoError(t, err)
+	assert.Equal(t, "invalid_state", location.Query().Get("oauth_error"))
+
+	resp, err = client.Get(env.server.URL + "/api/auth/oauth/unknown/start")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
@@ -0,0 +1,211 @@
+package http
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bbp/backend/internal/handler/dto"
+	"github.com/bbp/backend/internal/usecase/veto"
+	"github.com/gin-gonic/gin"
+)
+
+// OverlayHandler отдает состояние сессии для стрим-оверлеев (OBS browser source)
+// Оверлей открывается по share token и не требует авторизации
+type OverlayHandler struct {
+	getOverlayUseCase *veto.GetOverlayUseCase
+	// Как часто перечитывается состояние сессии для потоков и как часто поток шлет keep-alive комментарий
+	pollInterval      time.Duration
+	heartbeatInterval time.Duration
+	// Сколько потоков можно держать открытыми с одного IP
+	maxStreamsPerIP int
+
+	mu           sync.Mutex
+	pollers      map[string]*overlayPoller // Общий опрос сессии по share token
+	streamsPerIP map[string]int
+}
+
+func NewOverlayHandler(getOverlayUseCase *veto.GetOverlayUseCase) *OverlayHandler {
+	return &OverlayHandler{
+		getOverlayUseCase: getOverlayUseCase,
+		pollInterval:      time.Second,
+		heartbeatInterval: 15 * time.Second,
+		maxStreamsPerIP:   10,
+		pollers:           make(map[string]*overlayPoller),
+		streamsPerIP:      make(map[string]int),
+	}
+}
+
+// GetOverlay обрабатывает GET /api/overlay/:token
+// Поддерживает If-None-Match: пока состояние не изменилось, ответ 304 без тела
+func (h *OverlayHandler) GetOverlay(c *gin.Context) {
+	response, etag, err := h.loadOverlay(c.Param("token"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", "no-cache")
+	if etagMatches(c.GetHeader("If-None-Match"), etag) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.Data(http.StatusOK, "application/json; charset=utf-8", response)
+}
+
+// StreamOverlay обрабатывает GET /api/overlay/:token/stream
+// Server-Sent Events: событие overlay с полным состоянием при каждом изменении, id события - ETag состояния.
+// Переподключившийся клиент с актуальным Last-Event-ID не получает состояние повторно.
+// Потоки одного токена получают изменения из общего опроса сессии
+func (h *OverlayHandler) StreamOverlay(c *gin.Context) {
+	ip := c.ClientIP()
+	if !h.acquireStream(ip) {
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": "too many overlay streams"})
+		return
+	}
+	defer h.releaseStream(ip)
+
+	token := c.Param("token")
+	response, etag, err := h.loadOverlay(token)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	sub := h.subscribeOverlay(token, etag)
+	defer h.unsubscribeOverlay(token, sub)
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no") // Отключает буферизацию в nginx
+	c.Status(http.StatusOK)
+
+	fmt.Fprintf(c.Writer, "retry: %d\n\n", 3000)
+	if strings.Trim(c.GetHeader("Last-Event-ID"), `"`) != strings.Trim(etag, `"`) {
+		writeOverlayEvent(c, etag, response)
+	}
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(h.heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case <-heartbeat.C:
+			fmt.Fprint(c.Writer, ": ping\n\n")
+			c.Writer.Flush()
+		case state, ok := <-sub.updates:
+			if !ok {
+				// Сессия удалена - закрываем поток
+				return
+			}
+			writeOverlayEvent(c, state.etag, state.body)
+			c.Writer.Flush()
+		}
+	}
+}
+
+// loadOverlay возвращает JSON состояния оверлея и его ETag
+func (h *OverlayHandler) loadOverlay(token string) ([]byte, string, error) {
+	result, err := h.getOverlayUseCase.ExecuteByShareToken(token)
+	if err != nil {
+		return nil, "", err
+	}
+
+	body, err := json.Marshal(toOverlayResponse(result))
+	if err != nil {
+		return nil, "", err
+	}
+	sum := sha256.Sum256(body)
+	return body, `"` + hex.EncodeToString(sum[:16]) + `"`, nil
+}
+
+func (h *OverlayHandler) handleError(c *gin.Context, err error) {
+	switch err {
+	case veto.ErrSessionNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "session not found"})
+	case veto.ErrMapPoolNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "map pool not found"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+	}
+}
+
+// writeOverlayEvent пишет событие SSE с состоянием оверлея
+func writeOverlayEvent(c *gin.Context, etag string, body []byte) {
+	fmt.Fprintf(c.Writer, "id: %s\nevent: overlay\ndata: %s\n\n", strings.Trim(etag, `"`), body)
+}
+
+// etagMatches проверяет заголовок If-None-Match (список ETag через запятую, слабые ETag или *)
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// toOverlayResponse конвертирует состояние оверлея в DTO
+func toOverlayResponse(overlay *veto.GetOverlayOutput) dto.OverlayResponse {
+	response := dto.OverlayResponse{
+		SessionID: overlay.SessionID,
+		Type:      string(overlay.Type),
+		Status:    string(overlay.Status),
+		TeamA:     overlay.TeamAName,
+		TeamB:     overlay.TeamBName,
+		Steps:     make([]dto.OverlayStepResponse, len(overlay.Steps)),
+		UpdatedAt: overlay.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+
+	for i, step := range overlay.Steps {
+		response.Steps[i] = dto.OverlayStepResponse{
+			Step:         step.Step,
+			Team:         step.Team,
+			TeamName:     step.TeamName,
+			Action:       string(step.Action),
+			MapID:        step.Map.ID,
+			MapName:      step.Map.Name,
+			MapImageURL:  step.Map.ImageURL,
+			Side:         step.Side,
+			SideTeam:     step.SideTeam,
+			SideTeamName: step.SideTeamName,
+		}
+	}
+
+	if overlay.Turn != nil {
+		response.Turn = &dto.OverlayTurnResponse{
+			Step:         overlay.Turn.Step,
+			Team:         overlay.Turn.Team,
+			TeamName:     overlay.Turn.TeamName,
+			Action:       overlay.Turn.Action,
+			TimerSeconds: overlay.Turn.TimerSeconds,
+			StartedAt:    overlay.Turn.StartedAt.UTC().Format(time.RFC3339),
+		}
+		if overlay.Turn.Deadline != nil {
+			deadline := overlay.Turn.Deadline.UTC().Format(time.RFC3339)
+			response.Turn.Deadline = &deadline
+		}
+	}
+
+	if overlay.Decider != nil {
+		response.Decider = &dto.OverlayDeciderResponse{
+			MapID:       overlay.Decider.Map.ID,
+			MapName:     overlay.Decider.Map.Name,
+			MapImageURL: overlay.Decider.Map.ImageURL,
+			Side:        overlay.Decider.Side,
+		}
+	}
+
+	return response
+}
@@ -0,0 +1,170 @@
+package http
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/handler/dto"
+	"github.com/bbp/backend/internal/repository/sqlite"
+	"github.com/bbp/backend/internal/usecase/veto"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOverlayHandler_StateETagAndStream(t *testing.T) {
+	db, cleanup := setupVetoTestDB(t)
+	defer cleanup()
+
+	gameRepo := sqlite.NewGameRepository(db)
+	mapRepo := sqlite.NewMapRepository(db)
+	mapPoolRepo := sqlite.NewMapPoolRepository(db)
+	vetoSessionRepo := sqlite.NewVetoSessionRepository(db)
+	vetoActionRepo := sqlite.NewVetoActionRepository(db)
+	vetoLogicService := veto.NewVetoLogicService()
+
+	game := &entities.Game{Name: "CS2", Slug: "cs2", IsActive: true}
+	require.NoError(t, gameRepo.Create(game))
+	var maps []entities.Map
+	for _, name := range []string{"Mirage", "Inferno", "Nuke"} {
+		slug := strings.ToLower(name)
+		m := &entities.Map{GameID: game.ID, Name: name, Slug: slug, ImageURL: "/maps/" + slug + ".jpg", IsActive: true}
+		require.NoError(t, mapRepo.Create(m))
+		maps = append(maps, *m)
+	}
+	pool := &entities.MapPool{GameID: game.ID, Name: "Pool", Type: entities.MapPoolTypeAll, IsSystem: true, Maps: maps}
+	require.NoError(t, mapPoolRepo.Create(pool))
+
+	created, err := veto.NewCreateSessionUseCase(vetoSessionRepo, mapPoolRepo, gameRepo, sqlite.NewMapRotationRepository(db), sqlite.NewTeamRepository(db), vetoLogicService).
+		Execute(veto.CreateSessionInput{GameID: game.ID, MapPoolID: pool.ID, Type: entities.VetoTypeBo1, TeamAName: "Alpha", TeamBName: "Bravo", TimerSeconds: 30})
+	require.NoError(t, err)
+	session := created.Session
+	_, err = veto.NewStartSessionUseCase(vetoSessionRepo).Execute(veto.StartSessionInput{SessionID: session.ID})
+	require.NoError(t, err)
+	banMap := veto.NewBanMapUseCase(vetoSessionRepo, vetoActionRepo, mapRepo, mapPoolRepo, vetoLogicService)
+
+	handler := NewOverlayHandler(veto.NewGetOverlayUseCase(vetoSessionRepo, mapPoolRepo, vetoLogicService))
+	handler.pollInterval = 20 * time.Millisecond
+	handler.maxStreamsPerIP = 2
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/api/overlay/:token", handler.GetOverlay)
+	router.GET("/api/overlay/:token/stream", handler.StreamOverlay)
+
+	get := func(etag string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/overlay/"+session.ShareToken, nil)
+		if etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/overlay/unknown", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// Ход команды A с отсчетом от старта сессии
+	w = get("")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	etag := w.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+	var overlay dto.OverlayResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &overlay))
+	assert.Equal(t, "Alpha", overlay.TeamA)
+	assert.Empty(t, overlay.Steps)
+	require.NotNil(t, overlay.Turn)
+	assert.Equal(t, "A", overlay.Turn.Team)
+	assert.Equal(t, "Alpha", overlay.Turn.TeamName)
+	assert.Equal(t, veto.OverlayTurnBan, overlay.Turn.Action)
+	assert.NotNil(t, overlay.Turn.Deadline)
+	assert.Nil(t, overlay.Decider)
+
+	// Пока состояние не изменилось - 304
+	w = get(etag)
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Body.String())
+
+	// Поток отдает текущее состояние и новое после каждого изменения
+	server := httptest.NewServer(router)
+	defer server.Close()
+	resp, err := http.Get(server.URL + "/api/overlay/" + session.ShareToken + "/stream")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	reader := bufio.NewReader(resp.Body)
+	nextEvent := func() (string, dto.OverlayResponse) {
+		var id string
+		var event dto.OverlayResponse
+		for {
+			line, err := reader.ReadString('\n')
+			require.NoError(t, err)
+			line = strings.TrimRight(line, "\n")
+			switch {
+			case strings.HasPrefix(line, "id: "):
+				id = strings.TrimPrefix(line, "id: ")
+			case strings.HasPrefix(line, "data: "):
+				require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &event))
+				return id, event
+			}
+		}
+	}
+
+	id, event := nextEvent()
+	assert.Equal(t, strings.Trim(etag, `"`), id)
+	assert.Empty(t, event.Steps)
+
+	_, err = banMap.Execute(veto.BanMapInput{SessionID: session.ID, MapID: maps[0].ID, Team: "A"})
+	require.NoError(t, err)
+	_, event = nextEvent()
+	require.Len(t, event.Steps, 1)
+	assert.Equal(t, "Alpha", event.Steps[0].TeamName)
+	assert.Equal(t, "ban", event.Steps[0].Action)
+	assert.Equal(t, "Mirage", event.Steps[0].MapName)
+	assert.Equal(t, "/maps/mirage.jpg", event.Steps[0].MapImageURL)
+	require.NotNil(t, event.Turn)
+	assert.Equal(t, "Bravo", event.Turn.TeamName)
+
+	// После последнего бана хода нет, оставшаяся карта - десайдер
+	_, err = banMap.Execute(veto.BanMapInput{SessionID: session.ID, MapID: maps[1].ID, Team: "B"})
+	require.NoError(t, err)
+	_, event = nextEvent()
+	assert.Equal(t, "finished", event.Status)
+	assert.Len(t, event.Steps, 2)
+	assert.Nil(t, event.Turn)
+	require.NotNil(t, event.Decider)
+	assert.Equal(t, "Nuke", event.Decider.MapName)
+
+	w = get(etag)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotEqual(t, etag, w.Header().Get("ETag"))
+
+	// Потоки одного токена читают сессию общим опросом, с одного IP открыто не больше maxStreamsPerIP потоков
+	second, err := http.Get(server.URL + "/api/overlay/" + session.ShareToken + "/stream")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, second.StatusCode)
+	pollers := func() int {
+		handler.mu.Lock()
+		defer handler.mu.Unlock()
+		return len(handler.pollers)
+	}
+	assert.Equal(t, 1, pollers())
+
+	third, err := http.Get(server.URL + "/api/overlay/" + session.ShareToken + "/stream")
+	require.NoError(t, err)
+	third.Body.Close()
+	assert.Equal(t, http.StatusTooManyRequests, third.StatusCode)
+
+	// Опрос останавливается вместе с последним потоком
+	second.Body.Close()
+	resp.Body.Close()
+	assert.Eventually(t, func() bool { return pollers() == 0 }, time.Second, 10*time.Millisecond)
+}
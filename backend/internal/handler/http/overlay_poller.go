@@ -0,0 +1,144 @@
+package http
+
+import (
+	"log"
+	"time"
+
+	"github.com/bbp/backend/internal/usecase/veto"
+)
+
+// overlayState состояние оверлея, отправляемое потоку
+type overlayState struct {
+	body []byte
+	etag string
+}
+
+// overlayPoller перечитывает сессию для всех потоков, открытых по ее share token:
+// сколько бы оверлеев ни было открыто, сессия читается один раз за pollInterval
+type overlayPoller struct {
+	subscribers map[*overlaySubscriber]bool
+	seq         int // Номер последнего начатого чтения
+	stop        chan struct{}
+}
+
+type overlaySubscriber struct {
+	updates chan overlayState // Не больше одного состояния: поток всегда получает последнее
+	etag    string            // Состояние, уже отправленное потоку
+	fromSeq int               // Первое чтение, начатое после подписки; более ранние могут быть старше etag
+}
+
+// subscribeOverlay подписывает поток, уже отправивший состояние etag, на изменения оверлея.
+// Канал updates закрывается, если сессию удалили
+func (h *OverlayHandler) subscribeOverlay(token, etag string) *overlaySubscriber {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	poller, ok := h.pollers[token]
+	if !ok {
+		poller = &overlayPoller{
+			subscribers: make(map[*overlaySubscriber]bool),
+			stop:        make(chan struct{}),
+		}
+		h.pollers[token] = poller
+		go h.poll(token, poller)
+	}
+
+	sub := &overlaySubscriber{
+		updates: make(chan overlayState, 1),
+		etag:    etag,
+		fromSeq: poller.seq + 1,
+	}
+	poller.subscribers[sub] = true
+	return sub
+}
+
+// unsubscribeOverlay отписывает поток; опрос останавливается вместе с последним потоком токена
+func (h *OverlayHandler) unsubscribeOverlay(token string, sub *overlaySubscriber) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	poller, ok := h.pollers[token]
+	if !ok || !poller.subscribers[sub] {
+		return
+	}
+	delete(poller.subscribers, sub)
+	if len(poller.subscribers) == 0 {
+		close(poller.stop)
+		delete(h.pollers, token)
+	}
+}
+
+func (h *OverlayHandler) poll(token string, poller *overlayPoller) {
+	ticker := time.NewTicker(h.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-poller.stop:
+			return
+		case <-ticker.C:
+		}
+
+		h.mu.Lock()
+		poller.seq++
+		seq := poller.seq
+		h.mu.Unlock()
+
+		body, etag, err := h.loadOverlay(token)
+		if err != nil && err != veto.ErrSessionNotFound {
+			log.Printf("Failed to load overlay for stream: %v", err)
+			continue
+		}
+
+		h.mu.Lock()
+		select {
+		case <-poller.stop:
+			h.mu.Unlock()
+			return
+		default:
+		}
+		if err == veto.ErrSessionNotFound {
+			// Сессия удалена - закрываем все потоки токена
+			for sub := range poller.subscribers {
+				close(sub.updates)
+			}
+			delete(h.pollers, token)
+			h.mu.Unlock()
+			return
+		}
+		for sub := range poller.subscribers {
+			if seq < sub.fromSeq || sub.etag == etag {
+				continue
+			}
+			sub.etag = etag
+			select {
+			case <-sub.updates:
+			default:
+			}
+			sub.updates <- overlayState{body: body, etag: etag}
+		}
+		h.mu.Unlock()
+	}
+}
+
+// acquireStream учитывает открытый поток адреса ip; false, если у адреса уже maxStreamsPerIP потоков
+func (h *OverlayHandler) acquireStream(ip string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.streamsPerIP[ip] >= h.maxStreamsPerIP {
+		return false
+	}
+	h.streamsPerIP[ip]++
+	return true
+}
+
+func (h *OverlayHandler) releaseStream(ip string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.streamsPerIP[ip]--
+	if h.streamsPerIP[ip] <= 0 {
+		delete(h.streamsPerIP, ip)
+	}
+}
@@ -0,0 +1,197 @@
+package http
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bbp/backend/internal/domain/entities"
+	wsHandler "github.com/bbp/backend/internal/handler/websocket"
+	"github.com/bbp/backend/internal/repository/sqlite"
+	"github.com/bbp/backend/internal/usecase/veto"
+	"github.com/bbp/backend/pkg/jwt"
+	ws "github.com/bbp/backend/pkg/websocket"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoomEvents_StreamAndResume(t *testing.T) {
+	db, cleanup := setupVetoTestDB(t)
+	defer cleanup()
+
+	userRepo := sqlite.NewUserRepository(db)
+	roomRepo := sqlite.NewRoomRepository(db)
+	gameRepo := sqlite.NewGameRepository(db)
+	mapRepo := sqlite.NewMapRepository(db)
+	mapPoolRepo := sqlite.NewMapPoolRepository(db)
+	vetoSessionRepo := sqlite.NewVetoSessionRepository(db)
+	vetoActionRepo := sqlite.NewVetoActionRepository(db)
+	vetoLogicService := veto.NewVetoLogicService()
+
+	jwtService := jwt.NewJWTService("test-secret", time.Hour)
+	wsManager := ws.NewManager()
+	go wsManager.Run()
+	handler := wsHandler.NewRoomWebSocketHandler(wsManager, roomRepo, vetoSessionRepo, vetoActionRepo, mapRepo, mapPoolRepo, jwtService, nil, nil, nil, nil)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/api/rooms/:id/events", handler.StreamRoomEvents)
+	router.GET("/api/veto/sessions/share/:token/events", handler.StreamSessionEvents)
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	createUser := func(username string) (*entities.User, string) {
+		u := &entities.User{Email: username + "@example.com", Username: username, Password: "hashed"}
+		require.NoError(t, userRepo.Create(u))
+		token, err := jwtService.GenerateToken(u.ID, u.Username)
+		require.NoError(t, err)
+		return u, token
+	}
+	owner, ownerToken := createUser("owner")
+	_, strangerToken := createUser("stranger")
+
+	game := &entities.Game{Name: "CS2", Slug: "cs2", IsActive: true}
+	require.NoError(t, gameRepo.Create(game))
+	var maps []entities.Map
+	for _, slug := range []string{"mirage", "inferno", "nuke"} {
+		m := &entities.Map{GameID: game.ID, Name: slug, Slug: slug, IsActive: true}
+		require.NoError(t, mapRepo.Create(m))
+		maps = append(maps, *m)
+	}
+	pool := &entities.MapPool{GameID: game.ID, Name: "Pool", Type: entities.MapPoolTypeAll, IsSystem: true, Maps: maps}
+	require.NoError(t, mapPoolRepo.Create(pool))
+	created, err := veto.NewCreateSessionUseCase(vetoSessionRepo, mapPoolRepo, gameRepo, sqlite.NewMapRotationRepository(db), sqlite.NewTeamRepository(db), vetoLogicService).
+		Execute(veto.CreateSessionInput{GameID: game.ID, MapPoolID: pool.ID, Type: entities.VetoTypeBo1, TeamAName: "Alpha", TeamBName: "Bravo"})
+	require.NoError(t, err)
+	session := created.Session
+
+	r := &entities.Room{OwnerID: owner.ID, Name: "Scrim", Code: "SCRIM1", Type: entities.RoomTypePublic, Status: entities.RoomStatusWaiting, GameID: game.ID, MaxParticipants: 2, VetoSessionID: &session.ID}
+	require.NoError(t, roomRepo.Create(r))
+	require.NoError(t, roomRepo.AddParticipant(&entities.RoomParticipant{RoomID: r.ID, UserID: owner.ID, Role: entities.ParticipantRoleOwner}))
+	roomPath := fmt.Sprintf("/api/rooms/%d/events", r.ID)
+
+	type event struct {
+		ID   string
+		Type string
+		Data map[string]interface{}
+	}
+	open := func(path, token, lastEventID string) (*http.Response, func() event) {
+		req, err := http.NewRequest(http.MethodGet, server.URL+path, nil)
+		require.NoError(t, err)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		if lastEventID != "" {
+			req.Header.Set("Last-Event-ID", lastEventID)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		reader := bufio.NewReader(resp.Body)
+		return resp, func() event {
+			var e event
+			for {
+				line, err := reader.ReadString('\n')
+				require.NoError(t, err)
+				line = strings.TrimRight(line, "\n")
+				switch {
+				case strings.HasPrefix(line, "id: "):
+					e.ID = strings.TrimPrefix(line, "id: ")
+				case strings.HasPrefix(line, "data: "):
+					var msg struct {
+						Type string                 `json:"type"`
+						Data map[string]interface{} `json:"data"`
+					}
+					require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &msg))
+					e.Type = msg.Type
+					e.Data = msg.Data
+					return e
+				}
+			}
+		}
+	}
+
+	// Поток комнаты только для участников
+	resp, _ := open(roomPath, "", "")
+	resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	resp, _ = open(roomPath, strangerToken, "")
+	resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	resp, _ = open("/api/veto/sessions/share/unknown/events", "", "")
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	// Первым приходит состояние комнаты, затем рассылки комнаты
+	roomResp, nextRoomEvent := open(roomPath, ownerToken, "")
+	require.Equal(t, http.StatusOK, roomResp.StatusCode)
+	assert.Equal(t, "text/event-stream", roomResp.Header.Get("Content-Type"))
+	shareResp, nextShareEvent := open("/api/veto/sessions/share/"+session.ShareToken+"/events", "", "")
+	defer shareResp.Body.Close()
+	require.Equal(t, http.StatusOK, shareResp.StatusCode)
+	assert.Equal(t, "room:state", nextRoomEvent().Type)
+	// Публичное состояние содержит только комнату и share token текущей сессии
+	assert.Equal(t, event{Type: "room:state", Data: map[string]interface{}{"room_id": float64(r.ID), "share_token": session.ShareToken}}, nextShareEvent())
+
+	sessionEvent := func(eventType string, s *entities.VetoSession) ws.Message {
+		return ws.Message{Type: eventType, Data: map[string]interface{}{"session": map[string]interface{}{"id": s.ID}}}
+	}
+	wsManager.BroadcastToRoom(r.ID, ws.Message{Type: "room:participants:updated", Data: map[string]interface{}{"room_id": r.ID}})
+	wsManager.BroadcastToRoom(r.ID, sessionEvent("veto:ban", session))
+	participants := nextRoomEvent()
+	assert.Equal(t, "room:participants:updated", participants.Type)
+	ban := nextRoomEvent()
+	assert.Equal(t, "veto:ban", ban.Type)
+	assert.NotEmpty(t, ban.ID)
+
+	// По share token видны только события вето
+	assert.Equal(t, ban, nextShareEvent())
+	roomResp.Body.Close()
+
+	// Пропущенные события досылаются после Last-Event-ID без повторного состояния
+	wsManager.BroadcastToRoom(r.ID, sessionEvent("veto:pick", session))
+	pick := nextShareEvent()
+	assert.Equal(t, "veto:pick", pick.Type)
+	resumeResp, nextResumeEvent := open(roomPath, ownerToken, participants.ID)
+	defer resumeResp.Body.Close()
+	assert.Equal(t, ban, nextResumeEvent())
+	assert.Equal(t, pick, nextResumeEvent())
+
+	// Неизвестный Last-Event-ID (например, после перезапуска сервера) - снова полное состояние
+	staleResp, nextStaleEvent := open(roomPath, ownerToken, "1")
+	defer staleResp.Body.Close()
+	assert.Equal(t, "room:state", nextStaleEvent().Type)
+
+	// Следующее вето серии: зритель получает share token новой сессии и следит уже за ней
+	next, err := veto.NewCreateSessionUseCase(vetoSessionRepo, mapPoolRepo, gameRepo, sqlite.NewMapRotationRepository(db), sqlite.NewTeamRepository(db), vetoLogicService).
+		Execute(veto.CreateSessionInput{GameID: game.ID, MapPoolID: pool.ID, Type: entities.VetoTypeBo1, TeamAName: "Alpha", TeamBName: "Bravo"})
+	require.NoError(t, err)
+	added, err := roomRepo.AddMatch(&entities.RoomMatch{RoomID: r.ID, VetoSessionID: next.Session.ID}, &session.ID)
+	require.NoError(t, err)
+	require.True(t, added)
+	wsManager.BroadcastToRoom(r.ID, ws.Message{Type: "room:state", Data: map[string]interface{}{"room_id": r.ID, "veto_session_id": next.Session.ID, "status": "in_progress"}})
+	wsManager.BroadcastToRoom(r.ID, sessionEvent("veto:ban", session))
+	wsManager.BroadcastToRoom(r.ID, sessionEvent("veto:ban", next.Session))
+
+	switched := nextShareEvent()
+	assert.Equal(t, "room:state", switched.Type)
+	assert.Equal(t, map[string]interface{}{"room_id": float64(r.ID), "share_token": next.Session.ShareToken}, switched.Data)
+	nextBan := nextShareEvent()
+	assert.Equal(t, "veto:ban", nextBan.Type)
+	assert.Equal(t, float64(next.Session.ID), nextBan.Data["session"].(map[string]interface{})["id"])
+
+	// Переподключение по старой ссылке сразу переключается на текущую сессию
+	oldResp, nextOldEvent := open("/api/veto/sessions/share/"+session.ShareToken+"/events", "", "")
+	defer oldResp.Body.Close()
+	assert.Equal(t, next.Session.ShareToken, nextOldEvent().Data["share_token"])
+	resumedResp, nextResumedEvent := open("/api/veto/sessions/share/"+session.ShareToken+"/events", "", nextBan.ID)
+	defer resumedResp.Body.Close()
+	wsManager.BroadcastToRoom(r.ID, sessionEvent("veto:pick", next.Session))
+	assert.Equal(t, next.Session.ShareToken, nextResumedEvent().Data["share_token"])
+	assert.Equal(t, "veto:pick", nextResumedEvent().Type)
+}
@@ -5,9 +5,11 @@ import (
 	"strconv"
 
 	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
 	"github.com/bbp/backend/internal/handler/dto"
 	"github.com/bbp/backend/internal/middleware"
 	"github.com/bbp/backend/internal/usecase/room"
+	"github.com/bbp/backend/internal/usecase/webhook"
 	ws "github.com/bbp/backend/pkg/websocket"
 	"github.com/gin-gonic/gin"
 )
@@ -21,7 +23,11 @@ type RoomHandler struct {
 	leaveRoomUseCase        *room.LeaveRoomUseCase
 	deleteRoomUseCase       *room.DeleteRoomUseCase
 	updateRoomUseCase       *room.UpdateRoomUseCase
+	startVetoUseCase        *room.StartVetoUseCase
+	getRoomMatchesUseCase   *room.GetRoomMatchesUseCase
 	wsManager               *ws.Manager
+	webhookDispatcher       *webhook.Dispatcher
+	mapPoolRepo             repositories.MapPoolRepository
 }
 
 func NewRoomHandler(
@@ -33,6 +39,8 @@ func NewRoomHandler(
 	leaveRoomUseCase *room.LeaveRoomUseCase,
 	deleteRoomUseCase *room.DeleteRoomUseCase,
 	updateRoomUseCase *room.UpdateRoomUseCase,
+	startVetoUseCase *room.StartVetoUseCase,
+	getRoomMatchesUseCase *room.GetRoomMatchesUseCase,
 	wsManager *ws.Manager,
 ) *RoomHandler {
 	return &RoomHandler{
@@ -44,10 +52,19 @@ func NewRoomHandler(
 		leaveRoomUseCase:        leaveRoomUseCase,
 		deleteRoomUseCase:       deleteRoomUseCase,
 		updateRoomUseCase:       updateRoomUseCase,
+		startVetoUseCase:        startVetoUseCase,
+		getRoomMatchesUseCase:   getRoomMatchesUseCase,
 		wsManager:               wsManager,
 	}
 }
 
+// SetWebhookDispatcher подключает отправку veto.started во внешние подписки при запуске вето из комнаты;
+// пул карт нужен, чтобы событие содержало ту же сессию, что и при запуске через /api/veto/sessions/:id/start
+func (h *RoomHandler) SetWebhookDispatcher(dispatcher *webhook.Dispatcher, mapPoolRepo repositories.MapPoolRepository) {
+	h.webhookDispatcher = dispatcher
+	h.mapPoolRepo = mapPoolRepo
+}
+
 // GetRooms обрабатывает GET /api/rooms
 func (h *RoomHandler) GetRooms(c *gin.Context) {
 	limitStr := c.DefaultQuery("limit", "20")
@@ -122,6 +139,8 @@ func (h *RoomHandler) CreateRoom(c *gin.Context) {
 		VetoType:        vetoType,
 		MaxParticipants: maxParticipants,
 		Password:        req.Password,
+		TeamAID:         req.TeamAID,
+		TeamBID:         req.TeamBID,
 	})
 
 	if err != nil {
@@ -132,6 +151,12 @@ func (h *RoomHandler) CreateRoom(c *gin.Context) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "map pool not found"})
 		case room.ErrInvalidRoom:
 			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid room"})
+		case room.ErrPoolTooSmall:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "map pool is too small for the veto type"})
+		case room.ErrTeamNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "team not found"})
+		case room.ErrNotTeamMember:
+			c.JSON(http.StatusForbidden, gin.H{"error": "user is not a member of the team"})
 		default:
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
 		}
@@ -214,6 +239,7 @@ func (h *RoomHandler) JoinRoom(c *gin.Context) {
 		RoomID:   &roomID,
 		UserID:   user.ID,
 		Password: req.Password,
+		TeamID:   req.TeamID,
 	})
 
 	if err != nil {
@@ -228,6 +254,10 @@ func (h *RoomHandler) JoinRoom(c *gin.Context) {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid room password"})
 		case room.ErrInvalidRoom:
 			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid room"})
+		case room.ErrTeamNotInRoom:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "team does not play in this room"})
+		case room.ErrNotTeamMember:
+			c.JSON(http.StatusForbidden, gin.H{"error": "user is not a member of the team"})
 		default:
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
 		}
@@ -389,12 +419,13 @@ func (h *RoomHandler) UpdateRoom(c *gin.Context) {
 	}
 
 	result, err := h.updateRoomUseCase.Execute(room.UpdateRoomInput{
-		RoomID:        uint(id),
-		UserID:        user.ID,
-		MapPoolID:     req.MapPoolID,
-		VetoType:      vetoType,
-		VetoSessionID: req.VetoSessionID,
-		Status:        status,
+		RoomID:    uint(id),
+		UserID:    user.ID,
+		MapPoolID: req.MapPoolID,
+		VetoType:  vetoType,
+		Status:    status,
+		TeamAID:   req.TeamAID,
+		TeamBID:   req.TeamBID,
 	})
 
 	if err != nil {
@@ -403,6 +434,16 @@ func (h *RoomHandler) UpdateRoom(c *gin.Context) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "room not found"})
 		case room.ErrUnauthorized:
 			c.JSON(http.StatusForbidden, gin.H{"error": "unauthorized"})
+		case room.ErrMapPoolNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "map pool not found"})
+		case room.ErrInvalidRoom:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid room"})
+		case room.ErrPoolTooSmall:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "map pool is too small for the veto type"})
+		case room.ErrTeamNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "team not found"})
+		case room.ErrNotTeamMember:
+			c.JSON(http.StatusForbidden, gin.H{"error": "user is not a member of the team"})
 		default:
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
 		}
@@ -410,28 +451,145 @@ func (h *RoomHandler) UpdateRoom(c *gin.Context) {
 	}
 
 	// Отправляем WebSocket сообщение об обновлении комнаты
-	if h.wsManager != nil {
-		var vetoTypeStr *string
-		if result.Room.VetoType != nil {
-			s := string(*result.Room.VetoType)
-			vetoTypeStr = &s
+	h.broadcastRoomState(result.Room)
+
+	c.JSON(http.StatusOK, dto.ToRoomResponse(result.Room))
+}
+
+// StartVeto обрабатывает POST /api/rooms/:id/veto
+func (h *RoomHandler) StartVeto(c *gin.Context) {
+	// Получаем пользователя из контекста (требует авторизации)
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid room id"})
+		return
+	}
+
+	// Тело запроса необязательно
+	var req dto.StartVetoRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
 		}
-		
-		h.wsManager.BroadcastToRoom(uint(id), ws.Message{
-			Type: "room:state",
-			Data: map[string]interface{}{
-				"room_id":        uint(id),
-				"veto_session_id": result.Room.VetoSessionID,
-				"map_pool_id":     result.Room.MapPoolID,
-				"veto_type":       vetoTypeStr,
-				"status":          result.Room.Status,
-			},
-		})
 	}
 
+	result, err := h.startVetoUseCase.Execute(room.StartVetoInput{
+		RoomID:    uint(id),
+		UserID:    user.ID,
+		SwapSides: req.SwapSides,
+	})
+
+	if err != nil {
+		switch err {
+		case room.ErrRoomNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "room not found"})
+		case room.ErrUnauthorized:
+			c.JSON(http.StatusForbidden, gin.H{"error": "unauthorized"})
+		case room.ErrInvalidRoom:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "room has no map pool or veto type"})
+		case room.ErrVetoAlreadyStarted:
+			c.JSON(http.StatusConflict, gin.H{"error": "veto is already in progress in this room"})
+		case room.ErrGameNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "game not found"})
+		case room.ErrMapPoolNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "map pool not found"})
+		case room.ErrPoolTooSmall:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "map pool is too small for the veto type"})
+		case room.ErrTeamNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "team not found"})
+		case room.ErrNotTeamMember:
+			c.JSON(http.StatusForbidden, gin.H{"error": "user is not a member of the team"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		}
+		return
+	}
+
+	// Участники комнаты переходят к вето по room:state
+	h.broadcastRoomState(result.Room)
+	h.publishVetoStarted(result.Room, result.Session)
+
 	c.JSON(http.StatusOK, dto.ToRoomResponse(result.Room))
 }
 
+// GetRoomMatches обрабатывает GET /api/rooms/:id/matches
+func (h *RoomHandler) GetRoomMatches(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid room id"})
+		return
+	}
+
+	result, err := h.getRoomMatchesUseCase.Execute(uint(id))
+	if err != nil {
+		switch err {
+		case room.ErrRoomNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "room not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToRoomMatchesResponse(result.Room, result.Matches))
+}
+
+// publishVetoStarted отправляет veto.started во внешние подписки комнаты и ее владельца
+func (h *RoomHandler) publishVetoStarted(r *entities.Room, session *entities.VetoSession) {
+	if h.webhookDispatcher == nil || session == nil {
+		return
+	}
+
+	sessionDTO := dto.ToVetoSessionResponse(session)
+	if h.mapPoolRepo != nil {
+		sessionDTO.MapPool = sessionMapPoolResponse(session, h.mapPoolRepo)
+	}
+
+	h.webhookDispatcher.PublishVetoEvent(entities.WebhookEventVetoStarted, session, r, map[string]interface{}{
+		"session": sessionDTO,
+	})
+}
+
+// broadcastRoomState рассылает участникам комнаты ее текущие настройки, статус и положение в серии
+func (h *RoomHandler) broadcastRoomState(r *entities.Room) {
+	if h.wsManager == nil {
+		return
+	}
+
+	var vetoTypeStr *string
+	if r.VetoType != nil {
+		s := string(*r.VetoType)
+		vetoTypeStr = &s
+	}
+
+	matchNumber := 0
+	if current := r.CurrentMatch(); current != nil {
+		matchNumber = current.Number
+	}
+
+	h.wsManager.BroadcastToRoom(r.ID, ws.Message{
+		Type: "room:state",
+		Data: map[string]interface{}{
+			"room_id":         r.ID,
+			"veto_session_id": r.VetoSessionID,
+			"map_pool_id":     r.MapPoolID,
+			"veto_type":       vetoTypeStr,
+			"status":          r.Status,
+			"match_number":    matchNumber,
+			"match_count":     len(r.Matches),
+		},
+	})
+}
+
 // GetParticipants обрабатывает GET /api/rooms/:id/participants
 func (h *RoomHandler) GetParticipants(c *gin.Context) {
 	idStr := c.Param("id")
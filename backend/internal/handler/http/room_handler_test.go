@@ -3,18 +3,26 @@ package http
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/bbp/backend/internal/domain/entities"
 	"github.com/bbp/backend/internal/handler/dto"
+	"github.com/bbp/backend/internal/middleware"
 	"github.com/bbp/backend/internal/repository/models"
 	"github.com/bbp/backend/internal/repository/sqlite"
 	"github.com/bbp/backend/internal/usecase/room"
+	"github.com/bbp/backend/internal/usecase/veto"
+	"github.com/bbp/backend/internal/usecase/webhook"
 	"github.com/bbp/backend/pkg/database"
+	"github.com/bbp/backend/pkg/jwt"
 	ws "github.com/bbp/backend/pkg/websocket"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"gorm.io/gorm"
 )
 
@@ -32,6 +40,9 @@ func setupRoomTestDB(t *testing.T) (*gorm.DB, func()) {
 		&models.MapPoolModel{},
 		&models.RoomModel{},
 		&models.RoomParticipantModel{},
+		&models.RoomMatchModel{},
+		&models.TeamModel{},
+		&models.TeamMemberModel{},
 	); err != nil {
 		t.Fatalf("Failed to run migrations: %v", err)
 	}
@@ -53,16 +64,18 @@ func setupRoomTestRouter(t *testing.T) (*gin.Engine, func()) {
 	roomRepo := sqlite.NewRoomRepository(db)
 	gameRepo := sqlite.NewGameRepository(db)
 	mapPoolRepo := sqlite.NewMapPoolRepository(db)
+	teamRepo := sqlite.NewTeamRepository(db)
+	vetoLogicService := veto.NewVetoLogicService()
 
 	// Инициализируем use cases
-	createRoomUseCase := room.NewCreateRoomUseCase(roomRepo, gameRepo, mapPoolRepo)
+	createRoomUseCase := room.NewCreateRoomUseCase(roomRepo, gameRepo, mapPoolRepo, teamRepo, vetoLogicService)
 	getRoomUseCase := room.NewGetRoomUseCase(roomRepo)
 	getRoomBySessionUseCase := room.NewGetRoomBySessionUseCase(roomRepo)
 	getRoomsListUseCase := room.NewGetRoomsListUseCase(roomRepo)
-	joinRoomUseCase := room.NewJoinRoomUseCase(roomRepo)
+	joinRoomUseCase := room.NewJoinRoomUseCase(roomRepo, teamRepo)
 	leaveRoomUseCase := room.NewLeaveRoomUseCase(roomRepo)
 	deleteRoomUseCase := room.NewDeleteRoomUseCase(roomRepo)
-	updateRoomUseCase := room.NewUpdateRoomUseCase(roomRepo)
+	updateRoomUseCase := room.NewUpdateRoomUseCase(roomRepo, mapPoolRepo, teamRepo, vetoLogicService)
 
 	// Инициализируем WebSocket manager
 	wsManager := ws.NewManager()
@@ -77,6 +90,8 @@ func setupRoomTestRouter(t *testing.T) (*gin.Engine, func()) {
 		leaveRoomUseCase,
 		deleteRoomUseCase,
 		updateRoomUseCase,
+		room.NewStartVetoUseCase(roomRepo, nil, nil, nil),
+		room.NewGetRoomMatchesUseCase(roomRepo, nil),
 		wsManager,
 	)
 
@@ -177,3 +192,174 @@ func TestRoomHandler_LeaveRoom(t *testing.T) {
 	// Тест может не пройти без существующей комнаты, но структура готова
 	assert.True(t, w.Code == http.StatusOK || w.Code == http.StatusNotFound || w.Code == http.StatusBadRequest)
 }
+
+func TestRoomHandler_StartVeto(t *testing.T) {
+	db, cleanup := setupVetoTestDB(t)
+	defer cleanup()
+	require.NoError(t, database.Migrate(db,
+		&models.RevokedTokenModel{},
+		&models.WebhookModel{},
+		&models.WebhookEventModel{},
+		&models.WebhookDeliveryModel{},
+	))
+
+	userRepo := sqlite.NewUserRepository(db)
+	roomRepo := sqlite.NewRoomRepository(db)
+	gameRepo := sqlite.NewGameRepository(db)
+	mapRepo := sqlite.NewMapRepository(db)
+	mapPoolRepo := sqlite.NewMapPoolRepository(db)
+	vetoSessionRepo := sqlite.NewVetoSessionRepository(db)
+	teamRepo := sqlite.NewTeamRepository(db)
+	vetoLogicService := veto.NewVetoLogicService()
+
+	jwtService := jwt.NewJWTService("test-secret", time.Hour)
+	jwtService.SetDenylist(sqlite.NewRevokedTokenRepository(db))
+
+	createSessionUseCase := veto.NewCreateSessionUseCase(vetoSessionRepo, mapPoolRepo, gameRepo, sqlite.NewMapRotationRepository(db), teamRepo, vetoLogicService)
+	startVetoUseCase := room.NewStartVetoUseCase(roomRepo, vetoSessionRepo, createSessionUseCase, veto.NewStartSessionUseCase(vetoSessionRepo))
+	roomHandler := NewRoomHandler(nil, nil, room.NewGetRoomBySessionUseCase(roomRepo), nil, nil, nil, nil, nil, startVetoUseCase, room.NewGetRoomMatchesUseCase(roomRepo, vetoSessionRepo), nil)
+	webhookRepo := sqlite.NewWebhookRepository(db)
+	webhookDeliveryRepo := sqlite.NewWebhookDeliveryRepository(db)
+	roomHandler.SetWebhookDispatcher(webhook.NewDispatcher(webhookRepo, webhookDeliveryRepo, webhook.DefaultDeliveryPolicy()), mapPoolRepo)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/api/rooms/:id/veto", middleware.AuthMiddleware(jwtService), roomHandler.StartVeto)
+	router.GET("/api/rooms/:id/matches", roomHandler.GetRoomMatches)
+	router.GET("/api/rooms/by-session/:sessionId", roomHandler.GetRoomBySession)
+
+	createUser := func(username string) (*entities.User, string) {
+		u := &entities.User{Email: username + "@example.com", Username: username, Password: "hashed"}
+		require.NoError(t, userRepo.Create(u))
+		token, err := jwtService.GenerateToken(u.ID, u.Username)
+		require.NoError(t, err)
+		return u, token
+	}
+	owner, ownerToken := createUser("owner")
+	opponent, opponentToken := createUser("opponent")
+	hook := &entities.Webhook{UserID: owner.ID, URL: "https://example.com/hook", Secret: "secret", Events: []entities.WebhookEvent{entities.WebhookEventVetoStarted}, IsActive: true}
+	require.NoError(t, webhookRepo.Create(hook))
+
+	game := &entities.Game{Name: "Valorant", Slug: "valorant", IsActive: true}
+	require.NoError(t, gameRepo.Create(game))
+	var maps []entities.Map
+	for _, slug := range []string{"bind", "haven", "lotus", "split", "ascent", "icebox", "sunset"} {
+		m := &entities.Map{GameID: game.ID, Name: slug, Slug: slug, IsActive: true}
+		require.NoError(t, mapRepo.Create(m))
+		maps = append(maps, *m)
+	}
+	pool := &entities.MapPool{GameID: game.ID, UserID: &owner.ID, Name: "Pool", Type: entities.MapPoolTypeCustom, Maps: maps}
+	require.NoError(t, mapPoolRepo.Create(pool))
+
+	r := &entities.Room{OwnerID: owner.ID, Name: "Scrim", Code: "SCRIM1", Type: entities.RoomTypePublic, Status: entities.RoomStatusWaiting, GameID: game.ID, MaxParticipants: 2}
+	require.NoError(t, roomRepo.Create(r))
+	require.NoError(t, roomRepo.AddParticipant(&entities.RoomParticipant{RoomID: r.ID, UserID: owner.ID, Role: entities.ParticipantRoleOwner}))
+	require.NoError(t, roomRepo.AddParticipant(&entities.RoomParticipant{RoomID: r.ID, UserID: opponent.ID, Role: entities.ParticipantRoleMember}))
+	path := fmt.Sprintf("/api/rooms/%d/veto", r.ID)
+
+	request := func(token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, path, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	nextMatch := func(swap bool) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(dto.StartVetoRequest{SwapSides: swap})
+		req := httptest.NewRequest(http.MethodPost, path, bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+ownerToken)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// Без пула и формата сессию создать не из чего
+	assert.Equal(t, http.StatusBadRequest, request(ownerToken).Code)
+
+	vetoType := entities.VetoTypeBo1
+	r.MapPoolID = &pool.ID
+	r.VetoType = &vetoType
+	require.NoError(t, roomRepo.Update(r))
+
+	assert.Equal(t, http.StatusForbidden, request(opponentToken).Code)
+
+	w := request(ownerToken)
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp dto.RoomResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.NotNil(t, resp.VetoSessionID)
+	assert.Equal(t, "active", resp.Status)
+	assert.Equal(t, 1, resp.CurrentMatch)
+
+	session, err := vetoSessionRepo.GetByID(*resp.VetoSessionID)
+	require.NoError(t, err)
+	require.NotNil(t, session)
+	assert.Equal(t, pool.ID, session.MapPoolID)
+	assert.Equal(t, entities.VetoTypeBo1, session.Type)
+	assert.Equal(t, entities.VetoStatusInProgress, session.Status)
+	assert.Equal(t, "owner", session.TeamAName)
+	assert.Equal(t, "opponent", session.TeamBName)
+
+	// Запуск из комнаты публикует veto.started для подписок владельца
+	deliveries, err := webhookDeliveryRepo.GetByWebhookID(hook.ID, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, entities.WebhookEventVetoStarted, deliveries[0].Event)
+	assert.Contains(t, deliveries[0].Payload, fmt.Sprintf(`"session_id":%d`, session.ID))
+
+	// Пока сессия идет, повторный запуск запрещен
+	assert.Equal(t, http.StatusConflict, request(ownerToken).Code)
+
+	// После завершения следующий матч серии играется со сменой сторон
+	session.Status = entities.VetoStatusFinished
+	require.NoError(t, vetoSessionRepo.Update(session))
+	w = nextMatch(true)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.NotEqual(t, session.ID, *resp.VetoSessionID)
+	assert.Equal(t, 2, resp.CurrentMatch)
+	second, err := vetoSessionRepo.GetByID(*resp.VetoSessionID)
+	require.NoError(t, err)
+	assert.Equal(t, "opponent", second.TeamAName)
+	assert.Equal(t, "owner", second.TeamBName)
+
+	// Без смены стороны переносятся с предыдущего матча
+	second.Status = entities.VetoStatusFinished
+	require.NoError(t, vetoSessionRepo.Update(second))
+	w = nextMatch(false)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	third, err := vetoSessionRepo.GetByID(*resp.VetoSessionID)
+	require.NoError(t, err)
+	assert.Equal(t, "opponent", third.TeamAName)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/rooms/%d/matches", r.ID), nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	var history dto.RoomMatchesResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &history))
+	assert.Equal(t, 3, history.CurrentMatch)
+	require.Len(t, history.Matches, 3)
+	assert.Equal(t, session.ID, history.Matches[0].VetoSessionID)
+	assert.True(t, history.Matches[1].SidesSwapped)
+	assert.True(t, history.Matches[2].SidesSwapped)
+	require.NotNil(t, history.Matches[0].Session)
+	assert.Equal(t, "finished", history.Matches[0].Session.Status)
+
+	// Прошлые сессии серии по-прежнему ведут в комнату
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/rooms/by-session/%d", session.ID), nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, r.ID, resp.ID)
+	assert.Len(t, resp.Matches, 3)
+
+	// Матч с устаревшей текущей сессией (параллельный запуск) не добавляется
+	added, err := roomRepo.AddMatch(&entities.RoomMatch{RoomID: r.ID, VetoSessionID: second.ID}, &session.ID)
+	require.NoError(t, err)
+	assert.False(t, added)
+	matches, err := roomRepo.GetMatches(r.ID)
+	require.NoError(t, err)
+	assert.Len(t, matches, 3)
+}
@@ -0,0 +1,419 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/handler/dto"
+	"github.com/bbp/backend/internal/middleware"
+	"github.com/bbp/backend/internal/usecase/team"
+	"github.com/gin-gonic/gin"
+)
+
+type TeamHandler struct {
+	createTeamUseCase      *team.CreateTeamUseCase
+	getTeamUseCase         *team.GetTeamUseCase
+	getUserTeamsUseCase    *team.GetUserTeamsUseCase
+	updateTeamUseCase      *team.UpdateTeamUseCase
+	deleteTeamUseCase      *team.DeleteTeamUseCase
+	inviteMemberUseCase    *team.InviteMemberUseCase
+	getInvitesUseCase      *team.GetInvitesUseCase
+	respondInviteUseCase   *team.RespondInviteUseCase
+	removeMemberUseCase    *team.RemoveMemberUseCase
+	updateMemberUseCase    *team.UpdateMemberUseCase
+	getTeamSessionsUseCase *team.GetTeamSessionsUseCase
+	getTeamStatsUseCase    *team.GetTeamStatsUseCase
+}
+
+func NewTeamHandler(
+	createTeamUseCase *team.CreateTeamUseCase,
+	getTeamUseCase *team.GetTeamUseCase,
+	getUserTeamsUseCase *team.GetUserTeamsUseCase,
+	updateTeamUseCase *team.UpdateTeamUseCase,
+	deleteTeamUseCase *team.DeleteTeamUseCase,
+	inviteMemberUseCase *team.InviteMemberUseCase,
+	getInvitesUseCase *team.GetInvitesUseCase,
+	respondInviteUseCase *team.RespondInviteUseCase,
+	removeMemberUseCase *team.RemoveMemberUseCase,
+	updateMemberUseCase *team.UpdateMemberUseCase,
+	getTeamSessionsUseCase *team.GetTeamSessionsUseCase,
+	getTeamStatsUseCase *team.GetTeamStatsUseCase,
+) *TeamHandler {
+	return &TeamHandler{
+		createTeamUseCase:      createTeamUseCase,
+		getTeamUseCase:         getTeamUseCase,
+		getUserTeamsUseCase:    getUserTeamsUseCase,
+		updateTeamUseCase:      updateTeamUseCase,
+		deleteTeamUseCase:      deleteTeamUseCase,
+		inviteMemberUseCase:    inviteMemberUseCase,
+		getInvitesUseCase:      getInvitesUseCase,
+		respondInviteUseCase:   respondInviteUseCase,
+		removeMemberUseCase:    removeMemberUseCase,
+		updateMemberUseCase:    updateMemberUseCase,
+		getTeamSessionsUseCase: getTeamSessionsUseCase,
+		getTeamStatsUseCase:    getTeamStatsUseCase,
+	}
+}
+
+// CreateTeam обрабатывает POST /api/teams
+func (h *TeamHandler) CreateTeam(c *gin.Context) {
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req dto.CreateTeamRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.createTeamUseCase.Execute(team.CreateTeamInput{
+		OwnerID: user.ID,
+		Name:    req.Name,
+		Tag:     req.Tag,
+		LogoURL: req.LogoURL,
+	})
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ToTeamResponse(result.Team))
+}
+
+// GetMyTeams обрабатывает GET /api/teams
+func (h *TeamHandler) GetMyTeams(c *gin.Context) {
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	result, err := h.getUserTeamsUseCase.Execute(user.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToTeamResponseList(result.Teams))
+}
+
+// GetTeam обрабатывает GET /api/teams/:id
+func (h *TeamHandler) GetTeam(c *gin.Context) {
+	teamID, ok := parseTeamID(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.getTeamUseCase.Execute(teamID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToTeamResponse(result.Team))
+}
+
+// UpdateTeam обрабатывает PUT /api/teams/:id
+func (h *TeamHandler) UpdateTeam(c *gin.Context) {
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	teamID, ok := parseTeamID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateTeamRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.updateTeamUseCase.Execute(team.UpdateTeamInput{
+		TeamID:  teamID,
+		UserID:  user.ID,
+		Name:    req.Name,
+		Tag:     req.Tag,
+		LogoURL: req.LogoURL,
+	})
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToTeamResponse(result.Team))
+}
+
+// DeleteTeam обрабатывает DELETE /api/teams/:id
+func (h *TeamHandler) DeleteTeam(c *gin.Context) {
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	teamID, ok := parseTeamID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.deleteTeamUseCase.Execute(team.DeleteTeamInput{
+		TeamID: teamID,
+		UserID: user.ID,
+	}); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "team deleted"})
+}
+
+// InviteMember обрабатывает POST /api/teams/:id/invites
+func (h *TeamHandler) InviteMember(c *gin.Context) {
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	teamID, ok := parseTeamID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.InviteTeamMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.inviteMemberUseCase.Execute(team.InviteMemberInput{
+		TeamID:   teamID,
+		UserID:   user.ID,
+		Username: req.Username,
+		Role:     entities.TeamRole(req.Role),
+	})
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ToTeamInviteResponse(result.Invite))
+}
+
+// GetInvites обрабатывает GET /api/teams/invites
+func (h *TeamHandler) GetInvites(c *gin.Context) {
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	result, err := h.getInvitesUseCase.Execute(user.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToTeamInviteResponseList(result.Invites))
+}
+
+// AcceptInvite обрабатывает POST /api/teams/invites/:inviteId/accept
+func (h *TeamHandler) AcceptInvite(c *gin.Context) {
+	h.respondInvite(c, true)
+}
+
+// DeclineInvite обрабатывает POST /api/teams/invites/:inviteId/decline
+func (h *TeamHandler) DeclineInvite(c *gin.Context) {
+	h.respondInvite(c, false)
+}
+
+func (h *TeamHandler) respondInvite(c *gin.Context, accept bool) {
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	inviteID, err := strconv.ParseUint(c.Param("inviteId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid invite id"})
+		return
+	}
+
+	result, err := h.respondInviteUseCase.Execute(team.RespondInviteInput{
+		InviteID: uint(inviteID),
+		UserID:   user.ID,
+		Accept:   accept,
+	})
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToTeamResponse(result.Team))
+}
+
+// UpdateMember обрабатывает PUT /api/teams/:id/members/:userId
+func (h *TeamHandler) UpdateMember(c *gin.Context) {
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	teamID, memberID, ok := parseTeamMemberParams(c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateTeamMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.updateMemberUseCase.Execute(team.UpdateMemberInput{
+		TeamID:   teamID,
+		UserID:   user.ID,
+		MemberID: memberID,
+		Role:     entities.TeamRole(req.Role),
+	})
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToTeamResponse(result.Team))
+}
+
+// RemoveMember обрабатывает DELETE /api/teams/:id/members/:userId
+// Участник может удалить себя сам, чтобы выйти из команды
+func (h *TeamHandler) RemoveMember(c *gin.Context) {
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	teamID, memberID, ok := parseTeamMemberParams(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.removeMemberUseCase.Execute(team.RemoveMemberInput{
+		TeamID:   teamID,
+		UserID:   user.ID,
+		MemberID: memberID,
+	})
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	if result.Team == nil {
+		c.JSON(http.StatusOK, gin.H{"message": "team deleted"})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToTeamResponse(result.Team))
+}
+
+// GetTeamSessions обрабатывает GET /api/teams/:id/sessions
+func (h *TeamHandler) GetTeamSessions(c *gin.Context) {
+	teamID, ok := parseTeamID(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.getTeamSessionsUseCase.Execute(teamID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.TeamSessionsResponse{
+		Sessions: dto.ToVetoSessionResponseList(result.Sessions),
+	})
+}
+
+// GetTeamStats обрабатывает GET /api/teams/:id/stats
+func (h *TeamHandler) GetTeamStats(c *gin.Context) {
+	teamID, ok := parseTeamID(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.getTeamStatsUseCase.Execute(teamID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.TeamStatsResponse{
+		TeamID: result.Team.ID,
+		Stats:  result.Stats,
+	})
+}
+
+func (h *TeamHandler) handleError(c *gin.Context, err error) {
+	switch err {
+	case team.ErrTeamNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "team not found"})
+	case team.ErrUserNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+	case team.ErrMemberNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "team member not found"})
+	case team.ErrInviteNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "team invite not found"})
+	case team.ErrUnauthorized:
+		c.JSON(http.StatusForbidden, gin.H{"error": "unauthorized"})
+	case team.ErrTeamNameTaken:
+		c.JSON(http.StatusConflict, gin.H{"error": "team name already taken"})
+	case team.ErrAlreadyMember:
+		c.JSON(http.StatusConflict, gin.H{"error": "user is already a team member"})
+	case team.ErrInviteExists:
+		c.JSON(http.StatusConflict, gin.H{"error": "user already has a pending invite"})
+	case team.ErrLastCaptain:
+		c.JSON(http.StatusConflict, gin.H{"error": "team must have at least one captain"})
+	case team.ErrInvalidTeam:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid team"})
+	case team.ErrInvalidRole:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid team role"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+	}
+}
+
+// parseTeamID разбирает параметр :id
+func parseTeamID(c *gin.Context) (uint, bool) {
+	teamID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid team id"})
+		return 0, false
+	}
+	return uint(teamID), true
+}
+
+// parseTeamMemberParams разбирает параметры :id и :userId
+func parseTeamMemberParams(c *gin.Context) (uint, uint, bool) {
+	teamID, ok := parseTeamID(c)
+	if !ok {
+		return 0, 0, false
+	}
+
+	memberID, err := strconv.ParseUint(c.Param("userId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return 0, 0, false
+	}
+
+	return teamID, uint(memberID), true
+}
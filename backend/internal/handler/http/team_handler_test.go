@@ -0,0 +1,202 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/handler/dto"
+	"github.com/bbp/backend/internal/middleware"
+	"github.com/bbp/backend/internal/repository/models"
+	"github.com/bbp/backend/internal/repository/sqlite"
+	"github.com/bbp/backend/internal/usecase/team"
+	"github.com/bbp/backend/pkg/database"
+	"github.com/bbp/backend/pkg/jwt"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTeamHandler_RosterAndHistory(t *testing.T) {
+	db, err := database.NewDatabase(":memory:")
+	require.NoError(t, err)
+	defer database.Close(db)
+	require.NoError(t, database.Migrate(db,
+		&models.UserModel{},
+		&models.MapModel{},
+		&models.VetoSessionModel{},
+		&models.VetoActionModel{},
+		&models.VetoSessionMapModel{},
+		&models.VetoSessionConstraintModel{},
+		&models.RoomModel{},
+		&models.RoomParticipantModel{},
+		&models.RoomMatchModel{},
+		&models.RevokedTokenModel{},
+		&models.TeamModel{},
+		&models.TeamMemberModel{},
+		&models.MatchResultModel{},
+		&models.MapResultModel{},
+		&models.MatchResultPlayerModel{},
+		&models.TeamInviteModel{},
+	))
+
+	userRepo := sqlite.NewUserRepository(db)
+	teamRepo := sqlite.NewTeamRepository(db)
+	inviteRepo := sqlite.NewTeamInviteRepository(db)
+	vetoSessionRepo := sqlite.NewVetoSessionRepository(db)
+	vetoActionRepo := sqlite.NewVetoActionRepository(db)
+
+	jwtService := jwt.NewJWTService("test-secret", time.Hour)
+	jwtService.SetDenylist(sqlite.NewRevokedTokenRepository(db))
+
+	teamHandler := NewTeamHandler(
+		team.NewCreateTeamUseCase(teamRepo),
+		team.NewGetTeamUseCase(teamRepo),
+		team.NewGetUserTeamsUseCase(teamRepo),
+		team.NewUpdateTeamUseCase(teamRepo),
+		team.NewDeleteTeamUseCase(teamRepo),
+		team.NewInviteMemberUseCase(teamRepo, inviteRepo, userRepo),
+		team.NewGetInvitesUseCase(inviteRepo),
+		team.NewRespondInviteUseCase(teamRepo, inviteRepo),
+		team.NewRemoveMemberUseCase(teamRepo),
+		team.NewUpdateMemberUseCase(teamRepo),
+		team.NewGetTeamSessionsUseCase(teamRepo, vetoSessionRepo),
+		team.NewGetTeamStatsUseCase(teamRepo, sqlite.NewVetoStatsRepository(db)),
+	)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/api/teams/:id", teamHandler.GetTeam)
+	router.GET("/api/teams/:id/sessions", teamHandler.GetTeamSessions)
+	router.GET("/api/teams/:id/stats", teamHandler.GetTeamStats)
+	teams := router.Group("/api/teams")
+	teams.Use(middleware.AuthMiddleware(jwtService))
+	{
+		teams.GET("", teamHandler.GetMyTeams)
+		teams.POST("", teamHandler.CreateTeam)
+		teams.GET("/invites", teamHandler.GetInvites)
+		teams.POST("/invites/:inviteId/accept", teamHandler.AcceptInvite)
+		teams.POST("/invites/:inviteId/decline", teamHandler.DeclineInvite)
+		teams.PUT("/:id", teamHandler.UpdateTeam)
+		teams.POST("/:id/invites", teamHandler.InviteMember)
+		teams.PUT("/:id/members/:userId", teamHandler.UpdateMember)
+		teams.DELETE("/:id/members/:userId", teamHandler.RemoveMember)
+	}
+
+	createUser := func(username string) (*entities.User, string) {
+		u := &entities.User{Email: username + "@example.com", Username: username, Password: "hashed"}
+		require.NoError(t, userRepo.Create(u))
+		token, err := jwtService.GenerateToken(u.ID, u.Username)
+		require.NoError(t, err)
+		return u, token
+	}
+	request := func(method, path, token string, payload interface{}) *httptest.ResponseRecorder {
+		var body bytes.Buffer
+		if payload != nil {
+			json.NewEncoder(&body).Encode(payload)
+		}
+		req := httptest.NewRequest(method, path, &body)
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	captain, captainToken := createUser("captain")
+	player, playerToken := createUser("player")
+	_, outsiderToken := createUser("outsider")
+
+	// Создатель становится капитаном
+	w := request(http.MethodPost, "/api/teams", captainToken, dto.CreateTeamRequest{Name: "Falcons", Tag: "FLC"})
+	require.Equal(t, http.StatusCreated, w.Code)
+	var created dto.TeamResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	require.Len(t, created.Members, 1)
+	assert.Equal(t, "captain", created.Members[0].Role)
+	teamPath := fmt.Sprintf("/api/teams/%d", created.ID)
+
+	assert.Equal(t, http.StatusConflict, request(http.MethodPost, "/api/teams", outsiderToken, dto.CreateTeamRequest{Name: "falcons", Tag: "FL2"}).Code)
+	assert.Equal(t, http.StatusForbidden, request(http.MethodPut, teamPath, outsiderToken, dto.UpdateTeamRequest{}).Code)
+
+	// Приглашение и его принятие
+	assert.Equal(t, http.StatusForbidden, request(http.MethodPost, teamPath+"/invites", outsiderToken, dto.InviteTeamMemberRequest{Username: "player", Role: "player"}).Code)
+	w = request(http.MethodPost, teamPath+"/invites", captainToken, dto.InviteTeamMemberRequest{Username: "player", Role: "player"})
+	require.Equal(t, http.StatusCreated, w.Code)
+	var invite dto.TeamInviteResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &invite))
+	assert.Equal(t, http.StatusConflict, request(http.MethodPost, teamPath+"/invites", captainToken, dto.InviteTeamMemberRequest{Username: "player", Role: "coach"}).Code)
+
+	w = request(http.MethodGet, "/api/teams/invites", playerToken, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var invites []dto.TeamInviteResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &invites))
+	require.Len(t, invites, 1)
+	assert.Equal(t, "Falcons", invites[0].Team.Name)
+
+	invitePath := fmt.Sprintf("/api/teams/invites/%d", invite.ID)
+	assert.Equal(t, http.StatusNotFound, request(http.MethodPost, invitePath+"/accept", outsiderToken, nil).Code)
+	require.Equal(t, http.StatusOK, request(http.MethodPost, invitePath+"/accept", playerToken, nil).Code)
+	assert.Equal(t, http.StatusNotFound, request(http.MethodPost, invitePath+"/decline", playerToken, nil).Code)
+
+	w = request(http.MethodGet, "/api/teams", playerToken, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var myTeams []dto.TeamResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &myTeams))
+	require.Len(t, myTeams, 1)
+	assert.Len(t, myTeams[0].Members, 2)
+
+	// Единственный капитан не может снять с себя роль, но при выходе передает её
+	assert.Equal(t, http.StatusConflict, request(http.MethodPut, fmt.Sprintf("%s/members/%d", teamPath, captain.ID), captainToken, dto.UpdateTeamMemberRequest{Role: "coach"}).Code)
+	assert.Equal(t, http.StatusForbidden, request(http.MethodDelete, fmt.Sprintf("%s/members/%d", teamPath, captain.ID), playerToken, nil).Code)
+
+	// Сессии команды: действия соперника в статистику не попадают
+	opponentID := created.ID + 100
+	session := &entities.VetoSession{GameID: 1, MapPoolID: 1, Type: entities.VetoTypeBo3, Status: entities.VetoStatusFinished, TeamAName: "Falcons", TeamBName: "Rivals", TeamAID: &created.ID, TeamBID: &opponentID, CurrentTeam: "A", ShareToken: "team-session"}
+	require.NoError(t, vetoSessionRepo.Create(session))
+	attack := "attack"
+	require.NoError(t, vetoActionRepo.Create(&entities.VetoAction{VetoSessionID: session.ID, MapID: 1, Team: "A", ActionType: entities.VetoActionTypeBan, StepNumber: 1}))
+	require.NoError(t, vetoActionRepo.Create(&entities.VetoAction{VetoSessionID: session.ID, MapID: 2, Team: "B", ActionType: entities.VetoActionTypeBan, StepNumber: 2}))
+	require.NoError(t, vetoActionRepo.Create(&entities.VetoAction{VetoSessionID: session.ID, MapID: 3, Team: "A", ActionType: entities.VetoActionTypePick, StepNumber: 3}))
+	require.NoError(t, vetoActionRepo.Create(&entities.VetoAction{VetoSessionID: session.ID, MapID: 4, Team: "B", ActionType: entities.VetoActionTypePick, StepNumber: 4, SelectedSide: &attack}))
+
+	w = request(http.MethodGet, teamPath+"/sessions", "", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var history dto.TeamSessionsResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &history))
+	require.Len(t, history.Sessions, 1)
+	assert.Equal(t, created.ID, *history.Sessions[0].TeamAID)
+
+	w = request(http.MethodGet, teamPath+"/stats", "", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var stats dto.TeamStatsResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &stats))
+	assert.Equal(t, int64(1), stats.Stats.SessionsTotal)
+	require.Len(t, stats.Stats.MostBanned, 1)
+	assert.Equal(t, uint(1), stats.Stats.MostBanned[0].MapID)
+	require.Len(t, stats.Stats.MostPicked, 1)
+	assert.Equal(t, uint(3), stats.Stats.MostPicked[0].MapID)
+	// Сторону после пика соперника выбирала команда
+	require.Len(t, stats.Stats.Sides, 1)
+	assert.Equal(t, "attack", stats.Stats.Sides[0].Side)
+
+	require.Equal(t, http.StatusOK, request(http.MethodDelete, fmt.Sprintf("%s/members/%d", teamPath, captain.ID), captainToken, nil).Code)
+	w = request(http.MethodGet, teamPath, "", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var remaining dto.TeamResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &remaining))
+	require.Len(t, remaining.Members, 1)
+	assert.Equal(t, player.ID, remaining.Members[0].UserID)
+	assert.Equal(t, "captain", remaining.Members[0].Role)
+
+	// Последний участник уходит - команда удаляется
+	require.Equal(t, http.StatusOK, request(http.MethodDelete, fmt.Sprintf("%s/members/%d", teamPath, player.ID), playerToken, nil).Code)
+	assert.Equal(t, http.StatusNotFound, request(http.MethodGet, teamPath, "", nil).Code)
+}
@@ -0,0 +1,370 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/handler/dto"
+	"github.com/bbp/backend/internal/middleware"
+	"github.com/bbp/backend/internal/usecase/tournament"
+	"github.com/gin-gonic/gin"
+)
+
+type TournamentHandler struct {
+	createTournamentUseCase *tournament.CreateTournamentUseCase
+	getTournamentUseCase    *tournament.GetTournamentUseCase
+	getTournamentsUseCase   *tournament.GetTournamentsUseCase
+	deleteTournamentUseCase *tournament.DeleteTournamentUseCase
+	registerTeamUseCase     *tournament.RegisterTeamUseCase
+	withdrawTeamUseCase     *tournament.WithdrawTeamUseCase
+	seedTeamsUseCase        *tournament.SeedTeamsUseCase
+	startTournamentUseCase  *tournament.StartTournamentUseCase
+	scheduleMatchUseCase    *tournament.ScheduleMatchUseCase
+}
+
+func NewTournamentHandler(
+	createTournamentUseCase *tournament.CreateTournamentUseCase,
+	getTournamentUseCase *tournament.GetTournamentUseCase,
+	getTournamentsUseCase *tournament.GetTournamentsUseCase,
+	deleteTournamentUseCase *tournament.DeleteTournamentUseCase,
+	registerTeamUseCase *tournament.RegisterTeamUseCase,
+	withdrawTeamUseCase *tournament.WithdrawTeamUseCase,
+	seedTeamsUseCase *tournament.SeedTeamsUseCase,
+	startTournamentUseCase *tournament.StartTournamentUseCase,
+	scheduleMatchUseCase *tournament.ScheduleMatchUseCase,
+) *TournamentHandler {
+	return &TournamentHandler{
+		createTournamentUseCase: createTournamentUseCase,
+		getTournamentUseCase:    getTournamentUseCase,
+		getTournamentsUseCase:   getTournamentsUseCase,
+		deleteTournamentUseCase: deleteTournamentUseCase,
+		registerTeamUseCase:     registerTeamUseCase,
+		withdrawTeamUseCase:     withdrawTeamUseCase,
+		seedTeamsUseCase:        seedTeamsUseCase,
+		startTournamentUseCase:  startTournamentUseCase,
+		scheduleMatchUseCase:    scheduleMatchUseCase,
+	}
+}
+
+// GetTournaments обрабатывает GET /api/tournaments
+func (h *TournamentHandler) GetTournaments(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit <= 0 {
+		limit = 20
+	}
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+
+	input := tournament.GetTournamentsInput{
+		Limit:  limit,
+		Offset: offset,
+	}
+	switch status := entities.TournamentStatus(c.Query("status")); status {
+	case entities.TournamentStatusRegistration, entities.TournamentStatusInProgress, entities.TournamentStatusFinished:
+		input.Status = &status
+	}
+
+	result, err := h.getTournamentsUseCase.Execute(input)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToTournamentListResponse(result.Tournaments, result.Total, limit, offset))
+}
+
+// CreateTournament обрабатывает POST /api/tournaments
+func (h *TournamentHandler) CreateTournament(c *gin.Context) {
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req dto.CreateTournamentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	stageRules := make([]entities.TournamentStageRule, len(req.StageRules))
+	for i, rule := range req.StageRules {
+		stageRules[i] = entities.TournamentStageRule{
+			Stage:          entities.TournamentStage(rule.Stage),
+			NoRepeatPicks:  rule.NoRepeatPicks,
+			CarryOverBans:  rule.CarryOverBans,
+			ExcludedMapIDs: rule.ExcludedMapIDs,
+			DeciderMapID:   rule.DeciderMapID,
+		}
+	}
+
+	result, err := h.createTournamentUseCase.Execute(tournament.CreateTournamentInput{
+		OwnerID:       user.ID,
+		Name:          req.Name,
+		GameID:        req.GameID,
+		MapPoolID:     req.MapPoolID,
+		Format:        entities.TournamentFormat(req.Format),
+		MaxTeams:      req.MaxTeams,
+		SwissRounds:   req.SwissRounds,
+		FinalVetoType: entities.VetoType(req.FinalVetoType),
+		StageRules:    stageRules,
+	})
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ToTournamentResponse(result.Tournament, nil))
+}
+
+// GetTournament обрабатывает GET /api/tournaments/:id
+func (h *TournamentHandler) GetTournament(c *gin.Context) {
+	tournamentID, ok := parseTournamentID(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.getTournamentUseCase.Execute(tournamentID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToTournamentResponse(result.Tournament, result.Standings))
+}
+
+// DeleteTournament обрабатывает DELETE /api/tournaments/:id
+func (h *TournamentHandler) DeleteTournament(c *gin.Context) {
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	tournamentID, ok := parseTournamentID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.deleteTournamentUseCase.Execute(tournament.DeleteTournamentInput{
+		TournamentID: tournamentID,
+		UserID:       user.ID,
+	}); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "tournament deleted"})
+}
+
+// RegisterTeam обрабатывает POST /api/tournaments/:id/teams
+func (h *TournamentHandler) RegisterTeam(c *gin.Context) {
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	tournamentID, ok := parseTournamentID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.RegisterTournamentTeamRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.registerTeamUseCase.Execute(tournament.RegisterTeamInput{
+		TournamentID: tournamentID,
+		TeamID:       req.TeamID,
+		UserID:       user.ID,
+	})
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ToTournamentResponse(result.Tournament, nil))
+}
+
+// WithdrawTeam обрабатывает DELETE /api/tournaments/:id/teams/:teamId
+func (h *TournamentHandler) WithdrawTeam(c *gin.Context) {
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	tournamentID, ok := parseTournamentID(c)
+	if !ok {
+		return
+	}
+	teamID, err := strconv.ParseUint(c.Param("teamId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid team id"})
+		return
+	}
+
+	result, err := h.withdrawTeamUseCase.Execute(tournament.WithdrawTeamInput{
+		TournamentID: tournamentID,
+		TeamID:       uint(teamID),
+		UserID:       user.ID,
+	})
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToTournamentResponse(result.Tournament, nil))
+}
+
+// SeedTeams обрабатывает PUT /api/tournaments/:id/seeds
+func (h *TournamentHandler) SeedTeams(c *gin.Context) {
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	tournamentID, ok := parseTournamentID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.SeedTournamentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.seedTeamsUseCase.Execute(tournament.SeedTeamsInput{
+		TournamentID: tournamentID,
+		UserID:       user.ID,
+		TeamIDs:      req.TeamIDs,
+	})
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToTournamentResponse(result.Tournament, nil))
+}
+
+// StartTournament обрабатывает POST /api/tournaments/:id/start
+func (h *TournamentHandler) StartTournament(c *gin.Context) {
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	tournamentID, ok := parseTournamentID(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.startTournamentUseCase.Execute(tournament.StartTournamentInput{
+		TournamentID: tournamentID,
+		UserID:       user.ID,
+	})
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToTournamentResponse(result.Tournament, nil))
+}
+
+// ScheduleMatch обрабатывает PUT /api/tournaments/:id/matches/:matchId
+func (h *TournamentHandler) ScheduleMatch(c *gin.Context) {
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	tournamentID, ok := parseTournamentID(c)
+	if !ok {
+		return
+	}
+	matchID, err := strconv.ParseUint(c.Param("matchId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid match id"})
+		return
+	}
+
+	var req dto.ScheduleTournamentMatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.scheduleMatchUseCase.Execute(tournament.ScheduleMatchInput{
+		TournamentID: tournamentID,
+		MatchID:      uint(matchID),
+		UserID:       user.ID,
+		ScheduledAt:  req.ScheduledAt,
+	})
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToTournamentMatchResponse(result.Match))
+}
+
+func (h *TournamentHandler) handleError(c *gin.Context, err error) {
+	switch err {
+	case tournament.ErrTournamentNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "tournament not found"})
+	case tournament.ErrGameNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "game not found"})
+	case tournament.ErrMapPoolNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "map pool not found"})
+	case tournament.ErrTeamNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "team not found"})
+	case tournament.ErrTeamNotRegistered:
+		c.JSON(http.StatusNotFound, gin.H{"error": "team is not registered"})
+	case tournament.ErrMatchNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "tournament match not found"})
+	case tournament.ErrUnauthorized:
+		c.JSON(http.StatusForbidden, gin.H{"error": "unauthorized"})
+	case tournament.ErrRegistrationClosed:
+		c.JSON(http.StatusConflict, gin.H{"error": "tournament registration is closed"})
+	case tournament.ErrTournamentFull:
+		c.JSON(http.StatusConflict, gin.H{"error": "tournament is full"})
+	case tournament.ErrAlreadyRegistered:
+		c.JSON(http.StatusConflict, gin.H{"error": "team is already registered"})
+	case tournament.ErrNotEnoughTeams:
+		c.JSON(http.StatusConflict, gin.H{"error": "not enough teams to start the tournament"})
+	case tournament.ErrMatchFinished:
+		c.JSON(http.StatusConflict, gin.H{"error": "tournament match is already finished"})
+	case tournament.ErrInvalidTournament:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid tournament"})
+	case tournament.ErrPoolTooSmall:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "map pool is too small for the tournament formats"})
+	case tournament.ErrPoolTooSmallForFinal:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "map pool is too small for the final format, choose a shorter final_veto_type"})
+	case tournament.ErrInvalidSeeds:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "seeds must list every registered team once"})
+	case tournament.ErrInvalidStageRules:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid stage map rules"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+	}
+}
+
+// parseTournamentID разбирает параметр :id
+func parseTournamentID(c *gin.Context) (uint, bool) {
+	tournamentID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid tournament id"})
+		return 0, false
+	}
+	return uint(tournamentID), true
+}
@@ -0,0 +1,398 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/handler/dto"
+	"github.com/bbp/backend/internal/middleware"
+	"github.com/bbp/backend/internal/repository/models"
+	"github.com/bbp/backend/internal/repository/sqlite"
+	"github.com/bbp/backend/internal/usecase/room"
+	"github.com/bbp/backend/internal/usecase/tournament"
+	"github.com/bbp/backend/internal/usecase/veto"
+	"github.com/bbp/backend/pkg/catalog"
+	"github.com/bbp/backend/pkg/database"
+	"github.com/bbp/backend/pkg/jwt"
+	"github.com/bbp/backend/pkg/seed"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTournamentHandler_SingleEliminationFlow(t *testing.T) {
+	db, cleanup := setupVetoTestDB(t)
+	defer cleanup()
+	require.NoError(t, database.Migrate(db,
+		&models.RevokedTokenModel{},
+		&models.TournamentModel{},
+		&models.TournamentTeamModel{},
+		&models.TournamentMatchModel{},
+		&models.TournamentStageRuleModel{},
+		&models.TournamentStageMapModel{},
+	))
+
+	userRepo := sqlite.NewUserRepository(db)
+	gameRepo := sqlite.NewGameRepository(db)
+	mapRepo := sqlite.NewMapRepository(db)
+	mapPoolRepo := sqlite.NewMapPoolRepository(db)
+	roomRepo := sqlite.NewRoomRepository(db)
+	teamRepo := sqlite.NewTeamRepository(db)
+	vetoSessionRepo := sqlite.NewVetoSessionRepository(db)
+	vetoActionRepo := sqlite.NewVetoActionRepository(db)
+	matchResultRepo := sqlite.NewMatchResultRepository(db)
+	tournamentRepo := sqlite.NewTournamentRepository(db)
+	vetoLogicService := veto.NewVetoLogicService()
+
+	jwtService := jwt.NewJWTService("test-secret", time.Hour)
+	jwtService.SetDenylist(sqlite.NewRevokedTokenRepository(db))
+
+	createSessionUseCase := veto.NewCreateSessionUseCase(vetoSessionRepo, mapPoolRepo, gameRepo, sqlite.NewMapRotationRepository(db), teamRepo, vetoLogicService)
+	createMatchRoomUseCase := room.NewCreateMatchRoomUseCase(roomRepo, vetoSessionRepo, createSessionUseCase)
+	stageConstraints := tournament.NewStageConstraintsService(vetoSessionRepo, matchResultRepo, mapPoolRepo, vetoLogicService)
+	handler := NewTournamentHandler(
+		tournament.NewCreateTournamentUseCase(tournamentRepo, gameRepo, mapPoolRepo, vetoLogicService),
+		tournament.NewGetTournamentUseCase(tournamentRepo),
+		tournament.NewGetTournamentsUseCase(tournamentRepo),
+		tournament.NewDeleteTournamentUseCase(tournamentRepo),
+		tournament.NewRegisterTeamUseCase(tournamentRepo, teamRepo),
+		tournament.NewWithdrawTeamUseCase(tournamentRepo, teamRepo),
+		tournament.NewSeedTeamsUseCase(tournamentRepo),
+		tournament.NewStartTournamentUseCase(tournamentRepo, createMatchRoomUseCase, stageConstraints),
+		tournament.NewScheduleMatchUseCase(tournamentRepo),
+	)
+	reportResultUseCase := veto.NewReportResultUseCase(vetoSessionRepo, matchResultRepo, teamRepo)
+	confirmResultUseCase := veto.NewConfirmResultUseCase(vetoSessionRepo, matchResultRepo, teamRepo)
+	advanceBracketUseCase := tournament.NewAdvanceBracketUseCase(tournamentRepo, createMatchRoomUseCase, stageConstraints)
+	reportResultUseCase.SetResultListener(advanceBracketUseCase)
+	confirmResultUseCase.SetResultListener(advanceBracketUseCase)
+	resultHandler := NewMatchResultHandler(reportResultUseCase, confirmResultUseCase, nil, nil)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/api/tournaments/:id", handler.GetTournament)
+	auth := router.Group("/api", middleware.AuthMiddleware(jwtService))
+	auth.POST("/tournaments", handler.CreateTournament)
+	auth.POST("/tournaments/:id/teams", handler.RegisterTeam)
+	auth.DELETE("/tournaments/:id/teams/:teamId", handler.WithdrawTeam)
+	auth.PUT("/tournaments/:id/seeds", handler.SeedTeams)
+	auth.POST("/tournaments/:id/start", handler.StartTournament)
+	auth.PUT("/tournaments/:id/matches/:matchId", handler.ScheduleMatch)
+	auth.POST("/veto/sessions/:id/result", resultHandler.ReportResult)
+	auth.POST("/veto/sessions/:id/result/confirm", resultHandler.ConfirmResult)
+	auth.PUT("/admin/veto/sessions/:id/result", resultHandler.ResolveResult)
+
+	createUser := func(username string) (*entities.User, string) {
+		u := &entities.User{Email: username + "@example.com", Username: username, Password: "hashed"}
+		require.NoError(t, userRepo.Create(u))
+		token, err := jwtService.GenerateToken(u.ID, u.Username)
+		require.NoError(t, err)
+		return u, token
+	}
+	request := func(method, path, token string, payload interface{}) *httptest.ResponseRecorder {
+		var body bytes.Buffer
+		if payload != nil {
+			json.NewEncoder(&body).Encode(payload)
+		}
+		req := httptest.NewRequest(method, path, &body)
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	organizer, organizerToken := createUser("organizer")
+	game := &entities.Game{Name: "Valorant", Slug: "valorant", IsActive: true}
+	require.NoError(t, gameRepo.Create(game))
+	var maps []entities.Map
+	for _, slug := range []string{"bind", "haven", "lotus", "split", "ascent", "icebox", "sunset", "breeze", "fracture", "pearl", "abyss", "district", "kasbah", "corrode", "glitch"} {
+		m := &entities.Map{GameID: game.ID, Name: slug, Slug: slug, IsActive: true}
+		require.NoError(t, mapRepo.Create(m))
+		maps = append(maps, *m)
+	}
+	pool := &entities.MapPool{GameID: game.ID, UserID: &organizer.ID, Name: "Cup pool", Type: entities.MapPoolTypeCustom, Maps: maps}
+	require.NoError(t, mapPoolRepo.Create(pool))
+
+	type club struct {
+		team  *entities.Team
+		token string
+	}
+	var clubs []club
+	for _, name := range []string{"Falcons", "Rivals", "Wolves", "Titans"} {
+		captain, token := createUser("captain_" + name)
+		team := &entities.Team{Name: name, Tag: name[:3]}
+		require.NoError(t, teamRepo.Create(team))
+		require.NoError(t, teamRepo.AddMember(&entities.TeamMember{TeamID: team.ID, UserID: captain.ID, Role: entities.TeamRoleCaptain}))
+		clubs = append(clubs, club{team: team, token: token})
+	}
+
+	// Правила пула задаются для стадий формата турнира и должны оставлять пулу достаточно карт
+	cupRequest := dto.CreateTournamentRequest{
+		Name: "Weekly Cup", GameID: game.ID, MapPoolID: pool.ID, Format: "single_elimination", MaxTeams: 3,
+		StageRules: []dto.TournamentStageRuleRequest{{Stage: "swiss", NoRepeatPicks: true}},
+	}
+	assert.Equal(t, http.StatusBadRequest, request(http.MethodPost, "/api/tournaments", organizerToken, cupRequest).Code)
+	cupRequest.StageRules = []dto.TournamentStageRuleRequest{{Stage: "playoffs", ExcludedMapIDs: []uint{maps[12].ID, maps[13].ID, maps[14].ID}}}
+	assert.Equal(t, http.StatusBadRequest, request(http.MethodPost, "/api/tournaments", organizerToken, cupRequest).Code)
+	cupRequest.StageRules = []dto.TournamentStageRuleRequest{{Stage: "playoffs", NoRepeatPicks: true, CarryOverBans: true, ExcludedMapIDs: []uint{maps[14].ID}}}
+
+	w := request(http.MethodPost, "/api/tournaments", organizerToken, cupRequest)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var cup dto.TournamentResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &cup))
+	assert.Equal(t, "registration", cup.Status)
+	base := fmt.Sprintf("/api/tournaments/%d", cup.ID)
+
+	// Регистрирует только капитан, не больше max_teams команд
+	assert.Equal(t, http.StatusForbidden, request(http.MethodPost, base+"/teams", organizerToken, dto.RegisterTournamentTeamRequest{TeamID: clubs[0].team.ID}).Code)
+	for _, c := range clubs[:3] {
+		require.Equal(t, http.StatusCreated, request(http.MethodPost, base+"/teams", c.token, dto.RegisterTournamentTeamRequest{TeamID: c.team.ID}).Code)
+	}
+	assert.Equal(t, http.StatusConflict, request(http.MethodPost, base+"/teams", clubs[0].token, dto.RegisterTournamentTeamRequest{TeamID: clubs[0].team.ID}).Code)
+	assert.Equal(t, http.StatusConflict, request(http.MethodPost, base+"/teams", clubs[3].token, dto.RegisterTournamentTeamRequest{TeamID: clubs[3].team.ID}).Code)
+
+	// Посев: Wolves первыми и проходят первый раунд без игры
+	falcons, rivals, wolves := clubs[0].team.ID, clubs[1].team.ID, clubs[2].team.ID
+	assert.Equal(t, http.StatusBadRequest, request(http.MethodPut, base+"/seeds", organizerToken, dto.SeedTournamentRequest{TeamIDs: []uint{wolves, falcons}}).Code)
+	assert.Equal(t, http.StatusForbidden, request(http.MethodPut, base+"/seeds", clubs[0].token, dto.SeedTournamentRequest{TeamIDs: []uint{wolves, falcons, rivals}}).Code)
+	require.Equal(t, http.StatusOK, request(http.MethodPut, base+"/seeds", organizerToken, dto.SeedTournamentRequest{TeamIDs: []uint{wolves, falcons, rivals}}).Code)
+
+	w = request(http.MethodPost, base+"/start", organizerToken, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &cup))
+	assert.Equal(t, "in_progress", cup.Status)
+	require.Len(t, cup.Matches, 3)
+	bye, semi, final := cup.Matches[0], cup.Matches[1], cup.Matches[2]
+	assert.True(t, bye.Bye)
+	assert.Equal(t, wolves, *bye.WinnerTeamID)
+	assert.Equal(t, "ready", semi.Status)
+	assert.Equal(t, "bo3", semi.VetoType)
+	require.NotNil(t, semi.RoomID)
+	require.NotNil(t, semi.VetoSessionID)
+	assert.Equal(t, "pending", final.Status)
+	assert.Equal(t, "bo5", final.VetoType)
+	assert.Equal(t, wolves, *final.TeamAID)
+	assert.Nil(t, final.TeamBID)
+
+	// Комната и сессия матча созданы для команд из сетки
+	matchRoom, err := roomRepo.GetByID(*semi.RoomID)
+	require.NoError(t, err)
+	assert.Equal(t, organizer.ID, matchRoom.OwnerID)
+	assert.True(t, matchRoom.HasTeam(falcons) && matchRoom.HasTeam(rivals))
+	assert.Equal(t, *semi.VetoSessionID, *matchRoom.VetoSessionID)
+	session, err := vetoSessionRepo.GetByID(*semi.VetoSessionID)
+	require.NoError(t, err)
+	assert.Equal(t, entities.VetoTypeBo3, session.Type)
+	assert.Equal(t, "Falcons", session.TeamAName)
+	require.NotNil(t, session.Constraints)
+	assert.Equal(t, []uint{maps[14].ID}, session.Constraints.ExcludedMapIDs)
+	assert.Empty(t, session.Constraints.TeamAExcludedMapIDs)
+	assert.Empty(t, session.Constraints.TeamBExcludedMapIDs)
+
+	// Регистрация закрыта
+	assert.Equal(t, http.StatusConflict, request(http.MethodDelete, fmt.Sprintf("%s/teams/%d", base, rivals), organizerToken, nil).Code)
+
+	scheduledAt := time.Date(2026, 10, 24, 18, 0, 0, 0, time.UTC)
+	w = request(http.MethodPut, fmt.Sprintf("%s/matches/%d", base, final.ID), organizerToken, dto.ScheduleTournamentMatchRequest{ScheduledAt: &scheduledAt})
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "2026-10-24T18:00:00Z")
+	assert.Equal(t, http.StatusConflict, request(http.MethodPut, fmt.Sprintf("%s/matches/%d", base, bye.ID), organizerToken, dto.ScheduleTournamentMatchRequest{}).Code)
+
+	// playSeries завершает вето сессии баном, пиками и десайдером, затем капитаны сообщают и подтверждают счет
+	seriesReport := func(scores [][2]int) dto.ReportMatchResultRequest {
+		report := dto.ReportMatchResultRequest{}
+		for i, score := range scores {
+			report.Maps = append(report.Maps, dto.MapScoreRequest{MapID: maps[i].ID, ScoreA: score[0], ScoreB: score[1]})
+		}
+		return report
+	}
+	playSeries := func(sessionID uint, mapCount int, reporter, confirmer string, scores [][2]int) {
+		session, err := vetoSessionRepo.GetByID(sessionID)
+		require.NoError(t, err)
+		require.NoError(t, vetoActionRepo.Create(&entities.VetoAction{VetoSessionID: sessionID, MapID: maps[mapCount].ID, Team: "A", ActionType: entities.VetoActionTypeBan, StepNumber: 1}))
+		for i := 0; i < mapCount-1; i++ {
+			require.NoError(t, vetoActionRepo.Create(&entities.VetoAction{VetoSessionID: sessionID, MapID: maps[i].ID, Team: "A", ActionType: entities.VetoActionTypePick, StepNumber: i + 2}))
+		}
+		decider := maps[mapCount-1].ID
+		session.SelectedMapID = &decider
+		session.Status = entities.VetoStatusFinished
+		require.NoError(t, vetoSessionRepo.Update(session))
+
+		path := fmt.Sprintf("/api/veto/sessions/%d/result", sessionID)
+		require.Equal(t, http.StatusOK, request(http.MethodPost, path, reporter, seriesReport(scores)).Code)
+		require.Equal(t, http.StatusOK, request(http.MethodPost, path+"/confirm", confirmer, nil).Code)
+	}
+
+	// Rivals выигрывают полуфинал 2-1 и выходят в финал Bo5
+	playSeries(*semi.VetoSessionID, 3, clubs[0].token, clubs[1].token, [][2]int{{13, 7}, {9, 13}, {11, 13}})
+	w = request(http.MethodGet, base, "", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &cup))
+	semi, final = cup.Matches[1], cup.Matches[2]
+	assert.Equal(t, "finished", semi.Status)
+	assert.Equal(t, rivals, *semi.WinnerTeamID)
+	assert.Equal(t, "ready", final.Status)
+	assert.Equal(t, rivals, *final.TeamBID)
+	require.NotNil(t, final.VetoSessionID)
+	require.NotNil(t, final.ScheduledAt)
+
+	// Rivals не могут пикнуть сыгранные в полуфинале карты, бан полуфинала переносится в финал
+	session, err = vetoSessionRepo.GetByID(*final.VetoSessionID)
+	require.NoError(t, err)
+	require.NotNil(t, session.Constraints)
+	assert.Equal(t, []uint{maps[14].ID, maps[3].ID}, session.Constraints.ExcludedMapIDs)
+	assert.Empty(t, session.Constraints.TeamAExcludedMapIDs)
+	assert.Equal(t, []uint{maps[0].ID, maps[1].ID, maps[2].ID}, session.Constraints.TeamBExcludedMapIDs)
+
+	// Победителя полуфинала нельзя поменять: финал с ним уже создан
+	resolvePath := fmt.Sprintf("/api/admin/veto/sessions/%d/result", *semi.VetoSessionID)
+	assert.Equal(t, http.StatusConflict, request(http.MethodPut, resolvePath, organizerToken, seriesReport([][2]int{{13, 7}, {13, 9}})).Code)
+	assert.Equal(t, http.StatusOK, request(http.MethodPut, resolvePath, organizerToken, seriesReport([][2]int{{13, 7}, {9, 13}, {10, 13}})).Code)
+
+	// Wolves выигрывают финал 3-0 и турнир
+	playSeries(*final.VetoSessionID, 5, clubs[1].token, clubs[2].token, [][2]int{{13, 3}, {13, 5}, {13, 11}})
+	w = request(http.MethodGet, base, "", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &cup))
+	assert.Equal(t, "finished", cup.Status)
+	require.NotNil(t, cup.WinnerTeamID)
+	assert.Equal(t, wolves, *cup.WinnerTeamID)
+}
+
+// Соревновательный пул каталога (7 карт) не выдерживает Bo5, поэтому кубок на нем играется с финалом Bo3
+func TestTournamentHandler_CatalogPool(t *testing.T) {
+	db, cleanup := setupVetoTestDB(t)
+	defer cleanup()
+	require.NoError(t, database.Migrate(db,
+		&models.RevokedTokenModel{},
+		&models.TournamentModel{},
+		&models.TournamentTeamModel{},
+		&models.TournamentMatchModel{},
+		&models.TournamentStageRuleModel{},
+		&models.TournamentStageMapModel{},
+	))
+
+	userRepo := sqlite.NewUserRepository(db)
+	gameRepo := sqlite.NewGameRepository(db)
+	mapPoolRepo := sqlite.NewMapPoolRepository(db)
+	rotationRepo := sqlite.NewMapRotationRepository(db)
+	roomRepo := sqlite.NewRoomRepository(db)
+	teamRepo := sqlite.NewTeamRepository(db)
+	vetoSessionRepo := sqlite.NewVetoSessionRepository(db)
+	tournamentRepo := sqlite.NewTournamentRepository(db)
+	vetoLogicService := veto.NewVetoLogicService()
+
+	cat, err := catalog.LoadDefault()
+	require.NoError(t, err)
+	_, err = seed.Import(cat, seed.Repositories{Games: gameRepo, Maps: sqlite.NewMapRepository(db), MapPools: mapPoolRepo, Rotations: rotationRepo}, seed.Options{})
+	require.NoError(t, err)
+	game, err := gameRepo.GetBySlug("cs2")
+	require.NoError(t, err)
+	require.NotNil(t, game)
+	systemPools, err := mapPoolRepo.GetSystemPools(game.ID)
+	require.NoError(t, err)
+	var pool *entities.MapPool
+	for i := range systemPools {
+		if systemPools[i].Type == entities.MapPoolTypeCompetitive {
+			pool = &systemPools[i]
+		}
+	}
+	require.NotNil(t, pool)
+	require.Len(t, pool.Maps, 7)
+
+	jwtService := jwt.NewJWTService("test-secret", time.Hour)
+	jwtService.SetDenylist(sqlite.NewRevokedTokenRepository(db))
+	createSessionUseCase := veto.NewCreateSessionUseCase(vetoSessionRepo, mapPoolRepo, gameRepo, rotationRepo, teamRepo, vetoLogicService)
+	createMatchRoomUseCase := room.NewCreateMatchRoomUseCase(roomRepo, vetoSessionRepo, createSessionUseCase)
+	stageConstraints := tournament.NewStageConstraintsService(vetoSessionRepo, sqlite.NewMatchResultRepository(db), mapPoolRepo, vetoLogicService)
+	handler := NewTournamentHandler(
+		tournament.NewCreateTournamentUseCase(tournamentRepo, gameRepo, mapPoolRepo, vetoLogicService),
+		tournament.NewGetTournamentUseCase(tournamentRepo),
+		tournament.NewGetTournamentsUseCase(tournamentRepo),
+		tournament.NewDeleteTournamentUseCase(tournamentRepo),
+		tournament.NewRegisterTeamUseCase(tournamentRepo, teamRepo),
+		tournament.NewWithdrawTeamUseCase(tournamentRepo, teamRepo),
+		tournament.NewSeedTeamsUseCase(tournamentRepo),
+		tournament.NewStartTournamentUseCase(tournamentRepo, createMatchRoomUseCase, stageConstraints),
+		tournament.NewScheduleMatchUseCase(tournamentRepo),
+	)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	auth := router.Group("/api", middleware.AuthMiddleware(jwtService))
+	auth.POST("/tournaments", handler.CreateTournament)
+	auth.POST("/tournaments/:id/teams", handler.RegisterTeam)
+	auth.POST("/tournaments/:id/start", handler.StartTournament)
+
+	createUser := func(username string) (*entities.User, string) {
+		u := &entities.User{Email: username + "@example.com", Username: username, Password: "hashed"}
+		require.NoError(t, userRepo.Create(u))
+		token, err := jwtService.GenerateToken(u.ID, u.Username)
+		require.NoError(t, err)
+		return u, token
+	}
+	request := func(method, path, token string, payload interface{}) *httptest.ResponseRecorder {
+		var body bytes.Buffer
+		if payload != nil {
+			json.NewEncoder(&body).Encode(payload)
+		}
+		req := httptest.NewRequest(method, path, &body)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	_, organizerToken := createUser("organizer")
+
+	// Финал по умолчанию Bo5 - пулу не хватает карт, и ошибка подсказывает, что поменять
+	cupRequest := dto.CreateTournamentRequest{Name: "Active Duty Cup", GameID: game.ID, MapPoolID: pool.ID, Format: "single_elimination", MaxTeams: 4}
+	w := request(http.MethodPost, "/api/tournaments", organizerToken, cupRequest)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "final_veto_type")
+
+	cupRequest.FinalVetoType = "bo1"
+	assert.Equal(t, http.StatusBadRequest, request(http.MethodPost, "/api/tournaments", organizerToken, cupRequest).Code)
+
+	// Правила стадии проверяются под формат финала турнира
+	cupRequest.FinalVetoType = "bo3"
+	cupRequest.StageRules = []dto.TournamentStageRuleRequest{{Stage: "playoffs", DeciderMapID: &pool.Maps[0].ID}}
+	w = request(http.MethodPost, "/api/tournaments", organizerToken, cupRequest)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var cup dto.TournamentResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &cup))
+	assert.Equal(t, "bo3", cup.FinalVetoType)
+	base := fmt.Sprintf("/api/tournaments/%d", cup.ID)
+
+	for _, name := range []string{"Falcons", "Rivals"} {
+		captain, token := createUser("captain_" + name)
+		team := &entities.Team{Name: name, Tag: name[:3]}
+		require.NoError(t, teamRepo.Create(team))
+		require.NoError(t, teamRepo.AddMember(&entities.TeamMember{TeamID: team.ID, UserID: captain.ID, Role: entities.TeamRoleCaptain}))
+		require.Equal(t, http.StatusCreated, request(http.MethodPost, base+"/teams", token, dto.RegisterTournamentTeamRequest{TeamID: team.ID}).Code)
+	}
+
+	// Единственный матч кубка из двух команд - финал, его сессия создается на пуле каталога
+	w = request(http.MethodPost, base+"/start", organizerToken, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &cup))
+	require.Len(t, cup.Matches, 1)
+	final := cup.Matches[0]
+	assert.Equal(t, "ready", final.Status)
+	assert.Equal(t, "bo3", final.VetoType)
+	require.NotNil(t, final.VetoSessionID)
+	session, err := vetoSessionRepo.GetByID(*final.VetoSessionID)
+	require.NoError(t, err)
+	assert.Equal(t, entities.VetoTypeBo3, session.Type)
+	assert.Equal(t, pool.ID, session.MapPoolID)
+}
@@ -0,0 +1,193 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/bbp/backend/internal/handler/dto"
+	"github.com/bbp/backend/internal/middleware"
+	"github.com/bbp/backend/internal/usecase/auth"
+	"github.com/gin-gonic/gin"
+)
+
+type TwoFactorHandler struct {
+	statusUseCase          *auth.GetTwoFactorStatusUseCase
+	setupUseCase           *auth.SetupTwoFactorUseCase
+	confirmUseCase         *auth.ConfirmTwoFactorUseCase
+	disableUseCase         *auth.DisableTwoFactorUseCase
+	verifyChallengeUseCase *auth.VerifyLoginChallengeUseCase
+}
+
+func NewTwoFactorHandler(
+	statusUseCase *auth.GetTwoFactorStatusUseCase,
+	setupUseCase *auth.SetupTwoFactorUseCase,
+	confirmUseCase *auth.ConfirmTwoFactorUseCase,
+	disableUseCase *auth.DisableTwoFactorUseCase,
+	verifyChallengeUseCase *auth.VerifyLoginChallengeUseCase,
+) *TwoFactorHandler {
+	return &TwoFactorHandler{
+		statusUseCase:          statusUseCase,
+		setupUseCase:           setupUseCase,
+		confirmUseCase:         confirmUseCase,
+		disableUseCase:         disableUseCase,
+		verifyChallengeUseCase: verifyChallengeUseCase,
+	}
+}
+
+// GetStatus обрабатывает GET /api/auth/2fa
+func (h *TwoFactorHandler) GetStatus(c *gin.Context) {
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	result, err := h.statusUseCase.Execute(user.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	response := dto.TwoFactorStatusResponse{
+		Enabled:                result.Enabled,
+		RecoveryCodesRemaining: result.RecoveryCodesRemaining,
+	}
+	if result.EnabledAt != nil {
+		enabledAt := result.EnabledAt.Format(time.RFC3339)
+		response.EnabledAt = &enabledAt
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// Setup обрабатывает POST /api/auth/2fa/setup
+func (h *TwoFactorHandler) Setup(c *gin.Context) {
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	result, err := h.setupUseCase.Execute(user.ID)
+	if err != nil {
+		switch err {
+		case auth.ErrUserNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		case auth.ErrTwoFactorAlreadyEnabled:
+			c.JSON(http.StatusConflict, gin.H{"error": "two-factor authentication already enabled"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.TwoFactorSetupResponse{
+		Secret:     result.Secret,
+		OtpauthURI: result.URI,
+	})
+}
+
+// Confirm обрабатывает POST /api/auth/2fa/confirm
+// Коды восстановления возвращаются только в этом ответе
+func (h *TwoFactorHandler) Confirm(c *gin.Context) {
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req dto.TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.confirmUseCase.Execute(auth.ConfirmTwoFactorInput{
+		UserID: user.ID,
+		Code:   req.Code,
+	})
+	if err != nil {
+		switch err {
+		case auth.ErrTwoFactorSetupRequired:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "two-factor setup has not been started"})
+		case auth.ErrTwoFactorAlreadyEnabled:
+			c.JSON(http.StatusConflict, gin.H{"error": "two-factor authentication already enabled"})
+		case auth.ErrInvalidTwoFactorCode:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid two-factor code"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"recovery_codes": result.RecoveryCodes})
+}
+
+// Disable обрабатывает POST /api/auth/2fa/disable
+func (h *TwoFactorHandler) Disable(c *gin.Context) {
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req dto.DisableTwoFactorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err = h.disableUseCase.Execute(auth.DisableTwoFactorInput{
+		UserID:   user.ID,
+		Password: req.Password,
+		Code:     req.Code,
+	})
+	if err != nil {
+		switch err {
+		case auth.ErrUserNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		case auth.ErrTwoFactorNotEnabled:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "two-factor authentication is not enabled"})
+		case auth.ErrInvalidPassword:
+			c.JSON(http.StatusForbidden, gin.H{"error": "invalid password"})
+		case auth.ErrInvalidTwoFactorCode:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid two-factor code"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "two-factor authentication disabled"})
+}
+
+// VerifyLogin обрабатывает POST /api/auth/login/2fa
+func (h *TwoFactorHandler) VerifyLogin(c *gin.Context) {
+	var req dto.LoginTwoFactorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.verifyChallengeUseCase.Execute(auth.VerifyLoginChallengeInput{
+		ChallengeToken: req.ChallengeToken,
+		Code:           req.Code,
+		IP:             c.ClientIP(),
+	})
+	if err != nil {
+		switch err {
+		case auth.ErrInvalidLoginChallenge:
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid or expired login challenge"})
+		case auth.ErrInvalidTwoFactorCode:
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid two-factor code"})
+		case auth.ErrTooManyLoginAttempts:
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": "too many login attempts, try again later"})
+		case auth.ErrUserNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, toAuthResponse(result.Tokens, dto.ToUserResponse(result.User)))
+}
@@ -1,7 +1,9 @@
 package http
 
 import (
+	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/bbp/backend/internal/handler/dto"
 	"github.com/bbp/backend/internal/middleware"
@@ -10,10 +12,14 @@ import (
 )
 
 type UserHandler struct {
-	getProfileUseCase    *user.GetProfileUseCase
-	updateProfileUseCase *user.UpdateProfileUseCase
-	getSessionsUseCase   *user.GetSessionsUseCase
-	getRoomsUseCase      *user.GetRoomsUseCase
+	getProfileUseCase       *user.GetProfileUseCase
+	updateProfileUseCase    *user.UpdateProfileUseCase
+	getSessionsUseCase      *user.GetSessionsUseCase
+	getRoomsUseCase         *user.GetRoomsUseCase
+	changePasswordUseCase   *user.ChangePasswordUseCase
+	deleteAccountUseCase    *user.DeleteAccountUseCase
+	exportDataUseCase       *user.ExportDataUseCase
+	getPublicProfileUseCase *user.GetPublicProfileUseCase
 }
 
 func NewUserHandler(
@@ -21,12 +27,20 @@ func NewUserHandler(
 	updateProfileUseCase *user.UpdateProfileUseCase,
 	getSessionsUseCase *user.GetSessionsUseCase,
 	getRoomsUseCase *user.GetRoomsUseCase,
+	changePasswordUseCase *user.ChangePasswordUseCase,
+	deleteAccountUseCase *user.DeleteAccountUseCase,
+	exportDataUseCase *user.ExportDataUseCase,
+	getPublicProfileUseCase *user.GetPublicProfileUseCase,
 ) *UserHandler {
 	return &UserHandler{
-		getProfileUseCase:    getProfileUseCase,
-		updateProfileUseCase: updateProfileUseCase,
-		getSessionsUseCase:   getSessionsUseCase,
-		getRoomsUseCase:      getRoomsUseCase,
+		getProfileUseCase:       getProfileUseCase,
+		updateProfileUseCase:    updateProfileUseCase,
+		getSessionsUseCase:      getSessionsUseCase,
+		getRoomsUseCase:         getRoomsUseCase,
+		changePasswordUseCase:   changePasswordUseCase,
+		deleteAccountUseCase:    deleteAccountUseCase,
+		exportDataUseCase:       exportDataUseCase,
+		getPublicProfileUseCase: getPublicProfileUseCase,
 	}
 }
 
@@ -49,12 +63,7 @@ func (h *UserHandler) GetProfile(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.UserResponse{
-		ID:        result.User.ID,
-		Email:     result.User.Email,
-		Username:  result.User.Username,
-		CreatedAt: result.User.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
-	})
+	c.JSON(http.StatusOK, dto.ToUserResponse(result.User))
 }
 
 // UpdateProfile обрабатывает PUT /api/users/profile
@@ -72,14 +81,18 @@ func (h *UserHandler) UpdateProfile(c *gin.Context) {
 	}
 
 	result, err := h.updateProfileUseCase.Execute(user.UpdateProfileInput{
-		UserID:   userCtx.ID,
-		Email:    req.Email,
-		Username: req.Username,
+		UserID:            userCtx.ID,
+		Email:             req.Email,
+		Username:          req.Username,
+		ProfileVisibility: req.ProfileVisibility,
+		CurrentPassword:   req.CurrentPassword,
 	})
 	if err != nil {
 		switch err {
 		case user.ErrUserNotFound:
 			c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		case user.ErrInvalidPassword:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "current password is incorrect"})
 		case user.ErrEmailAlreadyExists:
 			c.JSON(http.StatusConflict, gin.H{"error": "email already exists"})
 		case user.ErrUsernameAlreadyExists:
@@ -90,12 +103,31 @@ func (h *UserHandler) UpdateProfile(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.UserResponse{
-		ID:        result.User.ID,
-		Email:     result.User.Email,
-		Username:  result.User.Username,
-		CreatedAt: result.User.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
-	})
+	c.JSON(http.StatusOK, dto.ToUserResponse(result.User))
+}
+
+// GetPublicProfile обрабатывает GET /api/profiles/:username
+// Авторизация необязательна: владелец видит статистику даже в скрытом профиле
+func (h *UserHandler) GetPublicProfile(c *gin.Context) {
+	input := user.GetPublicProfileInput{
+		Username: c.Param("username"),
+	}
+	if userCtx, err := middleware.GetUserFromContext(c); err == nil {
+		input.ViewerID = &userCtx.ID
+	}
+
+	result, err := h.getPublicProfileUseCase.Execute(input)
+	if err != nil {
+		switch err {
+		case user.ErrUserNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToPublicProfileResponse(result.User, result.Stats))
 }
 
 // GetSessions обрабатывает GET /api/users/sessions
@@ -134,4 +166,122 @@ func (h *UserHandler) GetRooms(c *gin.Context) {
 	c.JSON(http.StatusOK, dto.UserRoomsResponse{
 		Rooms: dto.ToRoomResponseList(result.Rooms),
 	})
-}
\ No newline at end of file
+}
+
+// ChangePassword обрабатывает PUT /api/users/password
+func (h *UserHandler) ChangePassword(c *gin.Context) {
+	userCtx, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req dto.ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err = h.changePasswordUseCase.Execute(user.ChangePasswordInput{
+		UserID:          userCtx.ID,
+		CurrentPassword: req.CurrentPassword,
+		NewPassword:     req.NewPassword,
+	})
+	if err != nil {
+		switch err {
+		case user.ErrUserNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		case user.ErrInvalidPassword:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "current password is incorrect"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// DeleteAccount обрабатывает DELETE /api/users/me
+func (h *UserHandler) DeleteAccount(c *gin.Context) {
+	userCtx, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	// Тело запроса необязательно для гостей
+	var req dto.DeleteAccountRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	input := user.DeleteAccountInput{
+		UserID:   userCtx.ID,
+		Password: req.Password,
+	}
+	if claims, ok := middleware.GetTokenClaimsFromContext(c); ok {
+		input.AccessJTI = claims.ID
+		if claims.ExpiresAt != nil {
+			input.AccessExpiresAt = claims.ExpiresAt.Time
+		}
+	}
+
+	if err := h.deleteAccountUseCase.Execute(input); err != nil {
+		switch err {
+		case user.ErrUserNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		case user.ErrInvalidPassword:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "current password is incorrect"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ExportData обрабатывает GET /api/users/me/export
+// Отдает JSON архив профиля, сессий, комнат и пулов карт
+func (h *UserHandler) ExportData(c *gin.Context) {
+	userCtx, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	result, err := h.exportDataUseCase.Execute(userCtx.ID)
+	if err != nil {
+		switch err {
+		case user.ErrUserNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		}
+		return
+	}
+
+	identities := make([]dto.UserIdentityResponse, len(result.Identities))
+	for i, identity := range result.Identities {
+		identities[i] = dto.UserIdentityResponse{
+			Provider: identity.Provider,
+			Email:    identity.Email,
+			LinkedAt: identity.CreatedAt.Format(time.RFC3339),
+		}
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="mapban-export-%d.json"`, result.User.ID))
+	c.JSON(http.StatusOK, dto.UserDataExportResponse{
+		ExportedAt: result.ExportedAt.Format(time.RFC3339),
+		Profile:    dto.ToUserResponse(result.User),
+		Identities: identities,
+		Sessions:   dto.ToVetoSessionResponseList(result.Sessions),
+		Rooms:      dto.ToRoomResponseList(result.Rooms),
+		MapPools:   dto.ToMapPoolResponseList(result.MapPools),
+		Teams:      dto.ToTeamResponseList(result.Teams),
+	})
+}
@@ -0,0 +1,442 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+	"github.com/bbp/backend/internal/handler/dto"
+	"github.com/bbp/backend/internal/middleware"
+	"github.com/bbp/backend/internal/repository/models"
+	"github.com/bbp/backend/internal/repository/sqlite"
+	"github.com/bbp/backend/internal/usecase/auth"
+	"github.com/bbp/backend/internal/usecase/user"
+	"github.com/bbp/backend/pkg/database"
+	"github.com/bbp/backend/pkg/jwt"
+	"github.com/bbp/backend/pkg/password"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// failingRoomRepository отказывает в первом Update, имитируя сбой посреди удаления аккаунта
+type failingRoomRepository struct {
+	repositories.RoomRepository
+	failUpdate bool
+}
+
+func (r *failingRoomRepository) Update(room *entities.Room) error {
+	if r.failUpdate {
+		r.failUpdate = false
+		return errors.New("update failed")
+	}
+	return r.RoomRepository.Update(room)
+}
+
+// migrateAccountTables создает таблицы, которые затрагивает удаление аккаунта
+func migrateAccountTables(t *testing.T, db *gorm.DB) {
+	require.NoError(t, database.Migrate(db,
+		&models.UserModel{},
+		&models.MapModel{},
+		&models.MapPoolModel{},
+		&models.VetoSessionModel{},
+		&models.VetoActionModel{},
+		&models.VetoSessionMapModel{},
+		&models.VetoSessionConstraintModel{},
+		&models.RoomModel{},
+		&models.RoomParticipantModel{},
+		&models.RoomMatchModel{},
+		&models.RefreshTokenModel{},
+		&models.RevokedTokenModel{},
+		&models.PasswordResetTokenModel{},
+		&models.EmailVerificationTokenModel{},
+		&models.UserIdentityModel{},
+		&models.UserTOTPModel{},
+		&models.RecoveryCodeModel{},
+		&models.LoginChallengeModel{},
+		&models.LoginThrottleModel{},
+		&models.TeamModel{},
+		&models.TeamMemberModel{},
+		&models.MatchResultModel{},
+		&models.MapResultModel{},
+		&models.MatchResultPlayerModel{},
+		&models.TeamInviteModel{},
+		&models.WebhookModel{},
+		&models.WebhookEventModel{},
+		&models.WebhookDeliveryModel{},
+		&models.APIKeyModel{},
+		&models.APIKeyScopeModel{},
+		&models.APIKeyRoomModel{},
+	))
+}
+
+func TestUserHandler_ExportAndDeleteAccount(t *testing.T) {
+	db, err := database.NewDatabase(":memory:")
+	require.NoError(t, err)
+	defer database.Close(db)
+	migrateAccountTables(t, db)
+
+	userRepo := sqlite.NewUserRepository(db)
+	roomRepo := sqlite.NewRoomRepository(db)
+	mapPoolRepo := sqlite.NewMapPoolRepository(db)
+	vetoSessionRepo := sqlite.NewVetoSessionRepository(db)
+	teamRepo := sqlite.NewTeamRepository(db)
+	refreshTokenRepo := sqlite.NewRefreshTokenRepository(db)
+	revokedTokenRepo := sqlite.NewRevokedTokenRepository(db)
+
+	jwtService := jwt.NewJWTService("test-secret", time.Hour)
+	jwtService.SetDenylist(revokedTokenRepo)
+	tokenIssuer := auth.NewTokenIssuer(jwtService, refreshTokenRepo, 24*time.Hour)
+
+	userHandler := NewUserHandler(
+		user.NewGetProfileUseCase(userRepo),
+		nil,
+		nil,
+		nil,
+		nil,
+		user.NewDeleteAccountUseCase(userRepo, &failingRoomRepository{RoomRepository: roomRepo, failUpdate: true}, mapPoolRepo, vetoSessionRepo, teamRepo, auth.NewLogoutAllUseCase(refreshTokenRepo, revokedTokenRepo)),
+		user.NewExportDataUseCase(userRepo, vetoSessionRepo, roomRepo, mapPoolRepo, sqlite.NewUserIdentityRepository(db), teamRepo),
+		nil,
+	)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	users := router.Group("/api/users")
+	users.Use(middleware.AuthMiddleware(jwtService))
+	{
+		users.GET("/profile", userHandler.GetProfile)
+		users.DELETE("/me", userHandler.DeleteAccount)
+		users.GET("/me/export", userHandler.ExportData)
+	}
+
+	createUser := func(email, username string) (*entities.User, string) {
+		hashed, err := password.HashPassword("password123")
+		require.NoError(t, err)
+		u := &entities.User{Email: email, Username: username, Password: hashed}
+		require.NoError(t, userRepo.Create(u))
+		tokens, err := tokenIssuer.Issue(u)
+		require.NoError(t, err)
+		return u, tokens.AccessToken
+	}
+	request := func(method, path, token string, payload interface{}) *httptest.ResponseRecorder {
+		var body bytes.Buffer
+		if payload != nil {
+			json.NewEncoder(&body).Encode(payload)
+		}
+		req := httptest.NewRequest(method, path, &body)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	owner, ownerToken := createUser("owner@example.com", "owner")
+	first, _ := createUser("first@example.com", "first")
+	second, _ := createUser("second@example.com", "second")
+
+	// Комната владельца с двумя участниками
+	room := &entities.Room{OwnerID: owner.ID, Name: "Room", Code: "ABC123", Type: entities.RoomTypePublic, Status: entities.RoomStatusWaiting, GameID: 1, MaxParticipants: 10}
+	require.NoError(t, roomRepo.Create(room))
+	joinedAt := time.Now().Add(-time.Hour)
+	require.NoError(t, roomRepo.AddParticipant(&entities.RoomParticipant{RoomID: room.ID, UserID: owner.ID, Role: entities.ParticipantRoleOwner, JoinedAt: joinedAt}))
+	require.NoError(t, roomRepo.AddParticipant(&entities.RoomParticipant{RoomID: room.ID, UserID: first.ID, Role: entities.ParticipantRoleMember, JoinedAt: joinedAt.Add(time.Minute)}))
+	require.NoError(t, roomRepo.AddParticipant(&entities.RoomParticipant{RoomID: room.ID, UserID: second.ID, Role: entities.ParticipantRoleMember, JoinedAt: joinedAt.Add(2 * time.Minute)}))
+
+	pool := &entities.MapPool{GameID: 1, UserID: &owner.ID, Name: "My pool", Type: entities.MapPoolTypeCustom}
+	require.NoError(t, mapPoolRepo.Create(pool))
+
+	session := &entities.VetoSession{UserID: &owner.ID, GameID: 1, MapPoolID: pool.ID, Type: entities.VetoTypeBo1, Status: entities.VetoStatusFinished, TeamAName: "A", TeamBName: "B", CurrentTeam: "A", ShareToken: "share-token"}
+	require.NoError(t, vetoSessionRepo.Create(session))
+
+	// Подписка, API ключ и участие в результате матча удаляются вместе с аккаунтом
+	hook := &entities.Webhook{UserID: owner.ID, URL: "https://example.com/hook", Secret: "secret", Events: []entities.WebhookEvent{entities.WebhookEventVetoStarted}, IsActive: true}
+	require.NoError(t, sqlite.NewWebhookRepository(db).Create(hook))
+	require.NoError(t, sqlite.NewWebhookDeliveryRepository(db).Create(&entities.WebhookDelivery{WebhookID: hook.ID, Event: entities.WebhookEventPing, Payload: "{}", Status: entities.WebhookDeliveryStatusPending}))
+	require.NoError(t, sqlite.NewAPIKeyRepository(db).Create(&entities.APIKey{UserID: owner.ID, Name: "bot", Prefix: "bbp_test", KeyHash: "hash", Scopes: []entities.APIKeyScope{entities.APIKeyScopeVeto}, RoomIDs: []uint{room.ID}}))
+	require.NoError(t, db.Create(&models.MatchResultPlayerModel{MatchResultID: 1, UserID: owner.ID, Team: "A"}).Error)
+
+	exportW := request(http.MethodGet, "/api/users/me/export", ownerToken, nil)
+	assert.Equal(t, http.StatusOK, exportW.Code)
+	assert.Contains(t, exportW.Header().Get("Content-Disposition"), "attachment")
+
+	var export dto.UserDataExportResponse
+	require.NoError(t, json.Unmarshal(exportW.Body.Bytes(), &export))
+	assert.Equal(t, "owner@example.com", export.Profile.Email)
+	assert.Len(t, export.Sessions, 1)
+	assert.Len(t, export.Rooms, 1)
+	assert.Len(t, export.MapPools, 1)
+
+	// Удаление требует пароль
+	assert.Equal(t, http.StatusBadRequest, request(http.MethodDelete, "/api/users/me", ownerToken, dto.DeleteAccountRequest{Password: "wrong"}).Code)
+
+	// Сбой при передаче комнаты: аккаунт и токен остаются, владелец все еще в комнате, и запрос можно повторить
+	assert.Equal(t, http.StatusInternalServerError, request(http.MethodDelete, "/api/users/me", ownerToken, dto.DeleteAccountRequest{Password: "password123"}).Code)
+	assert.Equal(t, http.StatusOK, request(http.MethodGet, "/api/users/profile", ownerToken, nil).Code)
+	interrupted, err := roomRepo.GetByID(room.ID)
+	require.NoError(t, err)
+	assert.Equal(t, owner.ID, interrupted.OwnerID)
+	assert.Len(t, interrupted.Participants, 3)
+
+	assert.Equal(t, http.StatusNoContent, request(http.MethodDelete, "/api/users/me", ownerToken, dto.DeleteAccountRequest{Password: "password123"}).Code)
+
+	// Токен удаленного пользователя отозван
+	assert.Equal(t, http.StatusUnauthorized, request(http.MethodGet, "/api/users/profile", ownerToken, nil).Code)
+
+	deleted, err := userRepo.GetByID(owner.ID)
+	require.NoError(t, err)
+	assert.Nil(t, deleted)
+
+	// Комната передана участнику, вошедшему первым
+	transferred, err := roomRepo.GetByID(room.ID)
+	require.NoError(t, err)
+	require.NotNil(t, transferred)
+	assert.Equal(t, first.ID, transferred.OwnerID)
+	assert.Len(t, transferred.Participants, 2)
+	newOwner, err := roomRepo.GetParticipant(room.ID, first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entities.ParticipantRoleOwner, newOwner.Role)
+
+	// Пул удален, сессия осталась без привязки к пользователю
+	deletedPool, err := mapPoolRepo.GetByID(pool.ID)
+	require.NoError(t, err)
+	assert.Nil(t, deletedPool)
+
+	anonymized, err := vetoSessionRepo.GetByID(session.ID)
+	require.NoError(t, err)
+	require.NotNil(t, anonymized)
+	assert.Nil(t, anonymized.UserID)
+
+	for _, model := range []interface{}{
+		&models.WebhookModel{}, &models.WebhookEventModel{}, &models.WebhookDeliveryModel{},
+		&models.APIKeyModel{}, &models.APIKeyScopeModel{}, &models.APIKeyRoomModel{},
+		&models.MatchResultPlayerModel{},
+	} {
+		var count int64
+		require.NoError(t, db.Model(model).Count(&count).Error)
+		assert.Zero(t, count, "%T", model)
+	}
+
+	// Email освободился для новой регистрации
+	createUser("owner@example.com", "owner")
+}
+
+func TestUserHandler_PublicProfile(t *testing.T) {
+	db, err := database.NewDatabase(":memory:")
+	require.NoError(t, err)
+	defer database.Close(db)
+	require.NoError(t, database.Migrate(db,
+		&models.UserModel{},
+		&models.MapModel{},
+		&models.VetoSessionModel{},
+		&models.VetoActionModel{},
+		&models.RoomModel{},
+		&models.RoomParticipantModel{},
+		&models.RoomMatchModel{},
+		&models.RevokedTokenModel{},
+		&models.MatchResultModel{},
+		&models.MatchResultPlayerModel{},
+		&models.TeamModel{},
+		&models.TeamMemberModel{},
+	))
+
+	userRepo := sqlite.NewUserRepository(db)
+	mapRepo := sqlite.NewMapRepository(db)
+	teamRepo := sqlite.NewTeamRepository(db)
+	vetoSessionRepo := sqlite.NewVetoSessionRepository(db)
+	vetoActionRepo := sqlite.NewVetoActionRepository(db)
+
+	jwtService := jwt.NewJWTService("test-secret", time.Hour)
+	jwtService.SetDenylist(sqlite.NewRevokedTokenRepository(db))
+
+	userHandler := NewUserHandler(
+		user.NewGetProfileUseCase(userRepo),
+		user.NewUpdateProfileUseCase(userRepo, sqlite.NewPasswordResetTokenRepository(db), nil),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		user.NewGetPublicProfileUseCase(userRepo, sqlite.NewVetoStatsRepository(db)),
+	)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	users := router.Group("/api/users")
+	users.Use(middleware.AuthMiddleware(jwtService))
+	{
+		users.PUT("/profile", userHandler.UpdateProfile)
+	}
+	router.GET("/api/profiles/:username", middleware.OptionalAuthMiddleware(jwtService), userHandler.GetPublicProfile)
+
+	request := func(method, path, token string, payload interface{}) *httptest.ResponseRecorder {
+		var body bytes.Buffer
+		if payload != nil {
+			json.NewEncoder(&body).Encode(payload)
+		}
+		req := httptest.NewRequest(method, path, &body)
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	getProfile := func(token string) dto.PublicProfileResponse {
+		w := request(http.MethodGet, "/api/profiles/player", token, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		var profile dto.PublicProfileResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &profile))
+		return profile
+	}
+
+	player := &entities.User{Email: "player@example.com", Username: "player", Password: "hashed"}
+	require.NoError(t, userRepo.Create(player))
+	token, err := jwtService.GenerateToken(player.ID, player.Username)
+	require.NoError(t, err)
+
+	organizer := &entities.User{Email: "organizer@example.com", Username: "organizer", Password: "hashed"}
+	require.NoError(t, userRepo.Create(organizer))
+
+	dust := &entities.Map{GameID: 1, Name: "Dust", Slug: "dust", IsActive: true}
+	mirage := &entities.Map{GameID: 1, Name: "Mirage", Slug: "mirage", IsActive: true}
+	nuke := &entities.Map{GameID: 1, Name: "Nuke", Slug: "nuke", IsActive: true}
+	require.NoError(t, mapRepo.Create(dust))
+	require.NoError(t, mapRepo.Create(mirage))
+	require.NoError(t, mapRepo.Create(nuke))
+
+	falcons := &entities.Team{Name: "Falcons", Tag: "FAL"}
+	require.NoError(t, teamRepo.Create(falcons))
+	require.NoError(t, teamRepo.AddMember(&entities.TeamMember{TeamID: falcons.ID, UserID: player.ID, Role: entities.TeamRoleCaptain}))
+
+	createSession := func(ownerID uint, teamAID *uint, shareToken string) *entities.VetoSession {
+		session := &entities.VetoSession{UserID: &ownerID, GameID: 1, MapPoolID: 1, Type: entities.VetoTypeBo1, Status: entities.VetoStatusFinished, TeamAName: "A", TeamBName: "B", TeamAID: teamAID, CurrentTeam: "A", ShareToken: shareToken}
+		require.NoError(t, vetoSessionRepo.Create(session))
+		return session
+	}
+	addAction := func(session *entities.VetoSession, mapID uint, team string, actionType entities.VetoActionType, step int, side *string) {
+		require.NoError(t, vetoActionRepo.Create(&entities.VetoAction{VetoSessionID: session.ID, MapID: mapID, Team: team, ActionType: actionType, StepNumber: step, SelectedSide: side}))
+	}
+
+	// Сессия игрока за свою команду: бан соперника не попадает в статистику,
+	// а сторону на пике соперника выбирала команда игрока
+	attack := "attack"
+	teamSession := createSession(player.ID, &falcons.ID, "token-1")
+	addAction(teamSession, dust.ID, "A", entities.VetoActionTypeBan, 1, nil)
+	addAction(teamSession, nuke.ID, "B", entities.VetoActionTypeBan, 2, nil)
+	addAction(teamSession, mirage.ID, "B", entities.VetoActionTypePick, 3, &attack)
+
+	// Сессия без команд: игрок ведет обе стороны, и чьи это баны, неизвестно
+	soloSession := createSession(player.ID, nil, "token-2")
+	addAction(soloSession, nuke.ID, "A", entities.VetoActionTypeBan, 1, nil)
+
+	// Чужая сессия, в которой игрок играл за сторону B по составу подтвержденного результата
+	playedSession := createSession(organizer.ID, nil, "token-3")
+	addAction(playedSession, nuke.ID, "A", entities.VetoActionTypeBan, 1, nil)
+	addAction(playedSession, dust.ID, "B", entities.VetoActionTypePick, 2, nil)
+	result := &models.MatchResultModel{VetoSessionID: playedSession.ID, Status: string(entities.MatchResultStatusConfirmed), ReportedByID: organizer.ID, ScoreA: 0, ScoreB: 1, WinnerTeam: "B"}
+	require.NoError(t, db.Create(result).Error)
+	require.NoError(t, db.Create(&models.MatchResultPlayerModel{MatchResultID: result.ID, UserID: player.ID, Team: "B"}).Error)
+
+	assert.Equal(t, http.StatusNotFound, request(http.MethodGet, "/api/profiles/unknown", "", nil).Code)
+
+	profile := getProfile("")
+	assert.Equal(t, "player", profile.Username)
+	assert.False(t, profile.IsPrivate)
+	require.NotNil(t, profile.Stats)
+	assert.Equal(t, int64(3), profile.Stats.SessionsTotal)
+	assert.Equal(t, int64(3), profile.Stats.SessionsFinished)
+	require.Len(t, profile.Stats.Formats, 1)
+	assert.Equal(t, entities.VetoTypeBo1, profile.Stats.Formats[0].Type)
+	require.Len(t, profile.Stats.MostBanned, 1)
+	assert.Equal(t, "Dust", profile.Stats.MostBanned[0].MapName)
+	assert.Equal(t, int64(1), profile.Stats.MostBanned[0].Count)
+	require.Len(t, profile.Stats.MostPicked, 1)
+	assert.Equal(t, "Dust", profile.Stats.MostPicked[0].MapName)
+	require.Len(t, profile.Stats.Sides, 1)
+	assert.Equal(t, "attack", profile.Stats.Sides[0].Side)
+	assert.Equal(t, int64(1), profile.Stats.Results.SeriesWon)
+
+	// Скрытый профиль: статистику видит только владелец
+	private := "private"
+	assert.Equal(t, http.StatusOK, request(http.MethodPut, "/api/users/profile", token, dto.UpdateProfileRequest{ProfileVisibility: &private}).Code)
+
+	profile = getProfile("")
+	assert.True(t, profile.IsPrivate)
+	assert.Nil(t, profile.Stats)
+
+	profile = getProfile(token)
+	assert.NotNil(t, profile.Stats)
+}
+
+func TestCleanupGuestsUseCase(t *testing.T) {
+	db, err := database.NewDatabase(":memory:")
+	require.NoError(t, err)
+	defer database.Close(db)
+	migrateAccountTables(t, db)
+
+	userRepo := sqlite.NewUserRepository(db)
+	roomRepo := sqlite.NewRoomRepository(db)
+	deleteAccountUseCase := user.NewDeleteAccountUseCase(
+		userRepo, roomRepo, sqlite.NewMapPoolRepository(db), sqlite.NewVetoSessionRepository(db), sqlite.NewTeamRepository(db),
+		auth.NewLogoutAllUseCase(sqlite.NewRefreshTokenRepository(db), sqlite.NewRevokedTokenRepository(db)),
+	)
+	cleanupGuestsUseCase := user.NewCleanupGuestsUseCase(userRepo, deleteAccountUseCase, 24*time.Hour)
+
+	createUser := func(username string, isGuest bool, age time.Duration) *entities.User {
+		u := &entities.User{Email: username + "@example.com", Username: username, Password: "hashed", IsGuest: isGuest}
+		require.NoError(t, userRepo.Create(u))
+		require.NoError(t, db.Model(&models.UserModel{}).Where("id = ?", u.ID).Update("created_at", time.Now().Add(-age)).Error)
+		return u
+	}
+	createRoom := func(code string, owner *entities.User, members ...*entities.User) *entities.Room {
+		room := &entities.Room{OwnerID: owner.ID, Name: code, Code: code, Type: entities.RoomTypePublic, Status: entities.RoomStatusWaiting, GameID: 1, MaxParticipants: 10}
+		require.NoError(t, roomRepo.Create(room))
+		require.NoError(t, roomRepo.AddParticipant(&entities.RoomParticipant{RoomID: room.ID, UserID: owner.ID, Role: entities.ParticipantRoleOwner, JoinedAt: time.Now()}))
+		for _, member := range members {
+			require.NoError(t, roomRepo.AddParticipant(&entities.RoomParticipant{RoomID: room.ID, UserID: member.ID, Role: entities.ParticipantRoleMember, JoinedAt: time.Now()}))
+		}
+		return room
+	}
+
+	expired := createUser("expired_guest", true, 48*time.Hour)
+	active := createUser("active_guest", true, time.Hour)
+	veteran := createUser("veteran", false, 48*time.Hour)
+	friend := createUser("friend", false, time.Hour)
+
+	guestRoom := createRoom("GUEST1", expired)
+	sharedRoom := createRoom("SHARED", veteran, friend, expired)
+
+	result, err := cleanupGuestsUseCase.Execute(user.CleanupGuestsInput{Now: time.Now()})
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Deleted)
+
+	// Удален только гость с истекшей сессией; старые обычные аккаунты не затрагиваются
+	for _, u := range []*entities.User{expired, active, veteran} {
+		found, err := userRepo.GetByID(u.ID)
+		require.NoError(t, err)
+		assert.Equal(t, u != expired, found != nil, u.Username)
+	}
+
+	// Комната, где гость остался один, закрыта, из общей комнаты он вышел
+	closed, err := roomRepo.GetByID(guestRoom.ID)
+	require.NoError(t, err)
+	assert.Nil(t, closed)
+	shared, err := roomRepo.GetByID(sharedRoom.ID)
+	require.NoError(t, err)
+	require.NotNil(t, shared)
+	assert.Len(t, shared.Participants, 2)
+
+	// Повторный запуск ничего не удаляет
+	result, err = cleanupGuestsUseCase.Execute(user.CleanupGuestsInput{Now: time.Now()})
+	require.NoError(t, err)
+	assert.Zero(t, result.Deleted)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/bbp/backend/internal/handler/dto"
 	"github.com/bbp/backend/internal/middleware"
 	"github.com/bbp/backend/internal/usecase/veto"
+	"github.com/bbp/backend/internal/usecase/webhook"
 	"github.com/bbp/backend/internal/domain/entities"
 	ws "github.com/bbp/backend/pkg/websocket"
 	"github.com/gin-gonic/gin"
@@ -26,6 +27,7 @@ type VetoHandler struct {
 	mapPoolRepo           repositories.MapPoolRepository
 	roomRepo              repositories.RoomRepository
 	wsManager             *ws.Manager
+	webhookDispatcher     *webhook.Dispatcher
 }
 
 func NewVetoHandler(
@@ -56,6 +58,11 @@ func NewVetoHandler(
 	}
 }
 
+// SetWebhookDispatcher подключает отправку событий вето во внешние подписки
+func (h *VetoHandler) SetWebhookDispatcher(dispatcher *webhook.Dispatcher) {
+	h.webhookDispatcher = dispatcher
+}
+
 // CreateSession обрабатывает POST /api/veto/sessions
 func (h *VetoHandler) CreateSession(c *gin.Context) {
 	var req dto.CreateVetoSessionRequest
@@ -78,13 +85,20 @@ func (h *VetoHandler) CreateSession(c *gin.Context) {
 		Type:         entities.VetoType(req.Type),
 		TeamAName:    req.TeamAName,
 		TeamBName:    req.TeamBName,
+		TeamAID:      req.TeamAID,
+		TeamBID:      req.TeamBID,
 		TimerSeconds: req.TimerSeconds,
+		Constraints:  dto.ToVetoConstraints(req.Constraints),
 	})
 
 	if err != nil {
 		switch err {
-		case veto.ErrGameNotFound, veto.ErrMapPoolNotFound, veto.ErrInvalidMapPool:
+		case veto.ErrGameNotFound, veto.ErrMapPoolNotFound, veto.ErrInvalidMapPool, veto.ErrPoolTooSmall, veto.ErrInvalidTeam, veto.ErrInvalidConstraints:
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case veto.ErrTeamNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "team not found"})
+		case veto.ErrNotTeamMember:
+			c.JSON(http.StatusForbidden, gin.H{"error": "user is not a member of the team"})
 		default:
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
 		}
@@ -115,19 +129,8 @@ func (h *VetoHandler) GetSession(c *gin.Context) {
 	}
 
 	// Загружаем map_pool для включения в ответ
-	var mapPool *entities.MapPool
-	if result.Session != nil {
-		pool, err := h.mapPoolRepo.GetByID(result.Session.MapPoolID)
-		if err == nil && pool != nil {
-			mapPool = pool
-		}
-	}
-
 	response := dto.ToVetoSessionResponse(result.Session)
-	if mapPool != nil {
-		mapPoolResp := dto.ToMapPoolResponse(mapPool)
-		response.MapPool = &mapPoolResp
-	}
+	response.MapPool = sessionMapPoolResponse(result.Session, h.mapPoolRepo)
 
 	c.JSON(http.StatusOK, response)
 }
@@ -152,30 +155,8 @@ func (h *VetoHandler) GetSessionByShareToken(c *gin.Context) {
 	}
 
 	// Загружаем map_pool для включения в ответ
-	var mapPool *entities.MapPool
-	if result.Session != nil {
-		pool, err := h.mapPoolRepo.GetByID(result.Session.MapPoolID)
-		if err != nil {
-			log.Printf("Error loading map pool %d for session by token: %v", result.Session.MapPoolID, err)
-		} else if pool != nil {
-			mapPool = pool
-			log.Printf("Loaded map pool %d for session by token: %d maps", pool.ID, len(pool.Maps))
-			if len(pool.Maps) == 0 {
-				log.Printf("Warning: map pool %d has no maps", pool.ID)
-			}
-		} else {
-			log.Printf("Map pool %d not found for session by token", result.Session.MapPoolID)
-		}
-	}
-
 	response := dto.ToVetoSessionResponse(result.Session)
-	if mapPool != nil && len(mapPool.Maps) > 0 {
-		mapPoolResp := dto.ToMapPoolResponse(mapPool)
-		response.MapPool = &mapPoolResp
-		log.Printf("Including map pool in response for session by token with %d maps", len(mapPool.Maps))
-	} else {
-		log.Printf("Not including map pool in response for session by token (pool is nil or has no maps)")
-	}
+	response.MapPool = sessionMapPoolResponse(result.Session, h.mapPoolRepo)
 
 	c.JSON(http.StatusOK, response)
 }
@@ -209,6 +190,8 @@ func (h *VetoHandler) GetNextAction(c *gin.Context) {
 		NeedsSideSelection: result.NeedsSideSelection,
 		SideSelectionTeam:  result.SideSelectionTeam,
 		Message:            result.Message,
+		Constraints:        result.Constraints,
+		PickableMapIDs:     result.PickableMapIDs,
 	})
 }
 
@@ -243,6 +226,8 @@ func (h *VetoHandler) BanMap(c *gin.Context) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "map not found"})
 		case veto.ErrMapAlreadyBanned:
 			c.JSON(http.StatusBadRequest, gin.H{"error": "map is already banned"})
+		case veto.ErrMapExcluded:
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		case veto.ErrNotYourTurn:
 			c.JSON(http.StatusBadRequest, gin.H{"error": "not your turn"})
 		default:
@@ -251,6 +236,8 @@ func (h *VetoHandler) BanMap(c *gin.Context) {
 		return
 	}
 
+	h.publishWebhookEvent(entities.WebhookEventMapBanned, uint(id), result.Session, result.Action)
+
 	c.JSON(http.StatusOK, dto.ToVetoSessionResponse(result.Session))
 }
 
@@ -287,6 +274,8 @@ func (h *VetoHandler) PickMap(c *gin.Context) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "map not found"})
 		case veto.ErrMapAlreadyPicked:
 			c.JSON(http.StatusBadRequest, gin.H{"error": "map is already picked"})
+		case veto.ErrMapExcluded:
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		case veto.ErrNotYourTurn:
 			c.JSON(http.StatusBadRequest, gin.H{"error": "not your turn"})
 		default:
@@ -295,6 +284,8 @@ func (h *VetoHandler) PickMap(c *gin.Context) {
 		return
 	}
 
+	h.publishWebhookEvent(entities.WebhookEventMapPicked, uint(id), result.Session, result.Action)
+
 	c.JSON(http.StatusOK, dto.ToVetoSessionResponse(result.Session))
 }
 
@@ -342,13 +333,7 @@ func (h *VetoHandler) SelectSide(c *gin.Context) {
 		sessionDTO := dto.ToVetoSessionResponse(result.Session)
 		
 		// Загружаем map_pool для включения в ответ
-		if result.Session != nil {
-			mapPool, err := h.mapPoolRepo.GetByID(result.Session.MapPoolID)
-			if err == nil && mapPool != nil {
-				mapPoolResp := dto.ToMapPoolResponse(mapPool)
-				sessionDTO.MapPool = &mapPoolResp
-			}
-		}
+		sessionDTO.MapPool = sessionMapPoolResponse(result.Session, h.mapPoolRepo)
 		
 		// Broadcast обновленное состояние сессии всем участникам комнаты
 		h.wsManager.BroadcastToRoom(room.ID, ws.Message{
@@ -361,16 +346,11 @@ func (h *VetoHandler) SelectSide(c *gin.Context) {
 		
 		log.Printf("Broadcasted veto:side to room %d for session %d", room.ID, uint(id))
 	}
+	h.publishWebhookEvent(entities.WebhookEventSideSelected, uint(id), result.Session, result.Action)
 
 	// Загружаем map_pool для включения в ответ (как и в других handler'ах)
 	sessionDTO := dto.ToVetoSessionResponse(result.Session)
-	if result.Session != nil {
-		mapPool, err := h.mapPoolRepo.GetByID(result.Session.MapPoolID)
-		if err == nil && mapPool != nil {
-			mapPoolResp := dto.ToMapPoolResponse(mapPool)
-			sessionDTO.MapPool = &mapPoolResp
-		}
-	}
+	sessionDTO.MapPool = sessionMapPoolResponse(result.Session, h.mapPoolRepo)
 
 	c.JSON(http.StatusOK, sessionDTO)
 }
@@ -407,13 +387,7 @@ func (h *VetoHandler) StartSession(c *gin.Context) {
 		sessionDTO := dto.ToVetoSessionResponse(result.Session)
 		
 		// Загружаем map_pool для включения в ответ
-		if result.Session != nil {
-			mapPool, err := h.mapPoolRepo.GetByID(result.Session.MapPoolID)
-			if err == nil && mapPool != nil {
-				mapPoolResp := dto.ToMapPoolResponse(mapPool)
-				sessionDTO.MapPool = &mapPoolResp
-			}
-		}
+		sessionDTO.MapPool = sessionMapPoolResponse(result.Session, h.mapPoolRepo)
 		
 		// Broadcast обновленное состояние сессии всем участникам комнаты
 		h.wsManager.BroadcastToRoom(room.ID, ws.Message{
@@ -425,6 +399,7 @@ func (h *VetoHandler) StartSession(c *gin.Context) {
 		
 		log.Printf("Broadcasted veto:start to room %d for session %d", room.ID, uint(id))
 	}
+	h.publishWebhookEvent(entities.WebhookEventVetoStarted, uint(id), result.Session, nil)
 
 	c.JSON(http.StatusOK, dto.ToVetoSessionResponse(result.Session))
 }
@@ -459,13 +434,7 @@ func (h *VetoHandler) ResetSession(c *gin.Context) {
 		sessionDTO := dto.ToVetoSessionResponse(result.Session)
 		
 		// Загружаем map_pool для включения в ответ
-		if result.Session != nil {
-			mapPool, err := h.mapPoolRepo.GetByID(result.Session.MapPoolID)
-			if err == nil && mapPool != nil {
-				mapPoolResp := dto.ToMapPoolResponse(mapPool)
-				sessionDTO.MapPool = &mapPoolResp
-			}
-		}
+		sessionDTO.MapPool = sessionMapPoolResponse(result.Session, h.mapPoolRepo)
 		
 		// Broadcast обновленное состояние сессии всем участникам комнаты
 		h.wsManager.BroadcastToRoom(room.ID, ws.Message{
@@ -477,6 +446,48 @@ func (h *VetoHandler) ResetSession(c *gin.Context) {
 		
 		log.Printf("Broadcasted veto:reset to room %d for session %d", room.ID, uint(id))
 	}
+	h.publishWebhookEvent(entities.WebhookEventVetoReset, uint(id), result.Session, nil)
 
 	c.JSON(http.StatusOK, dto.ToVetoSessionResponse(result.Session))
-}
\ No newline at end of file
+}
+
+// publishWebhookEvent отправляет событие сессии во внешние подписки ее комнаты и владельца
+// Сессия без комнаты тоже порождает события - для подписок создателя сессии
+func (h *VetoHandler) publishWebhookEvent(event entities.WebhookEvent, sessionID uint, session *entities.VetoSession, action *entities.VetoAction) {
+	if h.webhookDispatcher == nil || session == nil {
+		return
+	}
+
+	room, err := h.roomRepo.GetByVetoSessionID(sessionID)
+	if err != nil {
+		log.Printf("Failed to load room for webhook event %s of session %d: %v", event, sessionID, err)
+	}
+
+	sessionDTO := dto.ToVetoSessionResponse(session)
+	sessionDTO.MapPool = sessionMapPoolResponse(session, h.mapPoolRepo)
+
+	data := map[string]interface{}{
+		"session": sessionDTO,
+	}
+	if action != nil {
+		data["action"] = action
+	}
+	h.webhookDispatcher.PublishVetoEvent(event, session, room, data)
+}
+
+// sessionMapPoolResponse пул карт сессии для ответа; карты берутся из снимка сессии,
+// поэтому пул остается в ответе и после его удаления. nil - пул загрузить не удалось
+func sessionMapPoolResponse(session *entities.VetoSession, mapPoolRepo repositories.MapPoolRepository) *dto.MapPoolResponse {
+	if session == nil {
+		return nil
+	}
+	mapPool, err := veto.LoadSessionMapPool(session, mapPoolRepo)
+	if err != nil {
+		if err != veto.ErrMapPoolNotFound {
+			log.Printf("Failed to load map pool %d of session %d: %v", session.MapPoolID, session.ID, err)
+		}
+		return nil
+	}
+	response := dto.ToMapPoolResponse(mapPool)
+	return &response
+}
@@ -3,10 +3,13 @@ package http
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/bbp/backend/internal/domain/entities"
 	"github.com/bbp/backend/internal/handler/dto"
 	"github.com/bbp/backend/internal/repository/models"
 	"github.com/bbp/backend/internal/repository/sqlite"
@@ -15,6 +18,7 @@ import (
 	ws "github.com/bbp/backend/pkg/websocket"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"gorm.io/gorm"
 )
 
@@ -32,8 +36,17 @@ func setupVetoTestDB(t *testing.T) (*gorm.DB, func()) {
 		&models.MapPoolModel{},
 		&models.RoomModel{},
 		&models.RoomParticipantModel{},
+		&models.RoomMatchModel{},
+		&models.MapRotationModel{},
+		&models.VetoSessionMapModel{},
+		&models.VetoSessionConstraintModel{},
 		&models.VetoSessionModel{},
 		&models.VetoActionModel{},
+		&models.TeamModel{},
+		&models.TeamMemberModel{},
+		&models.MatchResultModel{},
+		&models.MapResultModel{},
+		&models.MatchResultPlayerModel{},
 	); err != nil {
 		t.Fatalf("Failed to run migrations: %v", err)
 	}
@@ -57,19 +70,21 @@ func setupVetoTestRouter(t *testing.T) (*gin.Engine, func()) {
 	mapRepo := sqlite.NewMapRepository(db)
 	mapPoolRepo := sqlite.NewMapPoolRepository(db)
 	gameRepo := sqlite.NewGameRepository(db)
+	mapRotationRepo := sqlite.NewMapRotationRepository(db)
 	roomRepo := sqlite.NewRoomRepository(db)
+	teamRepo := sqlite.NewTeamRepository(db)
 
 	// Инициализируем VetoLogicService
 	vetoLogicService := veto.NewVetoLogicService()
 
 	// Инициализируем use cases
-	createSessionUseCase := veto.NewCreateSessionUseCase(vetoSessionRepo, mapPoolRepo, gameRepo, vetoLogicService)
+	createSessionUseCase := veto.NewCreateSessionUseCase(vetoSessionRepo, mapPoolRepo, gameRepo, mapRotationRepo, teamRepo, vetoLogicService)
 	getSessionUseCase := veto.NewGetSessionUseCase(vetoSessionRepo)
 	getNextActionUseCase := veto.NewGetNextActionUseCase(vetoSessionRepo, mapPoolRepo, vetoLogicService)
 	banMapUseCase := veto.NewBanMapUseCase(vetoSessionRepo, vetoActionRepo, mapRepo, mapPoolRepo, vetoLogicService)
 	pickMapUseCase := veto.NewPickMapUseCase(vetoSessionRepo, vetoActionRepo, mapRepo, mapPoolRepo, vetoLogicService)
 	selectSideUseCase := veto.NewSelectSideUseCase(vetoSessionRepo, vetoActionRepo, mapPoolRepo, vetoLogicService)
-	resetSessionUseCase := veto.NewResetSessionUseCase(vetoSessionRepo, vetoActionRepo)
+	resetSessionUseCase := veto.NewResetSessionUseCase(vetoSessionRepo, vetoActionRepo, sqlite.NewMatchResultRepository(db))
 	startSessionUseCase := veto.NewStartSessionUseCase(vetoSessionRepo)
 
 	// Инициализируем WebSocket manager
@@ -167,3 +182,61 @@ func TestVetoHandler_BanMap(t *testing.T) {
 	// Тест может не пройти без существующей сессии, но структура готова
 	assert.True(t, w.Code == http.StatusOK || w.Code == http.StatusNotFound || w.Code == http.StatusBadRequest)
 }
+
+func TestVetoHandler_SessionMapPoolAfterPoolDeleted(t *testing.T) {
+	db, cleanup := setupVetoTestDB(t)
+	defer cleanup()
+
+	gameRepo := sqlite.NewGameRepository(db)
+	mapRepo := sqlite.NewMapRepository(db)
+	mapPoolRepo := sqlite.NewMapPoolRepository(db)
+	vetoSessionRepo := sqlite.NewVetoSessionRepository(db)
+
+	game := &entities.Game{Name: "CS2", Slug: "cs2", IsActive: true}
+	require.NoError(t, gameRepo.Create(game))
+	var maps []entities.Map
+	for _, name := range []string{"Mirage", "Inferno", "Nuke"} {
+		m := &entities.Map{GameID: game.ID, Name: name, Slug: strings.ToLower(name), IsActive: true}
+		require.NoError(t, mapRepo.Create(m))
+		maps = append(maps, *m)
+	}
+	pool := &entities.MapPool{GameID: game.ID, Name: "Custom", Type: entities.MapPoolTypeCustom, Maps: maps}
+	require.NoError(t, mapPoolRepo.Create(pool))
+
+	created, err := veto.NewCreateSessionUseCase(vetoSessionRepo, mapPoolRepo, gameRepo, sqlite.NewMapRotationRepository(db), sqlite.NewTeamRepository(db), veto.NewVetoLogicService()).
+		Execute(veto.CreateSessionInput{GameID: game.ID, MapPoolID: pool.ID, Type: entities.VetoTypeBo1, TeamAName: "Alpha", TeamBName: "Bravo"})
+	require.NoError(t, err)
+	session := created.Session
+
+	handler := NewVetoHandler(nil, veto.NewGetSessionUseCase(vetoSessionRepo), nil, nil, nil, nil, nil, nil, mapPoolRepo, sqlite.NewRoomRepository(db), ws.NewManager())
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/api/veto/sessions/:id", handler.GetSession)
+	router.GET("/api/veto/sessions/share/:token", handler.GetSessionByShareToken)
+
+	getMapPool := func(path string) *dto.MapPoolResponse {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		require.Equal(t, http.StatusOK, w.Code)
+		var response dto.VetoSessionResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response.MapPool
+	}
+	byID := fmt.Sprintf("/api/veto/sessions/%d", session.ID)
+	byToken := "/api/veto/sessions/share/" + session.ShareToken
+
+	mapPool := getMapPool(byID)
+	require.NotNil(t, mapPool)
+	assert.Equal(t, "Custom", mapPool.Name)
+	assert.Len(t, mapPool.Maps, 3)
+
+	// После удаления пула список карт берется из снимка сессии
+	require.NoError(t, mapPoolRepo.Delete(pool.ID))
+	for _, path := range []string{byID, byToken} {
+		mapPool = getMapPool(path)
+		require.NotNil(t, mapPool, path)
+		assert.Equal(t, pool.ID, mapPool.ID)
+		require.Len(t, mapPool.Maps, 3)
+		assert.Equal(t, "Mirage", mapPool.Maps[0].Name)
+	}
+}
@@ -0,0 +1,283 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/bbp/backend/internal/handler/dto"
+	"github.com/bbp/backend/internal/middleware"
+	"github.com/bbp/backend/internal/usecase/webhook"
+	"github.com/gin-gonic/gin"
+)
+
+type WebhookHandler struct {
+	createWebhookUseCase *webhook.CreateWebhookUseCase
+	getWebhooksUseCase   *webhook.GetWebhooksUseCase
+	updateWebhookUseCase *webhook.UpdateWebhookUseCase
+	deleteWebhookUseCase *webhook.DeleteWebhookUseCase
+	getDeliveriesUseCase *webhook.GetDeliveriesUseCase
+	redeliverUseCase     *webhook.RedeliverUseCase
+	pingWebhookUseCase   *webhook.PingWebhookUseCase
+}
+
+func NewWebhookHandler(
+	createWebhookUseCase *webhook.CreateWebhookUseCase,
+	getWebhooksUseCase *webhook.GetWebhooksUseCase,
+	updateWebhookUseCase *webhook.UpdateWebhookUseCase,
+	deleteWebhookUseCase *webhook.DeleteWebhookUseCase,
+	getDeliveriesUseCase *webhook.GetDeliveriesUseCase,
+	redeliverUseCase *webhook.RedeliverUseCase,
+	pingWebhookUseCase *webhook.PingWebhookUseCase,
+) *WebhookHandler {
+	return &WebhookHandler{
+		createWebhookUseCase: createWebhookUseCase,
+		getWebhooksUseCase:   getWebhooksUseCase,
+		updateWebhookUseCase: updateWebhookUseCase,
+		deleteWebhookUseCase: deleteWebhookUseCase,
+		getDeliveriesUseCase: getDeliveriesUseCase,
+		redeliverUseCase:     redeliverUseCase,
+		pingWebhookUseCase:   pingWebhookUseCase,
+	}
+}
+
+// GetWebhooks обрабатывает GET /api/webhooks
+func (h *WebhookHandler) GetWebhooks(c *gin.Context) {
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	result, err := h.getWebhooksUseCase.Execute(user.ID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	webhooks := make([]dto.WebhookResponse, len(result.Webhooks))
+	for i := range result.Webhooks {
+		webhooks[i] = dto.ToWebhookResponse(&result.Webhooks[i], false)
+	}
+	c.JSON(http.StatusOK, gin.H{"webhooks": webhooks})
+}
+
+// CreateWebhook обрабатывает POST /api/webhooks
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req dto.CreateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.createWebhookUseCase.Execute(webhook.CreateWebhookInput{
+		UserID: user.ID,
+		RoomID: req.RoomID,
+		URL:    req.URL,
+		Events: dto.ToWebhookEvents(req.Events),
+	})
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	// Ключ подписи показывается один раз
+	c.JSON(http.StatusCreated, dto.ToWebhookResponse(result.Webhook, true))
+}
+
+// UpdateWebhook обрабатывает PUT /api/webhooks/:id
+func (h *WebhookHandler) UpdateWebhook(c *gin.Context) {
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	webhookID, ok := parseWebhookID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	input := webhook.UpdateWebhookInput{
+		WebhookID:    webhookID,
+		UserID:       user.ID,
+		URL:          req.URL,
+		IsActive:     req.IsActive,
+		RotateSecret: req.RotateSecret,
+	}
+	if req.Events != nil {
+		events := dto.ToWebhookEvents(*req.Events)
+		input.Events = &events
+	}
+
+	result, err := h.updateWebhookUseCase.Execute(input)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToWebhookResponse(result.Webhook, result.SecretRotated))
+}
+
+// DeleteWebhook обрабатывает DELETE /api/webhooks/:id
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	webhookID, ok := parseWebhookID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.deleteWebhookUseCase.Execute(webhook.DeleteWebhookInput{
+		WebhookID: webhookID,
+		UserID:    user.ID,
+	}); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "webhook deleted"})
+}
+
+// GetDeliveries обрабатывает GET /api/webhooks/:id/deliveries
+func (h *WebhookHandler) GetDeliveries(c *gin.Context) {
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	webhookID, ok := parseWebhookID(c)
+	if !ok {
+		return
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil || limit <= 0 || limit > 100 {
+		limit = 50
+	}
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+
+	result, err := h.getDeliveriesUseCase.Execute(webhook.GetDeliveriesInput{
+		WebhookID: webhookID,
+		UserID:    user.ID,
+		Limit:     limit,
+		Offset:    offset,
+	})
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	deliveries := make([]dto.WebhookDeliveryResponse, len(result.Deliveries))
+	for i := range result.Deliveries {
+		deliveries[i] = dto.ToWebhookDeliveryResponse(&result.Deliveries[i])
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"deliveries": deliveries,
+		"limit":      limit,
+		"offset":     offset,
+	})
+}
+
+// Redeliver обрабатывает POST /api/webhooks/:id/deliveries/:deliveryId/redeliver
+func (h *WebhookHandler) Redeliver(c *gin.Context) {
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	webhookID, ok := parseWebhookID(c)
+	if !ok {
+		return
+	}
+	deliveryID, err := strconv.ParseUint(c.Param("deliveryId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid delivery id"})
+		return
+	}
+
+	result, err := h.redeliverUseCase.Execute(webhook.RedeliverInput{
+		WebhookID:  webhookID,
+		DeliveryID: uint(deliveryID),
+		UserID:     user.ID,
+	})
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, dto.ToWebhookDeliveryResponse(result.Delivery))
+}
+
+// PingWebhook обрабатывает POST /api/webhooks/:id/ping
+func (h *WebhookHandler) PingWebhook(c *gin.Context) {
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	webhookID, ok := parseWebhookID(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.pingWebhookUseCase.Execute(webhook.PingWebhookInput{
+		WebhookID: webhookID,
+		UserID:    user.ID,
+	})
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, dto.ToWebhookDeliveryResponse(result.Delivery))
+}
+
+func (h *WebhookHandler) handleError(c *gin.Context, err error) {
+	switch err {
+	case webhook.ErrWebhookNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "webhook not found"})
+	case webhook.ErrDeliveryNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "webhook delivery not found"})
+	case webhook.ErrRoomNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "room not found"})
+	case webhook.ErrUnauthorized:
+		c.JSON(http.StatusForbidden, gin.H{"error": "unauthorized"})
+	case webhook.ErrInvalidURL, webhook.ErrInvalidEvent:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case webhook.ErrTooManyWebhooks:
+		c.JSON(http.StatusConflict, gin.H{"error": "webhook limit reached"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+	}
+}
+
+// parseWebhookID разбирает параметр :id
+func parseWebhookID(c *gin.Context) (uint, bool) {
+	webhookID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid webhook id"})
+		return 0, false
+	}
+	return uint(webhookID), true
+}
@@ -0,0 +1,299 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/handler/dto"
+	"github.com/bbp/backend/internal/middleware"
+	"github.com/bbp/backend/internal/repository/models"
+	"github.com/bbp/backend/internal/repository/sqlite"
+	"github.com/bbp/backend/internal/usecase/veto"
+	"github.com/bbp/backend/internal/usecase/webhook"
+	"github.com/bbp/backend/pkg/database"
+	"github.com/bbp/backend/pkg/jwt"
+	ws "github.com/bbp/backend/pkg/websocket"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// receivedWebhook запрос, принятый локальным получателем
+type receivedWebhook struct {
+	header http.Header
+	body   []byte
+}
+
+func TestWebhookHandler_DeliveryFlow(t *testing.T) {
+	db, cleanup := setupVetoTestDB(t)
+	defer cleanup()
+	require.NoError(t, database.Migrate(db,
+		&models.RevokedTokenModel{},
+		&models.WebhookModel{},
+		&models.WebhookEventModel{},
+		&models.WebhookDeliveryModel{},
+	))
+
+	// Локальный получатель: записывает запросы и отвечает заданным статусом
+	var mu sync.Mutex
+	var received []receivedWebhook
+	responseStatus := http.StatusOK
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		defer mu.Unlock()
+		received = append(received, receivedWebhook{header: r.Header.Clone(), body: body})
+		w.WriteHeader(responseStatus)
+	}))
+	defer receiver.Close()
+	setResponseStatus := func(status int) {
+		mu.Lock()
+		defer mu.Unlock()
+		responseStatus = status
+	}
+	takeReceived := func() []receivedWebhook {
+		mu.Lock()
+		defer mu.Unlock()
+		result := received
+		received = nil
+		return result
+	}
+
+	userRepo := sqlite.NewUserRepository(db)
+	gameRepo := sqlite.NewGameRepository(db)
+	mapRepo := sqlite.NewMapRepository(db)
+	mapPoolRepo := sqlite.NewMapPoolRepository(db)
+	roomRepo := sqlite.NewRoomRepository(db)
+	vetoSessionRepo := sqlite.NewVetoSessionRepository(db)
+	vetoActionRepo := sqlite.NewVetoActionRepository(db)
+	webhookRepo := sqlite.NewWebhookRepository(db)
+	webhookDeliveryRepo := sqlite.NewWebhookDeliveryRepository(db)
+	vetoLogicService := veto.NewVetoLogicService()
+
+	jwtService := jwt.NewJWTService("test-secret", time.Hour)
+	jwtService.SetDenylist(sqlite.NewRevokedTokenRepository(db))
+
+	// Получатель слушает на loopback, поэтому локальные адреса разрешены явно
+	policy := webhook.DefaultDeliveryPolicy()
+	policy.AllowPrivateNetworks = true
+	dispatcher := webhook.NewDispatcher(webhookRepo, webhookDeliveryRepo, policy)
+	webhookHandler := NewWebhookHandler(
+		webhook.NewCreateWebhookUseCase(webhookRepo, roomRepo),
+		webhook.NewGetWebhooksUseCase(webhookRepo),
+		webhook.NewUpdateWebhookUseCase(webhookRepo),
+		webhook.NewDeleteWebhookUseCase(webhookRepo),
+		webhook.NewGetDeliveriesUseCase(webhookRepo, webhookDeliveryRepo),
+		webhook.NewRedeliverUseCase(webhookRepo, webhookDeliveryRepo, dispatcher),
+		webhook.NewPingWebhookUseCase(webhookRepo, dispatcher),
+	)
+	vetoHandler := NewVetoHandler(
+		veto.NewCreateSessionUseCase(vetoSessionRepo, mapPoolRepo, gameRepo, sqlite.NewMapRotationRepository(db), sqlite.NewTeamRepository(db), vetoLogicService),
+		nil,
+		nil,
+		veto.NewBanMapUseCase(vetoSessionRepo, vetoActionRepo, mapRepo, mapPoolRepo, vetoLogicService),
+		nil,
+		nil,
+		nil,
+		veto.NewStartSessionUseCase(vetoSessionRepo),
+		mapPoolRepo,
+		roomRepo,
+		ws.NewManager(),
+	)
+	vetoHandler.SetWebhookDispatcher(dispatcher)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	auth := router.Group("/api", middleware.AuthMiddleware(jwtService))
+	auth.POST("/veto/sessions", vetoHandler.CreateSession)
+	auth.POST("/veto/sessions/:id/start", vetoHandler.StartSession)
+	auth.POST("/veto/sessions/:id/ban", vetoHandler.BanMap)
+	auth.GET("/webhooks", webhookHandler.GetWebhooks)
+	auth.POST("/webhooks", webhookHandler.CreateWebhook)
+	auth.PUT("/webhooks/:id", webhookHandler.UpdateWebhook)
+	auth.POST("/webhooks/:id/ping", webhookHandler.PingWebhook)
+	auth.GET("/webhooks/:id/deliveries", webhookHandler.GetDeliveries)
+	auth.POST("/webhooks/:id/deliveries/:deliveryId/redeliver", webhookHandler.Redeliver)
+
+	createUser := func(username string) string {
+		u := &entities.User{Email: username + "@example.com", Username: username, Password: "hashed"}
+		require.NoError(t, userRepo.Create(u))
+		token, err := jwtService.GenerateToken(u.ID, u.Username)
+		require.NoError(t, err)
+		return token
+	}
+	request := func(method, path, token string, payload interface{}) *httptest.ResponseRecorder {
+		var body bytes.Buffer
+		if payload != nil {
+			json.NewEncoder(&body).Encode(payload)
+		}
+		req := httptest.NewRequest(method, path, &body)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	deliveries := func(webhookID uint, token string) []dto.WebhookDeliveryResponse {
+		w := request(http.MethodGet, fmt.Sprintf("/api/webhooks/%d/deliveries", webhookID), token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var resp struct {
+			Deliveries []dto.WebhookDeliveryResponse `json:"deliveries"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		return resp.Deliveries
+	}
+
+	ownerToken := createUser("owner")
+	strangerToken := createUser("stranger")
+
+	game := &entities.Game{Name: "CS2", Slug: "cs2", IsActive: true}
+	require.NoError(t, gameRepo.Create(game))
+	var maps []entities.Map
+	for _, slug := range []string{"mirage", "inferno", "nuke"} {
+		m := &entities.Map{GameID: game.ID, Name: slug, Slug: slug, IsActive: true}
+		require.NoError(t, mapRepo.Create(m))
+		maps = append(maps, *m)
+	}
+	pool := &entities.MapPool{GameID: game.ID, Name: "Pool", Type: entities.MapPoolTypeAll, IsSystem: true, Maps: maps}
+	require.NoError(t, mapPoolRepo.Create(pool))
+
+	// Некорректные подписки отклоняются
+	w := request(http.MethodPost, "/api/webhooks", ownerToken, dto.CreateWebhookRequest{URL: "ftp://example.com/hook"})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = request(http.MethodPost, "/api/webhooks", ownerToken, dto.CreateWebhookRequest{URL: receiver.URL, Events: []string{"veto.unknown"}})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	roomID := uint(999)
+	w = request(http.MethodPost, "/api/webhooks", ownerToken, dto.CreateWebhookRequest{URL: receiver.URL, RoomID: &roomID})
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// Подписка пользователя получает события созданных им сессий; ключ подписи показывается при создании
+	w = request(http.MethodPost, "/api/webhooks", ownerToken, dto.CreateWebhookRequest{
+		URL:    receiver.URL,
+		Events: []string{"veto.started", "veto.map_banned", "veto.finished"},
+	})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var created dto.WebhookResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	require.NotEmpty(t, created.Secret)
+
+	w = request(http.MethodGet, "/api/webhooks", ownerToken, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), created.Secret)
+
+	w = request(http.MethodPost, "/api/veto/sessions", ownerToken, dto.CreateVetoSessionRequest{
+		GameID: game.ID, MapPoolID: pool.ID, Type: "bo1", TeamAName: "A", TeamBName: "B",
+	})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var session dto.VetoSessionResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &session))
+
+	// Доставка подписана HMAC от "<timestamp>.<body>"
+	w = request(http.MethodPost, fmt.Sprintf("/api/veto/sessions/%d/start", session.ID), ownerToken, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	dispatcher.DeliverDue()
+	got := takeReceived()
+	require.Len(t, got, 1)
+	assert.Equal(t, "veto.started", got[0].header.Get(webhook.HeaderEvent))
+	timestamp := mustParseInt(t, got[0].header.Get(webhook.HeaderTimestamp))
+	assert.Equal(t, webhook.Sign(created.Secret, timestamp, got[0].body), got[0].header.Get(webhook.HeaderSignature))
+	var payload struct {
+		Event     string `json:"event"`
+		SessionID uint   `json:"session_id"`
+	}
+	require.NoError(t, json.Unmarshal(got[0].body, &payload))
+	assert.Equal(t, "veto.started", payload.Event)
+	assert.Equal(t, session.ID, payload.SessionID)
+
+	// Получатель недоступен: доставка остается в журнале и ждет повтора
+	setResponseStatus(http.StatusInternalServerError)
+	w = request(http.MethodPost, fmt.Sprintf("/api/veto/sessions/%d/ban", session.ID), ownerToken, dto.BanMapRequest{MapID: maps[0].ID, Team: "A"})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	dispatcher.DeliverDue()
+	require.Len(t, takeReceived(), 1)
+	log := deliveries(created.ID, ownerToken)
+	require.Len(t, log, 2)
+	failed := log[0]
+	assert.Equal(t, "veto.map_banned", failed.Event)
+	assert.Equal(t, "pending", failed.Status)
+	assert.Equal(t, 1, failed.Attempts)
+	require.NotNil(t, failed.ResponseStatus)
+	assert.Equal(t, http.StatusInternalServerError, *failed.ResponseStatus)
+	assert.NotNil(t, failed.NextAttemptAt)
+
+	// Повторная попытка еще не наступила
+	dispatcher.DeliverDue()
+	assert.Empty(t, takeReceived())
+
+	// Ручная повторная доставка отправляет то же тело
+	setResponseStatus(http.StatusOK)
+	w = request(http.MethodPost, fmt.Sprintf("/api/webhooks/%d/deliveries/%d/redeliver", created.ID, failed.ID), strangerToken, nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = request(http.MethodPost, fmt.Sprintf("/api/webhooks/%d/deliveries/%d/redeliver", created.ID, failed.ID), ownerToken, nil)
+	require.Equal(t, http.StatusAccepted, w.Code, w.Body.String())
+	dispatcher.DeliverDue()
+	got = takeReceived()
+	require.Len(t, got, 1)
+	assert.Equal(t, failed.Payload, string(got[0].body))
+	log = deliveries(created.ID, ownerToken)
+	require.Len(t, log, 3)
+	assert.Equal(t, "succeeded", log[0].Status)
+	require.NotNil(t, log[0].RedeliveryOf)
+	assert.Equal(t, failed.ID, *log[0].RedeliveryOf)
+
+	// Последний бан завершает вето: событие бана и veto.finished
+	w = request(http.MethodPost, fmt.Sprintf("/api/veto/sessions/%d/ban", session.ID), ownerToken, dto.BanMapRequest{MapID: maps[1].ID, Team: "B"})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	dispatcher.DeliverDue()
+	events := map[string]bool{}
+	for _, r := range takeReceived() {
+		events[r.header.Get(webhook.HeaderEvent)] = true
+	}
+	assert.Equal(t, map[string]bool{"veto.map_banned": true, "veto.finished": true}, events)
+
+	// Отключенная подписка не получает событий, ping проверяет получателя вручную
+	isActive := false
+	w = request(http.MethodPut, fmt.Sprintf("/api/webhooks/%d", created.ID), ownerToken, dto.UpdateWebhookRequest{IsActive: &isActive, RotateSecret: true})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var updated dto.WebhookResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &updated))
+	assert.False(t, updated.IsActive)
+	assert.NotEqual(t, created.Secret, updated.Secret)
+
+	isActive = true
+	w = request(http.MethodPut, fmt.Sprintf("/api/webhooks/%d", created.ID), ownerToken, dto.UpdateWebhookRequest{IsActive: &isActive})
+	require.Equal(t, http.StatusOK, w.Code)
+	w = request(http.MethodPost, fmt.Sprintf("/api/webhooks/%d/ping", created.ID), ownerToken, nil)
+	require.Equal(t, http.StatusAccepted, w.Code, w.Body.String())
+	dispatcher.DeliverDue()
+	got = takeReceived()
+	require.Len(t, got, 1)
+	assert.Equal(t, "ping", got[0].header.Get(webhook.HeaderEvent))
+	assert.Equal(t, webhook.Sign(updated.Secret, mustParseInt(t, got[0].header.Get(webhook.HeaderTimestamp)), got[0].body), got[0].header.Get(webhook.HeaderSignature))
+
+	// С политикой по умолчанию подключение к локальному адресу запрещено, ответ не сохраняется
+	strictDispatcher := webhook.NewDispatcher(webhookRepo, webhookDeliveryRepo, webhook.DefaultDeliveryPolicy())
+	w = request(http.MethodPost, fmt.Sprintf("/api/webhooks/%d/ping", created.ID), ownerToken, nil)
+	require.Equal(t, http.StatusAccepted, w.Code, w.Body.String())
+	strictDispatcher.DeliverDue()
+	assert.Empty(t, takeReceived())
+	blocked := deliveries(created.ID, ownerToken)[0]
+	assert.Equal(t, "ping", blocked.Event)
+	assert.Contains(t, blocked.LastError, webhook.ErrAddressNotAllowed.Error())
+	assert.Nil(t, blocked.ResponseStatus)
+	assert.Empty(t, blocked.ResponseBody)
+}
+
+func mustParseInt(t *testing.T, value string) int64 {
+	n, err := strconv.ParseInt(value, 10, 64)
+	require.NoError(t, err)
+	return n
+}
@@ -4,12 +4,12 @@ import (
 	"log"
 	"net/http"
 	"strconv"
-	"strings"
 
 	"github.com/bbp/backend/internal/domain/entities"
 	"github.com/bbp/backend/internal/domain/repositories"
 	"github.com/bbp/backend/internal/handler/dto"
 	"github.com/bbp/backend/internal/usecase/veto"
+	"github.com/bbp/backend/internal/usecase/webhook"
 	ws "github.com/bbp/backend/pkg/websocket"
 	jwtPkg "github.com/bbp/backend/pkg/jwt"
 	"github.com/gin-gonic/gin"
@@ -37,6 +37,7 @@ type RoomWebSocketHandler struct {
 	pickMapUseCase    *veto.PickMapUseCase
 	resetSessionUseCase *veto.ResetSessionUseCase
 	startSessionUseCase *veto.StartSessionUseCase
+	webhookDispatcher   *webhook.Dispatcher
 }
 
 func NewRoomWebSocketHandler(
@@ -72,39 +73,19 @@ func NewRoomWebSocketHandler(
 	return handler
 }
 
+// SetWebhookDispatcher подключает отправку событий вето во внешние подписки
+func (h *RoomWebSocketHandler) SetWebhookDispatcher(dispatcher *webhook.Dispatcher) {
+	h.webhookDispatcher = dispatcher
+}
+
 // HandleWebSocket handles WebSocket connections for rooms
 func (h *RoomWebSocketHandler) HandleWebSocket(c *gin.Context) {
-	// Get token from query parameter (WebSocket doesn't support headers during upgrade)
-	token := c.Query("token")
-	if token == "" {
-		// Try to get from Authorization header as fallback
-		authHeader := c.GetHeader("Authorization")
-		if authHeader != "" {
-			parts := strings.Split(authHeader, " ")
-			if len(parts) == 2 && parts[0] == "Bearer" {
-				token = parts[1]
-			}
-		}
-	}
-
-	if token == "" {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "token required"})
-		return
-	}
-
-	// Validate token
-	claims, err := h.jwtService.ValidateToken(token)
-	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid or expired token"})
+	// Token comes from query parameter (WebSocket doesn't support headers during upgrade)
+	user, ok := h.authenticateUser(c)
+	if !ok {
 		return
 	}
 
-	// Create user from claims
-	user := &entities.User{
-		ID:       claims.UserID,
-		Username: claims.Username,
-	}
-
 	// Get room ID from URL
 	roomIDStr := c.Param("roomId")
 	roomID, err := strconv.ParseUint(roomIDStr, 10, 32)
@@ -158,6 +139,11 @@ func (h *RoomWebSocketHandler) HandleWebSocket(c *gin.Context) {
 
 // sendRoomState sends the current room state to the client
 func (h *RoomWebSocketHandler) sendRoomState(client *ws.Client, room *entities.Room) {
+	client.SendMessage(h.roomStateMessage(room))
+}
+
+// roomStateMessage builds the room:state message with the current veto session
+func (h *RoomWebSocketHandler) roomStateMessage(room *entities.Room) ws.Message {
 	// Load veto session if exists
 	var vetoSession *entities.VetoSession
 	if room.VetoSessionID != nil {
@@ -172,10 +158,10 @@ func (h *RoomWebSocketHandler) sendRoomState(client *ws.Client, room *entities.R
 		"veto_session": vetoSession,
 	}
 
-	client.SendMessage(ws.Message{
+	return ws.Message{
 		Type: "room:state",
 		Data: state,
-	})
+	}
 }
 
 // broadcastRoomState broadcasts room state to all clients in the room
@@ -331,13 +317,7 @@ func (h *RoomWebSocketHandler) handleVetoBan(client *ws.Client, msg *ws.Message)
 	sessionDTO := dto.ToVetoSessionResponse(output.Session)
 	
 	// Загружаем map_pool для включения в ответ
-	if output.Session != nil {
-		mapPool, err := h.mapPoolRepo.GetByID(output.Session.MapPoolID)
-		if err == nil && mapPool != nil {
-			mapPoolResp := dto.ToMapPoolResponse(mapPool)
-			sessionDTO.MapPool = &mapPoolResp
-		}
-	}
+	sessionDTO.MapPool = h.sessionMapPoolResponse(output.Session)
 	
 	h.manager.BroadcastToRoom(client.RoomID, ws.Message{
 		Type: "veto:ban",
@@ -347,6 +327,10 @@ func (h *RoomWebSocketHandler) handleVetoBan(client *ws.Client, msg *ws.Message)
 			"user_id": client.UserID,
 		},
 	})
+	h.webhookDispatcher.PublishVetoEvent(entities.WebhookEventMapBanned, output.Session, room, map[string]interface{}{
+		"session": sessionDTO,
+		"action":  output.Action,
+	})
 
 	// Если сессия завершена, отправляем обновленное состояние комнаты
 	if output.Session.Status == entities.VetoStatusFinished {
@@ -444,13 +428,7 @@ func (h *RoomWebSocketHandler) handleVetoPick(client *ws.Client, msg *ws.Message
 	sessionDTO := dto.ToVetoSessionResponse(output.Session)
 	
 	// Загружаем map_pool для включения в ответ
-	if output.Session != nil {
-		mapPool, err := h.mapPoolRepo.GetByID(output.Session.MapPoolID)
-		if err == nil && mapPool != nil {
-			mapPoolResp := dto.ToMapPoolResponse(mapPool)
-			sessionDTO.MapPool = &mapPoolResp
-		}
-	}
+	sessionDTO.MapPool = h.sessionMapPoolResponse(output.Session)
 	
 	h.manager.BroadcastToRoom(client.RoomID, ws.Message{
 		Type: "veto:pick",
@@ -460,6 +438,10 @@ func (h *RoomWebSocketHandler) handleVetoPick(client *ws.Client, msg *ws.Message
 			"user_id": client.UserID,
 		},
 	})
+	h.webhookDispatcher.PublishVetoEvent(entities.WebhookEventMapPicked, output.Session, room, map[string]interface{}{
+		"session": sessionDTO,
+		"action":  output.Action,
+	})
 
 	// Если сессия завершена, отправляем обновленное состояние комнаты
 	if output.Session.Status == entities.VetoStatusFinished {
@@ -537,13 +519,7 @@ func (h *RoomWebSocketHandler) handleVetoStart(client *ws.Client, msg *ws.Messag
 	sessionDTO := dto.ToVetoSessionResponse(output.Session)
 	
 	// Загружаем map_pool для включения в ответ
-	if output.Session != nil {
-		mapPool, err := h.mapPoolRepo.GetByID(output.Session.MapPoolID)
-		if err == nil && mapPool != nil {
-			mapPoolResp := dto.ToMapPoolResponse(mapPool)
-			sessionDTO.MapPool = &mapPoolResp
-		}
-	}
+	sessionDTO.MapPool = h.sessionMapPoolResponse(output.Session)
 	
 	h.manager.BroadcastToRoom(client.RoomID, ws.Message{
 		Type: "veto:start",
@@ -552,6 +528,9 @@ func (h *RoomWebSocketHandler) handleVetoStart(client *ws.Client, msg *ws.Messag
 			"user_id": client.UserID,
 		},
 	})
+	h.webhookDispatcher.PublishVetoEvent(entities.WebhookEventVetoStarted, output.Session, room, map[string]interface{}{
+		"session": sessionDTO,
+	})
 	
 	log.Printf("Broadcasted veto:start to room %d for session %d", client.RoomID, sessionID)
 }
@@ -595,13 +574,7 @@ func (h *RoomWebSocketHandler) handleVetoReset(client *ws.Client, msg *ws.Messag
 	sessionDTO := dto.ToVetoSessionResponse(output.Session)
 	
 	// Загружаем map_pool для включения в ответ
-	if output.Session != nil {
-		mapPool, err := h.mapPoolRepo.GetByID(output.Session.MapPoolID)
-		if err == nil && mapPool != nil {
-			mapPoolResp := dto.ToMapPoolResponse(mapPool)
-			sessionDTO.MapPool = &mapPoolResp
-		}
-	}
+	sessionDTO.MapPool = h.sessionMapPoolResponse(output.Session)
 	
 	h.manager.BroadcastToRoom(client.RoomID, ws.Message{
 		Type: "veto:reset",
@@ -610,6 +583,26 @@ func (h *RoomWebSocketHandler) handleVetoReset(client *ws.Client, msg *ws.Messag
 			"user_id": client.UserID,
 		},
 	})
+	h.webhookDispatcher.PublishVetoEvent(entities.WebhookEventVetoReset, output.Session, room, map[string]interface{}{
+		"session": sessionDTO,
+	})
 	
 	log.Printf("Broadcasted veto:reset to room %d for session %d", client.RoomID, *room.VetoSessionID)
 }
+
+// sessionMapPoolResponse пул карт сессии для рассылки; карты берутся из снимка сессии,
+// поэтому пул остается в сообщениях и после его удаления
+func (h *RoomWebSocketHandler) sessionMapPoolResponse(session *entities.VetoSession) *dto.MapPoolResponse {
+	if session == nil {
+		return nil
+	}
+	mapPool, err := veto.LoadSessionMapPool(session, h.mapPoolRepo)
+	if err != nil {
+		if err != veto.ErrMapPoolNotFound {
+			log.Printf("Failed to load map pool %d of session %d: %v", session.MapPoolID, session.ID, err)
+		}
+		return nil
+	}
+	response := dto.ToMapPoolResponse(mapPool)
+	return &response
+}
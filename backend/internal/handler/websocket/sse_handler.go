@@ -0,0 +1,308 @@
+package websocket
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+	ws "github.com/bbp/backend/pkg/websocket"
+	"github.com/gin-gonic/gin"
+)
+
+// Интервал комментариев-пингов, чтобы прокси не закрывали неактивный поток
+const sseHeartbeatInterval = 15 * time.Second
+
+// StreamRoomEvents отдает события комнаты через Server-Sent Events для сетей, где websocket недоступен.
+// События те же, что получают websocket клиенты; команды отправляются через REST.
+func (h *RoomWebSocketHandler) StreamRoomEvents(c *gin.Context) {
+	user, ok := h.authenticateUser(c)
+	if !ok {
+		return
+	}
+
+	roomID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid room id"})
+		return
+	}
+
+	room, err := h.roomRepo.GetByID(uint(roomID))
+	if err != nil || room == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "room not found"})
+		return
+	}
+
+	isParticipant := false
+	for _, p := range room.Participants {
+		if p.UserID == user.ID {
+			isParticipant = true
+			break
+		}
+	}
+	if !isParticipant {
+		c.JSON(http.StatusForbidden, gin.H{"error": "not a participant"})
+		return
+	}
+
+	h.streamEvents(c, room.ID, nil)
+}
+
+// StreamSessionEvents отдает события вето комнаты по share token без авторизации.
+// Участники комнаты и ее служебные события в публичный поток не попадают; поток следует
+// за текущей сессией комнаты, когда владелец начинает следующее вето серии.
+func (h *RoomWebSocketHandler) StreamSessionEvents(c *gin.Context) {
+	session, err := h.vetoSessionRepo.GetByShareToken(c.Param("token"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+	if session == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "session not found"})
+		return
+	}
+
+	room, err := h.roomRepo.GetByVetoSessionID(session.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+	if room == nil {
+		// События рассылаются только в комнаты; сессию без комнаты можно отслеживать через /api/overlay/:token/stream
+		c.JSON(http.StatusNotFound, gin.H{"error": "session has no room"})
+		return
+	}
+
+	h.streamEvents(c, room.ID, &shareEventFilter{sessionRepo: h.vetoSessionRepo, roomID: room.ID, sessionID: session.ID})
+}
+
+// streamEvents подписывает запрос на события комнаты и пишет их в поток до отключения клиента.
+// При переподключении с Last-Event-ID пропущенные события досылаются; если их уже нет в журнале,
+// клиент получает текущее состояние room:state. Поток по share token получает только события,
+// отобранные share.
+func (h *RoomWebSocketHandler) streamEvents(c *gin.Context, roomID uint, share *shareEventFilter) {
+	lastEventID, _ := strconv.ParseUint(c.GetHeader("Last-Event-ID"), 10, 64)
+	sub, missed, resumed := h.manager.Subscribe(roomID, lastEventID)
+	defer h.manager.Unsubscribe(sub)
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no") // Отключает буферизацию в nginx
+	c.Status(http.StatusOK)
+
+	fmt.Fprintf(c.Writer, "retry: %d\n\n", 3000)
+	if !resumed {
+		// Состояние загружается после подписки, чтобы не потерять события между ними
+		room, err := h.roomRepo.GetByID(roomID)
+		if err != nil || room == nil {
+			return
+		}
+		state := h.roomStateMessage(room)
+		if share != nil {
+			if state, err = share.follow(room); err != nil {
+				log.Printf("Error loading current session of room %d: %v", roomID, err)
+				return
+			}
+		}
+		if !writeSSEState(c, state) {
+			return
+		}
+	}
+	for _, event := range missed {
+		if event, ok := share.pass(event); ok {
+			writeSSEEvent(c, event)
+		}
+	}
+	if resumed && share != nil {
+		// Смена сессии комнаты могла уже выпасть из журнала
+		room, err := h.roomRepo.GetByID(roomID)
+		if err != nil || room == nil {
+			return
+		}
+		if room.VetoSessionID != nil && *room.VetoSessionID != share.sessionID {
+			state, err := share.follow(room)
+			if err != nil {
+				log.Printf("Error loading current session of room %d: %v", roomID, err)
+				return
+			}
+			if !writeSSEState(c, state) {
+				return
+			}
+		}
+	}
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(sseHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case <-heartbeat.C:
+			fmt.Fprint(c.Writer, ": ping\n\n")
+			c.Writer.Flush()
+		case event, ok := <-sub.Events:
+			if !ok {
+				// Клиент не успевает читать события - переподключится с Last-Event-ID
+				return
+			}
+			if event, ok := share.pass(event); ok {
+				writeSSEEvent(c, event)
+				c.Writer.Flush()
+			}
+			if event.Type == "room:deleted" {
+				return
+			}
+		}
+	}
+}
+
+// authenticateUser проверяет access token из query параметра или заголовка Authorization
+func (h *RoomWebSocketHandler) authenticateUser(c *gin.Context) (*entities.User, bool) {
+	// EventSource в браузере не умеет отправлять заголовки, поэтому токен можно передать в query
+	token := c.Query("token")
+	if token == "" {
+		parts := strings.Split(c.GetHeader("Authorization"), " ")
+		if len(parts) == 2 && parts[0] == "Bearer" {
+			token = parts[1]
+		}
+	}
+
+	if token == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "token required"})
+		return nil, false
+	}
+
+	claims, err := h.jwtService.ValidateToken(token)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid or expired token"})
+		return nil, false
+	}
+
+	return &entities.User{
+		ID:       claims.UserID,
+		Username: claims.Username,
+	}, true
+}
+
+// shareEventFilter отбирает события комнаты для потока по share token: ход вето текущей
+// сессии и удаление комнаты. Когда комната переходит к следующей сессии серии, зритель
+// получает room:state только с room_id и share token новой сессии и дальше следует за ней.
+type shareEventFilter struct {
+	sessionRepo repositories.VetoSessionRepository
+	roomID      uint
+	sessionID   uint
+}
+
+// pass решает, попадает ли событие в публичный поток; room:state заменяется безопасной версией.
+// Без фильтра (поток участника комнаты) проходят все события.
+func (f *shareEventFilter) pass(event ws.Event) (ws.Event, bool) {
+	if f == nil {
+		return event, true
+	}
+
+	switch {
+	case event.Type == "room:deleted":
+		return event, true
+	case strings.HasPrefix(event.Type, "veto:"):
+		var msg struct {
+			Data struct {
+				Session *struct {
+					ID uint `json:"id"`
+				} `json:"session"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal(event.Data, &msg); err != nil || msg.Data.Session == nil {
+			return event, false
+		}
+		return event, msg.Data.Session.ID == f.sessionID
+	case event.Type == "room:state":
+		// room:state из REST содержит veto_session_id, из websocket - veto_session
+		var msg struct {
+			Data struct {
+				VetoSessionID *uint `json:"veto_session_id"`
+				VetoSession   *struct {
+					ID uint `json:"id"`
+				} `json:"veto_session"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal(event.Data, &msg); err != nil {
+			return event, false
+		}
+		sessionID := uint(0)
+		if msg.Data.VetoSessionID != nil {
+			sessionID = *msg.Data.VetoSessionID
+		} else if msg.Data.VetoSession != nil {
+			sessionID = msg.Data.VetoSession.ID
+		}
+		if sessionID == 0 || sessionID == f.sessionID {
+			return event, false
+		}
+
+		state, err := f.switchTo(sessionID)
+		if err != nil {
+			log.Printf("Error loading session %d of room %d: %v", sessionID, f.roomID, err)
+			return event, false
+		}
+		data, err := json.Marshal(state)
+		if err != nil {
+			log.Printf("Error marshaling room state: %v", err)
+			return event, false
+		}
+		event.Data = data
+		return event, true
+	default:
+		return event, false
+	}
+}
+
+// follow переключает поток на текущую сессию комнаты и возвращает room:state с ее share token
+func (f *shareEventFilter) follow(room *entities.Room) (ws.Message, error) {
+	sessionID := f.sessionID
+	if room.VetoSessionID != nil {
+		sessionID = *room.VetoSessionID
+	}
+	return f.switchTo(sessionID)
+}
+
+func (f *shareEventFilter) switchTo(sessionID uint) (ws.Message, error) {
+	session, err := f.sessionRepo.GetByID(sessionID)
+	if err != nil {
+		return ws.Message{}, err
+	}
+	if session == nil {
+		return ws.Message{}, fmt.Errorf("session %d not found", sessionID)
+	}
+	f.sessionID = session.ID
+
+	return ws.Message{
+		Type: "room:state",
+		Data: map[string]interface{}{
+			"room_id":     f.roomID,
+			"share_token": session.ShareToken,
+		},
+	}, nil
+}
+
+// writeSSEState пишет состояние без id: оно не хранится в журнале событий комнаты
+func writeSSEState(c *gin.Context, state ws.Message) bool {
+	data, err := json.Marshal(state)
+	if err != nil {
+		log.Printf("Error marshaling room state: %v", err)
+		return false
+	}
+	fmt.Fprintf(c.Writer, "data: %s\n\n", data)
+	return true
+}
+
+// writeSSEEvent пишет событие без имени, чтобы клиент обрабатывал его так же, как сообщение websocket
+func writeSSEEvent(c *gin.Context, event ws.Event) {
+	fmt.Fprintf(c.Writer, "id: %d\ndata: %s\n\n", event.ID, event.Data)
+}
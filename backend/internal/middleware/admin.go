@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/bbp/backend/internal/domain/repositories"
+	"github.com/gin-gonic/gin"
+)
+
+// RequireAdmin пропускает только администраторов: пользователей с подтвержденным email из списка ADMIN_EMAILS
+// Должен идти после AuthMiddleware
+func RequireAdmin(userRepo repositories.UserRepository, adminEmails []string) gin.HandlerFunc {
+	admins := make(map[string]struct{}, len(adminEmails))
+	for _, email := range adminEmails {
+		admins[strings.ToLower(strings.TrimSpace(email))] = struct{}{}
+	}
+
+	return func(c *gin.Context) {
+		userCtx, err := GetUserFromContext(c)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+			c.Abort()
+			return
+		}
+
+		user, err := userRepo.GetByID(userCtx.ID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+			c.Abort()
+			return
+		}
+		if user == nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+			c.Abort()
+			return
+		}
+
+		// Без подтверждения email чужой адрес из списка мог бы занять кто угодно
+		_, isAdmin := admins[strings.ToLower(user.Email)]
+		if !isAdmin || !user.EmailVerified {
+			c.JSON(http.StatusForbidden, gin.H{"error": "admin access required"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,163 @@
+package middleware
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// APIKeyHeader заголовок с персональным API ключом
+	APIKeyHeader = "X-API-Key"
+	// APIKeyContextKey ключ для хранения API ключа в контексте
+	APIKeyContextKey = "api_key"
+)
+
+// APIKeyAuthenticator проверяет API ключи
+type APIKeyAuthenticator interface {
+	// Authenticate возвращает nil, если ключ неизвестен, отозван или истек
+	Authenticate(rawKey, ip string) (*entities.APIKey, *entities.User, error)
+	CanControlSession(key *entities.APIKey, sessionID uint) (bool, error)
+}
+
+// apiKeyVetoTarget что проверять у ключа, ограниченного комнатами
+type apiKeyVetoTarget int
+
+const (
+	apiKeyVetoNewSession apiKeyVetoTarget = iota // Новая сессия еще не относится ни к одной комнате
+	apiKeyVetoSession                            // :id - сессия вето
+	apiKeyVetoRoom                               // :id - комната
+)
+
+// apiKeyVetoRoutes маршруты, доступные ключам со scope veto
+var apiKeyVetoRoutes = map[string]apiKeyVetoTarget{
+	"/api/veto/sessions":                 apiKeyVetoNewSession,
+	"/api/veto/sessions/:id/start":       apiKeyVetoSession,
+	"/api/veto/sessions/:id/ban":         apiKeyVetoSession,
+	"/api/veto/sessions/:id/pick":        apiKeyVetoSession,
+	"/api/veto/sessions/:id/select-side": apiKeyVetoSession,
+	"/api/veto/sessions/:id/reset":       apiKeyVetoSession,
+	"/api/rooms/:id/veto":                apiKeyVetoRoom,
+}
+
+// apiKeyReadDenied разделы, закрытые для API ключей даже на чтение
+var apiKeyReadDenied = []string{
+	"/api/admin",
+	"/api/api-keys",
+	"/api/auth/2fa",
+	"/api/users/me/export",
+	"/api/webhooks",
+}
+
+// APIKeyMiddleware аутентифицирует запросы с заголовком X-API-Key
+// Подключается глобально до RateLimitMiddleware: у ключей свой лимит запросов на ключ,
+// а AuthMiddleware пропускает уже аутентифицированные ключом запросы
+func APIKeyMiddleware(authenticator APIKeyAuthenticator, rate int, window time.Duration) gin.HandlerFunc {
+	limiter := NewRateLimiter(rate, window)
+
+	return func(c *gin.Context) {
+		rawKey := strings.TrimSpace(c.GetHeader(APIKeyHeader))
+		if rawKey == "" {
+			c.Next()
+			return
+		}
+
+		key, user, err := authenticator.Authenticate(rawKey, c.ClientIP())
+		if err != nil {
+			log.Printf("Failed to authenticate api key: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+			c.Abort()
+			return
+		}
+		if key == nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid or revoked api key"})
+			c.Abort()
+			return
+		}
+
+		if !limiter.Allow(fmt.Sprintf("apikey:%d", key.ID)) {
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": "too many requests"})
+			c.Abort()
+			return
+		}
+
+		allowed, err := apiKeyAllows(c, authenticator, key)
+		if err != nil {
+			log.Printf("Failed to check api key %d scope: %v", key.ID, err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+			c.Abort()
+			return
+		}
+		if !allowed {
+			c.JSON(http.StatusForbidden, gin.H{"error": "api key is not allowed to perform this action"})
+			c.Abort()
+			return
+		}
+
+		c.Set(UserContextKey, &entities.User{
+			ID:       user.ID,
+			Username: user.Username,
+		})
+		c.Set(APIKeyContextKey, key)
+
+		c.Next()
+	}
+}
+
+// apiKeyAllows проверяет scopes ключа для маршрута запроса
+func apiKeyAllows(c *gin.Context, authenticator APIKeyAuthenticator, key *entities.APIKey) (bool, error) {
+	route := c.FullPath()
+	if route == "" {
+		return false, nil
+	}
+
+	if c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead {
+		for _, prefix := range apiKeyReadDenied {
+			if route == prefix || strings.HasPrefix(route, prefix+"/") {
+				return false, nil
+			}
+		}
+		return key.HasScope(entities.APIKeyScopeRead), nil
+	}
+
+	target, ok := apiKeyVetoRoutes[route]
+	if c.Request.Method != http.MethodPost || !ok || !key.HasScope(entities.APIKeyScopeVeto) {
+		return false, nil
+	}
+	if !key.IsRoomRestricted() {
+		return true, nil
+	}
+
+	switch target {
+	case apiKeyVetoSession:
+		id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+		if err != nil {
+			return false, nil
+		}
+		return authenticator.CanControlSession(key, uint(id))
+	case apiKeyVetoRoom:
+		id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+		if err != nil {
+			return false, nil
+		}
+		return key.AllowsRoom(uint(id)), nil
+	default:
+		return false, nil
+	}
+}
+
+// GetAPIKeyFromContext извлекает API ключ, которым аутентифицирован запрос
+func GetAPIKeyFromContext(c *gin.Context) (*entities.APIKey, bool) {
+	value, exists := c.Get(APIKeyContextKey)
+	if !exists {
+		return nil, false
+	}
+	key, ok := value.(*entities.APIKey)
+	return key, ok
+}
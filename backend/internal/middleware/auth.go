@@ -12,11 +12,19 @@ import (
 const (
 	// UserContextKey ключ для хранения пользователя в контексте
 	UserContextKey = "user"
+	// TokenClaimsContextKey ключ для хранения claims access токена в контексте
+	TokenClaimsContextKey = "token_claims"
 )
 
 // AuthMiddleware создает middleware для проверки JWT токена
 func AuthMiddleware(jwtService *jwt.JWTService) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		// Запрос уже аутентифицирован API ключом
+		if _, ok := GetAPIKeyFromContext(c); ok {
+			c.Next()
+			return
+		}
+
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "authorization header required"})
@@ -46,13 +54,49 @@ func AuthMiddleware(jwtService *jwt.JWTService) gin.HandlerFunc {
 		user := &entities.User{
 			ID:       claims.UserID,
 			Username: claims.Username,
+			IsGuest:  claims.Guest,
 		}
 		c.Set(UserContextKey, user)
+		c.Set(TokenClaimsContextKey, claims)
+
+		c.Next()
+	}
+}
+
+// OptionalAuthMiddleware сохраняет пользователя в контексте, если передан валидный токен
+// Запросы без токена или с невалидным токеном пропускаются как анонимные
+func OptionalAuthMiddleware(jwtService *jwt.JWTService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := GetAPIKeyFromContext(c); ok {
+			c.Next()
+			return
+		}
+
+		parts := strings.Split(c.GetHeader("Authorization"), " ")
+		if len(parts) == 2 && parts[0] == "Bearer" {
+			if claims, err := jwtService.ValidateToken(parts[1]); err == nil {
+				c.Set(UserContextKey, &entities.User{
+					ID:       claims.UserID,
+					Username: claims.Username,
+					IsGuest:  claims.Guest,
+				})
+			}
+		}
 
 		c.Next()
 	}
 }
 
+// GetTokenClaimsFromContext извлекает claims access токена из контекста
+func GetTokenClaimsFromContext(c *gin.Context) (*jwt.Claims, bool) {
+	value, exists := c.Get(TokenClaimsContextKey)
+	if !exists {
+		return nil, false
+	}
+	claims, ok := value.(*jwt.Claims)
+	return claims, ok
+}
+
 // GetUserFromContext извлекает пользователя из контекста
 func GetUserFromContext(c *gin.Context) (*entities.User, error) {
 	userInterface, exists := c.Get(UserContextKey)
@@ -66,4 +110,4 @@ func GetUserFromContext(c *gin.Context) (*entities.User, error) {
 	}
 
 	return user, nil
-}
\ No newline at end of file
+}
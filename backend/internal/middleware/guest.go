@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RejectGuests запрещает действие гостевым аккаунтам
+// Должен идти после AuthMiddleware
+func RejectGuests() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, err := GetUserFromContext(c)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+			c.Abort()
+			return
+		}
+		if user.IsGuest {
+			c.JSON(http.StatusForbidden, gin.H{"error": "guest accounts cannot perform this action"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
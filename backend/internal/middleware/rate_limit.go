@@ -98,6 +98,12 @@ func RateLimitMiddleware(rate int, window time.Duration) gin.HandlerFunc {
 	limiter := NewRateLimiter(rate, window)
 
 	return func(c *gin.Context) {
+		// Запросы по API ключам ограничивает APIKeyMiddleware
+		if _, ok := GetAPIKeyFromContext(c); ok {
+			c.Next()
+			return
+		}
+
 		// Пытаемся получить user ID из контекста (если пользователь авторизован)
 		// Это позволяет разным пользователям иметь свои лимиты
 		var identifier string
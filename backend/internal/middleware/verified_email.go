@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/bbp/backend/internal/domain/repositories"
+	"github.com/gin-gonic/gin"
+)
+
+// RequireVerifiedEmail пропускает запрос только для пользователей с подтвержденным email
+// Должен идти после AuthMiddleware
+func RequireVerifiedEmail(userRepo repositories.UserRepository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userCtx, err := GetUserFromContext(c)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+			c.Abort()
+			return
+		}
+
+		// Статус проверяем по БД: в токене он может быть устаревшим
+		user, err := userRepo.GetByID(userCtx.ID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+			c.Abort()
+			return
+		}
+		if user == nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+			c.Abort()
+			return
+		}
+		if !user.EmailVerified {
+			c.JSON(http.StatusForbidden, gin.H{"error": "email verification required"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
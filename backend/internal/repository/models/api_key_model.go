@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+type APIKeyModel struct {
+	ID         uint   `gorm:"primaryKey"`
+	UserID     uint   `gorm:"not null;index"`
+	Name       string `gorm:"not null;size:100"`
+	Prefix     string `gorm:"not null;size:20"`
+	KeyHash    string `gorm:"not null;uniqueIndex;size:64"`
+	LastUsedAt *time.Time
+	LastUsedIP string `gorm:"size:45"`
+	ExpiresAt  *time.Time
+	RevokedAt  *time.Time
+	CreatedAt  time.Time
+}
+
+func (APIKeyModel) TableName() string {
+	return "api_keys"
+}
+
+// APIKeyScopeModel scope ключа
+type APIKeyScopeModel struct {
+	ID       uint   `gorm:"primaryKey"`
+	APIKeyID uint   `gorm:"not null;uniqueIndex:idx_api_key_scope"`
+	Scope    string `gorm:"not null;size:20;uniqueIndex:idx_api_key_scope"`
+}
+
+func (APIKeyScopeModel) TableName() string {
+	return "api_key_scopes"
+}
+
+// APIKeyRoomModel комната, в которой ключ управляет вето
+type APIKeyRoomModel struct {
+	ID       uint `gorm:"primaryKey"`
+	APIKeyID uint `gorm:"not null;uniqueIndex:idx_api_key_room"`
+	RoomID   uint `gorm:"not null;uniqueIndex:idx_api_key_room"`
+}
+
+func (APIKeyRoomModel) TableName() string {
+	return "api_key_rooms"
+}
@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+type RefreshTokenModel struct {
+	ID           uint      `gorm:"primaryKey"`
+	UserID       uint      `gorm:"not null;index"`
+	TokenHash    string    `gorm:"uniqueIndex;not null;size:64"`
+	AccessJTI    string    `gorm:"size:64"`
+	ExpiresAt    time.Time `gorm:"not null;index"`
+	RevokedAt    *time.Time
+	ReplacedByID *uint
+	CreatedAt    time.Time
+}
+
+func (RefreshTokenModel) TableName() string {
+	return "refresh_tokens"
+}
+
+type RevokedTokenModel struct {
+	JTI       string    `gorm:"primaryKey;size:64"`
+	UserID    uint      `gorm:"not null;index"`
+	ExpiresAt time.Time `gorm:"not null;index"`
+	CreatedAt time.Time
+}
+
+func (RevokedTokenModel) TableName() string {
+	return "revoked_tokens"
+}
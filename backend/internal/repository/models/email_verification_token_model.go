@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+type EmailVerificationTokenModel struct {
+	ID        uint      `gorm:"primaryKey"`
+	UserID    uint      `gorm:"not null;index"`
+	Email     string    `gorm:"not null;size:255"`
+	TokenHash string    `gorm:"uniqueIndex;not null;size:64"`
+	ExpiresAt time.Time `gorm:"not null"`
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+func (EmailVerificationTokenModel) TableName() string {
+	return "email_verification_tokens"
+}
@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+type LoginThrottleModel struct {
+	Scope         string    `gorm:"primaryKey;size:20"`
+	Key           string    `gorm:"primaryKey;size:255"`
+	Failures      int       `gorm:"not null;default:0"`
+	LastFailureAt time.Time `gorm:"not null;index"`
+	LockedUntil   *time.Time
+}
+
+func (LoginThrottleModel) TableName() string {
+	return "login_throttles"
+}
+
+type LockoutEventModel struct {
+	ID          uint   `gorm:"primaryKey"`
+	Action      string `gorm:"not null;size:20"`
+	Scope       string `gorm:"not null;size:20"`
+	Key         string `gorm:"not null;size:255;index"`
+	UserID      *uint  `gorm:"index"`
+	IP          string `gorm:"size:64"`
+	Failures    int    `gorm:"not null;default:0"`
+	LockedUntil *time.Time
+	ActorID     *uint
+	CreatedAt   time.Time `gorm:"index"`
+}
+
+func (LockoutEventModel) TableName() string {
+	return "lockout_events"
+}
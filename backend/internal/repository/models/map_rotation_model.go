@@ -0,0 +1,17 @@
+package models
+
+import (
+	"time"
+)
+
+type MapRotationModel struct {
+	ID            uint       `gorm:"primaryKey"`
+	GameID        uint       `gorm:"not null;index"`
+	EffectiveFrom time.Time  `gorm:"not null;index"`
+	CreatedAt     time.Time
+	Maps          []MapModel `gorm:"many2many:map_rotation_maps;"`
+}
+
+func (MapRotationModel) TableName() string {
+	return "map_rotations"
+}
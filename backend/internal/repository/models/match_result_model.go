@@ -0,0 +1,49 @@
+package models
+
+import "time"
+
+type MatchResultModel struct {
+	ID             uint   `gorm:"primaryKey"`
+	VetoSessionID  uint   `gorm:"not null;uniqueIndex"`
+	Status         string `gorm:"not null;size:20;index"`
+	ReportedByID   uint   `gorm:"not null"`
+	ReportedByTeam string `gorm:"size:1"`
+	ConfirmedByID  *uint
+	DisputedByID   *uint
+	DisputeReason  *string `gorm:"size:500"`
+	ScoreA         int     `gorm:"not null"`
+	ScoreB         int     `gorm:"not null"`
+	WinnerTeam     string  `gorm:"not null;size:1"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	ConfirmedAt    *time.Time
+}
+
+func (MatchResultModel) TableName() string {
+	return "match_results"
+}
+
+type MapResultModel struct {
+	ID            uint  `gorm:"primaryKey"`
+	MatchResultID uint  `gorm:"not null;index"`
+	VetoActionID  *uint `gorm:"index"`
+	MapID         uint  `gorm:"not null"`
+	Order         int   `gorm:"column:map_order;not null"`
+	ScoreA        int   `gorm:"not null"`
+	ScoreB        int   `gorm:"not null"`
+}
+
+func (MapResultModel) TableName() string {
+	return "map_results"
+}
+
+type MatchResultPlayerModel struct {
+	ID            uint   `gorm:"primaryKey"`
+	MatchResultID uint   `gorm:"not null;uniqueIndex:idx_match_result_players_result_user"`
+	UserID        uint   `gorm:"not null;uniqueIndex:idx_match_result_players_result_user;index"`
+	Team          string `gorm:"not null;size:1"`
+}
+
+func (MatchResultPlayerModel) TableName() string {
+	return "match_result_players"
+}
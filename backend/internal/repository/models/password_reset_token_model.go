@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+type PasswordResetTokenModel struct {
+	ID        uint      `gorm:"primaryKey"`
+	UserID    uint      `gorm:"not null;index"`
+	TokenHash string    `gorm:"uniqueIndex;not null;size:64"`
+	ExpiresAt time.Time `gorm:"not null"`
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+func (PasswordResetTokenModel) TableName() string {
+	return "password_reset_tokens"
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+type RoomMatchModel struct {
+	ID            uint `gorm:"primaryKey"`
+	RoomID        uint `gorm:"not null;index:idx_room_matches_room_number,unique"`
+	Number        int  `gorm:"not null;index:idx_room_matches_room_number,unique"`
+	VetoSessionID uint `gorm:"not null;index"`
+	SidesSwapped  bool `gorm:"not null;default:false"`
+	CreatedAt     time.Time
+}
+
+func (RoomMatchModel) TableName() string {
+	return "room_matches"
+}
@@ -17,6 +17,8 @@ type RoomModel struct {
 	MapPoolID       *uint          `gorm:"index"`
 	VetoType        *string        `gorm:"size:10"` // Тип вето (bo1, bo3, bo5)
 	VetoSessionID   *uint       `gorm:"index"`
+	TeamAID         *uint          `gorm:"index"`
+	TeamBID         *uint          `gorm:"index"`
 	MaxParticipants int            `gorm:"default:10"`
 	CreatedAt       time.Time
 	UpdatedAt       time.Time
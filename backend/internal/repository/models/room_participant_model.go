@@ -10,6 +10,7 @@ type RoomParticipantModel struct {
 	RoomID    uint           `gorm:"not null;index"`
 	UserID    uint           `gorm:"not null;index"`
 	Role      string         `gorm:"not null;size:20"`
+	TeamID    *uint          `gorm:"index"`
 	JoinedAt  time.Time
 	DeletedAt gorm.DeletedAt `gorm:"index"`
 }
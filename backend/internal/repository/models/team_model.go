@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type TeamModel struct {
+	ID        uint   `gorm:"primaryKey"`
+	Name      string `gorm:"not null;size:100;index"` // Уникальность проверяется среди неудаленных команд
+	Tag       string `gorm:"not null;size:10"`
+	LogoURL   string `gorm:"size:255"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"` // Удаленная команда остается в истории сессий и комнат
+}
+
+func (TeamModel) TableName() string {
+	return "teams"
+}
+
+type TeamMemberModel struct {
+	ID       uint   `gorm:"primaryKey"`
+	TeamID   uint   `gorm:"not null;uniqueIndex:idx_team_members_team_user"`
+	UserID   uint   `gorm:"not null;index;uniqueIndex:idx_team_members_team_user"`
+	Role     string `gorm:"not null;size:20"`
+	JoinedAt time.Time
+}
+
+func (TeamMemberModel) TableName() string {
+	return "team_members"
+}
+
+type TeamInviteModel struct {
+	ID          uint   `gorm:"primaryKey"`
+	TeamID      uint   `gorm:"not null;index"`
+	UserID      uint   `gorm:"not null;index"`
+	InvitedByID uint   `gorm:"not null"`
+	Role        string `gorm:"not null;size:20"`
+	Status      string `gorm:"not null;size:20;index"`
+	CreatedAt   time.Time
+	RespondedAt *time.Time
+}
+
+func (TeamInviteModel) TableName() string {
+	return "team_invites"
+}
@@ -0,0 +1,93 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type TournamentModel struct {
+	ID            uint   `gorm:"primaryKey"`
+	OwnerID       uint   `gorm:"not null;index"`
+	Name          string `gorm:"not null;size:100"`
+	GameID        uint   `gorm:"not null"`
+	MapPoolID     uint   `gorm:"not null"`
+	Format        string `gorm:"not null;size:30"`
+	Status        string `gorm:"not null;size:20;index"`
+	MaxTeams      int    `gorm:"not null"`
+	SwissRounds   int    `gorm:"not null;default:0"`
+	FinalVetoType string `gorm:"size:10"`
+	WinnerTeamID  *uint
+	StartedAt     *time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	DeletedAt     gorm.DeletedAt `gorm:"index"`
+}
+
+func (TournamentModel) TableName() string {
+	return "tournaments"
+}
+
+type TournamentTeamModel struct {
+	ID           uint `gorm:"primaryKey"`
+	TournamentID uint `gorm:"not null;uniqueIndex:idx_tournament_teams_tournament_team"`
+	TeamID       uint `gorm:"not null;index;uniqueIndex:idx_tournament_teams_tournament_team"`
+	Seed         int  `gorm:"not null"`
+	RegisteredAt time.Time
+}
+
+func (TournamentTeamModel) TableName() string {
+	return "tournament_teams"
+}
+
+type TournamentMatchModel struct {
+	ID               uint   `gorm:"primaryKey"`
+	TournamentID     uint   `gorm:"not null;index"`
+	Bracket          string `gorm:"not null;size:20"`
+	Round            int    `gorm:"not null"`
+	Position         int    `gorm:"not null"`
+	TeamAID          *uint
+	TeamBID          *uint
+	WinnerTeamID     *uint
+	Status           string `gorm:"not null;size:20"`
+	VetoType         string `gorm:"not null;size:10"`
+	ScheduledAt      *time.Time
+	RoomID           *uint
+	VetoSessionID    *uint `gorm:"uniqueIndex"`
+	NextMatchID      *uint
+	NextSlot         string `gorm:"size:1"`
+	LoserNextMatchID *uint
+	LoserNextSlot    string `gorm:"size:1"`
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+func (TournamentMatchModel) TableName() string {
+	return "tournament_matches"
+}
+
+// TournamentStageRuleModel правила пула карт стадии турнира
+type TournamentStageRuleModel struct {
+	ID            uint   `gorm:"primaryKey"`
+	TournamentID  uint   `gorm:"not null;uniqueIndex:idx_tournament_stage_rules_tournament_stage"`
+	Stage         string `gorm:"not null;size:20;uniqueIndex:idx_tournament_stage_rules_tournament_stage"`
+	NoRepeatPicks bool   `gorm:"not null;default:false"`
+	CarryOverBans bool   `gorm:"not null;default:false"`
+	DeciderMapID  *uint
+}
+
+func (TournamentStageRuleModel) TableName() string {
+	return "tournament_stage_rules"
+}
+
+// TournamentStageMapModel карта, исключенная на стадии турнира
+type TournamentStageMapModel struct {
+	ID           uint   `gorm:"primaryKey"`
+	TournamentID uint   `gorm:"not null;index"`
+	Stage        string `gorm:"not null;size:20"`
+	MapID        uint   `gorm:"not null"`
+}
+
+func (TournamentStageMapModel) TableName() string {
+	return "tournament_stage_maps"
+}
@@ -0,0 +1,41 @@
+package models
+
+import "time"
+
+type UserTOTPModel struct {
+	UserID       uint   `gorm:"primaryKey"`
+	Secret       string `gorm:"not null;size:64"`
+	ConfirmedAt  *time.Time
+	LastUsedStep int64 `gorm:"not null;default:0"`
+	CreatedAt    time.Time
+}
+
+func (UserTOTPModel) TableName() string {
+	return "user_totp"
+}
+
+type RecoveryCodeModel struct {
+	ID        uint   `gorm:"primaryKey"`
+	UserID    uint   `gorm:"not null;index"`
+	CodeHash  string `gorm:"not null;size:64"`
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+func (RecoveryCodeModel) TableName() string {
+	return "recovery_codes"
+}
+
+type LoginChallengeModel struct {
+	ID        uint      `gorm:"primaryKey"`
+	UserID    uint      `gorm:"not null;index"`
+	TokenHash string    `gorm:"uniqueIndex;not null;size:64"`
+	Attempts  int       `gorm:"not null;default:0"`
+	ExpiresAt time.Time `gorm:"not null"`
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+func (LoginChallengeModel) TableName() string {
+	return "login_challenges"
+}
@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+type UserIdentityModel struct {
+	ID        uint   `gorm:"primaryKey"`
+	UserID    uint   `gorm:"not null;index"`
+	Provider  string `gorm:"not null;size:50;uniqueIndex:idx_user_identity_provider_subject"`
+	Subject   string `gorm:"not null;size:255;uniqueIndex:idx_user_identity_provider_subject"`
+	Email     string `gorm:"size:255"`
+	CreatedAt time.Time
+}
+
+func (UserIdentityModel) TableName() string {
+	return "user_identities"
+}
+
+type OAuthStateModel struct {
+	State        string    `gorm:"primaryKey;size:64"`
+	Provider     string    `gorm:"not null;size:50"`
+	CodeVerifier string    `gorm:"not null;size:128"`
+	ExpiresAt    time.Time `gorm:"not null;index"`
+	CreatedAt    time.Time
+}
+
+func (OAuthStateModel) TableName() string {
+	return "oauth_states"
+}
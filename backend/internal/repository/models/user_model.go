@@ -6,13 +6,17 @@ import (
 )
 
 type UserModel struct {
-	ID        uint           `gorm:"primaryKey"`
-	Email     string         `gorm:"uniqueIndex;not null;size:255"`
-	Username  string         `gorm:"uniqueIndex;not null;size:100"`
-	Password  string         `gorm:"not null;size:255"`
-	CreatedAt time.Time
-	UpdatedAt time.Time
-	DeletedAt gorm.DeletedAt `gorm:"index"`
+	ID            uint   `gorm:"primaryKey"`
+	Email         string `gorm:"uniqueIndex;not null;size:255"`
+	Username      string `gorm:"uniqueIndex;not null;size:100"`
+	Password      string `gorm:"not null;size:255"`
+	EmailVerified bool   `gorm:"not null;default:false"`
+	IsGuest       bool   `gorm:"not null;default:false;index"`
+	// Видимость публичного профиля: public или private
+	ProfileVisibility string `gorm:"not null;size:20;default:public"`
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	DeletedAt         gorm.DeletedAt `gorm:"index"`
 }
 
 func (UserModel) TableName() string {
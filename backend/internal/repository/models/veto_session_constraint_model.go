@@ -0,0 +1,16 @@
+package models
+
+// VetoSessionConstraintModel ограничение пула карт сессии
+// Kind: "excluded" - карта недоступна обеим командам, "team_excluded" - команда Team
+// не может ее пикнуть, "decider" - карта назначена десайдером
+type VetoSessionConstraintModel struct {
+	ID            uint   `gorm:"primaryKey"`
+	VetoSessionID uint   `gorm:"not null;index"`
+	MapID         uint   `gorm:"not null"`
+	Kind          string `gorm:"not null;size:20"`
+	Team          string `gorm:"size:1"`
+}
+
+func (VetoSessionConstraintModel) TableName() string {
+	return "veto_session_constraints"
+}
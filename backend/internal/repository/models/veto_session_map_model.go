@@ -0,0 +1,18 @@
+package models
+
+// VetoSessionMapModel снимок карты, доступной в сессии на момент её создания
+// Хранит название и изображение, чтобы старые сессии отображались
+// корректно после изменения пула или каталога
+type VetoSessionMapModel struct {
+	ID            uint   `gorm:"primaryKey"`
+	VetoSessionID uint   `gorm:"not null;index"`
+	MapID         uint   `gorm:"not null;index"`
+	Position      int    `gorm:"not null"`
+	Name          string `gorm:"not null;size:100"`
+	Slug          string `gorm:"not null;size:100"`
+	ImageURL      string `gorm:"size:255"`
+}
+
+func (VetoSessionMapModel) TableName() string {
+	return "veto_session_maps"
+}
@@ -10,10 +10,13 @@ type VetoSessionModel struct {
 	UserID        *uint          `gorm:"index"`
 	GameID        uint           `gorm:"not null;index"`
 	MapPoolID     uint           `gorm:"not null;index"`
+	MapRotationID *uint          `gorm:"index"`
 	Type          string         `gorm:"not null;size:10"`
 	Status        string         `gorm:"not null;size:20"`
 	TeamAName     string         `gorm:"not null;size:100"`
 	TeamBName     string         `gorm:"not null;size:100"`
+	TeamAID       *uint          `gorm:"index"` // Команда A, если сессия создана для зарегистрированной команды
+	TeamBID       *uint          `gorm:"index"`
 	CurrentTeam   string         `gorm:"not null;size:1"`
 	SelectedMapID *uint          `gorm:"index"`
 	SelectedSide  *string        `gorm:"size:20"`
@@ -0,0 +1,50 @@
+package models
+
+import "time"
+
+type WebhookModel struct {
+	ID        uint   `gorm:"primaryKey"`
+	UserID    uint   `gorm:"not null;index"`
+	RoomID    *uint  `gorm:"index"`
+	URL       string `gorm:"not null;size:2048"`
+	Secret    string `gorm:"not null;size:128"`
+	IsActive  bool   `gorm:"not null"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (WebhookModel) TableName() string {
+	return "webhooks"
+}
+
+// WebhookEventModel событие из фильтра подписки
+type WebhookEventModel struct {
+	ID        uint   `gorm:"primaryKey"`
+	WebhookID uint   `gorm:"not null;uniqueIndex:idx_webhook_event"`
+	Event     string `gorm:"not null;size:50;uniqueIndex:idx_webhook_event"`
+}
+
+func (WebhookEventModel) TableName() string {
+	return "webhook_events"
+}
+
+type WebhookDeliveryModel struct {
+	ID             uint   `gorm:"primaryKey"`
+	WebhookID      uint   `gorm:"not null;index"`
+	Event          string `gorm:"not null;size:50"`
+	Payload        string `gorm:"type:text;not null"`
+	Status         string `gorm:"not null;size:20;index:idx_webhook_delivery_due"`
+	Attempts       int    `gorm:"not null;default:0"`
+	ResponseStatus *int
+	ResponseBody   string     `gorm:"type:text"`
+	LastError      string     `gorm:"size:500"`
+	NextAttemptAt  *time.Time `gorm:"index:idx_webhook_delivery_due"`
+	DeliveredAt    *time.Time
+	RedeliveryOf   *uint
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func (WebhookDeliveryModel) TableName() string {
+	return "webhook_deliveries"
+}
@@ -0,0 +1,130 @@
+package sqlite
+
+import (
+	"errors"
+	"time"
+
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+	"github.com/bbp/backend/internal/repository/models"
+	"gorm.io/gorm"
+)
+
+type apiKeyRepository struct {
+	db *gorm.DB
+}
+
+func NewAPIKeyRepository(db *gorm.DB) repositories.APIKeyRepository {
+	return &apiKeyRepository{db: db}
+}
+
+func (r *apiKeyRepository) Create(key *entities.APIKey) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		model := &models.APIKeyModel{
+			UserID:    key.UserID,
+			Name:      key.Name,
+			Prefix:    key.Prefix,
+			KeyHash:   key.KeyHash,
+			ExpiresAt: key.ExpiresAt,
+		}
+		if err := tx.Create(model).Error; err != nil {
+			return err
+		}
+		key.ID = model.ID
+		key.CreatedAt = model.CreatedAt
+
+		for _, scope := range key.Scopes {
+			if err := tx.Create(&models.APIKeyScopeModel{APIKeyID: key.ID, Scope: string(scope)}).Error; err != nil {
+				return err
+			}
+		}
+		for _, roomID := range key.RoomIDs {
+			if err := tx.Create(&models.APIKeyRoomModel{APIKeyID: key.ID, RoomID: roomID}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *apiKeyRepository) GetByID(id uint) (*entities.APIKey, error) {
+	return r.getOne(r.db.Where("id = ?", id))
+}
+
+func (r *apiKeyRepository) GetByHash(keyHash string) (*entities.APIKey, error) {
+	return r.getOne(r.db.Where("key_hash = ?", keyHash))
+}
+
+func (r *apiKeyRepository) GetByUserID(userID uint) ([]entities.APIKey, error) {
+	var modelList []models.APIKeyModel
+	if err := r.db.Where("user_id = ?", userID).Order("created_at DESC, id DESC").Find(&modelList).Error; err != nil {
+		return nil, err
+	}
+
+	keys := make([]entities.APIKey, len(modelList))
+	for i := range modelList {
+		key, err := r.withDetails(&modelList[i])
+		if err != nil {
+			return nil, err
+		}
+		keys[i] = *key
+	}
+	return keys, nil
+}
+
+func (r *apiKeyRepository) Revoke(id uint, revokedAt time.Time) error {
+	return r.db.Model(&models.APIKeyModel{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", revokedAt).Error
+}
+
+func (r *apiKeyRepository) UpdateLastUsed(id uint, usedAt time.Time, ip string) error {
+	return r.db.Model(&models.APIKeyModel{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{"last_used_at": usedAt, "last_used_ip": ip}).Error
+}
+
+func (r *apiKeyRepository) getOne(query *gorm.DB) (*entities.APIKey, error) {
+	var model models.APIKeyModel
+	if err := query.First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return r.withDetails(&model)
+}
+
+// withDetails конвертирует модель и загружает scopes и комнаты ключа
+func (r *apiKeyRepository) withDetails(model *models.APIKeyModel) (*entities.APIKey, error) {
+	key := &entities.APIKey{
+		ID:         model.ID,
+		UserID:     model.UserID,
+		Name:       model.Name,
+		Prefix:     model.Prefix,
+		KeyHash:    model.KeyHash,
+		Scopes:     []entities.APIKeyScope{},
+		LastUsedAt: model.LastUsedAt,
+		LastUsedIP: model.LastUsedIP,
+		ExpiresAt:  model.ExpiresAt,
+		RevokedAt:  model.RevokedAt,
+		CreatedAt:  model.CreatedAt,
+	}
+
+	var scopeModels []models.APIKeyScopeModel
+	if err := r.db.Where("api_key_id = ?", model.ID).Order("id ASC").Find(&scopeModels).Error; err != nil {
+		return nil, err
+	}
+	for _, s := range scopeModels {
+		key.Scopes = append(key.Scopes, entities.APIKeyScope(s.Scope))
+	}
+
+	var roomModels []models.APIKeyRoomModel
+	if err := r.db.Where("api_key_id = ?", model.ID).Order("id ASC").Find(&roomModels).Error; err != nil {
+		return nil, err
+	}
+	for _, room := range roomModels {
+		key.RoomIDs = append(key.RoomIDs, room.RoomID)
+	}
+	return key, nil
+}
@@ -0,0 +1,136 @@
+package sqlite
+
+import (
+	"errors"
+	"time"
+
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+	"github.com/bbp/backend/internal/repository/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type refreshTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewRefreshTokenRepository(db *gorm.DB) repositories.RefreshTokenRepository {
+	return &refreshTokenRepository{db: db}
+}
+
+func (r *refreshTokenRepository) Create(token *entities.RefreshToken) error {
+	model := &models.RefreshTokenModel{
+		UserID:    token.UserID,
+		TokenHash: token.TokenHash,
+		AccessJTI: token.AccessJTI,
+		ExpiresAt: token.ExpiresAt,
+	}
+
+	if err := r.db.Create(model).Error; err != nil {
+		return err
+	}
+
+	token.ID = model.ID
+	token.CreatedAt = model.CreatedAt
+	return nil
+}
+
+func (r *refreshTokenRepository) GetByHash(tokenHash string) (*entities.RefreshToken, error) {
+	var model models.RefreshTokenModel
+	if err := r.db.Where("token_hash = ?", tokenHash).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return toRefreshTokenEntity(&model), nil
+}
+
+func (r *refreshTokenRepository) GetActiveByUserID(userID uint) ([]entities.RefreshToken, error) {
+	var modelList []models.RefreshTokenModel
+	if err := r.db.Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, time.Now()).
+		Find(&modelList).Error; err != nil {
+		return nil, err
+	}
+
+	tokens := make([]entities.RefreshToken, len(modelList))
+	for i, model := range modelList {
+		tokens[i] = *toRefreshTokenEntity(&model)
+	}
+
+	return tokens, nil
+}
+
+func (r *refreshTokenRepository) Revoke(id uint, replacedByID *uint) (bool, error) {
+	result := r.db.Model(&models.RefreshTokenModel{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Updates(map[string]interface{}{
+			"revoked_at":     time.Now(),
+			"replaced_by_id": replacedByID,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *refreshTokenRepository) RevokeAllByUserID(userID uint) error {
+	return r.db.Model(&models.RefreshTokenModel{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now()).Error
+}
+
+func (r *refreshTokenRepository) DeleteExpired(now time.Time) error {
+	return r.db.Where("expires_at <= ?", now).Delete(&models.RefreshTokenModel{}).Error
+}
+
+func toRefreshTokenEntity(model *models.RefreshTokenModel) *entities.RefreshToken {
+	return &entities.RefreshToken{
+		ID:           model.ID,
+		UserID:       model.UserID,
+		TokenHash:    model.TokenHash,
+		AccessJTI:    model.AccessJTI,
+		ExpiresAt:    model.ExpiresAt,
+		RevokedAt:    model.RevokedAt,
+		ReplacedByID: model.ReplacedByID,
+		CreatedAt:    model.CreatedAt,
+	}
+}
+
+type revokedTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewRevokedTokenRepository(db *gorm.DB) repositories.RevokedTokenRepository {
+	return &revokedTokenRepository{db: db}
+}
+
+func (r *revokedTokenRepository) Create(token *entities.RevokedToken) error {
+	model := &models.RevokedTokenModel{
+		JTI:       token.JTI,
+		UserID:    token.UserID,
+		ExpiresAt: token.ExpiresAt,
+	}
+
+	// Повторный отзыв того же токена не является ошибкой
+	if err := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(model).Error; err != nil {
+		return err
+	}
+
+	token.CreatedAt = model.CreatedAt
+	return nil
+}
+
+func (r *revokedTokenRepository) IsRevoked(jti string) (bool, error) {
+	var count int64
+	if err := r.db.Model(&models.RevokedTokenModel{}).Where("jti = ?", jti).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *revokedTokenRepository) DeleteExpired(now time.Time) error {
+	return r.db.Where("expires_at <= ?", now).Delete(&models.RevokedTokenModel{}).Error
+}
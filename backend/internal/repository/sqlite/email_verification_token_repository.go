@@ -0,0 +1,72 @@
+package sqlite
+
+import (
+	"errors"
+	"time"
+
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+	"github.com/bbp/backend/internal/repository/models"
+	"gorm.io/gorm"
+)
+
+type emailVerificationTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewEmailVerificationTokenRepository(db *gorm.DB) repositories.EmailVerificationTokenRepository {
+	return &emailVerificationTokenRepository{db: db}
+}
+
+func (r *emailVerificationTokenRepository) Create(token *entities.EmailVerificationToken) error {
+	model := &models.EmailVerificationTokenModel{
+		UserID:    token.UserID,
+		Email:     token.Email,
+		TokenHash: token.TokenHash,
+		ExpiresAt: token.ExpiresAt,
+	}
+
+	if err := r.db.Create(model).Error; err != nil {
+		return err
+	}
+
+	token.ID = model.ID
+	token.CreatedAt = model.CreatedAt
+	return nil
+}
+
+func (r *emailVerificationTokenRepository) GetByHash(tokenHash string) (*entities.EmailVerificationToken, error) {
+	var model models.EmailVerificationTokenModel
+	if err := r.db.Where("token_hash = ?", tokenHash).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &entities.EmailVerificationToken{
+		ID:        model.ID,
+		UserID:    model.UserID,
+		Email:     model.Email,
+		TokenHash: model.TokenHash,
+		ExpiresAt: model.ExpiresAt,
+		UsedAt:    model.UsedAt,
+		CreatedAt: model.CreatedAt,
+	}, nil
+}
+
+func (r *emailVerificationTokenRepository) MarkUsed(id uint) (bool, error) {
+	result := r.db.Model(&models.EmailVerificationTokenModel{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *emailVerificationTokenRepository) InvalidateByUserID(userID uint) error {
+	return r.db.Model(&models.EmailVerificationTokenModel{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Update("used_at", time.Now()).Error
+}
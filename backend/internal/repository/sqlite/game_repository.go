@@ -79,7 +79,10 @@ func (r *gameRepository) Update(game *entities.Game) error {
 		IsActive: game.IsActive,
 	}
 
-	return r.db.Model(&models.GameModel{}).Where("id = ?", game.ID).Updates(model).Error
+	// Явно указываем колонки, чтобы false в IsActive тоже сохранялся
+	return r.db.Model(&models.GameModel{}).Where("id = ?", game.ID).
+		Select("Name", "Slug", "IsActive").
+		Updates(model).Error
 }
 
 func (r *gameRepository) Delete(id uint) error {
@@ -0,0 +1,111 @@
+package sqlite
+
+import (
+	"errors"
+	"time"
+
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+	"github.com/bbp/backend/internal/repository/models"
+	"gorm.io/gorm"
+)
+
+type loginThrottleRepository struct {
+	db *gorm.DB
+}
+
+func NewLoginThrottleRepository(db *gorm.DB) repositories.LoginThrottleRepository {
+	return &loginThrottleRepository{db: db}
+}
+
+func (r *loginThrottleRepository) Get(scope entities.LoginThrottleScope, key string) (*entities.LoginThrottle, error) {
+	var model models.LoginThrottleModel
+	if err := r.db.Where("scope = ? AND key = ?", string(scope), key).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &entities.LoginThrottle{
+		Scope:         entities.LoginThrottleScope(model.Scope),
+		Key:           model.Key,
+		Failures:      model.Failures,
+		LastFailureAt: model.LastFailureAt,
+		LockedUntil:   model.LockedUntil,
+	}, nil
+}
+
+func (r *loginThrottleRepository) Save(throttle *entities.LoginThrottle) error {
+	model := &models.LoginThrottleModel{
+		Scope:         string(throttle.Scope),
+		Key:           throttle.Key,
+		Failures:      throttle.Failures,
+		LastFailureAt: throttle.LastFailureAt,
+		LockedUntil:   throttle.LockedUntil,
+	}
+	return r.db.Save(model).Error
+}
+
+func (r *loginThrottleRepository) Delete(scope entities.LoginThrottleScope, key string) error {
+	return r.db.Where("scope = ? AND key = ?", string(scope), key).Delete(&models.LoginThrottleModel{}).Error
+}
+
+func (r *loginThrottleRepository) DeleteStale(before time.Time) error {
+	return r.db.
+		Where("last_failure_at < ? AND (locked_until IS NULL OR locked_until < ?)", before, time.Now()).
+		Delete(&models.LoginThrottleModel{}).Error
+}
+
+type lockoutEventRepository struct {
+	db *gorm.DB
+}
+
+func NewLockoutEventRepository(db *gorm.DB) repositories.LockoutEventRepository {
+	return &lockoutEventRepository{db: db}
+}
+
+func (r *lockoutEventRepository) Create(event *entities.LockoutEvent) error {
+	model := &models.LockoutEventModel{
+		Action:      string(event.Action),
+		Scope:       string(event.Scope),
+		Key:         event.Key,
+		UserID:      event.UserID,
+		IP:          event.IP,
+		Failures:    event.Failures,
+		LockedUntil: event.LockedUntil,
+		ActorID:     event.ActorID,
+	}
+
+	if err := r.db.Create(model).Error; err != nil {
+		return err
+	}
+
+	event.ID = model.ID
+	event.CreatedAt = model.CreatedAt
+	return nil
+}
+
+func (r *lockoutEventRepository) List(limit, offset int) ([]entities.LockoutEvent, error) {
+	var modelList []models.LockoutEventModel
+	if err := r.db.Order("created_at DESC, id DESC").Limit(limit).Offset(offset).Find(&modelList).Error; err != nil {
+		return nil, err
+	}
+
+	events := make([]entities.LockoutEvent, len(modelList))
+	for i, model := range modelList {
+		events[i] = entities.LockoutEvent{
+			ID:          model.ID,
+			Action:      entities.LockoutAction(model.Action),
+			Scope:       entities.LoginThrottleScope(model.Scope),
+			Key:         model.Key,
+			UserID:      model.UserID,
+			IP:          model.IP,
+			Failures:    model.Failures,
+			LockedUntil: model.LockedUntil,
+			ActorID:     model.ActorID,
+			CreatedAt:   model.CreatedAt,
+		}
+	}
+	return events, nil
+}
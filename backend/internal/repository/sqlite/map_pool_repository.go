@@ -2,6 +2,8 @@ package sqlite
 
 import (
 	"errors"
+	"strings"
+
 	"github.com/bbp/backend/internal/domain/entities"
 	"github.com/bbp/backend/internal/domain/repositories"
 	"github.com/bbp/backend/internal/repository/models"
@@ -18,11 +20,17 @@ func NewMapPoolRepository(db *gorm.DB) repositories.MapPoolRepository {
 
 func (r *mapPoolRepository) Create(pool *entities.MapPool) error {
 	model := &models.MapPoolModel{
-		GameID:   pool.GameID,
-		UserID:   pool.UserID,
-		Name:     pool.Name,
-		Type:     string(pool.Type),
-		IsSystem: pool.IsSystem,
+		GameID:       pool.GameID,
+		UserID:       pool.UserID,
+		Name:         pool.Name,
+		Type:         string(pool.Type),
+		IsSystem:     pool.IsSystem,
+		Visibility:   string(pool.Visibility),
+		ShareSlug:    pool.ShareSlug,
+		ForkedFromID: pool.ForkedFromID,
+	}
+	if model.Visibility == "" {
+		model.Visibility = string(entities.MapPoolVisibilityPrivate)
 	}
 
 	// Преобразуем карты в модели
@@ -39,6 +47,7 @@ func (r *mapPoolRepository) Create(pool *entities.MapPool) error {
 	}
 
 	pool.ID = model.ID
+	pool.Visibility = entities.MapPoolVisibility(model.Visibility)
 	pool.CreatedAt = model.CreatedAt
 	pool.UpdatedAt = model.UpdatedAt
 	return nil
@@ -115,15 +124,81 @@ func (r *mapPoolRepository) GetSystemPools(gameID uint) ([]entities.MapPool, err
 
 func (r *mapPoolRepository) Update(pool *entities.MapPool) error {
 	model := &models.MapPoolModel{
-		ID:       pool.ID,
-		GameID:   pool.GameID,
-		UserID:   pool.UserID,
-		Name:     pool.Name,
-		Type:     string(pool.Type),
-		IsSystem: pool.IsSystem,
+		ID:         pool.ID,
+		GameID:     pool.GameID,
+		UserID:     pool.UserID,
+		Name:       pool.Name,
+		Type:       string(pool.Type),
+		IsSystem:   pool.IsSystem,
+		Visibility: string(pool.Visibility),
+		ShareSlug:  pool.ShareSlug,
+	}
+	if model.Visibility == "" {
+		model.Visibility = string(entities.MapPoolVisibilityPrivate)
+	}
+
+	// Select - чтобы сохранялись и нулевые значения (например, снятие флагов)
+	return r.db.Model(&models.MapPoolModel{}).Where("id = ?", pool.ID).
+		Select("GameID", "UserID", "Name", "Type", "IsSystem", "Visibility", "ShareSlug").
+		Updates(model).Error
+}
+
+func (r *mapPoolRepository) GetByShareSlug(slug string) (*entities.MapPool, error) {
+	var model models.MapPoolModel
+	if err := r.db.Preload("Maps").Where("share_slug = ?", slug).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return toMapPoolEntity(&model), nil
+}
+
+func (r *mapPoolRepository) GetPublic(filter *repositories.MapPoolFilter, limit, offset int) ([]entities.MapPool, error) {
+	var modelList []models.MapPoolModel
+	query := r.publicQuery(filter).Preload("Maps").Order("updated_at DESC, id DESC")
+
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if offset > 0 {
+		query = query.Offset(offset)
+	}
+
+	if err := query.Find(&modelList).Error; err != nil {
+		return nil, err
+	}
+
+	pools := make([]entities.MapPool, len(modelList))
+	for i, model := range modelList {
+		pools[i] = *toMapPoolEntity(&model)
+	}
+
+	return pools, nil
+}
+
+func (r *mapPoolRepository) CountPublic(filter *repositories.MapPoolFilter) (int64, error) {
+	var count int64
+	err := r.publicQuery(filter).Count(&count).Error
+	return count, err
+}
+
+// publicQuery строит запрос по публичным пулам с учетом фильтра
+func (r *mapPoolRepository) publicQuery(filter *repositories.MapPoolFilter) *gorm.DB {
+	query := r.db.Model(&models.MapPoolModel{}).
+		Where("visibility = ?", string(entities.MapPoolVisibilityPublic))
+
+	if filter != nil {
+		if filter.GameID != nil {
+			query = query.Where("game_id = ?", *filter.GameID)
+		}
+		if filter.Query != nil && *filter.Query != "" {
+			query = query.Where("LOWER(name) LIKE ?", "%"+strings.ToLower(*filter.Query)+"%")
+		}
 	}
 
-	return r.db.Model(&models.MapPoolModel{}).Where("id = ?", pool.ID).Updates(model).Error
+	return query
 }
 
 func (r *mapPoolRepository) Delete(id uint) error {
@@ -175,14 +250,17 @@ func toMapPoolEntity(model *models.MapPoolModel) *entities.MapPool {
 	}
 
 	return &entities.MapPool{
-		ID:        model.ID,
-		GameID:    model.GameID,
-		UserID:    model.UserID,
-		Name:      model.Name,
-		Type:      entities.MapPoolType(model.Type),
-		IsSystem:  model.IsSystem,
-		CreatedAt: model.CreatedAt,
-		UpdatedAt: model.UpdatedAt,
-		Maps:      maps,
+		ID:           model.ID,
+		GameID:       model.GameID,
+		UserID:       model.UserID,
+		Name:         model.Name,
+		Type:         entities.MapPoolType(model.Type),
+		IsSystem:     model.IsSystem,
+		Visibility:   entities.MapPoolVisibility(model.Visibility),
+		ShareSlug:    model.ShareSlug,
+		ForkedFromID: model.ForkedFromID,
+		CreatedAt:    model.CreatedAt,
+		UpdatedAt:    model.UpdatedAt,
+		Maps:         maps,
 	}
 }
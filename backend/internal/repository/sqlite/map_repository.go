@@ -74,6 +74,18 @@ func (r *mapRepository) GetBySlug(slug string) (*entities.Map, error) {
 	return toMapEntity(&model), nil
 }
 
+func (r *mapRepository) GetByGameIDAndSlug(gameID uint, slug string) (*entities.Map, error) {
+	var model models.MapModel
+	if err := r.db.Where("game_id = ? AND slug = ?", gameID, slug).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return toMapEntity(&model), nil
+}
+
 func (r *mapRepository) Update(m *entities.Map) error {
 	model := &models.MapModel{
 		ID:            m.ID,
@@ -85,7 +97,10 @@ func (r *mapRepository) Update(m *entities.Map) error {
 		IsCompetitive: m.IsCompetitive,
 	}
 
-	return r.db.Model(&models.MapModel{}).Where("id = ?", m.ID).Updates(model).Error
+	// Явно указываем колонки, чтобы false в IsActive/IsCompetitive тоже сохранялся
+	return r.db.Model(&models.MapModel{}).Where("id = ?", m.ID).
+		Select("GameID", "Name", "Slug", "ImageURL", "IsActive", "IsCompetitive").
+		Updates(model).Error
 }
 
 func (r *mapRepository) Delete(id uint) error {
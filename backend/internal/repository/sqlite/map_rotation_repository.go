@@ -0,0 +1,87 @@
+package sqlite
+
+import (
+	"errors"
+	"time"
+
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+	"github.com/bbp/backend/internal/repository/models"
+	"gorm.io/gorm"
+)
+
+type mapRotationRepository struct {
+	db *gorm.DB
+}
+
+func NewMapRotationRepository(db *gorm.DB) repositories.MapRotationRepository {
+	return &mapRotationRepository{db: db}
+}
+
+func (r *mapRotationRepository) Create(rotation *entities.MapRotation) error {
+	model := &models.MapRotationModel{
+		GameID:        rotation.GameID,
+		EffectiveFrom: rotation.EffectiveFrom,
+	}
+
+	mapModels := make([]models.MapModel, len(rotation.MapIDs))
+	for i, id := range rotation.MapIDs {
+		mapModels[i] = models.MapModel{ID: id}
+	}
+	model.Maps = mapModels
+
+	// Карты уже существуют - создаем только связи
+	if err := r.db.Omit("Maps.*").Create(model).Error; err != nil {
+		return err
+	}
+
+	rotation.ID = model.ID
+	rotation.CreatedAt = model.CreatedAt
+	return nil
+}
+
+func (r *mapRotationRepository) GetByGameID(gameID uint) ([]entities.MapRotation, error) {
+	var modelList []models.MapRotationModel
+	if err := r.db.Preload("Maps").Where("game_id = ?", gameID).
+		Order("effective_from DESC, id DESC").Find(&modelList).Error; err != nil {
+		return nil, err
+	}
+
+	rotations := make([]entities.MapRotation, len(modelList))
+	for i, model := range modelList {
+		rotations[i] = *toMapRotationEntity(&model)
+	}
+
+	return rotations, nil
+}
+
+func (r *mapRotationRepository) GetActive(gameID uint, at time.Time) (*entities.MapRotation, error) {
+	var model models.MapRotationModel
+	if err := r.db.Preload("Maps").Where("game_id = ? AND effective_from <= ?", gameID, at).
+		Order("effective_from DESC, id DESC").First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return toMapRotationEntity(&model), nil
+}
+
+func toMapRotationEntity(model *models.MapRotationModel) *entities.MapRotation {
+	mapIDs := make([]uint, len(model.Maps))
+	maps := make([]entities.Map, len(model.Maps))
+	for i, m := range model.Maps {
+		mapIDs[i] = m.ID
+		maps[i] = *toMapEntity(&m)
+	}
+
+	return &entities.MapRotation{
+		ID:            model.ID,
+		GameID:        model.GameID,
+		EffectiveFrom: model.EffectiveFrom,
+		MapIDs:        mapIDs,
+		CreatedAt:     model.CreatedAt,
+		Maps:          maps,
+	}
+}
@@ -0,0 +1,171 @@
+package sqlite
+
+import (
+	"errors"
+
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+	"github.com/bbp/backend/internal/repository/models"
+	"gorm.io/gorm"
+)
+
+type matchResultRepository struct {
+	db *gorm.DB
+}
+
+func NewMatchResultRepository(db *gorm.DB) repositories.MatchResultRepository {
+	return &matchResultRepository{db: db}
+}
+
+func (r *matchResultRepository) Create(result *entities.MatchResult) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		model := toMatchResultModel(result)
+		if err := tx.Create(model).Error; err != nil {
+			return err
+		}
+
+		result.ID = model.ID
+		result.CreatedAt = model.CreatedAt
+		result.UpdatedAt = model.UpdatedAt
+		return saveMatchResultDetails(tx, result)
+	})
+}
+
+func (r *matchResultRepository) GetBySessionID(sessionID uint) (*entities.MatchResult, error) {
+	var model models.MatchResultModel
+	if err := r.db.Where("veto_session_id = ?", sessionID).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	result := toMatchResultEntity(&model)
+
+	var mapModels []models.MapResultModel
+	if err := r.db.Where("match_result_id = ?", model.ID).Order("map_order ASC").Find(&mapModels).Error; err != nil {
+		return nil, err
+	}
+	result.Maps = make([]entities.MapResult, len(mapModels))
+	for i, m := range mapModels {
+		result.Maps[i] = entities.MapResult{
+			ID:           m.ID,
+			VetoActionID: m.VetoActionID,
+			MapID:        m.MapID,
+			Order:        m.Order,
+			ScoreA:       m.ScoreA,
+			ScoreB:       m.ScoreB,
+		}
+	}
+
+	var playerModels []models.MatchResultPlayerModel
+	if err := r.db.Where("match_result_id = ?", model.ID).Order("id ASC").Find(&playerModels).Error; err != nil {
+		return nil, err
+	}
+	result.Players = make([]entities.MatchResultPlayer, len(playerModels))
+	for i, p := range playerModels {
+		result.Players[i] = entities.MatchResultPlayer{UserID: p.UserID, Team: p.Team}
+	}
+
+	return result, nil
+}
+
+func (r *matchResultRepository) Update(result *entities.MatchResult) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		model := toMatchResultModel(result)
+		model.ID = result.ID
+		// Select нужен, чтобы сбросить поля спора и подтверждения при повторном отчете
+		if err := tx.Model(model).
+			Select("Status", "ReportedByID", "ReportedByTeam", "ConfirmedByID", "DisputedByID", "DisputeReason", "ScoreA", "ScoreB", "WinnerTeam", "ConfirmedAt", "UpdatedAt").
+			Updates(model).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("match_result_id = ?", result.ID).Delete(&models.MapResultModel{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("match_result_id = ?", result.ID).Delete(&models.MatchResultPlayerModel{}).Error; err != nil {
+			return err
+		}
+		return saveMatchResultDetails(tx, result)
+	})
+}
+
+func (r *matchResultRepository) DeleteBySessionID(sessionID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		results := tx.Model(&models.MatchResultModel{}).Select("id").Where("veto_session_id = ?", sessionID)
+		if err := tx.Where("match_result_id IN (?)", results).Delete(&models.MapResultModel{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("match_result_id IN (?)", results).Delete(&models.MatchResultPlayerModel{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("veto_session_id = ?", sessionID).Delete(&models.MatchResultModel{}).Error
+	})
+}
+
+// saveMatchResultDetails сохраняет счет карт и составы результата
+func saveMatchResultDetails(tx *gorm.DB, result *entities.MatchResult) error {
+	for i := range result.Maps {
+		m := &result.Maps[i]
+		model := &models.MapResultModel{
+			MatchResultID: result.ID,
+			VetoActionID:  m.VetoActionID,
+			MapID:         m.MapID,
+			Order:         m.Order,
+			ScoreA:        m.ScoreA,
+			ScoreB:        m.ScoreB,
+		}
+		if err := tx.Create(model).Error; err != nil {
+			return err
+		}
+		m.ID = model.ID
+	}
+
+	for _, p := range result.Players {
+		model := &models.MatchResultPlayerModel{
+			MatchResultID: result.ID,
+			UserID:        p.UserID,
+			Team:          p.Team,
+		}
+		if err := tx.Create(model).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func toMatchResultModel(result *entities.MatchResult) *models.MatchResultModel {
+	return &models.MatchResultModel{
+		VetoSessionID:  result.VetoSessionID,
+		Status:         string(result.Status),
+		ReportedByID:   result.ReportedByID,
+		ReportedByTeam: result.ReportedByTeam,
+		ConfirmedByID:  result.ConfirmedByID,
+		DisputedByID:   result.DisputedByID,
+		DisputeReason:  result.DisputeReason,
+		ScoreA:         result.ScoreA,
+		ScoreB:         result.ScoreB,
+		WinnerTeam:     result.WinnerTeam,
+		ConfirmedAt:    result.ConfirmedAt,
+	}
+}
+
+func toMatchResultEntity(model *models.MatchResultModel) *entities.MatchResult {
+	return &entities.MatchResult{
+		ID:             model.ID,
+		VetoSessionID:  model.VetoSessionID,
+		Status:         entities.MatchResultStatus(model.Status),
+		ReportedByID:   model.ReportedByID,
+		ReportedByTeam: model.ReportedByTeam,
+		ConfirmedByID:  model.ConfirmedByID,
+		DisputedByID:   model.DisputedByID,
+		DisputeReason:  model.DisputeReason,
+		ScoreA:         model.ScoreA,
+		ScoreB:         model.ScoreB,
+		WinnerTeam:     model.WinnerTeam,
+		CreatedAt:      model.CreatedAt,
+		UpdatedAt:      model.UpdatedAt,
+		ConfirmedAt:    model.ConfirmedAt,
+	}
+}
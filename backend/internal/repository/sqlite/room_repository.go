@@ -34,6 +34,8 @@ func (r *roomRepository) Create(room *entities.Room) error {
 		MapPoolID:       room.MapPoolID,
 		VetoType:        vetoTypeStr,
 		VetoSessionID:   room.VetoSessionID,
+		TeamAID:         room.TeamAID,
+		TeamBID:         room.TeamBID,
 		MaxParticipants: room.MaxParticipants,
 	}
 
@@ -65,6 +67,12 @@ func (r *roomRepository) GetByID(id uint) (*entities.Room, error) {
 	}
 	room.Participants = participants
 
+	matches, err := r.GetMatches(id)
+	if err != nil {
+		return nil, err
+	}
+	room.Matches = matches
+
 	return room, nil
 }
 
@@ -167,6 +175,8 @@ func (r *roomRepository) Update(room *entities.Room) error {
 		MapPoolID:      room.MapPoolID,
 		VetoType:       vetoTypeStr,
 		VetoSessionID:  room.VetoSessionID,
+		TeamAID:         room.TeamAID,
+		TeamBID:         room.TeamBID,
 		MaxParticipants: room.MaxParticipants,
 	}
 
@@ -182,6 +192,7 @@ func (r *roomRepository) AddParticipant(participant *entities.RoomParticipant) e
 		RoomID:   participant.RoomID,
 		UserID:   participant.UserID,
 		Role:     string(participant.Role),
+		TeamID:   participant.TeamID,
 		JoinedAt: participant.JoinedAt,
 	}
 
@@ -197,6 +208,12 @@ func (r *roomRepository) RemoveParticipant(roomID, userID uint) error {
 	return r.db.Where("room_id = ? AND user_id = ?", roomID, userID).Delete(&models.RoomParticipantModel{}).Error
 }
 
+func (r *roomRepository) UpdateParticipantRole(roomID, userID uint, role entities.ParticipantRole) error {
+	return r.db.Model(&models.RoomParticipantModel{}).
+		Where("room_id = ? AND user_id = ?", roomID, userID).
+		Update("role", string(role)).Error
+}
+
 func (r *roomRepository) GetParticipants(roomID uint) ([]entities.RoomParticipant, error) {
 	type ParticipantWithUser struct {
 		models.RoomParticipantModel
@@ -234,8 +251,10 @@ func (r *roomRepository) GetParticipant(roomID, userID uint) (*entities.RoomPart
 
 // GetUserRoom получает комнату, в которой участвует пользователь
 func (r *roomRepository) GetByVetoSessionID(sessionID uint) (*entities.Room, error) {
+	// Сессия может быть текущей или одной из прошлых в серии комнаты
+	matchRooms := r.db.Model(&models.RoomMatchModel{}).Select("room_id").Where("veto_session_id = ?", sessionID)
 	var model models.RoomModel
-	if err := r.db.Where("veto_session_id = ?", sessionID).First(&model).Error; err != nil {
+	if err := r.db.Where("veto_session_id = ? OR id IN (?)", sessionID, matchRooms).First(&model).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
 		}
@@ -251,9 +270,99 @@ func (r *roomRepository) GetByVetoSessionID(sessionID uint) (*entities.Room, err
 	}
 	room.Participants = participants
 
+	matches, err := r.GetMatches(model.ID)
+	if err != nil {
+		return nil, err
+	}
+	room.Matches = matches
+
 	return room, nil
 }
 
+// errRoomSessionChanged откатывает AddMatch, если текущую сессию комнаты сменили
+var errRoomSessionChanged = errors.New("room veto session changed")
+
+func (r *roomRepository) AddMatch(match *entities.RoomMatch, expectedSessionID *uint) (bool, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var room models.RoomModel
+		if err := tx.First(&room, match.RoomID).Error; err != nil {
+			return err
+		}
+
+		// Новый матч становится текущим (compare-and-set по прочитанной ранее сессии)
+		result := tx.Model(&models.RoomModel{}).
+			Where("id = ? AND veto_session_id IS ?", match.RoomID, expectedSessionID).
+			Updates(map[string]interface{}{
+				"veto_session_id": match.VetoSessionID,
+				"status":          string(entities.RoomStatusActive),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errRoomSessionChanged
+		}
+
+		var count int64
+		if err := tx.Model(&models.RoomMatchModel{}).Where("room_id = ?", match.RoomID).Count(&count).Error; err != nil {
+			return err
+		}
+
+		// Сессия, привязанная до появления серий, становится первым матчем
+		if count == 0 && room.VetoSessionID != nil && *room.VetoSessionID != match.VetoSessionID {
+			first := &models.RoomMatchModel{
+				RoomID:        match.RoomID,
+				Number:        1,
+				VetoSessionID: *room.VetoSessionID,
+				CreatedAt:     room.UpdatedAt,
+			}
+			if err := tx.Create(first).Error; err != nil {
+				return err
+			}
+			count = 1
+		}
+
+		model := &models.RoomMatchModel{
+			RoomID:        match.RoomID,
+			Number:        int(count) + 1,
+			VetoSessionID: match.VetoSessionID,
+			SidesSwapped:  match.SidesSwapped,
+		}
+		if err := tx.Create(model).Error; err != nil {
+			return err
+		}
+
+		match.ID = model.ID
+		match.Number = model.Number
+		match.CreatedAt = model.CreatedAt
+		return nil
+	})
+	if errors.Is(err, errRoomSessionChanged) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (r *roomRepository) GetMatches(roomID uint) ([]entities.RoomMatch, error) {
+	var modelList []models.RoomMatchModel
+	if err := r.db.Where("room_id = ?", roomID).Order("number ASC").Find(&modelList).Error; err != nil {
+		return nil, err
+	}
+
+	matches := make([]entities.RoomMatch, len(modelList))
+	for i, model := range modelList {
+		matches[i] = entities.RoomMatch{
+			ID:            model.ID,
+			RoomID:        model.RoomID,
+			VetoSessionID: model.VetoSessionID,
+			Number:        model.Number,
+			SidesSwapped:  model.SidesSwapped,
+			CreatedAt:     model.CreatedAt,
+		}
+	}
+	return matches, nil
+}
+
 func (r *roomRepository) GetUserRoom(userID uint) (*entities.Room, error) {
 	var participant models.RoomParticipantModel
 	if err := r.db.Where("user_id = ?", userID).First(&participant).Error; err != nil {
@@ -267,6 +376,30 @@ func (r *roomRepository) GetUserRoom(userID uint) (*entities.Room, error) {
 	return r.GetByID(participant.RoomID)
 }
 
+func (r *roomRepository) GetByParticipantID(userID uint) ([]entities.Room, error) {
+	var roomIDs []uint
+	if err := r.db.Model(&models.RoomParticipantModel{}).
+		Where("user_id = ?", userID).
+		Distinct().
+		Pluck("room_id", &roomIDs).Error; err != nil {
+		return nil, err
+	}
+
+	rooms := make([]entities.Room, 0, len(roomIDs))
+	for _, roomID := range roomIDs {
+		room, err := r.GetByID(roomID)
+		if err != nil {
+			return nil, err
+		}
+		// Участие в удаленных комнатах пропускаем
+		if room != nil {
+			rooms = append(rooms, *room)
+		}
+	}
+
+	return rooms, nil
+}
+
 // Count подсчитывает количество комнат с фильтром
 func (r *roomRepository) Count(filter *repositories.RoomFilter) (int64, error) {
 	var count int64
@@ -307,6 +440,8 @@ func toRoomEntity(model *models.RoomModel) *entities.Room {
 		MapPoolID:       model.MapPoolID,
 		VetoType:        vetoType,
 		VetoSessionID:   model.VetoSessionID,
+		TeamAID:         model.TeamAID,
+		TeamBID:         model.TeamBID,
 		MaxParticipants: model.MaxParticipants,
 		CreatedAt:       model.CreatedAt,
 		UpdatedAt:       model.UpdatedAt,
@@ -325,6 +460,7 @@ func toRoomParticipantEntityWithUsername(model *models.RoomParticipantModel, use
 		UserID:   model.UserID,
 		Username: username,
 		Role:     entities.ParticipantRole(model.Role),
+		TeamID:   model.TeamID,
 		JoinedAt: model.JoinedAt,
 	}
 }
@@ -0,0 +1,316 @@
+package sqlite
+
+import (
+	"errors"
+
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+	"github.com/bbp/backend/internal/repository/models"
+	"gorm.io/gorm"
+)
+
+type teamRepository struct {
+	db *gorm.DB
+}
+
+func NewTeamRepository(db *gorm.DB) repositories.TeamRepository {
+	return &teamRepository{db: db}
+}
+
+func (r *teamRepository) Create(team *entities.Team) error {
+	model := &models.TeamModel{
+		Name:    team.Name,
+		Tag:     team.Tag,
+		LogoURL: team.LogoURL,
+	}
+
+	if err := r.db.Create(model).Error; err != nil {
+		return err
+	}
+
+	team.ID = model.ID
+	team.CreatedAt = model.CreatedAt
+	team.UpdatedAt = model.UpdatedAt
+	return nil
+}
+
+func (r *teamRepository) GetByID(id uint) (*entities.Team, error) {
+	var model models.TeamModel
+	if err := r.db.First(&model, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return r.withMembers(&model)
+}
+
+func (r *teamRepository) GetByName(name string) (*entities.Team, error) {
+	var model models.TeamModel
+	if err := r.db.Where("LOWER(name) = LOWER(?)", name).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return r.withMembers(&model)
+}
+
+func (r *teamRepository) GetByMemberID(userID uint) ([]entities.Team, error) {
+	var modelList []models.TeamModel
+	if err := r.db.
+		Joins("JOIN team_members ON team_members.team_id = teams.id").
+		Where("team_members.user_id = ?", userID).
+		Order("teams.name").
+		Find(&modelList).Error; err != nil {
+		return nil, err
+	}
+
+	teams := make([]entities.Team, 0, len(modelList))
+	for i := range modelList {
+		team, err := r.withMembers(&modelList[i])
+		if err != nil {
+			return nil, err
+		}
+		teams = append(teams, *team)
+	}
+
+	return teams, nil
+}
+
+func (r *teamRepository) Update(team *entities.Team) error {
+	return r.db.Model(&models.TeamModel{}).
+		Where("id = ?", team.ID).
+		Select("Name", "Tag", "LogoURL").
+		Updates(&models.TeamModel{
+			Name:    team.Name,
+			Tag:     team.Tag,
+			LogoURL: team.LogoURL,
+		}).Error
+}
+
+func (r *teamRepository) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("team_id = ?", id).Delete(&models.TeamMemberModel{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("team_id = ?", id).Delete(&models.TeamInviteModel{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.TeamModel{}, id).Error
+	})
+}
+
+func (r *teamRepository) AddMember(member *entities.TeamMember) error {
+	model := &models.TeamMemberModel{
+		TeamID:   member.TeamID,
+		UserID:   member.UserID,
+		Role:     string(member.Role),
+		JoinedAt: member.JoinedAt,
+	}
+
+	if err := r.db.Create(model).Error; err != nil {
+		return err
+	}
+
+	member.ID = model.ID
+	return nil
+}
+
+func (r *teamRepository) RemoveMember(teamID, userID uint) error {
+	return r.db.Where("team_id = ? AND user_id = ?", teamID, userID).Delete(&models.TeamMemberModel{}).Error
+}
+
+func (r *teamRepository) UpdateMemberRole(teamID, userID uint, role entities.TeamRole) error {
+	return r.db.Model(&models.TeamMemberModel{}).
+		Where("team_id = ? AND user_id = ?", teamID, userID).
+		Update("role", string(role)).Error
+}
+
+func (r *teamRepository) GetMember(teamID, userID uint) (*entities.TeamMember, error) {
+	var model models.TeamMemberModel
+	if err := r.db.Where("team_id = ? AND user_id = ?", teamID, userID).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return toTeamMemberEntity(&model, nil), nil
+}
+
+// withMembers загружает состав команды вместе с никнеймами
+func (r *teamRepository) withMembers(model *models.TeamModel) (*entities.Team, error) {
+	type memberWithUser struct {
+		models.TeamMemberModel
+		Username *string `gorm:"column:username"`
+	}
+
+	var results []memberWithUser
+	if err := r.db.Table("team_members").
+		Select("team_members.*, users.username").
+		Joins("LEFT JOIN users ON team_members.user_id = users.id").
+		Where("team_members.team_id = ?", model.ID).
+		Order("team_members.joined_at, team_members.id").
+		Find(&results).Error; err != nil {
+		return nil, err
+	}
+
+	team := toTeamEntity(model)
+	team.Members = make([]entities.TeamMember, len(results))
+	for i, result := range results {
+		team.Members[i] = *toTeamMemberEntity(&result.TeamMemberModel, result.Username)
+	}
+
+	return team, nil
+}
+
+type teamInviteRepository struct {
+	db *gorm.DB
+}
+
+func NewTeamInviteRepository(db *gorm.DB) repositories.TeamInviteRepository {
+	return &teamInviteRepository{db: db}
+}
+
+func (r *teamInviteRepository) Create(invite *entities.TeamInvite) error {
+	model := &models.TeamInviteModel{
+		TeamID:      invite.TeamID,
+		UserID:      invite.UserID,
+		InvitedByID: invite.InvitedByID,
+		Role:        string(invite.Role),
+		Status:      string(invite.Status),
+	}
+
+	if err := r.db.Create(model).Error; err != nil {
+		return err
+	}
+
+	invite.ID = model.ID
+	invite.CreatedAt = model.CreatedAt
+	return nil
+}
+
+func (r *teamInviteRepository) GetByID(id uint) (*entities.TeamInvite, error) {
+	var model models.TeamInviteModel
+	if err := r.db.First(&model, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return toTeamInviteEntity(&model), nil
+}
+
+func (r *teamInviteRepository) GetPending(teamID, userID uint) (*entities.TeamInvite, error) {
+	var model models.TeamInviteModel
+	if err := r.db.
+		Where("team_id = ? AND user_id = ? AND status = ?", teamID, userID, string(entities.TeamInviteStatusPending)).
+		First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return toTeamInviteEntity(&model), nil
+}
+
+func (r *teamInviteRepository) GetPendingByUserID(userID uint) ([]entities.TeamInvite, error) {
+	var modelList []models.TeamInviteModel
+	if err := r.db.
+		Where("user_id = ? AND status = ?", userID, string(entities.TeamInviteStatusPending)).
+		Order("created_at DESC").
+		Find(&modelList).Error; err != nil {
+		return nil, err
+	}
+
+	teams := NewTeamRepository(r.db)
+	invites := make([]entities.TeamInvite, 0, len(modelList))
+	for i := range modelList {
+		invite := toTeamInviteEntity(&modelList[i])
+		team, err := teams.GetByID(invite.TeamID)
+		if err != nil {
+			return nil, err
+		}
+		// Приглашения в удаленные команды не показываем
+		if team == nil {
+			continue
+		}
+		invite.Team = team
+		invites = append(invites, *invite)
+	}
+
+	return invites, nil
+}
+
+func (r *teamInviteRepository) GetPendingByTeamID(teamID uint) ([]entities.TeamInvite, error) {
+	var modelList []models.TeamInviteModel
+	if err := r.db.
+		Where("team_id = ? AND status = ?", teamID, string(entities.TeamInviteStatusPending)).
+		Order("created_at DESC").
+		Find(&modelList).Error; err != nil {
+		return nil, err
+	}
+
+	invites := make([]entities.TeamInvite, len(modelList))
+	for i := range modelList {
+		invites[i] = *toTeamInviteEntity(&modelList[i])
+	}
+
+	return invites, nil
+}
+
+func (r *teamInviteRepository) Update(invite *entities.TeamInvite) error {
+	return r.db.Model(&models.TeamInviteModel{}).
+		Where("id = ?", invite.ID).
+		Select("Role", "Status", "RespondedAt").
+		Updates(&models.TeamInviteModel{
+			Role:        string(invite.Role),
+			Status:      string(invite.Status),
+			RespondedAt: invite.RespondedAt,
+		}).Error
+}
+
+func (r *teamInviteRepository) Delete(id uint) error {
+	return r.db.Delete(&models.TeamInviteModel{}, id).Error
+}
+
+func toTeamEntity(model *models.TeamModel) *entities.Team {
+	return &entities.Team{
+		ID:        model.ID,
+		Name:      model.Name,
+		Tag:       model.Tag,
+		LogoURL:   model.LogoURL,
+		CreatedAt: model.CreatedAt,
+		UpdatedAt: model.UpdatedAt,
+		Members:   []entities.TeamMember{},
+	}
+}
+
+func toTeamMemberEntity(model *models.TeamMemberModel, username *string) *entities.TeamMember {
+	return &entities.TeamMember{
+		ID:       model.ID,
+		TeamID:   model.TeamID,
+		UserID:   model.UserID,
+		Username: username,
+		Role:     entities.TeamRole(model.Role),
+		JoinedAt: model.JoinedAt,
+	}
+}
+
+func toTeamInviteEntity(model *models.TeamInviteModel) *entities.TeamInvite {
+	return &entities.TeamInvite{
+		ID:          model.ID,
+		TeamID:      model.TeamID,
+		UserID:      model.UserID,
+		InvitedByID: model.InvitedByID,
+		Role:        entities.TeamRole(model.Role),
+		Status:      entities.TeamInviteStatus(model.Status),
+		CreatedAt:   model.CreatedAt,
+		RespondedAt: model.RespondedAt,
+	}
+}
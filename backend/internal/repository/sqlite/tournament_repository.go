@@ -0,0 +1,405 @@
+package sqlite
+
+import (
+	"errors"
+
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+	"github.com/bbp/backend/internal/repository/models"
+	"gorm.io/gorm"
+)
+
+type tournamentRepository struct {
+	db *gorm.DB
+}
+
+func NewTournamentRepository(db *gorm.DB) repositories.TournamentRepository {
+	return &tournamentRepository{db: db}
+}
+
+func (r *tournamentRepository) Create(tournament *entities.Tournament) error {
+	model := toTournamentModel(tournament)
+
+	// Турнир и правила стадий сохраняются атомарно
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(model).Error; err != nil {
+			return err
+		}
+
+		for _, rule := range tournament.StageRules {
+			ruleModel := &models.TournamentStageRuleModel{
+				TournamentID:  model.ID,
+				Stage:         string(rule.Stage),
+				NoRepeatPicks: rule.NoRepeatPicks,
+				CarryOverBans: rule.CarryOverBans,
+				DeciderMapID:  rule.DeciderMapID,
+			}
+			if err := tx.Create(ruleModel).Error; err != nil {
+				return err
+			}
+			for _, mapID := range rule.ExcludedMapIDs {
+				if err := tx.Create(&models.TournamentStageMapModel{
+					TournamentID: model.ID,
+					Stage:        string(rule.Stage),
+					MapID:        mapID,
+				}).Error; err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	tournament.ID = model.ID
+	tournament.CreatedAt = model.CreatedAt
+	tournament.UpdatedAt = model.UpdatedAt
+	return nil
+}
+
+func (r *tournamentRepository) GetByID(id uint) (*entities.Tournament, error) {
+	var model models.TournamentModel
+	if err := r.db.First(&model, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	tournament := toTournamentEntity(&model)
+	teams, err := r.getTeams(model.ID)
+	if err != nil {
+		return nil, err
+	}
+	tournament.Teams = teams
+
+	rules, err := r.getStageRules(model.ID)
+	if err != nil {
+		return nil, err
+	}
+	tournament.StageRules = rules
+
+	var matchModels []models.TournamentMatchModel
+	if err := r.db.Where("tournament_id = ?", model.ID).Order("id ASC").Find(&matchModels).Error; err != nil {
+		return nil, err
+	}
+	tournament.Matches = make([]entities.TournamentMatch, len(matchModels))
+	for i := range matchModels {
+		tournament.Matches[i] = *toTournamentMatchEntity(&matchModels[i])
+	}
+
+	return tournament, nil
+}
+
+func (r *tournamentRepository) GetList(status *entities.TournamentStatus, limit, offset int) ([]entities.Tournament, error) {
+	var modelList []models.TournamentModel
+	query := r.listQuery(status).Order("created_at DESC, id DESC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if offset > 0 {
+		query = query.Offset(offset)
+	}
+	if err := query.Find(&modelList).Error; err != nil {
+		return nil, err
+	}
+
+	tournaments := make([]entities.Tournament, len(modelList))
+	for i := range modelList {
+		tournament := toTournamentEntity(&modelList[i])
+		teams, err := r.getTeams(tournament.ID)
+		if err != nil {
+			return nil, err
+		}
+		tournament.Teams = teams
+		tournaments[i] = *tournament
+	}
+
+	return tournaments, nil
+}
+
+func (r *tournamentRepository) Count(status *entities.TournamentStatus) (int64, error) {
+	var count int64
+	err := r.listQuery(status).Count(&count).Error
+	return count, err
+}
+
+func (r *tournamentRepository) Update(tournament *entities.Tournament) error {
+	model := toTournamentModel(tournament)
+	return r.db.Model(&models.TournamentModel{}).
+		Where("id = ?", tournament.ID).
+		Select("Name", "Status", "WinnerTeamID", "StartedAt").
+		Updates(model).Error
+}
+
+func (r *tournamentRepository) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("tournament_id = ?", id).Delete(&models.TournamentMatchModel{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("tournament_id = ?", id).Delete(&models.TournamentTeamModel{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("tournament_id = ?", id).Delete(&models.TournamentStageMapModel{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("tournament_id = ?", id).Delete(&models.TournamentStageRuleModel{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.TournamentModel{}, id).Error
+	})
+}
+
+func (r *tournamentRepository) AddTeam(team *entities.TournamentTeam) error {
+	model := &models.TournamentTeamModel{
+		TournamentID: team.TournamentID,
+		TeamID:       team.TeamID,
+		Seed:         team.Seed,
+		RegisteredAt: team.RegisteredAt,
+	}
+	if err := r.db.Create(model).Error; err != nil {
+		return err
+	}
+
+	team.ID = model.ID
+	return nil
+}
+
+func (r *tournamentRepository) RemoveTeam(tournamentID, teamID uint) error {
+	return r.db.Where("tournament_id = ? AND team_id = ?", tournamentID, teamID).Delete(&models.TournamentTeamModel{}).Error
+}
+
+func (r *tournamentRepository) UpdateSeeds(tournamentID uint, teams []entities.TournamentTeam) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, team := range teams {
+			if err := tx.Model(&models.TournamentTeamModel{}).
+				Where("tournament_id = ? AND team_id = ?", tournamentID, team.TeamID).
+				Update("seed", team.Seed).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *tournamentRepository) CreateMatches(matches []*entities.TournamentMatch) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		created := make([]*models.TournamentMatchModel, len(matches))
+		for i, match := range matches {
+			created[i] = toTournamentMatchModel(match)
+			if err := tx.Create(created[i]).Error; err != nil {
+				return err
+			}
+			match.ID = created[i].ID
+			match.CreatedAt = created[i].CreatedAt
+			match.UpdatedAt = created[i].UpdatedAt
+		}
+
+		// Связи проставляются, когда у всех матчей появились ID
+		for i, match := range matches {
+			if match.NextMatch == nil && match.LoserNextMatch == nil {
+				continue
+			}
+			if match.NextMatch != nil {
+				match.NextMatchID = &match.NextMatch.ID
+			}
+			if match.LoserNextMatch != nil {
+				match.LoserNextMatchID = &match.LoserNextMatch.ID
+			}
+			if err := tx.Model(created[i]).
+				Select("NextMatchID", "LoserNextMatchID").
+				Updates(&models.TournamentMatchModel{
+					NextMatchID:      match.NextMatchID,
+					LoserNextMatchID: match.LoserNextMatchID,
+				}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *tournamentRepository) UpdateMatch(match *entities.TournamentMatch) error {
+	model := toTournamentMatchModel(match)
+	model.ID = match.ID
+	return r.db.Model(model).
+		Select("TeamAID", "TeamBID", "WinnerTeamID", "Status", "ScheduledAt", "RoomID", "VetoSessionID").
+		Updates(model).Error
+}
+
+func (r *tournamentRepository) GetMatchBySessionID(sessionID uint) (*entities.TournamentMatch, error) {
+	var model models.TournamentMatchModel
+	if err := r.db.Where("veto_session_id = ?", sessionID).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toTournamentMatchEntity(&model), nil
+}
+
+func (r *tournamentRepository) listQuery(status *entities.TournamentStatus) *gorm.DB {
+	query := r.db.Model(&models.TournamentModel{})
+	if status != nil {
+		query = query.Where("status = ?", string(*status))
+	}
+	return query
+}
+
+// getTeams загружает зарегистрированные команды по посеву; удаленные команды остаются с названием
+func (r *tournamentRepository) getTeams(tournamentID uint) ([]entities.TournamentTeam, error) {
+	var teamModels []models.TournamentTeamModel
+	if err := r.db.Where("tournament_id = ?", tournamentID).Order("seed ASC, id ASC").Find(&teamModels).Error; err != nil {
+		return nil, err
+	}
+
+	teamIDs := make([]uint, len(teamModels))
+	for i, model := range teamModels {
+		teamIDs[i] = model.TeamID
+	}
+	var teamList []models.TeamModel
+	if len(teamIDs) > 0 {
+		if err := r.db.Unscoped().Where("id IN ?", teamIDs).Find(&teamList).Error; err != nil {
+			return nil, err
+		}
+	}
+	byID := make(map[uint]*entities.Team, len(teamList))
+	for _, team := range teamList {
+		byID[team.ID] = &entities.Team{
+			ID:        team.ID,
+			Name:      team.Name,
+			Tag:       team.Tag,
+			LogoURL:   team.LogoURL,
+			CreatedAt: team.CreatedAt,
+			UpdatedAt: team.UpdatedAt,
+		}
+	}
+
+	teams := make([]entities.TournamentTeam, len(teamModels))
+	for i, model := range teamModels {
+		teams[i] = entities.TournamentTeam{
+			ID:           model.ID,
+			TournamentID: model.TournamentID,
+			TeamID:       model.TeamID,
+			Seed:         model.Seed,
+			RegisteredAt: model.RegisteredAt,
+			Team:         byID[model.TeamID],
+		}
+	}
+	return teams, nil
+}
+
+// getStageRules загружает правила пула карт по стадиям вместе с исключенными картами
+func (r *tournamentRepository) getStageRules(tournamentID uint) ([]entities.TournamentStageRule, error) {
+	var ruleModels []models.TournamentStageRuleModel
+	if err := r.db.Where("tournament_id = ?", tournamentID).Order("id ASC").Find(&ruleModels).Error; err != nil {
+		return nil, err
+	}
+	if len(ruleModels) == 0 {
+		return nil, nil
+	}
+
+	var mapModels []models.TournamentStageMapModel
+	if err := r.db.Where("tournament_id = ?", tournamentID).Order("id ASC").Find(&mapModels).Error; err != nil {
+		return nil, err
+	}
+	excluded := make(map[string][]uint)
+	for _, m := range mapModels {
+		excluded[m.Stage] = append(excluded[m.Stage], m.MapID)
+	}
+
+	rules := make([]entities.TournamentStageRule, len(ruleModels))
+	for i, model := range ruleModels {
+		rules[i] = entities.TournamentStageRule{
+			Stage:          entities.TournamentStage(model.Stage),
+			NoRepeatPicks:  model.NoRepeatPicks,
+			CarryOverBans:  model.CarryOverBans,
+			ExcludedMapIDs: excluded[model.Stage],
+			DeciderMapID:   model.DeciderMapID,
+		}
+	}
+	return rules, nil
+}
+
+func toTournamentModel(tournament *entities.Tournament) *models.TournamentModel {
+	return &models.TournamentModel{
+		ID:            tournament.ID,
+		OwnerID:       tournament.OwnerID,
+		Name:          tournament.Name,
+		GameID:        tournament.GameID,
+		MapPoolID:     tournament.MapPoolID,
+		Format:        string(tournament.Format),
+		Status:        string(tournament.Status),
+		MaxTeams:      tournament.MaxTeams,
+		SwissRounds:   tournament.SwissRounds,
+		FinalVetoType: string(tournament.FinalVetoType),
+		WinnerTeamID:  tournament.WinnerTeamID,
+		StartedAt:     tournament.StartedAt,
+	}
+}
+
+func toTournamentEntity(model *models.TournamentModel) *entities.Tournament {
+	return &entities.Tournament{
+		ID:            model.ID,
+		OwnerID:       model.OwnerID,
+		Name:          model.Name,
+		GameID:        model.GameID,
+		MapPoolID:     model.MapPoolID,
+		Format:        entities.TournamentFormat(model.Format),
+		Status:        entities.TournamentStatus(model.Status),
+		MaxTeams:      model.MaxTeams,
+		SwissRounds:   model.SwissRounds,
+		FinalVetoType: entities.VetoType(model.FinalVetoType),
+		WinnerTeamID:  model.WinnerTeamID,
+		StartedAt:     model.StartedAt,
+		CreatedAt:     model.CreatedAt,
+		UpdatedAt:     model.UpdatedAt,
+	}
+}
+
+func toTournamentMatchModel(match *entities.TournamentMatch) *models.TournamentMatchModel {
+	return &models.TournamentMatchModel{
+		TournamentID:     match.TournamentID,
+		Bracket:          string(match.Bracket),
+		Round:            match.Round,
+		Position:         match.Position,
+		TeamAID:          match.TeamAID,
+		TeamBID:          match.TeamBID,
+		WinnerTeamID:     match.WinnerTeamID,
+		Status:           string(match.Status),
+		VetoType:         string(match.VetoType),
+		ScheduledAt:      match.ScheduledAt,
+		RoomID:           match.RoomID,
+		VetoSessionID:    match.VetoSessionID,
+		NextMatchID:      match.NextMatchID,
+		NextSlot:         match.NextSlot,
+		LoserNextMatchID: match.LoserNextMatchID,
+		LoserNextSlot:    match.LoserNextSlot,
+	}
+}
+
+func toTournamentMatchEntity(model *models.TournamentMatchModel) *entities.TournamentMatch {
+	return &entities.TournamentMatch{
+		ID:               model.ID,
+		TournamentID:     model.TournamentID,
+		Bracket:          entities.TournamentBracket(model.Bracket),
+		Round:            model.Round,
+		Position:         model.Position,
+		TeamAID:          model.TeamAID,
+		TeamBID:          model.TeamBID,
+		WinnerTeamID:     model.WinnerTeamID,
+		Status:           entities.TournamentMatchStatus(model.Status),
+		VetoType:         entities.VetoType(model.VetoType),
+		ScheduledAt:      model.ScheduledAt,
+		RoomID:           model.RoomID,
+		VetoSessionID:    model.VetoSessionID,
+		NextMatchID:      model.NextMatchID,
+		NextSlot:         model.NextSlot,
+		LoserNextMatchID: model.LoserNextMatchID,
+		LoserNextSlot:    model.LoserNextSlot,
+		CreatedAt:        model.CreatedAt,
+		UpdatedAt:        model.UpdatedAt,
+	}
+}
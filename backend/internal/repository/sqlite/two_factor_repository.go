@@ -0,0 +1,191 @@
+package sqlite
+
+import (
+	"errors"
+	"time"
+
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+	"github.com/bbp/backend/internal/repository/models"
+	"gorm.io/gorm"
+)
+
+type twoFactorRepository struct {
+	db *gorm.DB
+}
+
+func NewTwoFactorRepository(db *gorm.DB) repositories.TwoFactorRepository {
+	return &twoFactorRepository{db: db}
+}
+
+func (r *twoFactorRepository) GetByUserID(userID uint) (*entities.UserTOTP, error) {
+	var model models.UserTOTPModel
+	if err := r.db.Where("user_id = ?", userID).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &entities.UserTOTP{
+		UserID:       model.UserID,
+		Secret:       model.Secret,
+		ConfirmedAt:  model.ConfirmedAt,
+		LastUsedStep: model.LastUsedStep,
+		CreatedAt:    model.CreatedAt,
+	}, nil
+}
+
+func (r *twoFactorRepository) Save(totp *entities.UserTOTP) error {
+	model := &models.UserTOTPModel{
+		UserID:       totp.UserID,
+		Secret:       totp.Secret,
+		ConfirmedAt:  totp.ConfirmedAt,
+		LastUsedStep: totp.LastUsedStep,
+	}
+
+	// Save выполняет upsert по первичному ключу user_id
+	if err := r.db.Save(model).Error; err != nil {
+		return err
+	}
+
+	totp.CreatedAt = model.CreatedAt
+	return nil
+}
+
+func (r *twoFactorRepository) Confirm(userID uint, step int64) error {
+	return r.db.Model(&models.UserTOTPModel{}).
+		Where("user_id = ?", userID).
+		Updates(map[string]interface{}{
+			"confirmed_at":   time.Now(),
+			"last_used_step": step,
+		}).Error
+}
+
+func (r *twoFactorRepository) UseStep(userID uint, step int64) (bool, error) {
+	result := r.db.Model(&models.UserTOTPModel{}).
+		Where("user_id = ? AND last_used_step < ?", userID, step).
+		Update("last_used_step", step)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *twoFactorRepository) Delete(userID uint) error {
+	return r.db.Where("user_id = ?", userID).Delete(&models.UserTOTPModel{}).Error
+}
+
+type recoveryCodeRepository struct {
+	db *gorm.DB
+}
+
+func NewRecoveryCodeRepository(db *gorm.DB) repositories.RecoveryCodeRepository {
+	return &recoveryCodeRepository{db: db}
+}
+
+func (r *recoveryCodeRepository) ReplaceForUser(userID uint, codeHashes []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&models.RecoveryCodeModel{}).Error; err != nil {
+			return err
+		}
+
+		modelList := make([]models.RecoveryCodeModel, len(codeHashes))
+		for i, hash := range codeHashes {
+			modelList[i] = models.RecoveryCodeModel{
+				UserID:   userID,
+				CodeHash: hash,
+			}
+		}
+		if len(modelList) == 0 {
+			return nil
+		}
+		return tx.Create(&modelList).Error
+	})
+}
+
+func (r *recoveryCodeRepository) UseCode(userID uint, codeHash string) (bool, error) {
+	result := r.db.Model(&models.RecoveryCodeModel{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *recoveryCodeRepository) CountUnused(userID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.RecoveryCodeModel{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *recoveryCodeRepository) DeleteByUserID(userID uint) error {
+	return r.db.Where("user_id = ?", userID).Delete(&models.RecoveryCodeModel{}).Error
+}
+
+type loginChallengeRepository struct {
+	db *gorm.DB
+}
+
+func NewLoginChallengeRepository(db *gorm.DB) repositories.LoginChallengeRepository {
+	return &loginChallengeRepository{db: db}
+}
+
+func (r *loginChallengeRepository) Create(challenge *entities.LoginChallenge) error {
+	model := &models.LoginChallengeModel{
+		UserID:    challenge.UserID,
+		TokenHash: challenge.TokenHash,
+		ExpiresAt: challenge.ExpiresAt,
+	}
+
+	if err := r.db.Create(model).Error; err != nil {
+		return err
+	}
+
+	challenge.ID = model.ID
+	challenge.CreatedAt = model.CreatedAt
+	return nil
+}
+
+func (r *loginChallengeRepository) GetByHash(tokenHash string) (*entities.LoginChallenge, error) {
+	var model models.LoginChallengeModel
+	if err := r.db.Where("token_hash = ?", tokenHash).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &entities.LoginChallenge{
+		ID:        model.ID,
+		UserID:    model.UserID,
+		TokenHash: model.TokenHash,
+		Attempts:  model.Attempts,
+		ExpiresAt: model.ExpiresAt,
+		UsedAt:    model.UsedAt,
+		CreatedAt: model.CreatedAt,
+	}, nil
+}
+
+func (r *loginChallengeRepository) IncrementAttempts(id uint) error {
+	return r.db.Model(&models.LoginChallengeModel{}).
+		Where("id = ?", id).
+		Update("attempts", gorm.Expr("attempts + 1")).Error
+}
+
+func (r *loginChallengeRepository) MarkUsed(id uint) (bool, error) {
+	result := r.db.Model(&models.LoginChallengeModel{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *loginChallengeRepository) DeleteExpired(now time.Time) error {
+	return r.db.Where("expires_at < ?", now).Delete(&models.LoginChallengeModel{}).Error
+}
@@ -0,0 +1,132 @@
+package sqlite
+
+import (
+	"errors"
+	"time"
+
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+	"github.com/bbp/backend/internal/repository/models"
+	"gorm.io/gorm"
+)
+
+type userIdentityRepository struct {
+	db *gorm.DB
+}
+
+func NewUserIdentityRepository(db *gorm.DB) repositories.UserIdentityRepository {
+	return &userIdentityRepository{db: db}
+}
+
+func (r *userIdentityRepository) Create(identity *entities.UserIdentity) error {
+	model := &models.UserIdentityModel{
+		UserID:   identity.UserID,
+		Provider: identity.Provider,
+		Subject:  identity.Subject,
+		Email:    identity.Email,
+	}
+
+	if err := r.db.Create(model).Error; err != nil {
+		return err
+	}
+
+	identity.ID = model.ID
+	identity.CreatedAt = model.CreatedAt
+	return nil
+}
+
+func (r *userIdentityRepository) GetByProviderSubject(provider, subject string) (*entities.UserIdentity, error) {
+	var model models.UserIdentityModel
+	if err := r.db.Where("provider = ? AND subject = ?", provider, subject).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return toUserIdentityEntity(&model), nil
+}
+
+func (r *userIdentityRepository) GetByUserID(userID uint) ([]entities.UserIdentity, error) {
+	var modelList []models.UserIdentityModel
+	if err := r.db.Where("user_id = ?", userID).Order("created_at ASC").Find(&modelList).Error; err != nil {
+		return nil, err
+	}
+
+	identities := make([]entities.UserIdentity, len(modelList))
+	for i, model := range modelList {
+		identities[i] = *toUserIdentityEntity(&model)
+	}
+	return identities, nil
+}
+
+func toUserIdentityEntity(model *models.UserIdentityModel) *entities.UserIdentity {
+	return &entities.UserIdentity{
+		ID:        model.ID,
+		UserID:    model.UserID,
+		Provider:  model.Provider,
+		Subject:   model.Subject,
+		Email:     model.Email,
+		CreatedAt: model.CreatedAt,
+	}
+}
+
+type oauthStateRepository struct {
+	db *gorm.DB
+}
+
+func NewOAuthStateRepository(db *gorm.DB) repositories.OAuthStateRepository {
+	return &oauthStateRepository{db: db}
+}
+
+func (r *oauthStateRepository) Create(state *entities.OAuthState) error {
+	model := &models.OAuthStateModel{
+		State:        state.State,
+		Provider:     state.Provider,
+		CodeVerifier: state.CodeVerifier,
+		ExpiresAt:    state.ExpiresAt,
+	}
+
+	if err := r.db.Create(model).Error; err != nil {
+		return err
+	}
+
+	state.CreatedAt = model.CreatedAt
+	return nil
+}
+
+func (r *oauthStateRepository) Consume(state string) (*entities.OAuthState, error) {
+	var model models.OAuthStateModel
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("state = ?", state).First(&model).Error; err != nil {
+			return err
+		}
+		// Удаление в той же транзакции делает state одноразовым
+		result := tx.Where("state = ?", state).Delete(&models.OAuthStateModel{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &entities.OAuthState{
+		State:        model.State,
+		Provider:     model.Provider,
+		CodeVerifier: model.CodeVerifier,
+		ExpiresAt:    model.ExpiresAt,
+		CreatedAt:    model.CreatedAt,
+	}, nil
+}
+
+func (r *oauthStateRepository) DeleteExpired(now time.Time) error {
+	return r.db.Where("expires_at <= ?", now).Delete(&models.OAuthStateModel{}).Error
+}
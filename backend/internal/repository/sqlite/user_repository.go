@@ -6,6 +6,8 @@ import (
 	"github.com/bbp/backend/internal/domain/repositories"
 	"github.com/bbp/backend/internal/repository/models"
 	"gorm.io/gorm"
+	"strings"
+	"time"
 )
 
 type userRepository struct {
@@ -18,9 +20,15 @@ func NewUserRepository(db *gorm.DB) repositories.UserRepository {
 
 func (r *userRepository) Create(user *entities.User) error {
 	model := &models.UserModel{
-		Email:    user.Email,
-		Username: user.Username,
-		Password: user.Password,
+		Email:             user.Email,
+		Username:          user.Username,
+		Password:          user.Password,
+		EmailVerified:     user.EmailVerified,
+		IsGuest:           user.IsGuest,
+		ProfileVisibility: string(user.ProfileVisibility),
+	}
+	if model.ProfileVisibility == "" {
+		model.ProfileVisibility = string(entities.ProfileVisibilityPublic)
 	}
 
 	if err := r.db.Create(model).Error; err != nil {
@@ -28,6 +36,7 @@ func (r *userRepository) Create(user *entities.User) error {
 	}
 
 	user.ID = model.ID
+	user.ProfileVisibility = entities.ProfileVisibility(model.ProfileVisibility)
 	user.CreatedAt = model.CreatedAt
 	user.UpdatedAt = model.UpdatedAt
 	return nil
@@ -69,28 +78,105 @@ func (r *userRepository) GetByUsername(username string) (*entities.User, error)
 	return toUserEntity(&model), nil
 }
 
+func (r *userRepository) GetGuestsCreatedBefore(before time.Time) ([]entities.User, error) {
+	var modelList []models.UserModel
+	if err := r.db.Where("is_guest = ? AND created_at < ?", true, before).Order("id").Find(&modelList).Error; err != nil {
+		return nil, err
+	}
+
+	users := make([]entities.User, len(modelList))
+	for i := range modelList {
+		users[i] = *toUserEntity(&modelList[i])
+	}
+	return users, nil
+}
+
 func (r *userRepository) Update(user *entities.User) error {
 	model := &models.UserModel{
-		ID:       user.ID,
-		Email:    user.Email,
-		Username: user.Username,
-		Password: user.Password,
+		ID:                user.ID,
+		Email:             user.Email,
+		Username:          user.Username,
+		Password:          user.Password,
+		EmailVerified:     user.EmailVerified,
+		IsGuest:           user.IsGuest,
+		ProfileVisibility: string(user.ProfileVisibility),
+	}
+	if model.ProfileVisibility == "" {
+		model.ProfileVisibility = string(entities.ProfileVisibilityPublic)
 	}
 
-	return r.db.Model(&models.UserModel{}).Where("id = ?", user.ID).Updates(model).Error
+	// Select нужен, чтобы сохранялись сбросы EmailVerified и IsGuest в false
+	return r.db.Model(&models.UserModel{}).Where("id = ?", user.ID).
+		Select("Email", "Username", "Password", "EmailVerified", "IsGuest", "ProfileVisibility").
+		Updates(model).Error
 }
 
 func (r *userRepository) Delete(id uint) error {
-	return r.db.Delete(&models.UserModel{}, id).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var model models.UserModel
+		if err := tx.Unscoped().First(&model, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return err
+		}
+
+		// Дочерние строки подписок и API ключей удаляются раньше самих подписок и ключей
+		webhookIDs := tx.Model(&models.WebhookModel{}).Select("id").Where("user_id = ?", id)
+		for _, dependent := range []interface{}{&models.WebhookEventModel{}, &models.WebhookDeliveryModel{}} {
+			if err := tx.Where("webhook_id IN (?)", webhookIDs).Delete(dependent).Error; err != nil {
+				return err
+			}
+		}
+		apiKeyIDs := tx.Model(&models.APIKeyModel{}).Select("id").Where("user_id = ?", id)
+		for _, dependent := range []interface{}{&models.APIKeyScopeModel{}, &models.APIKeyRoomModel{}} {
+			if err := tx.Where("api_key_id IN (?)", apiKeyIDs).Delete(dependent).Error; err != nil {
+				return err
+			}
+		}
+
+		// Отозванные access токены не удаляем: они нужны denylist до истечения срока
+		dependents := []interface{}{
+			&models.RefreshTokenModel{},
+			&models.PasswordResetTokenModel{},
+			&models.EmailVerificationTokenModel{},
+			&models.UserIdentityModel{},
+			&models.UserTOTPModel{},
+			&models.RecoveryCodeModel{},
+			&models.LoginChallengeModel{},
+			&models.TeamMemberModel{},
+			&models.TeamInviteModel{},
+			&models.WebhookModel{},
+			&models.APIKeyModel{},
+			&models.MatchResultPlayerModel{},
+		}
+		for _, dependent := range dependents {
+			if err := tx.Where("user_id = ?", id).Delete(dependent).Error; err != nil {
+				return err
+			}
+		}
+
+		// Счетчик неудачных входов хранит email
+		if err := tx.Where("scope = ? AND key = ?", string(entities.LoginThrottleScopeAccount), strings.ToLower(model.Email)).
+			Delete(&models.LoginThrottleModel{}).Error; err != nil {
+			return err
+		}
+
+		// Удаляем окончательно, чтобы email и username освободились
+		return tx.Unscoped().Delete(&models.UserModel{}, id).Error
+	})
 }
 
 func toUserEntity(model *models.UserModel) *entities.User {
 	return &entities.User{
-		ID:        model.ID,
-		Email:     model.Email,
-		Username:  model.Username,
-		Password:  model.Password,
-		CreatedAt: model.CreatedAt,
-		UpdatedAt: model.UpdatedAt,
+		ID:                model.ID,
+		Email:             model.Email,
+		Username:          model.Username,
+		Password:          model.Password,
+		EmailVerified:     model.EmailVerified,
+		IsGuest:           model.IsGuest,
+		ProfileVisibility: entities.ProfileVisibility(model.ProfileVisibility),
+		CreatedAt:         model.CreatedAt,
+		UpdatedAt:         model.UpdatedAt,
 	}
 }
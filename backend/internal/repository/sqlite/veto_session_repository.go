@@ -21,10 +21,13 @@ func (r *vetoSessionRepository) Create(session *entities.VetoSession) error {
 		UserID:        session.UserID,
 		GameID:        session.GameID,
 		MapPoolID:     session.MapPoolID,
+		MapRotationID: session.MapRotationID,
 		Type:          string(session.Type),
 		Status:        string(session.Status),
 		TeamAName:     session.TeamAName,
 		TeamBName:     session.TeamBName,
+		TeamAID:       session.TeamAID,
+		TeamBID:       session.TeamBID,
 		CurrentTeam:   session.CurrentTeam,
 		SelectedMapID: session.SelectedMapID,
 		SelectedSide:  session.SelectedSide,
@@ -33,7 +36,36 @@ func (r *vetoSessionRepository) Create(session *entities.VetoSession) error {
 		FinishedAt:    session.FinishedAt,
 	}
 
-	if err := r.db.Create(model).Error; err != nil {
+	// Сессия и снимок её карт сохраняются атомарно
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(model).Error; err != nil {
+			return err
+		}
+
+		if constraints := toVetoSessionConstraintModels(model.ID, session.Constraints); len(constraints) > 0 {
+			if err := tx.Create(&constraints).Error; err != nil {
+				return err
+			}
+		}
+
+		if len(session.MapSnapshot) == 0 {
+			return nil
+		}
+
+		snapshot := make([]models.VetoSessionMapModel, len(session.MapSnapshot))
+		for i, m := range session.MapSnapshot {
+			snapshot[i] = models.VetoSessionMapModel{
+				VetoSessionID: model.ID,
+				MapID:         m.ID,
+				Position:      i,
+				Name:          m.Name,
+				Slug:          m.Slug,
+				ImageURL:      m.ImageURL,
+			}
+		}
+		return tx.Create(&snapshot).Error
+	})
+	if err != nil {
 		return err
 	}
 
@@ -75,6 +107,18 @@ func (r *vetoSessionRepository) GetByID(id uint) (*entities.VetoSession, error)
 	}
 	session.Actions = actions
 
+	snapshot, err := r.loadMapSnapshot(session)
+	if err != nil {
+		return nil, err
+	}
+	session.MapSnapshot = snapshot
+
+	constraints, err := r.loadConstraints(session.ID)
+	if err != nil {
+		return nil, err
+	}
+	session.Constraints = constraints
+
 	return session, nil
 }
 
@@ -110,6 +154,18 @@ func (r *vetoSessionRepository) GetByShareToken(token string) (*entities.VetoSes
 	}
 	session.Actions = actions
 
+	snapshot, err := r.loadMapSnapshot(session)
+	if err != nil {
+		return nil, err
+	}
+	session.MapSnapshot = snapshot
+
+	constraints, err := r.loadConstraints(session.ID)
+	if err != nil {
+		return nil, err
+	}
+	session.Constraints = constraints
+
 	return session, nil
 }
 
@@ -129,6 +185,55 @@ func (r *vetoSessionRepository) GetByUserID(userID uint) ([]entities.VetoSession
 			return nil, err
 		}
 		
+		actions := make([]entities.VetoAction, len(actionModels))
+		for j, actionModel := range actionModels {
+			actions[j] = entities.VetoAction{
+				ID:            actionModel.ID,
+				VetoSessionID: actionModel.VetoSessionID,
+				MapID:         actionModel.MapID,
+				Team:          actionModel.Team,
+				ActionType:    entities.VetoActionType(actionModel.ActionType),
+				StepNumber:    actionModel.StepNumber,
+				SelectedSide:  actionModel.SelectedSide,
+				CreatedAt:     actionModel.CreatedAt,
+			}
+		}
+		session.Actions = actions
+
+		snapshot, err := r.loadMapSnapshot(session)
+		if err != nil {
+			return nil, err
+		}
+		session.MapSnapshot = snapshot
+
+		constraints, err := r.loadConstraints(session.ID)
+		if err != nil {
+			return nil, err
+		}
+		session.Constraints = constraints
+		sessions[i] = *session
+	}
+
+	return sessions, nil
+}
+
+func (r *vetoSessionRepository) GetByTeamID(teamID uint) ([]entities.VetoSession, error) {
+	var modelList []models.VetoSessionModel
+	if err := r.db.Where("team_a_id = ? OR team_b_id = ?", teamID, teamID).
+		Order("created_at DESC").
+		Find(&modelList).Error; err != nil {
+		return nil, err
+	}
+
+	sessions := make([]entities.VetoSession, len(modelList))
+	for i, model := range modelList {
+		session := toVetoSessionEntity(&model)
+
+		var actionModels []models.VetoActionModel
+		if err := r.db.Where("veto_session_id = ?", model.ID).Order("step_number ASC").Find(&actionModels).Error; err != nil {
+			return nil, err
+		}
+
 		actions := make([]entities.VetoAction, len(actionModels))
 		for j, actionModel := range actionModels {
 			actions[j] = entities.VetoAction{
@@ -149,16 +254,25 @@ func (r *vetoSessionRepository) GetByUserID(userID uint) ([]entities.VetoSession
 	return sessions, nil
 }
 
+func (r *vetoSessionRepository) AnonymizeByUserID(userID uint) error {
+	return r.db.Unscoped().Model(&models.VetoSessionModel{}).
+		Where("user_id = ?", userID).
+		Update("user_id", nil).Error
+}
+
 func (r *vetoSessionRepository) Update(session *entities.VetoSession) error {
 	model := &models.VetoSessionModel{
 		ID:            session.ID,
 		UserID:        session.UserID,
 		GameID:        session.GameID,
 		MapPoolID:     session.MapPoolID,
+		MapRotationID: session.MapRotationID,
 		Type:          string(session.Type),
 		Status:        string(session.Status),
 		TeamAName:     session.TeamAName,
 		TeamBName:     session.TeamBName,
+		TeamAID:       session.TeamAID,
+		TeamBID:       session.TeamBID,
 		CurrentTeam:   session.CurrentTeam,
 		SelectedMapID: session.SelectedMapID,
 		SelectedSide:  session.SelectedSide,
@@ -170,20 +284,107 @@ func (r *vetoSessionRepository) Update(session *entities.VetoSession) error {
 	return r.db.Model(&models.VetoSessionModel{}).Where("id = ?", session.ID).Updates(model).Error
 }
 
+func (r *vetoSessionRepository) CountInProgressByMapPoolID(mapPoolID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.VetoSessionModel{}).
+		Where("map_pool_id = ? AND status = ?", mapPoolID, string(entities.VetoStatusInProgress)).
+		Count(&count).Error
+	return count, err
+}
+
 func (r *vetoSessionRepository) Delete(id uint) error {
 	return r.db.Delete(&models.VetoSessionModel{}, id).Error
 }
 
+// loadMapSnapshot загружает снимок карт сессии, упорядоченный как в исходном пуле
+func (r *vetoSessionRepository) loadMapSnapshot(session *entities.VetoSession) ([]entities.Map, error) {
+	var snapshotModels []models.VetoSessionMapModel
+	if err := r.db.Where("veto_session_id = ?", session.ID).Order("position ASC").Find(&snapshotModels).Error; err != nil {
+		return nil, err
+	}
+
+	maps := make([]entities.Map, len(snapshotModels))
+	for i, m := range snapshotModels {
+		maps[i] = entities.Map{
+			ID:       m.MapID,
+			GameID:   session.GameID,
+			Name:     m.Name,
+			Slug:     m.Slug,
+			ImageURL: m.ImageURL,
+			IsActive: true,
+		}
+	}
+	return maps, nil
+}
+
+// loadConstraints загружает ограничения пула сессии или nil, если их нет
+func (r *vetoSessionRepository) loadConstraints(sessionID uint) (*entities.VetoConstraints, error) {
+	var constraintModels []models.VetoSessionConstraintModel
+	if err := r.db.Where("veto_session_id = ?", sessionID).Order("id ASC").Find(&constraintModels).Error; err != nil {
+		return nil, err
+	}
+	if len(constraintModels) == 0 {
+		return nil, nil
+	}
+
+	constraints := &entities.VetoConstraints{}
+	for _, m := range constraintModels {
+		switch m.Kind {
+		case vetoConstraintDecider:
+			mapID := m.MapID
+			constraints.DeciderMapID = &mapID
+		case vetoConstraintTeamExcluded:
+			if m.Team == "A" {
+				constraints.TeamAExcludedMapIDs = append(constraints.TeamAExcludedMapIDs, m.MapID)
+			} else {
+				constraints.TeamBExcludedMapIDs = append(constraints.TeamBExcludedMapIDs, m.MapID)
+			}
+		default:
+			constraints.ExcludedMapIDs = append(constraints.ExcludedMapIDs, m.MapID)
+		}
+	}
+	return constraints, nil
+}
+
+const (
+	vetoConstraintExcluded     = "excluded"
+	vetoConstraintTeamExcluded = "team_excluded"
+	vetoConstraintDecider      = "decider"
+)
+
+func toVetoSessionConstraintModels(sessionID uint, constraints *entities.VetoConstraints) []models.VetoSessionConstraintModel {
+	if constraints.IsEmpty() {
+		return nil
+	}
+
+	var result []models.VetoSessionConstraintModel
+	for _, mapID := range constraints.ExcludedMapIDs {
+		result = append(result, models.VetoSessionConstraintModel{VetoSessionID: sessionID, MapID: mapID, Kind: vetoConstraintExcluded})
+	}
+	for _, team := range []string{"A", "B"} {
+		for _, mapID := range constraints.TeamExcludedMapIDs(team) {
+			result = append(result, models.VetoSessionConstraintModel{VetoSessionID: sessionID, MapID: mapID, Kind: vetoConstraintTeamExcluded, Team: team})
+		}
+	}
+	if constraints.DeciderMapID != nil {
+		result = append(result, models.VetoSessionConstraintModel{VetoSessionID: sessionID, MapID: *constraints.DeciderMapID, Kind: vetoConstraintDecider})
+	}
+	return result
+}
+
 func toVetoSessionEntity(model *models.VetoSessionModel) *entities.VetoSession {
 	return &entities.VetoSession{
 		ID:            model.ID,
 		UserID:        model.UserID,
 		GameID:        model.GameID,
 		MapPoolID:     model.MapPoolID,
+		MapRotationID: model.MapRotationID,
 		Type:          entities.VetoType(model.Type),
 		Status:        entities.VetoStatus(model.Status),
 		TeamAName:     model.TeamAName,
 		TeamBName:     model.TeamBName,
+		TeamAID:       model.TeamAID,
+		TeamBID:       model.TeamBID,
 		CurrentTeam:   model.CurrentTeam,
 		SelectedMapID: model.SelectedMapID,
 		SelectedSide:  model.SelectedSide,
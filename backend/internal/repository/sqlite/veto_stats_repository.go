@@ -0,0 +1,183 @@
+package sqlite
+
+import (
+	"fmt"
+
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+	"gorm.io/gorm"
+)
+
+type vetoStatsRepository struct {
+	db *gorm.DB
+}
+
+func NewVetoStatsRepository(db *gorm.DB) repositories.VetoStatsRepository {
+	return &vetoStatsRepository{db: db}
+}
+
+// userPlayedSQL - SQL-условие, что пользователь играл в сессии: он есть в составе подтвержденного результата
+// или состоит в одной из команд сессии
+const userPlayedSQL = `(veto_sessions.id IN (
+		SELECT match_results.veto_session_id FROM match_results
+		JOIN match_result_players ON match_result_players.match_result_id = match_results.id
+		WHERE match_result_players.user_id = %[1]d
+	) OR veto_sessions.team_a_id IN (SELECT team_id FROM team_members WHERE user_id = %[1]d)
+	OR veto_sessions.team_b_id IN (SELECT team_id FROM team_members WHERE user_id = %[1]d))`
+
+// userSideSQL - SQL-условие, что сторона сессии (SQL-выражение со значением 'A' или 'B') принадлежит пользователю:
+// он играл за нее по составу подтвержденного результата или состоит в команде этой стороны
+const userSideSQL = `(EXISTS (
+		SELECT 1 FROM match_results
+		JOIN match_result_players ON match_result_players.match_result_id = match_results.id
+		WHERE match_results.veto_session_id = veto_sessions.id AND match_result_players.user_id = %[2]d AND match_result_players.team = %[1]s
+	) OR EXISTS (
+		SELECT 1 FROM team_members
+		WHERE team_members.user_id = %[2]d AND team_members.team_id = (CASE %[1]s WHEN 'A' THEN veto_sessions.team_a_id ELSE veto_sessions.team_b_id END)
+	))`
+
+func (r *vetoStatsRepository) GetUserStats(userID uint, mapLimit int) (*entities.UserVetoStats, error) {
+	played := fmt.Sprintf(userPlayedSQL, userID)
+	userSide := func(side string) string {
+		return played + " AND " + fmt.Sprintf(userSideSQL, side, userID)
+	}
+	stats, err := r.collect(mapLimit, func(db *gorm.DB) *gorm.DB {
+		return db.Where("veto_sessions.user_id = ? OR "+played, userID)
+	}, func(db *gorm.DB) *gorm.DB {
+		// Создатель сессии может вести обе стороны, поэтому баны и пики учитываются только за команду пользователя
+		return db.Where(userSide("veto_actions.team"))
+	}, func(db *gorm.DB) *gorm.DB {
+		// Сторону после пика выбирает соперник пикнувшей команды
+		return db.Where(userSide("(CASE veto_actions.team WHEN 'A' THEN 'B' ELSE 'A' END)"))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Итоги серий - по составам, зафиксированным при подтверждении результата
+	stats.Results, err = r.collectResults(func(db *gorm.DB) *gorm.DB {
+		return db.Joins("JOIN match_result_players ON match_result_players.match_result_id = match_results.id AND match_result_players.user_id = ?", userID)
+	}, "match_result_players.team")
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+func (r *vetoStatsRepository) GetTeamStats(teamID uint, mapLimit int) (*entities.UserVetoStats, error) {
+	stats, err := r.collect(mapLimit, func(db *gorm.DB) *gorm.DB {
+		return db.Where("veto_sessions.team_a_id = ? OR veto_sessions.team_b_id = ?", teamID, teamID)
+	}, func(db *gorm.DB) *gorm.DB {
+		// Учитываем только баны и пики самой команды, а не её соперника
+		return db.Where(
+			"(veto_actions.team = 'A' AND veto_sessions.team_a_id = ?) OR (veto_actions.team = 'B' AND veto_sessions.team_b_id = ?)",
+			teamID, teamID,
+		)
+	}, func(db *gorm.DB) *gorm.DB {
+		// Сторону после пика выбирает соперник пикнувшей команды
+		return db.Where(
+			"(veto_actions.team = 'B' AND veto_sessions.team_a_id = ?) OR (veto_actions.team = 'A' AND veto_sessions.team_b_id = ?)",
+			teamID, teamID,
+		)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	stats.Results, err = r.collectResults(func(db *gorm.DB) *gorm.DB {
+		return db.Where("veto_sessions.team_a_id = ? OR veto_sessions.team_b_id = ?", teamID, teamID)
+	}, fmt.Sprintf("(CASE WHEN veto_sessions.team_a_id = %d THEN 'A' ELSE 'B' END)", teamID))
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// collectResults считает подтвержденные результаты серий, отобранных scope;
+// sideExpr - SQL-выражение стороны ('A' или 'B'), за которую играл учитываемый участник
+func (r *vetoStatsRepository) collectResults(scope func(*gorm.DB) *gorm.DB, sideExpr string) (entities.ResultStats, error) {
+	var stats entities.ResultStats
+	err := r.db.Table("match_results").
+		Joins("JOIN veto_sessions ON veto_sessions.id = match_results.veto_session_id").
+		Where("match_results.status = ? AND veto_sessions.deleted_at IS NULL", string(entities.MatchResultStatusConfirmed)).
+		Scopes(scope).
+		Select(fmt.Sprintf(`COUNT(*) AS series_played,
+			COALESCE(SUM(CASE WHEN match_results.winner_team = %[1]s THEN 1 ELSE 0 END), 0) AS series_won,
+			COALESCE(SUM(CASE WHEN %[1]s = 'A' THEN match_results.score_a ELSE match_results.score_b END), 0) AS maps_won,
+			COALESCE(SUM(CASE WHEN %[1]s = 'A' THEN match_results.score_b ELSE match_results.score_a END), 0) AS maps_lost`, sideExpr)).
+		Scan(&stats).Error
+	if err != nil {
+		return stats, err
+	}
+	stats.SeriesLost = stats.SeriesPlayed - stats.SeriesWon
+	return stats, nil
+}
+
+// collect считает статистику по сессиям и действиям, отобранным фильтрами;
+// sideScope отбирает пики, после которых сторону выбирал учитываемый участник
+func (r *vetoStatsRepository) collect(mapLimit int, sessionScope, actionScope, sideScope func(*gorm.DB) *gorm.DB) (*entities.UserVetoStats, error) {
+	stats := &entities.UserVetoStats{}
+
+	sessions := func() *gorm.DB {
+		return r.db.Table("veto_sessions").
+			Where("veto_sessions.deleted_at IS NULL").
+			Scopes(sessionScope)
+	}
+	// Действия после сброса сессии удалены мягко и в статистику не попадают
+	actions := func(scope func(*gorm.DB) *gorm.DB) *gorm.DB {
+		return r.db.Table("veto_actions").
+			Joins("JOIN veto_sessions ON veto_sessions.id = veto_actions.veto_session_id").
+			Where("veto_sessions.deleted_at IS NULL AND veto_actions.deleted_at IS NULL").
+			Scopes(scope)
+	}
+
+	if err := sessions().Count(&stats.SessionsTotal).Error; err != nil {
+		return nil, err
+	}
+	if err := sessions().
+		Where("veto_sessions.status = ?", string(entities.VetoStatusFinished)).
+		Count(&stats.SessionsFinished).Error; err != nil {
+		return nil, err
+	}
+
+	if err := sessions().
+		Select("veto_sessions.type AS type, COUNT(*) AS count").
+		Group("veto_sessions.type").
+		Order("count DESC, type").
+		Scan(&stats.Formats).Error; err != nil {
+		return nil, err
+	}
+
+	mapCounts := func(actionType entities.VetoActionType) ([]entities.MapCount, error) {
+		var counts []entities.MapCount
+		err := actions(actionScope).
+			Select("veto_actions.map_id AS map_id, COALESCE(maps.name, '') AS map_name, COUNT(*) AS count").
+			Joins("LEFT JOIN maps ON maps.id = veto_actions.map_id").
+			Where("veto_actions.action_type = ?", string(actionType)).
+			Group("veto_actions.map_id, maps.name").
+			Order("count DESC, veto_actions.map_id").
+			Limit(mapLimit).
+			Scan(&counts).Error
+		return counts, err
+	}
+
+	var err error
+	if stats.MostBanned, err = mapCounts(entities.VetoActionTypeBan); err != nil {
+		return nil, err
+	}
+	if stats.MostPicked, err = mapCounts(entities.VetoActionTypePick); err != nil {
+		return nil, err
+	}
+
+	// Сторона десайдера выбирается случайно, поэтому учитываем только выбор команд после пика
+	if err := actions(sideScope).
+		Select("veto_actions.selected_side AS side, COUNT(*) AS count").
+		Where("veto_actions.selected_side IS NOT NULL").
+		Group("veto_actions.selected_side").
+		Order("count DESC, side").
+		Scan(&stats.Sides).Error; err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,273 @@
+package sqlite
+
+import (
+	"errors"
+	"time"
+
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+	"github.com/bbp/backend/internal/repository/models"
+	"gorm.io/gorm"
+)
+
+type webhookRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookRepository(db *gorm.DB) repositories.WebhookRepository {
+	return &webhookRepository{db: db}
+}
+
+func (r *webhookRepository) Create(webhook *entities.Webhook) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		model := toWebhookModel(webhook)
+		if err := tx.Create(model).Error; err != nil {
+			return err
+		}
+
+		webhook.ID = model.ID
+		webhook.CreatedAt = model.CreatedAt
+		webhook.UpdatedAt = model.UpdatedAt
+		return saveWebhookEvents(tx, webhook)
+	})
+}
+
+func (r *webhookRepository) GetByID(id uint) (*entities.Webhook, error) {
+	var model models.WebhookModel
+	if err := r.db.First(&model, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	webhooks, err := r.withEvents([]models.WebhookModel{model})
+	if err != nil {
+		return nil, err
+	}
+	return &webhooks[0], nil
+}
+
+func (r *webhookRepository) GetByUserID(userID uint) ([]entities.Webhook, error) {
+	var modelList []models.WebhookModel
+	if err := r.db.Where("user_id = ?", userID).Order("id ASC").Find(&modelList).Error; err != nil {
+		return nil, err
+	}
+	return r.withEvents(modelList)
+}
+
+func (r *webhookRepository) GetActiveByRoomID(roomID uint) ([]entities.Webhook, error) {
+	var modelList []models.WebhookModel
+	if err := r.db.Where("room_id = ? AND is_active = ?", roomID, true).Order("id ASC").Find(&modelList).Error; err != nil {
+		return nil, err
+	}
+	return r.withEvents(modelList)
+}
+
+func (r *webhookRepository) GetActiveByUser(userID uint) ([]entities.Webhook, error) {
+	var modelList []models.WebhookModel
+	if err := r.db.Where("user_id = ? AND room_id IS NULL AND is_active = ?", userID, true).Order("id ASC").Find(&modelList).Error; err != nil {
+		return nil, err
+	}
+	return r.withEvents(modelList)
+}
+
+func (r *webhookRepository) Update(webhook *entities.Webhook) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		model := toWebhookModel(webhook)
+		model.ID = webhook.ID
+		// Select нужен, чтобы сохранить отключение подписки (false)
+		if err := tx.Model(model).Select("URL", "Secret", "IsActive", "UpdatedAt").Updates(model).Error; err != nil {
+			return err
+		}
+		webhook.UpdatedAt = model.UpdatedAt
+
+		if err := tx.Where("webhook_id = ?", webhook.ID).Delete(&models.WebhookEventModel{}).Error; err != nil {
+			return err
+		}
+		return saveWebhookEvents(tx, webhook)
+	})
+}
+
+func (r *webhookRepository) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("webhook_id = ?", id).Delete(&models.WebhookDeliveryModel{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("webhook_id = ?", id).Delete(&models.WebhookEventModel{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.WebhookModel{}, id).Error
+	})
+}
+
+// withEvents конвертирует модели подписок и загружает их фильтры событий
+func (r *webhookRepository) withEvents(modelList []models.WebhookModel) ([]entities.Webhook, error) {
+	webhooks := make([]entities.Webhook, len(modelList))
+	if len(modelList) == 0 {
+		return webhooks, nil
+	}
+
+	ids := make([]uint, len(modelList))
+	byID := make(map[uint]*entities.Webhook, len(modelList))
+	for i := range modelList {
+		webhooks[i] = *toWebhookEntity(&modelList[i])
+		ids[i] = modelList[i].ID
+		byID[ids[i]] = &webhooks[i]
+	}
+
+	var eventModels []models.WebhookEventModel
+	if err := r.db.Where("webhook_id IN ?", ids).Order("id ASC").Find(&eventModels).Error; err != nil {
+		return nil, err
+	}
+	for _, e := range eventModels {
+		webhook := byID[e.WebhookID]
+		webhook.Events = append(webhook.Events, entities.WebhookEvent(e.Event))
+	}
+	return webhooks, nil
+}
+
+// saveWebhookEvents сохраняет фильтр событий подписки
+func saveWebhookEvents(tx *gorm.DB, webhook *entities.Webhook) error {
+	for _, event := range webhook.Events {
+		if err := tx.Create(&models.WebhookEventModel{WebhookID: webhook.ID, Event: string(event)}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func toWebhookModel(webhook *entities.Webhook) *models.WebhookModel {
+	return &models.WebhookModel{
+		UserID:   webhook.UserID,
+		RoomID:   webhook.RoomID,
+		URL:      webhook.URL,
+		Secret:   webhook.Secret,
+		IsActive: webhook.IsActive,
+	}
+}
+
+func toWebhookEntity(model *models.WebhookModel) *entities.Webhook {
+	return &entities.Webhook{
+		ID:        model.ID,
+		UserID:    model.UserID,
+		RoomID:    model.RoomID,
+		URL:       model.URL,
+		Secret:    model.Secret,
+		Events:    []entities.WebhookEvent{},
+		IsActive:  model.IsActive,
+		CreatedAt: model.CreatedAt,
+		UpdatedAt: model.UpdatedAt,
+	}
+}
+
+type webhookDeliveryRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookDeliveryRepository(db *gorm.DB) repositories.WebhookDeliveryRepository {
+	return &webhookDeliveryRepository{db: db}
+}
+
+func (r *webhookDeliveryRepository) Create(delivery *entities.WebhookDelivery) error {
+	model := toWebhookDeliveryModel(delivery)
+	if err := r.db.Create(model).Error; err != nil {
+		return err
+	}
+
+	delivery.ID = model.ID
+	delivery.CreatedAt = model.CreatedAt
+	delivery.UpdatedAt = model.UpdatedAt
+	return nil
+}
+
+func (r *webhookDeliveryRepository) GetByID(id uint) (*entities.WebhookDelivery, error) {
+	var model models.WebhookDeliveryModel
+	if err := r.db.First(&model, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toWebhookDeliveryEntity(&model), nil
+}
+
+func (r *webhookDeliveryRepository) GetByWebhookID(webhookID uint, limit, offset int) ([]entities.WebhookDelivery, error) {
+	var modelList []models.WebhookDeliveryModel
+	if err := r.db.Where("webhook_id = ?", webhookID).
+		Order("created_at DESC, id DESC").
+		Limit(limit).Offset(offset).
+		Find(&modelList).Error; err != nil {
+		return nil, err
+	}
+
+	deliveries := make([]entities.WebhookDelivery, len(modelList))
+	for i := range modelList {
+		deliveries[i] = *toWebhookDeliveryEntity(&modelList[i])
+	}
+	return deliveries, nil
+}
+
+func (r *webhookDeliveryRepository) GetDue(now time.Time, limit int) ([]entities.WebhookDelivery, error) {
+	var modelList []models.WebhookDeliveryModel
+	if err := r.db.Where("status = ? AND next_attempt_at <= ?", string(entities.WebhookDeliveryStatusPending), now).
+		Order("next_attempt_at ASC, id ASC").
+		Limit(limit).
+		Find(&modelList).Error; err != nil {
+		return nil, err
+	}
+
+	deliveries := make([]entities.WebhookDelivery, len(modelList))
+	for i := range modelList {
+		deliveries[i] = *toWebhookDeliveryEntity(&modelList[i])
+	}
+	return deliveries, nil
+}
+
+func (r *webhookDeliveryRepository) Update(delivery *entities.WebhookDelivery) error {
+	model := toWebhookDeliveryModel(delivery)
+	model.ID = delivery.ID
+	// Select нужен, чтобы сбросить время следующей попытки после завершения доставки
+	if err := r.db.Model(model).
+		Select("Status", "Attempts", "ResponseStatus", "ResponseBody", "LastError", "NextAttemptAt", "DeliveredAt", "UpdatedAt").
+		Updates(model).Error; err != nil {
+		return err
+	}
+	delivery.UpdatedAt = model.UpdatedAt
+	return nil
+}
+
+func toWebhookDeliveryModel(delivery *entities.WebhookDelivery) *models.WebhookDeliveryModel {
+	return &models.WebhookDeliveryModel{
+		WebhookID:      delivery.WebhookID,
+		Event:          string(delivery.Event),
+		Payload:        delivery.Payload,
+		Status:         string(delivery.Status),
+		Attempts:       delivery.Attempts,
+		ResponseStatus: delivery.ResponseStatus,
+		ResponseBody:   delivery.ResponseBody,
+		LastError:      delivery.LastError,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		DeliveredAt:    delivery.DeliveredAt,
+		RedeliveryOf:   delivery.RedeliveryOf,
+	}
+}
+
+func toWebhookDeliveryEntity(model *models.WebhookDeliveryModel) *entities.WebhookDelivery {
+	return &entities.WebhookDelivery{
+		ID:             model.ID,
+		WebhookID:      model.WebhookID,
+		Event:          entities.WebhookEvent(model.Event),
+		Payload:        model.Payload,
+		Status:         entities.WebhookDeliveryStatus(model.Status),
+		Attempts:       model.Attempts,
+		ResponseStatus: model.ResponseStatus,
+		ResponseBody:   model.ResponseBody,
+		LastError:      model.LastError,
+		NextAttemptAt:  model.NextAttemptAt,
+		DeliveredAt:    model.DeliveredAt,
+		RedeliveryOf:   model.RedeliveryOf,
+		CreatedAt:      model.CreatedAt,
+		UpdatedAt:      model.UpdatedAt,
+	}
+}
@@ -0,0 +1,77 @@
+package apikey
+
+import (
+	"log"
+	"strings"
+	"time"
+
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+)
+
+// lastUsedInterval как часто обновлять время последнего использования ключа,
+// чтобы частые запросы бота не писали в БД на каждый запрос
+const lastUsedInterval = time.Minute
+
+// Authenticator проверяет API ключи из заголовка запроса
+type Authenticator struct {
+	apiKeyRepo repositories.APIKeyRepository
+	userRepo   repositories.UserRepository
+	roomRepo   repositories.RoomRepository
+}
+
+func NewAuthenticator(apiKeyRepo repositories.APIKeyRepository, userRepo repositories.UserRepository, roomRepo repositories.RoomRepository) *Authenticator {
+	return &Authenticator{
+		apiKeyRepo: apiKeyRepo,
+		userRepo:   userRepo,
+		roomRepo:   roomRepo,
+	}
+}
+
+// Authenticate находит действующий ключ и его владельца, отмечает использование ключа
+// Для неизвестного, отозванного или истекшего ключа возвращает nil без ошибки
+func (a *Authenticator) Authenticate(rawKey, ip string) (*entities.APIKey, *entities.User, error) {
+	if !strings.HasPrefix(rawKey, KeyPrefix) {
+		return nil, nil, nil
+	}
+
+	key, err := a.apiKeyRepo.GetByHash(hashKey(rawKey))
+	if err != nil {
+		return nil, nil, err
+	}
+	now := time.Now()
+	if key == nil || !key.IsActive(now) {
+		return nil, nil, nil
+	}
+
+	user, err := a.userRepo.GetByID(key.UserID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if user == nil || user.IsGuest {
+		return nil, nil, nil
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= lastUsedInterval || key.LastUsedIP != ip {
+		if err := a.apiKeyRepo.UpdateLastUsed(key.ID, now, ip); err != nil {
+			log.Printf("Failed to update api key %d last use: %v", key.ID, err)
+		} else {
+			key.LastUsedAt = &now
+			key.LastUsedIP = ip
+		}
+	}
+
+	return key, user, nil
+}
+
+// CanControlSession проверяет, что сессия вето идет в одной из комнат ключа
+func (a *Authenticator) CanControlSession(key *entities.APIKey, sessionID uint) (bool, error) {
+	if !key.IsRoomRestricted() {
+		return true, nil
+	}
+	room, err := a.roomRepo.GetByVetoSessionID(sessionID)
+	if err != nil {
+		return false, err
+	}
+	return room != nil && key.AllowsRoom(room.ID), nil
+}
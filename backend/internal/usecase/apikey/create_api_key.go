@@ -0,0 +1,167 @@
+package apikey
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+)
+
+const (
+	// KeyPrefix начало каждого ключа, упрощает поиск утекших ключей
+	KeyPrefix = "bbp_"
+	// maxActiveKeys лимит действующих ключей на пользователя
+	maxActiveKeys = 10
+	// displayPrefixLength длина начала ключа, которое видно в списке
+	displayPrefixLength = len(KeyPrefix) + 8
+)
+
+type CreateAPIKeyUseCase struct {
+	apiKeyRepo repositories.APIKeyRepository
+	roomRepo   repositories.RoomRepository
+}
+
+type CreateAPIKeyInput struct {
+	UserID    uint
+	Name      string
+	Scopes    []entities.APIKeyScope
+	RoomIDs   []uint // Ограничить управление вето комнатами пользователя
+	ExpiresAt *time.Time
+}
+
+type CreateAPIKeyOutput struct {
+	APIKey *entities.APIKey
+	Key    string // Ключ целиком; больше нигде не хранится и не показывается
+}
+
+func NewCreateAPIKeyUseCase(apiKeyRepo repositories.APIKeyRepository, roomRepo repositories.RoomRepository) *CreateAPIKeyUseCase {
+	return &CreateAPIKeyUseCase{
+		apiKeyRepo: apiKeyRepo,
+		roomRepo:   roomRepo,
+	}
+}
+
+func (uc *CreateAPIKeyUseCase) Execute(input CreateAPIKeyInput) (*CreateAPIKeyOutput, error) {
+	name := strings.TrimSpace(input.Name)
+	if name == "" || len(name) > 100 {
+		return nil, ErrInvalidName
+	}
+
+	scopes, err := normalizeScopes(input.Scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if input.ExpiresAt != nil && !input.ExpiresAt.After(now) {
+		return nil, ErrInvalidExpiry
+	}
+
+	roomIDs, err := uc.checkRooms(input.RoomIDs, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := uc.apiKeyRepo.GetByUserID(input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	active := 0
+	for i := range existing {
+		if existing[i].IsActive(now) {
+			active++
+		}
+	}
+	if active >= maxActiveKeys {
+		return nil, ErrTooManyAPIKeys
+	}
+
+	key, err := generateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	apiKey := &entities.APIKey{
+		UserID:    input.UserID,
+		Name:      name,
+		Prefix:    key[:displayPrefixLength],
+		KeyHash:   hashKey(key),
+		Scopes:    scopes,
+		RoomIDs:   roomIDs,
+		ExpiresAt: input.ExpiresAt,
+	}
+	if err := uc.apiKeyRepo.Create(apiKey); err != nil {
+		return nil, err
+	}
+
+	return &CreateAPIKeyOutput{APIKey: apiKey, Key: key}, nil
+}
+
+// checkRooms проверяет, что пользователь владеет комнатами или участвует в них
+func (uc *CreateAPIKeyUseCase) checkRooms(roomIDs []uint, userID uint) ([]uint, error) {
+	seen := make(map[uint]bool, len(roomIDs))
+	var result []uint
+	for _, roomID := range roomIDs {
+		if seen[roomID] {
+			continue
+		}
+		seen[roomID] = true
+
+		room, err := uc.roomRepo.GetByID(roomID)
+		if err != nil {
+			return nil, err
+		}
+		if room == nil {
+			return nil, ErrRoomNotFound
+		}
+		if !room.IsOwner(userID) {
+			participant, err := uc.roomRepo.GetParticipant(roomID, userID)
+			if err != nil {
+				return nil, err
+			}
+			if participant == nil {
+				return nil, ErrUnauthorized
+			}
+		}
+		result = append(result, roomID)
+	}
+	return result, nil
+}
+
+// normalizeScopes проверяет scopes и убирает повторы
+func normalizeScopes(scopes []entities.APIKeyScope) ([]entities.APIKeyScope, error) {
+	if len(scopes) == 0 {
+		return nil, ErrInvalidScope
+	}
+	var result []entities.APIKeyScope
+	seen := make(map[entities.APIKeyScope]bool, len(scopes))
+	for _, scope := range scopes {
+		if !scope.IsValid() {
+			return nil, ErrInvalidScope
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			result = append(result, scope)
+		}
+	}
+	return result, nil
+}
+
+// generateKey генерирует ключ вида bbp_<64 hex>
+func generateKey() (string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return KeyPrefix + hex.EncodeToString(bytes), nil
+}
+
+// hashKey возвращает SHA-256 хэш ключа для хранения в БД
+func hashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,13 @@
+package apikey
+
+import "errors"
+
+var (
+	ErrAPIKeyNotFound = errors.New("api key not found")
+	ErrUnauthorized   = errors.New("unauthorized")
+	ErrRoomNotFound   = errors.New("room not found")
+	ErrInvalidName    = errors.New("api key name must be 1-100 characters")
+	ErrInvalidScope   = errors.New("api key scopes must be read and/or veto")
+	ErrInvalidExpiry  = errors.New("api key expiry must be in the future")
+	ErrTooManyAPIKeys = errors.New("api key limit reached")
+)
@@ -0,0 +1,29 @@
+package apikey
+
+import (
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+)
+
+type GetAPIKeysUseCase struct {
+	apiKeyRepo repositories.APIKeyRepository
+}
+
+type GetAPIKeysOutput struct {
+	APIKeys []entities.APIKey
+}
+
+func NewGetAPIKeysUseCase(apiKeyRepo repositories.APIKeyRepository) *GetAPIKeysUseCase {
+	return &GetAPIKeysUseCase{
+		apiKeyRepo: apiKeyRepo,
+	}
+}
+
+func (uc *GetAPIKeysUseCase) Execute(userID uint) (*GetAPIKeysOutput, error) {
+	keys, err := uc.apiKeyRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetAPIKeysOutput{APIKeys: keys}, nil
+}
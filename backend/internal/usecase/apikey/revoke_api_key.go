@@ -0,0 +1,47 @@
+package apikey
+
+import (
+	"time"
+
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+)
+
+type RevokeAPIKeyUseCase struct {
+	apiKeyRepo repositories.APIKeyRepository
+}
+
+type RevokeAPIKeyInput struct {
+	APIKeyID uint
+	UserID   uint
+}
+
+func NewRevokeAPIKeyUseCase(apiKeyRepo repositories.APIKeyRepository) *RevokeAPIKeyUseCase {
+	return &RevokeAPIKeyUseCase{
+		apiKeyRepo: apiKeyRepo,
+	}
+}
+
+// Execute отзывает ключ; отозванный ключ остается в списке с датой отзыва
+func (uc *RevokeAPIKeyUseCase) Execute(input RevokeAPIKeyInput) (*entities.APIKey, error) {
+	key, err := uc.apiKeyRepo.GetByID(input.APIKeyID)
+	if err != nil {
+		return nil, err
+	}
+	if key == nil {
+		return nil, ErrAPIKeyNotFound
+	}
+	if key.UserID != input.UserID {
+		return nil, ErrUnauthorized
+	}
+	if key.RevokedAt != nil {
+		return key, nil
+	}
+
+	now := time.Now()
+	if err := uc.apiKeyRepo.Revoke(key.ID, now); err != nil {
+		return nil, err
+	}
+	key.RevokedAt = &now
+	return key, nil
+}
@@ -0,0 +1,66 @@
+package auth
+
+import (
+	"time"
+
+	"github.com/bbp/backend/internal/domain/repositories"
+	"github.com/bbp/backend/pkg/totp"
+)
+
+type ConfirmTwoFactorUseCase struct {
+	totpRepo         repositories.TwoFactorRepository
+	recoveryCodeRepo repositories.RecoveryCodeRepository
+}
+
+type ConfirmTwoFactorInput struct {
+	UserID uint
+	Code   string
+}
+
+type ConfirmTwoFactorOutput struct {
+	// RecoveryCodes показываются пользователю один раз, в БД хранятся только хэши
+	RecoveryCodes []string
+}
+
+func NewConfirmTwoFactorUseCase(
+	totpRepo repositories.TwoFactorRepository,
+	recoveryCodeRepo repositories.RecoveryCodeRepository,
+) *ConfirmTwoFactorUseCase {
+	return &ConfirmTwoFactorUseCase{
+		totpRepo:         totpRepo,
+		recoveryCodeRepo: recoveryCodeRepo,
+	}
+}
+
+// Execute включает 2FA, если код из приложения совпадает с выданным секретом
+func (uc *ConfirmTwoFactorUseCase) Execute(input ConfirmTwoFactorInput) (*ConfirmTwoFactorOutput, error) {
+	settings, err := uc.totpRepo.GetByUserID(input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if settings == nil {
+		return nil, ErrTwoFactorSetupRequired
+	}
+	if settings.IsEnabled() {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+
+	step, ok := totp.Validate(settings.Secret, input.Code, time.Now())
+	if !ok {
+		return nil, ErrInvalidTwoFactorCode
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.recoveryCodeRepo.ReplaceForUser(input.UserID, hashes); err != nil {
+		return nil, err
+	}
+
+	if err := uc.totpRepo.Confirm(input.UserID, step); err != nil {
+		return nil, err
+	}
+
+	return &ConfirmTwoFactorOutput{RecoveryCodes: codes}, nil
+}
@@ -0,0 +1,105 @@
+package auth
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+	"github.com/bbp/backend/pkg/password"
+)
+
+// guestEmailDomain служебный домен для email гостей (RFC 2606, не доставляется)
+const guestEmailDomain = "guest.invalid"
+
+type CreateGuestUseCase struct {
+	userRepo    repositories.UserRepository
+	tokenIssuer *TokenIssuer
+	sessionTTL  time.Duration
+}
+
+type CreateGuestOutput struct {
+	Tokens *TokenPair
+	User   *entities.User
+}
+
+func NewCreateGuestUseCase(
+	userRepo repositories.UserRepository,
+	tokenIssuer *TokenIssuer,
+	sessionTTL time.Duration,
+) *CreateGuestUseCase {
+	return &CreateGuestUseCase{
+		userRepo:    userRepo,
+		tokenIssuer: tokenIssuer,
+		sessionTTL:  sessionTTL,
+	}
+}
+
+// Execute создает гостевой аккаунт со сгенерированным username
+// Сессия гостя ограничена sessionTTL и не продлевается
+func (uc *CreateGuestUseCase) Execute() (*CreateGuestOutput, error) {
+	username, err := uc.generateUsername()
+	if err != nil {
+		return nil, err
+	}
+
+	// У гостя нет пароля: сохраняем хэш случайного значения
+	secret, err := generateSecureToken()
+	if err != nil {
+		return nil, err
+	}
+	hashedPassword, err := password.HashPassword(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	emailID, err := generateSecureToken()
+	if err != nil {
+		return nil, err
+	}
+
+	user := &entities.User{
+		Email:    fmt.Sprintf("guest-%s@%s", emailID[:16], guestEmailDomain),
+		Username: username,
+		Password: hashedPassword,
+		IsGuest:  true,
+	}
+	if err := user.Validate(); err != nil {
+		return nil, err
+	}
+	if err := uc.userRepo.Create(user); err != nil {
+		return nil, err
+	}
+
+	tokens, err := uc.tokenIssuer.IssueUntil(user, time.Now().Add(uc.sessionTTL))
+	if err != nil {
+		return nil, err
+	}
+
+	return &CreateGuestOutput{
+		Tokens: tokens,
+		User:   user,
+	}, nil
+}
+
+func (uc *CreateGuestUseCase) generateUsername() (string, error) {
+	for attempt := 0; attempt < 10; attempt++ {
+		n, err := rand.Int(rand.Reader, big.NewInt(1000000))
+		if err != nil {
+			return "", err
+		}
+		candidate := fmt.Sprintf("Guest-%06d", n.Int64())
+
+		existing, err := uc.userRepo.GetByUsername(candidate)
+		if err != nil {
+			return "", err
+		}
+		if existing == nil {
+			return candidate, nil
+		}
+	}
+
+	return "", ErrUsernameAlreadyExists
+}
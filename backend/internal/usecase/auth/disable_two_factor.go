@@ -0,0 +1,69 @@
+package auth
+
+import (
+	"github.com/bbp/backend/internal/domain/repositories"
+	"github.com/bbp/backend/pkg/password"
+)
+
+type DisableTwoFactorUseCase struct {
+	userRepo          repositories.UserRepository
+	totpRepo          repositories.TwoFactorRepository
+	recoveryCodeRepo  repositories.RecoveryCodeRepository
+	twoFactorVerifier *TwoFactorVerifier
+}
+
+type DisableTwoFactorInput struct {
+	UserID   uint
+	Password string
+	Code     string // Код из приложения или код восстановления
+}
+
+func NewDisableTwoFactorUseCase(
+	userRepo repositories.UserRepository,
+	totpRepo repositories.TwoFactorRepository,
+	recoveryCodeRepo repositories.RecoveryCodeRepository,
+	twoFactorVerifier *TwoFactorVerifier,
+) *DisableTwoFactorUseCase {
+	return &DisableTwoFactorUseCase{
+		userRepo:          userRepo,
+		totpRepo:          totpRepo,
+		recoveryCodeRepo:  recoveryCodeRepo,
+		twoFactorVerifier: twoFactorVerifier,
+	}
+}
+
+// Execute отключает 2FA; украденного access токена для этого недостаточно
+func (uc *DisableTwoFactorUseCase) Execute(input DisableTwoFactorInput) error {
+	user, err := uc.userRepo.GetByID(input.UserID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return ErrUserNotFound
+	}
+
+	enabled, err := uc.twoFactorVerifier.Enabled(user.ID)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return ErrTwoFactorNotEnabled
+	}
+
+	if !password.CheckPassword(user.Password, input.Password) {
+		return ErrInvalidPassword
+	}
+
+	ok, err := uc.twoFactorVerifier.Verify(user.ID, input.Code)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrInvalidTwoFactorCode
+	}
+
+	if err := uc.recoveryCodeRepo.DeleteByUserID(user.ID); err != nil {
+		return err
+	}
+	return uc.totpRepo.Delete(user.ID)
+}
@@ -0,0 +1,67 @@
+package auth
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+	"github.com/bbp/backend/pkg/mailer"
+)
+
+// EmailVerifier выдает токены подтверждения email и отправляет ссылку пользователю
+// Используется при регистрации, смене email и повторной отправке письма
+type EmailVerifier struct {
+	tokenRepo repositories.EmailVerificationTokenRepository
+	mailer    mailer.Mailer
+	verifyURL string
+	expiry    time.Duration
+}
+
+func NewEmailVerifier(
+	tokenRepo repositories.EmailVerificationTokenRepository,
+	mailer mailer.Mailer,
+	verifyURL string,
+	expiry time.Duration,
+) *EmailVerifier {
+	return &EmailVerifier{
+		tokenRepo: tokenRepo,
+		mailer:    mailer,
+		verifyURL: verifyURL,
+		expiry:    expiry,
+	}
+}
+
+// Send создает новый токен для текущего email пользователя и отправляет письмо
+// Ранее выданные токены пользователя становятся недействительными
+func (v *EmailVerifier) Send(user *entities.User) error {
+	if err := v.tokenRepo.InvalidateByUserID(user.ID); err != nil {
+		return err
+	}
+
+	token, err := generateSecureToken()
+	if err != nil {
+		return err
+	}
+
+	verificationToken := &entities.EmailVerificationToken{
+		UserID:    user.ID,
+		Email:     user.Email,
+		TokenHash: hashToken(token),
+		ExpiresAt: time.Now().Add(v.expiry),
+	}
+	if err := v.tokenRepo.Create(verificationToken); err != nil {
+		return err
+	}
+
+	link := v.verifyURL + "?token=" + url.QueryEscape(token)
+	return v.mailer.Send(mailer.Message{
+		To:      user.Email,
+		Subject: "Confirm your email",
+		Body: fmt.Sprintf(
+			"Hello, %s!\n\nTo confirm your email address, follow the link:\n%s\n\nThe link is valid for %s.",
+			user.Username, link, v.expiry,
+		),
+	})
+}
@@ -3,8 +3,25 @@ package auth
 import "errors"
 
 var (
-	ErrEmailAlreadyExists    = errors.New("email already exists")
-	ErrUsernameAlreadyExists = errors.New("username already exists")
-	ErrInvalidCredentials    = errors.New("invalid email or password")
-	ErrUserNotFound          = errors.New("user not found")
-)
\ No newline at end of file
+	ErrEmailAlreadyExists       = errors.New("email already exists")
+	ErrUsernameAlreadyExists    = errors.New("username already exists")
+	ErrInvalidCredentials       = errors.New("invalid email or password")
+	ErrUserNotFound             = errors.New("user not found")
+	ErrInvalidRefreshToken      = errors.New("invalid or expired refresh token")
+	ErrInvalidResetToken        = errors.New("invalid or expired password reset token")
+	ErrInvalidVerificationToken = errors.New("invalid or expired email verification token")
+	ErrEmailAlreadyVerified     = errors.New("email already verified")
+	ErrUnknownOAuthProvider     = errors.New("unknown oauth provider")
+	ErrInvalidOAuthState        = errors.New("invalid or expired oauth state")
+	ErrOAuthExchangeFailed      = errors.New("oauth authorization failed")
+	ErrOAuthEmailRequired       = errors.New("oauth provider did not return an email")
+	ErrOAuthAccountConflict     = errors.New("account with this email already exists")
+	ErrNotGuest                 = errors.New("account is not a guest account")
+	ErrInvalidPassword          = errors.New("invalid password")
+	ErrTwoFactorAlreadyEnabled  = errors.New("two-factor authentication already enabled")
+	ErrTwoFactorNotEnabled      = errors.New("two-factor authentication is not enabled")
+	ErrTwoFactorSetupRequired   = errors.New("two-factor setup has not been started")
+	ErrInvalidTwoFactorCode     = errors.New("invalid two-factor code")
+	ErrInvalidLoginChallenge    = errors.New("invalid or expired login challenge")
+	ErrTooManyLoginAttempts     = errors.New("too many login attempts")
+)
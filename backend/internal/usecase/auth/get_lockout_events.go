@@ -0,0 +1,34 @@
+package auth
+
+import (
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+)
+
+type GetLockoutEventsUseCase struct {
+	eventRepo repositories.LockoutEventRepository
+}
+
+type GetLockoutEventsInput struct {
+	Limit  int
+	Offset int
+}
+
+func NewGetLockoutEventsUseCase(eventRepo repositories.LockoutEventRepository) *GetLockoutEventsUseCase {
+	return &GetLockoutEventsUseCase{
+		eventRepo: eventRepo,
+	}
+}
+
+func (uc *GetLockoutEventsUseCase) Execute(input GetLockoutEventsInput) ([]entities.LockoutEvent, error) {
+	limit := input.Limit
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+	offset := input.Offset
+	if offset < 0 {
+		offset = 0
+	}
+
+	return uc.eventRepo.List(limit, offset)
+}
@@ -0,0 +1,49 @@
+package auth
+
+import (
+	"time"
+
+	"github.com/bbp/backend/internal/domain/repositories"
+)
+
+type GetTwoFactorStatusUseCase struct {
+	totpRepo         repositories.TwoFactorRepository
+	recoveryCodeRepo repositories.RecoveryCodeRepository
+}
+
+type GetTwoFactorStatusOutput struct {
+	Enabled                bool
+	EnabledAt              *time.Time
+	RecoveryCodesRemaining int64
+}
+
+func NewGetTwoFactorStatusUseCase(
+	totpRepo repositories.TwoFactorRepository,
+	recoveryCodeRepo repositories.RecoveryCodeRepository,
+) *GetTwoFactorStatusUseCase {
+	return &GetTwoFactorStatusUseCase{
+		totpRepo:         totpRepo,
+		recoveryCodeRepo: recoveryCodeRepo,
+	}
+}
+
+func (uc *GetTwoFactorStatusUseCase) Execute(userID uint) (*GetTwoFactorStatusOutput, error) {
+	settings, err := uc.totpRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if !settings.IsEnabled() {
+		return &GetTwoFactorStatusOutput{}, nil
+	}
+
+	remaining, err := uc.recoveryCodeRepo.CountUnused(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetTwoFactorStatusOutput{
+		Enabled:                true,
+		EnabledAt:              settings.ConfirmedAt,
+		RecoveryCodesRemaining: remaining,
+	}, nil
+}
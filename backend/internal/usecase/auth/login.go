@@ -2,70 +2,114 @@ package auth
 
 import (
 	"github.com/bbp/backend/internal/domain/repositories"
-	"github.com/bbp/backend/pkg/jwt"
 	"github.com/bbp/backend/pkg/password"
 )
 
 type LoginUseCase struct {
-	userRepo   repositories.UserRepository
-	jwtService *jwt.JWTService
+	userRepo          repositories.UserRepository
+	tokenIssuer       *TokenIssuer
+	twoFactorVerifier *TwoFactorVerifier
+	loginThrottler    *LoginThrottler
 }
 
 type LoginInput struct {
 	Email    string
 	Password string
+	IP       string
 }
 
+// LoginOutput содержит либо токены, либо Challenge, если у пользователя включена 2FA
 type LoginOutput struct {
-	Token string
-	User  *LoginUser
+	Tokens    *TokenPair
+	Challenge *LoginChallengeToken
+	User      *LoginUser
 }
 
 type LoginUser struct {
-	ID        uint   `json:"id"`
-	Email     string `json:"email"`
-	Username  string `json:"username"`
-	CreatedAt string `json:"created_at"`
+	ID            uint   `json:"id"`
+	Email         string `json:"email"`
+	Username      string `json:"username"`
+	EmailVerified bool   `json:"email_verified"`
+	CreatedAt     string `json:"created_at"`
 }
 
-func NewLoginUseCase(userRepo repositories.UserRepository, jwtService *jwt.JWTService) *LoginUseCase {
+func NewLoginUseCase(
+	userRepo repositories.UserRepository,
+	tokenIssuer *TokenIssuer,
+	twoFactorVerifier *TwoFactorVerifier,
+	loginThrottler *LoginThrottler,
+) *LoginUseCase {
 	return &LoginUseCase{
-		userRepo:   userRepo,
-		jwtService: jwtService,
+		userRepo:          userRepo,
+		tokenIssuer:       tokenIssuer,
+		twoFactorVerifier: twoFactorVerifier,
+		loginThrottler:    loginThrottler,
 	}
 }
 
 func (uc *LoginUseCase) Execute(input LoginInput) (*LoginOutput, error) {
+	// Во время блокировки пароль не проверяем, чтобы перебор не продолжался
+	locked, err := uc.loginThrottler.IsLocked(input.Email, input.IP)
+	if err != nil {
+		return nil, err
+	}
+	if locked {
+		return nil, ErrTooManyLoginAttempts
+	}
+
 	// Находим пользователя по email
 	user, err := uc.userRepo.GetByEmail(input.Email)
 	if err != nil {
 		return nil, err
 	}
-	if user == nil {
-		return nil, ErrInvalidCredentials
-	}
 
 	// Проверяем пароль
-	if !password.CheckPassword(user.Password, input.Password) {
+	if user == nil || !password.CheckPassword(user.Password, input.Password) {
+		var userID *uint
+		if user != nil {
+			userID = &user.ID
+		}
+		if err := uc.loginThrottler.RecordFailure(input.Email, input.IP, userID); err != nil {
+			return nil, err
+		}
 		return nil, ErrInvalidCredentials
 	}
 
-	// Генерируем JWT токен
-	token, err := uc.jwtService.GenerateToken(user.ID, user.Username)
+	// С включенной 2FA токены выдаются только после проверки кода,
+	// и счетчик неудачных попыток сбрасывается тоже только после него
+	enabled, err := uc.twoFactorVerifier.Enabled(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if enabled {
+		challenge, err := uc.twoFactorVerifier.Challenge(user.ID)
+		if err != nil {
+			return nil, err
+		}
+		return &LoginOutput{Challenge: challenge}, nil
+	}
+
+	if err := uc.loginThrottler.RecordSuccess(input.Email); err != nil {
+		return nil, err
+	}
+
+	// Выдаем access и refresh токены
+	tokens, err := uc.tokenIssuer.Issue(user)
 	if err != nil {
 		return nil, err
 	}
 
 	// Возвращаем данные пользователя (без пароля)
 	loginUser := &LoginUser{
-		ID:       user.ID,
-		Email:    user.Email,
-		Username: user.Username,
-		CreatedAt: user.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		ID:            user.ID,
+		Email:         user.Email,
+		Username:      user.Username,
+		EmailVerified: user.EmailVerified,
+		CreatedAt:     user.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 	}
 
 	return &LoginOutput{
-		Token: token,
-		User:  loginUser,
+		Tokens: tokens,
+		User:   loginUser,
 	}, nil
-}
\ No newline at end of file
+}
@@ -0,0 +1,172 @@
+package auth
+
+import (
+	"log"
+	"strings"
+	"time"
+
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+)
+
+// LoginThrottlePolicy пороги и длительность блокировок входа
+type LoginThrottlePolicy struct {
+	AccountMaxFailures int           // Неудачных попыток на аккаунт до первой блокировки
+	IPMaxFailures      int           // Неудачных попыток с одного IP до первой блокировки
+	BaseLockout        time.Duration // Первая блокировка, каждая следующая вдвое дольше
+	MaxLockout         time.Duration
+	ResetAfter         time.Duration // Счетчик сбрасывается, если столько времени не было неудачных попыток
+}
+
+// DefaultLoginThrottlePolicy политика по умолчанию
+func DefaultLoginThrottlePolicy() LoginThrottlePolicy {
+	return LoginThrottlePolicy{
+		AccountMaxFailures: 5,
+		IPMaxFailures:      20,
+		BaseLockout:        time.Minute,
+		MaxLockout:         time.Hour,
+		ResetAfter:         24 * time.Hour,
+	}
+}
+
+// LoginThrottler считает неудачные попытки входа по аккаунту и по IP
+// Счетчик аккаунта ведется по email, в том числе несуществующему,
+// чтобы блокировка не выдавала наличие аккаунта
+type LoginThrottler struct {
+	throttleRepo repositories.LoginThrottleRepository
+	eventRepo    repositories.LockoutEventRepository
+	policy       LoginThrottlePolicy
+}
+
+func NewLoginThrottler(
+	throttleRepo repositories.LoginThrottleRepository,
+	eventRepo repositories.LockoutEventRepository,
+	policy LoginThrottlePolicy,
+) *LoginThrottler {
+	return &LoginThrottler{
+		throttleRepo: throttleRepo,
+		eventRepo:    eventRepo,
+		policy:       policy,
+	}
+}
+
+// IsLocked проверяет блокировку аккаунта и IP
+func (t *LoginThrottler) IsLocked(email, ip string) (bool, error) {
+	now := time.Now()
+
+	account, err := t.throttleRepo.Get(entities.LoginThrottleScopeAccount, normalizeEmail(email))
+	if err != nil {
+		return false, err
+	}
+	if account.IsLocked(now) {
+		return true, nil
+	}
+
+	if ip == "" {
+		return false, nil
+	}
+	byIP, err := t.throttleRepo.Get(entities.LoginThrottleScopeIP, ip)
+	if err != nil {
+		return false, err
+	}
+	return byIP.IsLocked(now), nil
+}
+
+// RecordFailure учитывает неудачную попытку и при превышении порога блокирует вход
+func (t *LoginThrottler) RecordFailure(email, ip string, userID *uint) error {
+	if err := t.recordFailure(entities.LoginThrottleScopeAccount, normalizeEmail(email), ip, userID, t.policy.AccountMaxFailures); err != nil {
+		return err
+	}
+	if ip == "" {
+		return nil
+	}
+	return t.recordFailure(entities.LoginThrottleScopeIP, ip, ip, nil, t.policy.IPMaxFailures)
+}
+
+// RecordSuccess сбрасывает счетчик аккаунта после успешного входа
+// Счетчик IP не сбрасывается: иначе свой аккаунт позволял бы перебирать чужие
+func (t *LoginThrottler) RecordSuccess(email string) error {
+	return t.throttleRepo.Delete(entities.LoginThrottleScopeAccount, normalizeEmail(email))
+}
+
+// Unlock снимает блокировку аккаунта и записывает это в журнал
+func (t *LoginThrottler) Unlock(user *entities.User, actorID uint) error {
+	key := normalizeEmail(user.Email)
+	if err := t.throttleRepo.Delete(entities.LoginThrottleScopeAccount, key); err != nil {
+		return err
+	}
+
+	userID := user.ID
+	return t.eventRepo.Create(&entities.LockoutEvent{
+		Action:  entities.LockoutActionUnlocked,
+		Scope:   entities.LoginThrottleScopeAccount,
+		Key:     key,
+		UserID:  &userID,
+		ActorID: &actorID,
+	})
+}
+
+// DeleteStale удаляет давно неиспользуемые счетчики
+func (t *LoginThrottler) DeleteStale(now time.Time) error {
+	return t.throttleRepo.DeleteStale(now.Add(-t.policy.ResetAfter))
+}
+
+func (t *LoginThrottler) recordFailure(
+	scope entities.LoginThrottleScope,
+	key, ip string,
+	userID *uint,
+	maxFailures int,
+) error {
+	now := time.Now()
+
+	throttle, err := t.throttleRepo.Get(scope, key)
+	if err != nil {
+		return err
+	}
+	if throttle == nil || now.Sub(throttle.LastFailureAt) > t.policy.ResetAfter {
+		throttle = &entities.LoginThrottle{Scope: scope, Key: key}
+	}
+
+	throttle.Failures++
+	throttle.LastFailureAt = now
+
+	locked := throttle.Failures >= maxFailures
+	if locked {
+		lockedUntil := now.Add(t.lockoutDuration(throttle.Failures - maxFailures))
+		throttle.LockedUntil = &lockedUntil
+	}
+
+	if err := t.throttleRepo.Save(throttle); err != nil {
+		return err
+	}
+	if !locked {
+		return nil
+	}
+
+	log.Printf("Login locked for %s %s until %s after %d failed attempts", scope, key, throttle.LockedUntil.Format(time.RFC3339), throttle.Failures)
+	return t.eventRepo.Create(&entities.LockoutEvent{
+		Action:      entities.LockoutActionLocked,
+		Scope:       scope,
+		Key:         key,
+		UserID:      userID,
+		IP:          ip,
+		Failures:    throttle.Failures,
+		LockedUntil: throttle.LockedUntil,
+	})
+}
+
+// lockoutDuration экспоненциально увеличивает блокировку с каждой попыткой сверх порога
+func (t *LoginThrottler) lockoutDuration(excess int) time.Duration {
+	duration := t.policy.BaseLockout
+	for i := 0; i < excess && duration < t.policy.MaxLockout; i++ {
+		duration *= 2
+	}
+	if duration > t.policy.MaxLockout {
+		duration = t.policy.MaxLockout
+	}
+	return duration
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
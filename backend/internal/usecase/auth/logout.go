@@ -0,0 +1,59 @@
+package auth
+
+import (
+	"time"
+
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+)
+
+type LogoutUseCase struct {
+	refreshTokenRepo repositories.RefreshTokenRepository
+	revokedTokenRepo repositories.RevokedTokenRepository
+}
+
+type LogoutInput struct {
+	UserID          uint
+	AccessJTI       string    // jti текущего access токена
+	AccessExpiresAt time.Time // Срок действия текущего access токена
+	RefreshToken    string    // Опционально: refresh токен этой сессии
+}
+
+func NewLogoutUseCase(
+	refreshTokenRepo repositories.RefreshTokenRepository,
+	revokedTokenRepo repositories.RevokedTokenRepository,
+) *LogoutUseCase {
+	return &LogoutUseCase{
+		refreshTokenRepo: refreshTokenRepo,
+		revokedTokenRepo: revokedTokenRepo,
+	}
+}
+
+// Execute завершает текущую сессию: отзывает access токен и связанный refresh токен
+func (uc *LogoutUseCase) Execute(input LogoutInput) error {
+	if input.AccessJTI != "" {
+		if err := uc.revokedTokenRepo.Create(&entities.RevokedToken{
+			JTI:       input.AccessJTI,
+			UserID:    input.UserID,
+			ExpiresAt: input.AccessExpiresAt,
+		}); err != nil {
+			return err
+		}
+	}
+
+	if input.RefreshToken == "" {
+		return nil
+	}
+
+	stored, err := uc.refreshTokenRepo.GetByHash(hashToken(input.RefreshToken))
+	if err != nil {
+		return err
+	}
+	// Чужой или неизвестный refresh токен молча игнорируем
+	if stored == nil || stored.UserID != input.UserID {
+		return nil
+	}
+
+	_, err = uc.refreshTokenRepo.Revoke(stored.ID, nil)
+	return err
+}
@@ -0,0 +1,73 @@
+package auth
+
+import (
+	"time"
+
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+)
+
+type LogoutAllUseCase struct {
+	refreshTokenRepo repositories.RefreshTokenRepository
+	revokedTokenRepo repositories.RevokedTokenRepository
+}
+
+type LogoutAllInput struct {
+	UserID          uint
+	AccessJTI       string    // jti текущего access токена
+	AccessExpiresAt time.Time // Срок действия текущего access токена
+}
+
+func NewLogoutAllUseCase(
+	refreshTokenRepo repositories.RefreshTokenRepository,
+	revokedTokenRepo repositories.RevokedTokenRepository,
+) *LogoutAllUseCase {
+	return &LogoutAllUseCase{
+		refreshTokenRepo: refreshTokenRepo,
+		revokedTokenRepo: revokedTokenRepo,
+	}
+}
+
+// Execute завершает все сессии пользователя на всех устройствах
+func (uc *LogoutAllUseCase) Execute(input LogoutAllInput) error {
+	if input.AccessJTI != "" {
+		if err := uc.revokedTokenRepo.Create(&entities.RevokedToken{
+			JTI:       input.AccessJTI,
+			UserID:    input.UserID,
+			ExpiresAt: input.AccessExpiresAt,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return revokeAllSessions(uc.refreshTokenRepo, uc.revokedTokenRepo, input.UserID)
+}
+
+// revokeAllSessions отзывает все refresh токены пользователя и access токены,
+// выданные вместе с ними
+func revokeAllSessions(
+	refreshTokenRepo repositories.RefreshTokenRepository,
+	revokedTokenRepo repositories.RevokedTokenRepository,
+	userID uint,
+) error {
+	active, err := refreshTokenRepo.GetActiveByUserID(userID)
+	if err != nil {
+		return err
+	}
+
+	for _, token := range active {
+		if token.AccessJTI == "" {
+			continue
+		}
+		// Access токен живет меньше refresh токена, поэтому срок refresh токена - верхняя граница
+		if err := revokedTokenRepo.Create(&entities.RevokedToken{
+			JTI:       token.AccessJTI,
+			UserID:    userID,
+			ExpiresAt: token.ExpiresAt,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return refreshTokenRepo.RevokeAllByUserID(userID)
+}
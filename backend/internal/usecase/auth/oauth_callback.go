@@ -0,0 +1,253 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+	"github.com/bbp/backend/pkg/oauth"
+	"github.com/bbp/backend/pkg/password"
+)
+
+type OAuthCallbackUseCase struct {
+	providers    map[string]oauth.Provider
+	stateRepo    repositories.OAuthStateRepository
+	identityRepo repositories.UserIdentityRepository
+	userRepo          repositories.UserRepository
+	tokenIssuer       *TokenIssuer
+	twoFactorVerifier *TwoFactorVerifier
+}
+
+type OAuthCallbackInput struct {
+	Provider    string
+	State       string
+	StateCookie string // State из cookie браузера, который начал вход
+	Code        string
+}
+
+// OAuthCallbackOutput содержит либо токены, либо Challenge, если у пользователя включена 2FA
+type OAuthCallbackOutput struct {
+	Tokens    *TokenPair
+	Challenge *LoginChallengeToken
+	User      *entities.User
+	Created   bool // Пользователь создан при этом входе
+}
+
+func NewOAuthCallbackUseCase(
+	providers []oauth.Provider,
+	stateRepo repositories.OAuthStateRepository,
+	identityRepo repositories.UserIdentityRepository,
+	userRepo repositories.UserRepository,
+	tokenIssuer *TokenIssuer,
+	twoFactorVerifier *TwoFactorVerifier,
+) *OAuthCallbackUseCase {
+	return &OAuthCallbackUseCase{
+		providers:         providerMap(providers),
+		stateRepo:         stateRepo,
+		identityRepo:      identityRepo,
+		userRepo:          userRepo,
+		tokenIssuer:       tokenIssuer,
+		twoFactorVerifier: twoFactorVerifier,
+	}
+}
+
+// Execute завершает вход через провайдера и выдает те же токены, что и LoginUseCase
+func (uc *OAuthCallbackUseCase) Execute(input OAuthCallbackInput) (*OAuthCallbackOutput, error) {
+	provider, ok := uc.providers[input.Provider]
+	if !ok {
+		return nil, ErrUnknownOAuthProvider
+	}
+
+	// Без совпадающей cookie callback мог бы открыть в чужом браузере ссылку атакующего
+	// и войти жертвой в его аккаунт (login CSRF)
+	if input.StateCookie == "" || subtle.ConstantTimeCompare([]byte(input.State), []byte(input.StateCookie)) != 1 {
+		return nil, ErrInvalidOAuthState
+	}
+
+	state, err := uc.stateRepo.Consume(input.State)
+	if err != nil {
+		return nil, err
+	}
+	if state == nil || state.Provider != input.Provider || !time.Now().Before(state.ExpiresAt) {
+		return nil, ErrInvalidOAuthState
+	}
+
+	info, err := provider.Exchange(context.Background(), input.Code, state.CodeVerifier)
+	if err != nil {
+		log.Printf("OAuth exchange with %s failed: %v", input.Provider, err)
+		return nil, ErrOAuthExchangeFailed
+	}
+
+	user, created, err := uc.resolveUser(input.Provider, info)
+	if err != nil {
+		return nil, err
+	}
+
+	// Вход через провайдера не заменяет второй фактор
+	enabled, err := uc.twoFactorVerifier.Enabled(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if enabled {
+		challenge, err := uc.twoFactorVerifier.Challenge(user.ID)
+		if err != nil {
+			return nil, err
+		}
+		return &OAuthCallbackOutput{
+			Challenge: challenge,
+			User:      user,
+		}, nil
+	}
+
+	tokens, err := uc.tokenIssuer.Issue(user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OAuthCallbackOutput{
+		Tokens:  tokens,
+		User:    user,
+		Created: created,
+	}, nil
+}
+
+// resolveUser находит пользователя по привязке, связывает аккаунт по email, подтвержденному
+// и провайдером, и владельцем аккаунта, или создает нового пользователя
+func (uc *OAuthCallbackUseCase) resolveUser(providerName string, info *oauth.UserInfo) (*entities.User, bool, error) {
+	identity, err := uc.identityRepo.GetByProviderSubject(providerName, info.Subject)
+	if err != nil {
+		return nil, false, err
+	}
+	if identity != nil {
+		user, err := uc.userRepo.GetByID(identity.UserID)
+		if err != nil {
+			return nil, false, err
+		}
+		if user == nil {
+			return nil, false, ErrUserNotFound
+		}
+		return user, false, nil
+	}
+
+	if info.Email == "" {
+		return nil, false, ErrOAuthEmailRequired
+	}
+
+	existing, err := uc.userRepo.GetByEmail(info.Email)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if existing != nil {
+		// Связываем только по email, подтвержденному провайдером, иначе это захват чужого аккаунта.
+		// Неподтвержденный аккаунт тоже не связываем: его мог заранее зарегистрировать кто угодно
+		// со своим паролем, и тогда аккаунт оказался бы общим с настоящим владельцем email
+		if !info.EmailVerified || !existing.EmailVerified {
+			return nil, false, ErrOAuthAccountConflict
+		}
+		if err := uc.linkIdentity(existing.ID, providerName, info); err != nil {
+			return nil, false, err
+		}
+		return existing, false, nil
+	}
+
+	user, err := uc.createUser(info)
+	if err != nil {
+		return nil, false, err
+	}
+	if err := uc.linkIdentity(user.ID, providerName, info); err != nil {
+		return nil, false, err
+	}
+	return user, true, nil
+}
+
+func (uc *OAuthCallbackUseCase) linkIdentity(userID uint, providerName string, info *oauth.UserInfo) error {
+	return uc.identityRepo.Create(&entities.UserIdentity{
+		UserID:   userID,
+		Provider: providerName,
+		Subject:  info.Subject,
+		Email:    info.Email,
+	})
+}
+
+func (uc *OAuthCallbackUseCase) createUser(info *oauth.UserInfo) (*entities.User, error) {
+	username, err := uc.uniqueUsername(info)
+	if err != nil {
+		return nil, err
+	}
+
+	// Пароль случайный: войти по паролю можно только после сброса пароля
+	secret, err := generateSecureToken()
+	if err != nil {
+		return nil, err
+	}
+	hashedPassword, err := password.HashPassword(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	user := &entities.User{
+		Email:         info.Email,
+		Username:      username,
+		Password:      hashedPassword,
+		EmailVerified: info.EmailVerified,
+	}
+	if err := user.Validate(); err != nil {
+		return nil, err
+	}
+	if err := uc.userRepo.Create(user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// uniqueUsername подбирает свободный username на основе имени у провайдера или email
+func (uc *OAuthCallbackUseCase) uniqueUsername(info *oauth.UserInfo) (string, error) {
+	base := sanitizeUsername(info.Username)
+	if len(base) < 3 {
+		base = sanitizeUsername(strings.SplitN(info.Email, "@", 2)[0])
+	}
+	if len(base) < 3 {
+		base = "player"
+	}
+	if len(base) > 40 {
+		base = base[:40]
+	}
+
+	candidate := base
+	for attempt := 0; attempt < 10; attempt++ {
+		existing, err := uc.userRepo.GetByUsername(candidate)
+		if err != nil {
+			return "", err
+		}
+		if existing == nil {
+			return candidate, nil
+		}
+
+		suffix, err := rand.Int(rand.Reader, big.NewInt(100000))
+		if err != nil {
+			return "", err
+		}
+		candidate = fmt.Sprintf("%s_%d", base, suffix.Int64())
+	}
+
+	return "", ErrUsernameAlreadyExists
+}
+
+func sanitizeUsername(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_', r == '-', r == '.':
+			return r
+		default:
+			return -1
+		}
+	}, s)
+}
@@ -0,0 +1,87 @@
+package auth
+
+import (
+	"sort"
+	"time"
+
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+	"github.com/bbp/backend/pkg/oauth"
+)
+
+// oauthStateTTL время, за которое пользователь должен пройти авторизацию у провайдера
+const oauthStateTTL = 10 * time.Minute
+
+type OAuthStartUseCase struct {
+	providers map[string]oauth.Provider
+	stateRepo repositories.OAuthStateRepository
+}
+
+type OAuthStartInput struct {
+	Provider string
+}
+
+type OAuthStartOutput struct {
+	AuthURL string
+	// State нужно сохранить в cookie браузера: callback принимается только вместе с ней
+	State     string
+	ExpiresAt time.Time
+}
+
+func NewOAuthStartUseCase(providers []oauth.Provider, stateRepo repositories.OAuthStateRepository) *OAuthStartUseCase {
+	return &OAuthStartUseCase{
+		providers: providerMap(providers),
+		stateRepo: stateRepo,
+	}
+}
+
+// Providers возвращает имена настроенных провайдеров
+func (uc *OAuthStartUseCase) Providers() []string {
+	names := make([]string, 0, len(uc.providers))
+	for name := range uc.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Execute создает state и PKCE verifier и возвращает URL авторизации у провайдера
+func (uc *OAuthStartUseCase) Execute(input OAuthStartInput) (*OAuthStartOutput, error) {
+	provider, ok := uc.providers[input.Provider]
+	if !ok {
+		return nil, ErrUnknownOAuthProvider
+	}
+
+	state, err := oauth.GenerateState()
+	if err != nil {
+		return nil, err
+	}
+	verifier, err := oauth.GenerateCodeVerifier()
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(oauthStateTTL)
+	if err := uc.stateRepo.Create(&entities.OAuthState{
+		State:        state,
+		Provider:     provider.Name(),
+		CodeVerifier: verifier,
+		ExpiresAt:    expiresAt,
+	}); err != nil {
+		return nil, err
+	}
+
+	return &OAuthStartOutput{
+		AuthURL:   provider.AuthCodeURL(state, oauth.CodeChallengeS256(verifier)),
+		State:     state,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+func providerMap(providers []oauth.Provider) map[string]oauth.Provider {
+	result := make(map[string]oauth.Provider, len(providers))
+	for _, provider := range providers {
+		result[provider.Name()] = provider
+	}
+	return result
+}
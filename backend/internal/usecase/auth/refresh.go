@@ -0,0 +1,96 @@
+package auth
+
+import (
+	"time"
+
+	"github.com/bbp/backend/internal/domain/repositories"
+)
+
+type RefreshUseCase struct {
+	userRepo         repositories.UserRepository
+	refreshTokenRepo repositories.RefreshTokenRepository
+	revokedTokenRepo repositories.RevokedTokenRepository
+	tokenIssuer      *TokenIssuer
+}
+
+type RefreshInput struct {
+	RefreshToken string
+}
+
+type RefreshOutput struct {
+	Tokens *TokenPair
+}
+
+func NewRefreshUseCase(
+	userRepo repositories.UserRepository,
+	refreshTokenRepo repositories.RefreshTokenRepository,
+	revokedTokenRepo repositories.RevokedTokenRepository,
+	tokenIssuer *TokenIssuer,
+) *RefreshUseCase {
+	return &RefreshUseCase{
+		userRepo:         userRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		revokedTokenRepo: revokedTokenRepo,
+		tokenIssuer:      tokenIssuer,
+	}
+}
+
+// Execute обменивает refresh токен на новую пару токенов (ротация)
+// Повторное использование уже отозванного токена считается утечкой:
+// в этом случае отзываются все сессии пользователя
+func (uc *RefreshUseCase) Execute(input RefreshInput) (*RefreshOutput, error) {
+	stored, err := uc.refreshTokenRepo.GetByHash(hashToken(input.RefreshToken))
+	if err != nil {
+		return nil, err
+	}
+	if stored == nil {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	now := time.Now()
+	if stored.RevokedAt != nil {
+		if err := revokeAllSessions(uc.refreshTokenRepo, uc.revokedTokenRepo, stored.UserID); err != nil {
+			return nil, err
+		}
+		return nil, ErrInvalidRefreshToken
+	}
+	if !stored.IsActive(now) {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	user, err := uc.userRepo.GetByID(stored.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	// Гостевая сессия не продлевается: новый refresh токен истекает вместе со старым
+	var tokens *TokenPair
+	if user.IsGuest {
+		tokens, err = uc.tokenIssuer.IssueUntil(user, stored.ExpiresAt)
+	} else {
+		tokens, err = uc.tokenIssuer.Issue(user)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Старый токен больше не действует. Если его уже отозвал параллельный запрос,
+	// токен использован дважды: это та же утечка, и отзываются все сессии, включая только что выданную
+	revoked, err := uc.refreshTokenRepo.Revoke(stored.ID, &tokens.RefreshTokenID)
+	if err != nil {
+		return nil, err
+	}
+	if !revoked {
+		if err := revokeAllSessions(uc.refreshTokenRepo, uc.revokedTokenRepo, stored.UserID); err != nil {
+			return nil, err
+		}
+		return nil, ErrInvalidRefreshToken
+	}
+
+	return &RefreshOutput{
+		Tokens: tokens,
+	}, nil
+}
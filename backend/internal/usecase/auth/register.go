@@ -1,15 +1,17 @@
 package auth
 
 import (
+	"log"
+
 	"github.com/bbp/backend/internal/domain/entities"
 	"github.com/bbp/backend/internal/domain/repositories"
-	"github.com/bbp/backend/pkg/jwt"
 	"github.com/bbp/backend/pkg/password"
 )
 
 type RegisterUseCase struct {
-	userRepo  repositories.UserRepository
-	jwtService *jwt.JWTService
+	userRepo      repositories.UserRepository
+	tokenIssuer   *TokenIssuer
+	emailVerifier *EmailVerifier
 }
 
 type RegisterInput struct {
@@ -19,14 +21,19 @@ type RegisterInput struct {
 }
 
 type RegisterOutput struct {
-	Token string
-	User  *entities.User
+	Tokens *TokenPair
+	User   *entities.User
 }
 
-func NewRegisterUseCase(userRepo repositories.UserRepository, jwtService *jwt.JWTService) *RegisterUseCase {
+func NewRegisterUseCase(
+	userRepo repositories.UserRepository,
+	tokenIssuer *TokenIssuer,
+	emailVerifier *EmailVerifier,
+) *RegisterUseCase {
 	return &RegisterUseCase{
-		userRepo:   userRepo,
-		jwtService: jwtService,
+		userRepo:      userRepo,
+		tokenIssuer:   tokenIssuer,
+		emailVerifier: emailVerifier,
 	}
 }
 
@@ -72,14 +79,20 @@ func (uc *RegisterUseCase) Execute(input RegisterInput) (*RegisterOutput, error)
 		return nil, err
 	}
 
-	// Генерируем JWT токен
-	token, err := uc.jwtService.GenerateToken(user.ID, user.Username)
+	// Отправляем письмо для подтверждения email
+	// Ошибка отправки не отменяет регистрацию: письмо можно запросить повторно
+	if err := uc.emailVerifier.Send(user); err != nil {
+		log.Printf("Failed to send verification email to user %d: %v", user.ID, err)
+	}
+
+	// Выдаем access и refresh токены
+	tokens, err := uc.tokenIssuer.Issue(user)
 	if err != nil {
 		return nil, err
 	}
 
 	return &RegisterOutput{
-		Token: token,
-		User:  user,
+		Tokens: tokens,
+		User:   user,
 	}, nil
-}
\ No newline at end of file
+}
@@ -0,0 +1,80 @@
+package auth
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+	"github.com/bbp/backend/pkg/mailer"
+)
+
+type RequestPasswordResetUseCase struct {
+	userRepo       repositories.UserRepository
+	resetTokenRepo repositories.PasswordResetTokenRepository
+	mailer         mailer.Mailer
+	resetURL       string
+	expiry         time.Duration
+}
+
+type RequestPasswordResetInput struct {
+	Email string
+}
+
+func NewRequestPasswordResetUseCase(
+	userRepo repositories.UserRepository,
+	resetTokenRepo repositories.PasswordResetTokenRepository,
+	mailer mailer.Mailer,
+	resetURL string,
+	expiry time.Duration,
+) *RequestPasswordResetUseCase {
+	return &RequestPasswordResetUseCase{
+		userRepo:       userRepo,
+		resetTokenRepo: resetTokenRepo,
+		mailer:         mailer,
+		resetURL:       resetURL,
+		expiry:         expiry,
+	}
+}
+
+// Execute создает токен сброса пароля и отправляет ссылку на email
+// Для неизвестного email ошибка не возвращается, чтобы не раскрывать наличие аккаунта
+func (uc *RequestPasswordResetUseCase) Execute(input RequestPasswordResetInput) error {
+	user, err := uc.userRepo.GetByEmail(input.Email)
+	if err != nil {
+		return err
+	}
+	if user == nil || user.IsGuest {
+		return nil
+	}
+
+	// Действителен только последний выданный токен
+	if err := uc.resetTokenRepo.InvalidateByUserID(user.ID); err != nil {
+		return err
+	}
+
+	token, err := generateSecureToken()
+	if err != nil {
+		return err
+	}
+
+	resetToken := &entities.PasswordResetToken{
+		UserID:    user.ID,
+		TokenHash: hashToken(token),
+		ExpiresAt: time.Now().Add(uc.expiry),
+	}
+	if err := uc.resetTokenRepo.Create(resetToken); err != nil {
+		return err
+	}
+
+	link := uc.resetURL + "?token=" + url.QueryEscape(token)
+	return uc.mailer.Send(mailer.Message{
+		To:      user.Email,
+		Subject: "Password reset",
+		Body: fmt.Sprintf(
+			"Hello, %s!\n\nTo reset your password, follow the link:\n%s\n\nThe link is valid for %s. If you did not request a reset, ignore this email.",
+			user.Username, link, uc.expiry,
+		),
+	})
+}
@@ -0,0 +1,31 @@
+package auth
+
+import "github.com/bbp/backend/internal/domain/repositories"
+
+type ResendVerificationUseCase struct {
+	userRepo      repositories.UserRepository
+	emailVerifier *EmailVerifier
+}
+
+func NewResendVerificationUseCase(userRepo repositories.UserRepository, emailVerifier *EmailVerifier) *ResendVerificationUseCase {
+	return &ResendVerificationUseCase{
+		userRepo:      userRepo,
+		emailVerifier: emailVerifier,
+	}
+}
+
+// Execute повторно отправляет письмо подтверждения текущему email пользователя
+func (uc *ResendVerificationUseCase) Execute(userID uint) error {
+	user, err := uc.userRepo.GetByID(userID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return ErrUserNotFound
+	}
+	if user.EmailVerified {
+		return ErrEmailAlreadyVerified
+	}
+
+	return uc.emailVerifier.Send(user)
+}
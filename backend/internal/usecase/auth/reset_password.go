@@ -0,0 +1,74 @@
+package auth
+
+import (
+	"time"
+
+	"github.com/bbp/backend/internal/domain/repositories"
+	"github.com/bbp/backend/pkg/password"
+)
+
+type ResetPasswordUseCase struct {
+	userRepo         repositories.UserRepository
+	resetTokenRepo   repositories.PasswordResetTokenRepository
+	refreshTokenRepo repositories.RefreshTokenRepository
+	revokedTokenRepo repositories.RevokedTokenRepository
+}
+
+type ResetPasswordInput struct {
+	Token       string
+	NewPassword string
+}
+
+func NewResetPasswordUseCase(
+	userRepo repositories.UserRepository,
+	resetTokenRepo repositories.PasswordResetTokenRepository,
+	refreshTokenRepo repositories.RefreshTokenRepository,
+	revokedTokenRepo repositories.RevokedTokenRepository,
+) *ResetPasswordUseCase {
+	return &ResetPasswordUseCase{
+		userRepo:         userRepo,
+		resetTokenRepo:   resetTokenRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		revokedTokenRepo: revokedTokenRepo,
+	}
+}
+
+// Execute устанавливает новый пароль по токену сброса и завершает все сессии пользователя
+func (uc *ResetPasswordUseCase) Execute(input ResetPasswordInput) error {
+	resetToken, err := uc.resetTokenRepo.GetByHash(hashToken(input.Token))
+	if err != nil {
+		return err
+	}
+	if resetToken == nil || !resetToken.IsUsable(time.Now()) {
+		return ErrInvalidResetToken
+	}
+
+	// Токен одноразовый
+	marked, err := uc.resetTokenRepo.MarkUsed(resetToken.ID)
+	if err != nil {
+		return err
+	}
+	if !marked {
+		return ErrInvalidResetToken
+	}
+
+	user, err := uc.userRepo.GetByID(resetToken.UserID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return ErrInvalidResetToken
+	}
+
+	hashedPassword, err := password.HashPassword(input.NewPassword)
+	if err != nil {
+		return err
+	}
+	user.Password = hashedPassword
+
+	if err := uc.userRepo.Update(user); err != nil {
+		return err
+	}
+
+	return revokeAllSessions(uc.refreshTokenRepo, uc.revokedTokenRepo, user.ID)
+}
@@ -0,0 +1,67 @@
+package auth
+
+import (
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+	"github.com/bbp/backend/pkg/totp"
+)
+
+type SetupTwoFactorUseCase struct {
+	userRepo repositories.UserRepository
+	totpRepo repositories.TwoFactorRepository
+	issuer   string
+}
+
+type SetupTwoFactorOutput struct {
+	Secret string
+	URI    string
+}
+
+func NewSetupTwoFactorUseCase(
+	userRepo repositories.UserRepository,
+	totpRepo repositories.TwoFactorRepository,
+	issuer string,
+) *SetupTwoFactorUseCase {
+	return &SetupTwoFactorUseCase{
+		userRepo: userRepo,
+		totpRepo: totpRepo,
+		issuer:   issuer,
+	}
+}
+
+// Execute генерирует новый секрет; 2FA включится только после подтверждения кодом
+// Повторный вызов до подтверждения заменяет секрет
+func (uc *SetupTwoFactorUseCase) Execute(userID uint) (*SetupTwoFactorOutput, error) {
+	user, err := uc.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	settings, err := uc.totpRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if settings.IsEnabled() {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.totpRepo.Save(&entities.UserTOTP{
+		UserID: userID,
+		Secret: secret,
+	}); err != nil {
+		return nil, err
+	}
+
+	return &SetupTwoFactorOutput{
+		Secret: secret,
+		URI:    totp.URI(uc.issuer, user.Email, secret),
+	}, nil
+}
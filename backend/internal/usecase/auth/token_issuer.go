@@ -0,0 +1,93 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+	"github.com/bbp/backend/pkg/jwt"
+)
+
+// TokenPair пара токенов, выдаваемая при входе, регистрации и обновлении
+type TokenPair struct {
+	AccessToken      string
+	AccessExpiresAt  time.Time
+	RefreshToken     string
+	RefreshExpiresAt time.Time
+	RefreshTokenID   uint
+}
+
+// TokenIssuer выдает короткоживущие access токены вместе с refresh токенами
+type TokenIssuer struct {
+	jwtService       *jwt.JWTService
+	refreshTokenRepo repositories.RefreshTokenRepository
+	refreshExpiry    time.Duration
+}
+
+func NewTokenIssuer(
+	jwtService *jwt.JWTService,
+	refreshTokenRepo repositories.RefreshTokenRepository,
+	refreshExpiry time.Duration,
+) *TokenIssuer {
+	return &TokenIssuer{
+		jwtService:       jwtService,
+		refreshTokenRepo: refreshTokenRepo,
+		refreshExpiry:    refreshExpiry,
+	}
+}
+
+// Issue выдает новую пару токенов для пользователя
+func (i *TokenIssuer) Issue(user *entities.User) (*TokenPair, error) {
+	return i.IssueUntil(user, time.Now().Add(i.refreshExpiry))
+}
+
+// IssueUntil выдает пару токенов с refresh токеном, действующим до refreshExpiresAt
+// Используется для гостевых сессий, которые не продлеваются при обновлении токенов
+func (i *TokenIssuer) IssueUntil(user *entities.User, refreshExpiresAt time.Time) (*TokenPair, error) {
+	accessToken, claims, err := i.jwtService.IssueToken(user.ID, user.Username, user.IsGuest)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := generateSecureToken()
+	if err != nil {
+		return nil, err
+	}
+
+	// В БД сохраняем только хэш refresh токена
+	stored := &entities.RefreshToken{
+		UserID:    user.ID,
+		TokenHash: hashToken(refreshToken),
+		AccessJTI: claims.ID,
+		ExpiresAt: refreshExpiresAt,
+	}
+	if err := i.refreshTokenRepo.Create(stored); err != nil {
+		return nil, err
+	}
+
+	return &TokenPair{
+		AccessToken:      accessToken,
+		AccessExpiresAt:  claims.ExpiresAt.Time,
+		RefreshToken:     refreshToken,
+		RefreshExpiresAt: stored.ExpiresAt,
+		RefreshTokenID:   stored.ID,
+	}, nil
+}
+
+// generateSecureToken генерирует случайный токен (refresh, сброс пароля)
+func generateSecureToken() (string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
+
+// hashToken возвращает SHA-256 хэш токена для хранения в БД
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
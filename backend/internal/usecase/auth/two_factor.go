@@ -0,0 +1,126 @@
+package auth
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+	"github.com/bbp/backend/pkg/totp"
+)
+
+const (
+	recoveryCodeCount = 10
+	// maxChallengeAttempts ограничивает перебор кодов в рамках одного challenge
+	maxChallengeAttempts = 5
+)
+
+// LoginChallengeToken выдается после проверки пароля, если у пользователя включена 2FA
+type LoginChallengeToken struct {
+	Token     string
+	ExpiresAt time.Time
+}
+
+// TwoFactorVerifier проверяет коды 2FA и выдает challenge для второго шага входа
+// Используется при входе по паролю, через OAuth и при отключении 2FA
+type TwoFactorVerifier struct {
+	totpRepo         repositories.TwoFactorRepository
+	recoveryCodeRepo repositories.RecoveryCodeRepository
+	challengeRepo    repositories.LoginChallengeRepository
+	challengeExpiry  time.Duration
+}
+
+func NewTwoFactorVerifier(
+	totpRepo repositories.TwoFactorRepository,
+	recoveryCodeRepo repositories.RecoveryCodeRepository,
+	challengeRepo repositories.LoginChallengeRepository,
+	challengeExpiry time.Duration,
+) *TwoFactorVerifier {
+	return &TwoFactorVerifier{
+		totpRepo:         totpRepo,
+		recoveryCodeRepo: recoveryCodeRepo,
+		challengeRepo:    challengeRepo,
+		challengeExpiry:  challengeExpiry,
+	}
+}
+
+// Enabled проверяет, включена ли у пользователя 2FA
+func (v *TwoFactorVerifier) Enabled(userID uint) (bool, error) {
+	settings, err := v.totpRepo.GetByUserID(userID)
+	if err != nil {
+		return false, err
+	}
+	return settings.IsEnabled(), nil
+}
+
+// Challenge выдает одноразовый токен второго шага входа
+func (v *TwoFactorVerifier) Challenge(userID uint) (*LoginChallengeToken, error) {
+	token, err := generateSecureToken()
+	if err != nil {
+		return nil, err
+	}
+
+	challenge := &entities.LoginChallenge{
+		UserID:    userID,
+		TokenHash: hashToken(token),
+		ExpiresAt: time.Now().Add(v.challengeExpiry),
+	}
+	if err := v.challengeRepo.Create(challenge); err != nil {
+		return nil, err
+	}
+
+	return &LoginChallengeToken{
+		Token:     token,
+		ExpiresAt: challenge.ExpiresAt,
+	}, nil
+}
+
+// Verify принимает код из приложения или неиспользованный код восстановления
+// Каждый код TOTP принимается только один раз
+func (v *TwoFactorVerifier) Verify(userID uint, code string) (bool, error) {
+	settings, err := v.totpRepo.GetByUserID(userID)
+	if err != nil {
+		return false, err
+	}
+	if !settings.IsEnabled() {
+		return false, nil
+	}
+
+	if step, ok := totp.Validate(settings.Secret, code, time.Now()); ok {
+		return v.totpRepo.UseStep(userID, step)
+	}
+
+	return v.recoveryCodeRepo.UseCode(userID, hashToken(normalizeRecoveryCode(code)))
+}
+
+// generateRecoveryCodes создает коды восстановления и их хэши для хранения в БД
+func generateRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		bytes := make([]byte, 5)
+		if _, err := rand.Read(bytes); err != nil {
+			return nil, nil, err
+		}
+		raw := hex.EncodeToString(bytes)
+		codes[i] = raw[:5] + "-" + raw[5:]
+		hashes[i] = hashToken(raw)
+	}
+	return codes, hashes, nil
+}
+
+// normalizeRecoveryCode убирает разделители и регистр, чтобы код можно было ввести как угодно
+func normalizeRecoveryCode(code string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r == '-' || r == ' ':
+			return -1
+		case r >= 'A' && r <= 'Z':
+			return r + ('a' - 'A')
+		default:
+			return r
+		}
+	}, code)
+}
@@ -0,0 +1,34 @@
+package auth
+
+import (
+	"github.com/bbp/backend/internal/domain/repositories"
+)
+
+type UnlockAccountUseCase struct {
+	userRepo       repositories.UserRepository
+	loginThrottler *LoginThrottler
+}
+
+type UnlockAccountInput struct {
+	UserID  uint
+	ActorID uint // Администратор, снимающий блокировку
+}
+
+func NewUnlockAccountUseCase(userRepo repositories.UserRepository, loginThrottler *LoginThrottler) *UnlockAccountUseCase {
+	return &UnlockAccountUseCase{
+		userRepo:       userRepo,
+		loginThrottler: loginThrottler,
+	}
+}
+
+func (uc *UnlockAccountUseCase) Execute(input UnlockAccountInput) error {
+	user, err := uc.userRepo.GetByID(input.UserID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return ErrUserNotFound
+	}
+
+	return uc.loginThrottler.Unlock(user, input.ActorID)
+}
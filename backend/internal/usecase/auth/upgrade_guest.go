@@ -0,0 +1,115 @@
+package auth
+
+import (
+	"log"
+
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+	"github.com/bbp/backend/pkg/password"
+)
+
+type UpgradeGuestUseCase struct {
+	userRepo         repositories.UserRepository
+	refreshTokenRepo repositories.RefreshTokenRepository
+	revokedTokenRepo repositories.RevokedTokenRepository
+	tokenIssuer      *TokenIssuer
+	emailVerifier    *EmailVerifier
+}
+
+type UpgradeGuestInput struct {
+	UserID   uint
+	Email    string
+	Username *string // Если не указан, сохраняется сгенерированный username
+	Password string
+}
+
+type UpgradeGuestOutput struct {
+	Tokens *TokenPair
+	User   *entities.User
+}
+
+func NewUpgradeGuestUseCase(
+	userRepo repositories.UserRepository,
+	refreshTokenRepo repositories.RefreshTokenRepository,
+	revokedTokenRepo repositories.RevokedTokenRepository,
+	tokenIssuer *TokenIssuer,
+	emailVerifier *EmailVerifier,
+) *UpgradeGuestUseCase {
+	return &UpgradeGuestUseCase{
+		userRepo:         userRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		revokedTokenRepo: revokedTokenRepo,
+		tokenIssuer:      tokenIssuer,
+		emailVerifier:    emailVerifier,
+	}
+}
+
+// Execute превращает гостя в полноценный аккаунт
+// ID пользователя не меняется, поэтому история комнат и сессий сохраняется
+func (uc *UpgradeGuestUseCase) Execute(input UpgradeGuestInput) (*UpgradeGuestOutput, error) {
+	user, err := uc.userRepo.GetByID(input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+	if !user.IsGuest {
+		return nil, ErrNotGuest
+	}
+
+	existing, err := uc.userRepo.GetByEmail(input.Email)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrEmailAlreadyExists
+	}
+
+	if input.Username != nil && *input.Username != user.Username {
+		existing, err := uc.userRepo.GetByUsername(*input.Username)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			return nil, ErrUsernameAlreadyExists
+		}
+		user.Username = *input.Username
+	}
+
+	hashedPassword, err := password.HashPassword(input.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	user.Email = input.Email
+	user.Password = hashedPassword
+	user.IsGuest = false
+	user.EmailVerified = false
+
+	if err := user.Validate(); err != nil {
+		return nil, err
+	}
+	if err := uc.userRepo.Update(user); err != nil {
+		return nil, err
+	}
+
+	// Гостевые токены с ограничениями больше не нужны
+	if err := revokeAllSessions(uc.refreshTokenRepo, uc.revokedTokenRepo, user.ID); err != nil {
+		return nil, err
+	}
+
+	if err := uc.emailVerifier.Send(user); err != nil {
+		log.Printf("Failed to send verification email to user %d: %v", user.ID, err)
+	}
+
+	tokens, err := uc.tokenIssuer.Issue(user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UpgradeGuestOutput{
+		Tokens: tokens,
+		User:   user,
+	}, nil
+}
@@ -0,0 +1,67 @@
+package auth
+
+import (
+	"time"
+
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+)
+
+type VerifyEmailUseCase struct {
+	userRepo  repositories.UserRepository
+	tokenRepo repositories.EmailVerificationTokenRepository
+}
+
+type VerifyEmailInput struct {
+	Token string
+}
+
+type VerifyEmailOutput struct {
+	User *entities.User
+}
+
+func NewVerifyEmailUseCase(
+	userRepo repositories.UserRepository,
+	tokenRepo repositories.EmailVerificationTokenRepository,
+) *VerifyEmailUseCase {
+	return &VerifyEmailUseCase{
+		userRepo:  userRepo,
+		tokenRepo: tokenRepo,
+	}
+}
+
+func (uc *VerifyEmailUseCase) Execute(input VerifyEmailInput) (*VerifyEmailOutput, error) {
+	token, err := uc.tokenRepo.GetByHash(hashToken(input.Token))
+	if err != nil {
+		return nil, err
+	}
+	if token == nil || !token.IsUsable(time.Now()) {
+		return nil, ErrInvalidVerificationToken
+	}
+
+	user, err := uc.userRepo.GetByID(token.UserID)
+	if err != nil {
+		return nil, err
+	}
+	// Токен, выданный для прежнего email, не подтверждает новый адрес
+	if user == nil || user.Email != token.Email {
+		return nil, ErrInvalidVerificationToken
+	}
+
+	marked, err := uc.tokenRepo.MarkUsed(token.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !marked {
+		return nil, ErrInvalidVerificationToken
+	}
+
+	user.EmailVerified = true
+	if err := uc.userRepo.Update(user); err != nil {
+		return nil, err
+	}
+
+	return &VerifyEmailOutput{
+		User: user,
+	}, nil
+}
@@ -0,0 +1,109 @@
+package auth
+
+import (
+	"time"
+
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+)
+
+type VerifyLoginChallengeUseCase struct {
+	userRepo          repositories.UserRepository
+	challengeRepo     repositories.LoginChallengeRepository
+	twoFactorVerifier *TwoFactorVerifier
+	tokenIssuer       *TokenIssuer
+	loginThrottler    *LoginThrottler
+}
+
+type VerifyLoginChallengeInput struct {
+	ChallengeToken string
+	Code           string
+	IP             string
+}
+
+type VerifyLoginChallengeOutput struct {
+	Tokens *TokenPair
+	User   *entities.User
+}
+
+func NewVerifyLoginChallengeUseCase(
+	userRepo repositories.UserRepository,
+	challengeRepo repositories.LoginChallengeRepository,
+	twoFactorVerifier *TwoFactorVerifier,
+	tokenIssuer *TokenIssuer,
+	loginThrottler *LoginThrottler,
+) *VerifyLoginChallengeUseCase {
+	return &VerifyLoginChallengeUseCase{
+		userRepo:          userRepo,
+		challengeRepo:     challengeRepo,
+		twoFactorVerifier: twoFactorVerifier,
+		tokenIssuer:       tokenIssuer,
+		loginThrottler:    loginThrottler,
+	}
+}
+
+// Execute завершает вход с 2FA: проверяет код и выдает токены
+// Неверные коды учитываются в блокировке входа так же, как неверные пароли
+func (uc *VerifyLoginChallengeUseCase) Execute(input VerifyLoginChallengeInput) (*VerifyLoginChallengeOutput, error) {
+	challenge, err := uc.challengeRepo.GetByHash(hashToken(input.ChallengeToken))
+	if err != nil {
+		return nil, err
+	}
+	if challenge == nil || challenge.UsedAt != nil ||
+		!time.Now().Before(challenge.ExpiresAt) || challenge.Attempts >= maxChallengeAttempts {
+		return nil, ErrInvalidLoginChallenge
+	}
+
+	user, err := uc.userRepo.GetByID(challenge.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	locked, err := uc.loginThrottler.IsLocked(user.Email, input.IP)
+	if err != nil {
+		return nil, err
+	}
+	if locked {
+		return nil, ErrTooManyLoginAttempts
+	}
+
+	ok, err := uc.twoFactorVerifier.Verify(challenge.UserID, input.Code)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		if err := uc.challengeRepo.IncrementAttempts(challenge.ID); err != nil {
+			return nil, err
+		}
+		if err := uc.loginThrottler.RecordFailure(user.Email, input.IP, &user.ID); err != nil {
+			return nil, err
+		}
+		return nil, ErrInvalidTwoFactorCode
+	}
+
+	// Challenge одноразовый: при параллельных запросах токены получит только один
+	used, err := uc.challengeRepo.MarkUsed(challenge.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !used {
+		return nil, ErrInvalidLoginChallenge
+	}
+
+	if err := uc.loginThrottler.RecordSuccess(user.Email); err != nil {
+		return nil, err
+	}
+
+	tokens, err := uc.tokenIssuer.Issue(user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &VerifyLoginChallengeOutput{
+		Tokens: tokens,
+		User:   user,
+	}, nil
+}
@@ -0,0 +1,73 @@
+package map_pool
+
+import (
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+)
+
+type AddMapUseCase struct {
+	mapPoolRepo repositories.MapPoolRepository
+	mapRepo     repositories.MapRepository
+	sessionRepo repositories.VetoSessionRepository
+}
+
+type AddMapInput struct {
+	PoolID uint
+	MapID  uint
+	UserID uint
+}
+
+type AddMapOutput struct {
+	Pool *entities.MapPool
+}
+
+func NewAddMapUseCase(
+	mapPoolRepo repositories.MapPoolRepository,
+	mapRepo repositories.MapRepository,
+	sessionRepo repositories.VetoSessionRepository,
+) *AddMapUseCase {
+	return &AddMapUseCase{
+		mapPoolRepo: mapPoolRepo,
+		mapRepo:     mapRepo,
+		sessionRepo: sessionRepo,
+	}
+}
+
+func (uc *AddMapUseCase) Execute(input AddMapInput) (*AddMapOutput, error) {
+	pool, err := loadEditablePool(uc.mapPoolRepo, uc.sessionRepo, input.PoolID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Проверяем, что карта существует и принадлежит игре пула
+	mapEntity, err := uc.mapRepo.GetByID(input.MapID)
+	if err != nil {
+		return nil, err
+	}
+	if mapEntity == nil {
+		return nil, ErrMapNotFound
+	}
+	if mapEntity.GameID != pool.GameID {
+		return nil, ErrInvalidMapPool
+	}
+
+	for _, m := range pool.Maps {
+		if m.ID == input.MapID {
+			return nil, ErrMapAlreadyInPool
+		}
+	}
+
+	if err := uc.mapPoolRepo.AddMap(pool.ID, input.MapID); err != nil {
+		return nil, err
+	}
+
+	// Перезагружаем пул с актуальным списком карт
+	pool, err = uc.mapPoolRepo.GetByID(pool.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AddMapOutput{
+		Pool: pool,
+	}, nil
+}
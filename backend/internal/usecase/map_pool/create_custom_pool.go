@@ -12,10 +12,11 @@ type CreateCustomPoolUseCase struct {
 }
 
 type CreateCustomPoolInput struct {
-	UserID  uint
-	GameID  uint
-	Name    string
-	MapIDs []uint
+	UserID     uint
+	GameID     uint
+	Name       string
+	MapIDs     []uint
+	Visibility entities.MapPoolVisibility // По умолчанию private
 }
 
 type CreateCustomPoolOutput struct {
@@ -67,12 +68,19 @@ func (uc *CreateCustomPoolUseCase) Execute(input CreateCustomPoolInput) (*Create
 	// Создаем пул
 	userID := &input.UserID
 	pool := &entities.MapPool{
-		GameID:   input.GameID,
-		UserID:   userID,
-		Name:     input.Name,
-		Type:     entities.MapPoolTypeCustom,
-		IsSystem: false,
-		Maps:     maps,
+		GameID:     input.GameID,
+		UserID:     userID,
+		Name:       input.Name,
+		Type:       entities.MapPoolTypeCustom,
+		IsSystem:   false,
+		Maps:       maps,
+		Visibility: input.Visibility,
+	}
+	if pool.Visibility == "" {
+		pool.Visibility = entities.MapPoolVisibilityPrivate
+	}
+	if err := ensureShareSlug(pool); err != nil {
+		return nil, err
 	}
 
 	// Валидация
@@ -0,0 +1,89 @@
+package map_pool
+
+import (
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+)
+
+type DuplicatePoolUseCase struct {
+	mapPoolRepo repositories.MapPoolRepository
+}
+
+type DuplicatePoolInput struct {
+	PoolID uint
+	UserID uint
+	Name   string // Необязательно, по умолчанию "<имя> (copy)"
+}
+
+type DuplicatePoolOutput struct {
+	Pool *entities.MapPool
+}
+
+func NewDuplicatePoolUseCase(
+	mapPoolRepo repositories.MapPoolRepository,
+) *DuplicatePoolUseCase {
+	return &DuplicatePoolUseCase{
+		mapPoolRepo: mapPoolRepo,
+	}
+}
+
+func (uc *DuplicatePoolUseCase) Execute(input DuplicatePoolInput) (*DuplicatePoolOutput, error) {
+	source, err := uc.mapPoolRepo.GetByID(input.PoolID)
+	if err != nil {
+		return nil, err
+	}
+	if source == nil {
+		return nil, ErrMapPoolNotFound
+	}
+
+	// Копировать можно системные, публичные и собственные пулы пользователя; пул по ссылке копируется через fork
+	if !source.IsAccessibleBy(&input.UserID) {
+		return nil, ErrUnauthorized
+	}
+
+	pool, err := copyPool(uc.mapPoolRepo, source, input.UserID, input.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DuplicatePoolOutput{
+		Pool: pool,
+	}, nil
+}
+
+// copyPool создает приватную копию пула для пользователя
+// Для чужих пулов в копии запоминается исходный пул
+func copyPool(
+	mapPoolRepo repositories.MapPoolRepository,
+	source *entities.MapPool,
+	userID uint,
+	name string,
+) (*entities.MapPool, error) {
+	if name == "" {
+		name = source.Name + " (copy)"
+	}
+
+	// Копия всегда становится кастомным пулом пользователя
+	pool := &entities.MapPool{
+		GameID:     source.GameID,
+		UserID:     &userID,
+		Name:       name,
+		Type:       entities.MapPoolTypeCustom,
+		IsSystem:   false,
+		Visibility: entities.MapPoolVisibilityPrivate,
+		Maps:       source.Maps,
+	}
+	if source.UserID != nil && *source.UserID != userID {
+		pool.ForkedFromID = &source.ID
+	}
+
+	if err := pool.Validate(); err != nil {
+		return nil, ErrInvalidMapPool
+	}
+
+	if err := mapPoolRepo.Create(pool); err != nil {
+		return nil, err
+	}
+
+	return pool, nil
+}
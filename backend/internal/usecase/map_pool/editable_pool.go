@@ -0,0 +1,42 @@
+package map_pool
+
+import (
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+)
+
+// loadEditablePool загружает пул и проверяет, что пользователь может его изменять:
+// пул должен принадлежать пользователю и не использоваться в идущей сессии вето
+func loadEditablePool(
+	mapPoolRepo repositories.MapPoolRepository,
+	sessionRepo repositories.VetoSessionRepository,
+	poolID, userID uint,
+) (*entities.MapPool, error) {
+	pool, err := mapPoolRepo.GetByID(poolID)
+	if err != nil {
+		return nil, err
+	}
+	if pool == nil {
+		return nil, ErrMapPoolNotFound
+	}
+
+	if pool.IsSystem {
+		return nil, ErrCannotEditSystem
+	}
+
+	// Проверяем, что пользователь является владельцем
+	if pool.UserID == nil || *pool.UserID != userID {
+		return nil, ErrUnauthorized
+	}
+
+	// Пока идет сессия вето, пул менять нельзя - иначе набор карт поменяется посреди вето
+	inProgress, err := sessionRepo.CountInProgressByMapPoolID(poolID)
+	if err != nil {
+		return nil, err
+	}
+	if inProgress > 0 {
+		return nil, ErrPoolInUse
+	}
+
+	return pool, nil
+}
@@ -10,4 +10,8 @@ var (
 	ErrUnauthorized       = errors.New("unauthorized")
 	ErrCannotDeleteSystem = errors.New("cannot delete system map pool")
 	ErrPoolHasNoMaps      = errors.New("map pool must have at least one map")
+	ErrCannotEditSystem   = errors.New("cannot edit system map pool")
+	ErrPoolInUse          = errors.New("map pool is used by an in-progress veto session")
+	ErrMapAlreadyInPool   = errors.New("map is already in the pool")
+	ErrMapNotInPool       = errors.New("map is not in the pool")
 )
@@ -0,0 +1,45 @@
+package map_pool
+
+import (
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+)
+
+type ForkPoolUseCase struct {
+	mapPoolRepo repositories.MapPoolRepository
+}
+
+type ForkPoolInput struct {
+	ShareSlug string
+	UserID    uint
+	Name      string // Необязательно, по умолчанию "<имя> (copy)"
+}
+
+type ForkPoolOutput struct {
+	Pool *entities.MapPool
+}
+
+func NewForkPoolUseCase(
+	mapPoolRepo repositories.MapPoolRepository,
+) *ForkPoolUseCase {
+	return &ForkPoolUseCase{
+		mapPoolRepo: mapPoolRepo,
+	}
+}
+
+// Execute копирует пул, опубликованный по ссылке, в пулы пользователя
+func (uc *ForkPoolUseCase) Execute(input ForkPoolInput) (*ForkPoolOutput, error) {
+	source, err := getSharedPool(uc.mapPoolRepo, input.ShareSlug)
+	if err != nil {
+		return nil, err
+	}
+
+	pool, err := copyPool(uc.mapPoolRepo, source, input.UserID, input.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ForkPoolOutput{
+		Pool: pool,
+	}, nil
+}
@@ -35,9 +35,9 @@ func (uc *GetPoolUseCase) Execute(input GetPoolInput) (*GetPoolOutput, error) {
 		return nil, ErrMapPoolNotFound
 	}
 
-	// Системные пулы (UserID == nil) доступны всем пользователям
-	// Пользовательские пулы доступны только их владельцам
-	if pool.UserID != nil && *pool.UserID != input.UserID {
+	// Системные и неприватные пулы доступны всем пользователям
+	// Приватные пулы доступны только их владельцам
+	if !pool.IsAccessibleBy(&input.UserID) {
 		return nil, ErrUnauthorized
 	}
 
@@ -3,11 +3,13 @@ package map_pool
 import (
 	"github.com/bbp/backend/internal/domain/entities"
 	"github.com/bbp/backend/internal/domain/repositories"
+	"github.com/bbp/backend/internal/usecase/veto"
 )
 
 type GetPoolsUseCase struct {
-	mapPoolRepo repositories.MapPoolRepository
-	gameRepo    repositories.GameRepository
+	mapPoolRepo  repositories.MapPoolRepository
+	gameRepo     repositories.GameRepository
+	logicService *veto.VetoLogicService
 }
 
 type GetPoolsInput struct {
@@ -16,16 +18,19 @@ type GetPoolsInput struct {
 }
 
 type GetPoolsOutput struct {
-	Pools []entities.MapPool
+	Pools         []entities.MapPool
+	Compatibility map[uint][]veto.PoolCompatibility // Совместимость с форматами вето по ID пула
 }
 
 func NewGetPoolsUseCase(
 	mapPoolRepo repositories.MapPoolRepository,
 	gameRepo repositories.GameRepository,
+	logicService *veto.VetoLogicService,
 ) *GetPoolsUseCase {
 	return &GetPoolsUseCase{
-		mapPoolRepo: mapPoolRepo,
-		gameRepo:    gameRepo,
+		mapPoolRepo:  mapPoolRepo,
+		gameRepo:     gameRepo,
+		logicService: logicService,
 	}
 }
 
@@ -45,7 +50,13 @@ func (uc *GetPoolsUseCase) Execute(input GetPoolsInput) (*GetPoolsOutput, error)
 		return nil, err
 	}
 
+	compatibility := make(map[uint][]veto.PoolCompatibility, len(pools))
+	for _, pool := range pools {
+		compatibility[pool.ID] = uc.logicService.GetPoolCompatibility(len(pool.Maps))
+	}
+
 	return &GetPoolsOutput{
-		Pools: pools,
+		Pools:         pools,
+		Compatibility: compatibility,
 	}, nil
 }
@@ -0,0 +1,66 @@
+package map_pool
+
+import (
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+)
+
+type GetPublicPoolsUseCase struct {
+	mapPoolRepo repositories.MapPoolRepository
+}
+
+type GetPublicPoolsInput struct {
+	GameID *uint   // Опционально: только пулы игры
+	Query  *string // Опционально: поиск по названию
+	Limit  int
+	Offset int
+}
+
+type GetPublicPoolsOutput struct {
+	Pools []entities.MapPool
+	Total int64
+}
+
+func NewGetPublicPoolsUseCase(
+	mapPoolRepo repositories.MapPoolRepository,
+) *GetPublicPoolsUseCase {
+	return &GetPublicPoolsUseCase{
+		mapPoolRepo: mapPoolRepo,
+	}
+}
+
+func (uc *GetPublicPoolsUseCase) Execute(input GetPublicPoolsInput) (*GetPublicPoolsOutput, error) {
+	// Устанавливаем значения по умолчанию
+	limit := input.Limit
+	if limit <= 0 {
+		limit = 20 // По умолчанию
+	}
+	if limit > 100 {
+		limit = 100 // Максимум
+	}
+
+	offset := input.Offset
+	if offset < 0 {
+		offset = 0
+	}
+
+	filter := &repositories.MapPoolFilter{
+		GameID: input.GameID,
+		Query:  input.Query,
+	}
+
+	pools, err := uc.mapPoolRepo.GetPublic(filter, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := uc.mapPoolRepo.CountPublic(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetPublicPoolsOutput{
+		Pools: pools,
+		Total: total,
+	}, nil
+}
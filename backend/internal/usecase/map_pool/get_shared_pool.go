@@ -0,0 +1,50 @@
+package map_pool
+
+import (
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+)
+
+type GetSharedPoolUseCase struct {
+	mapPoolRepo repositories.MapPoolRepository
+}
+
+type GetSharedPoolInput struct {
+	ShareSlug string
+}
+
+type GetSharedPoolOutput struct {
+	Pool *entities.MapPool
+}
+
+func NewGetSharedPoolUseCase(
+	mapPoolRepo repositories.MapPoolRepository,
+) *GetSharedPoolUseCase {
+	return &GetSharedPoolUseCase{
+		mapPoolRepo: mapPoolRepo,
+	}
+}
+
+func (uc *GetSharedPoolUseCase) Execute(input GetSharedPoolInput) (*GetSharedPoolOutput, error) {
+	pool, err := getSharedPool(uc.mapPoolRepo, input.ShareSlug)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetSharedPoolOutput{
+		Pool: pool,
+	}, nil
+}
+
+// getSharedPool находит пул по ссылке
+// Приватные пулы по ссылке недоступны, даже если slug уже был выдан
+func getSharedPool(mapPoolRepo repositories.MapPoolRepository, slug string) (*entities.MapPool, error) {
+	pool, err := mapPoolRepo.GetByShareSlug(slug)
+	if err != nil {
+		return nil, err
+	}
+	if pool == nil || pool.Visibility == entities.MapPoolVisibilityPrivate {
+		return nil, ErrMapPoolNotFound
+	}
+	return pool, nil
+}
@@ -0,0 +1,68 @@
+package map_pool
+
+import (
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+)
+
+type RemoveMapUseCase struct {
+	mapPoolRepo repositories.MapPoolRepository
+	sessionRepo repositories.VetoSessionRepository
+}
+
+type RemoveMapInput struct {
+	PoolID uint
+	MapID  uint
+	UserID uint
+}
+
+type RemoveMapOutput struct {
+	Pool *entities.MapPool
+}
+
+func NewRemoveMapUseCase(
+	mapPoolRepo repositories.MapPoolRepository,
+	sessionRepo repositories.VetoSessionRepository,
+) *RemoveMapUseCase {
+	return &RemoveMapUseCase{
+		mapPoolRepo: mapPoolRepo,
+		sessionRepo: sessionRepo,
+	}
+}
+
+func (uc *RemoveMapUseCase) Execute(input RemoveMapInput) (*RemoveMapOutput, error) {
+	pool, err := loadEditablePool(uc.mapPoolRepo, uc.sessionRepo, input.PoolID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	found := false
+	for _, m := range pool.Maps {
+		if m.ID == input.MapID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, ErrMapNotInPool
+	}
+
+	// В пуле должна остаться хотя бы одна карта
+	if len(pool.Maps) == 1 {
+		return nil, ErrPoolHasNoMaps
+	}
+
+	if err := uc.mapPoolRepo.RemoveMap(pool.ID, input.MapID); err != nil {
+		return nil, err
+	}
+
+	// Перезагружаем пул с актуальным списком карт
+	pool, err = uc.mapPoolRepo.GetByID(pool.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RemoveMapOutput{
+		Pool: pool,
+	}, nil
+}
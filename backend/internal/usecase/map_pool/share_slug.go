@@ -0,0 +1,24 @@
+package map_pool
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/bbp/backend/internal/domain/entities"
+)
+
+// ensureShareSlug выдает пулу slug для ссылки, если пул доступен не только владельцу
+// Slug не меняется при повторной смене видимости, чтобы старые ссылки продолжали работать
+func ensureShareSlug(pool *entities.MapPool) error {
+	if pool.ShareSlug != nil || pool.Visibility == entities.MapPoolVisibilityPrivate {
+		return nil
+	}
+
+	bytes := make([]byte, 8) // 8 байт = 16 hex символов
+	if _, err := rand.Read(bytes); err != nil {
+		return err
+	}
+	slug := hex.EncodeToString(bytes)
+	pool.ShareSlug = &slug
+	return nil
+}
@@ -0,0 +1,59 @@
+package map_pool
+
+import (
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+)
+
+type UpdatePoolUseCase struct {
+	mapPoolRepo repositories.MapPoolRepository
+	sessionRepo repositories.VetoSessionRepository
+}
+
+type UpdatePoolInput struct {
+	PoolID     uint
+	UserID     uint
+	Name       string
+	Visibility *entities.MapPoolVisibility // Опционально
+}
+
+type UpdatePoolOutput struct {
+	Pool *entities.MapPool
+}
+
+func NewUpdatePoolUseCase(
+	mapPoolRepo repositories.MapPoolRepository,
+	sessionRepo repositories.VetoSessionRepository,
+) *UpdatePoolUseCase {
+	return &UpdatePoolUseCase{
+		mapPoolRepo: mapPoolRepo,
+		sessionRepo: sessionRepo,
+	}
+}
+
+func (uc *UpdatePoolUseCase) Execute(input UpdatePoolInput) (*UpdatePoolOutput, error) {
+	pool, err := loadEditablePool(uc.mapPoolRepo, uc.sessionRepo, input.PoolID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Переименовываем пул
+	pool.Name = input.Name
+	if input.Visibility != nil {
+		pool.Visibility = *input.Visibility
+	}
+	if err := pool.Validate(); err != nil {
+		return nil, ErrInvalidMapPool
+	}
+	if err := ensureShareSlug(pool); err != nil {
+		return nil, err
+	}
+
+	if err := uc.mapPoolRepo.Update(pool); err != nil {
+		return nil, err
+	}
+
+	return &UpdatePoolOutput{
+		Pool: pool,
+	}, nil
+}
@@ -0,0 +1,131 @@
+package room
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+	"github.com/bbp/backend/internal/usecase/veto"
+)
+
+// CreateMatchRoomUseCase создает комнату и сессию вето для матча, команды которого
+// назначил организатор (например, матч турнирной сетки). Сессия ждет старта командами
+type CreateMatchRoomUseCase struct {
+	roomRepo             repositories.RoomRepository
+	sessionRepo          repositories.VetoSessionRepository
+	createSessionUseCase *veto.CreateSessionUseCase
+}
+
+type CreateMatchRoomInput struct {
+	OwnerID   uint // Организатор матча становится владельцем комнаты
+	Name      string
+	GameID    uint
+	MapPoolID uint
+	VetoType  entities.VetoType
+	TeamAID   uint
+	TeamBID   uint
+	// Ограничения пула из предыдущих серий стадии (опционально)
+	Constraints *entities.VetoConstraints
+}
+
+type CreateMatchRoomOutput struct {
+	Room    *entities.Room
+	Session *entities.VetoSession
+}
+
+func NewCreateMatchRoomUseCase(
+	roomRepo repositories.RoomRepository,
+	sessionRepo repositories.VetoSessionRepository,
+	createSessionUseCase *veto.CreateSessionUseCase,
+) *CreateMatchRoomUseCase {
+	return &CreateMatchRoomUseCase{
+		roomRepo:             roomRepo,
+		sessionRepo:          sessionRepo,
+		createSessionUseCase: createSessionUseCase,
+	}
+}
+
+func (uc *CreateMatchRoomUseCase) Execute(input CreateMatchRoomInput) (*CreateMatchRoomOutput, error) {
+	code, err := generateRoomCode()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate room code: %w", err)
+	}
+
+	mapPoolID, vetoType := input.MapPoolID, input.VetoType
+	teamAID, teamBID := input.TeamAID, input.TeamBID
+	room := &entities.Room{
+		OwnerID:         input.OwnerID,
+		Name:            input.Name,
+		Code:            code,
+		Type:            entities.RoomTypePublic,
+		Status:          entities.RoomStatusWaiting,
+		GameID:          input.GameID,
+		MapPoolID:       &mapPoolID,
+		VetoType:        &vetoType,
+		TeamAID:         &teamAID,
+		TeamBID:         &teamBID,
+		MaxParticipants: 10,
+	}
+	if err := room.Validate(); err != nil {
+		return nil, ErrInvalidRoom
+	}
+
+	ownerID := input.OwnerID
+	created, err := uc.createSessionUseCase.Execute(veto.CreateSessionInput{
+		UserID:        &ownerID,
+		GameID:        input.GameID,
+		MapPoolID:     input.MapPoolID,
+		Type:          input.VetoType,
+		TeamAID:       &teamAID,
+		TeamBID:       &teamBID,
+		TeamsAssigned: true,
+		TimerSeconds:  roomVetoTimerSeconds,
+		Constraints:   input.Constraints,
+	})
+	if err != nil {
+		return nil, mapSessionError(err)
+	}
+
+	if err := uc.roomRepo.Create(room); err != nil {
+		discardSession(uc.sessionRepo, created.Session.ID)
+		return nil, err
+	}
+
+	// Организатор входит в комнату без команды, игроки присоединяются за свои команды
+	participant := &entities.RoomParticipant{
+		RoomID:   room.ID,
+		UserID:   input.OwnerID,
+		Role:     entities.ParticipantRoleOwner,
+		JoinedAt: time.Now(),
+	}
+	if err := uc.roomRepo.AddParticipant(participant); err != nil {
+		_ = uc.roomRepo.Delete(room.ID)
+		discardSession(uc.sessionRepo, created.Session.ID)
+		return nil, fmt.Errorf("failed to add owner as participant: %w", err)
+	}
+
+	match := &entities.RoomMatch{
+		RoomID:        room.ID,
+		VetoSessionID: created.Session.ID,
+	}
+	// Комната только что создана без сессии
+	if added, err := uc.roomRepo.AddMatch(match, nil); err != nil || !added {
+		_ = uc.roomRepo.Delete(room.ID)
+		discardSession(uc.sessionRepo, created.Session.ID)
+		if err == nil {
+			err = ErrVetoAlreadyStarted
+		}
+		return nil, err
+	}
+
+	room, err = uc.roomRepo.GetByID(room.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CreateMatchRoomOutput{
+		Room:    room,
+		Session: created.Session,
+	}, nil
+}
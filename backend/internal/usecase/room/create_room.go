@@ -7,13 +7,16 @@ import (
 
 	"github.com/bbp/backend/internal/domain/entities"
 	"github.com/bbp/backend/internal/domain/repositories"
+	"github.com/bbp/backend/internal/usecase/veto"
 	"github.com/bbp/backend/pkg/password"
 )
 
 type CreateRoomUseCase struct {
-	roomRepo    repositories.RoomRepository
-	gameRepo    repositories.GameRepository
-	mapPoolRepo repositories.MapPoolRepository
+	roomRepo     repositories.RoomRepository
+	gameRepo     repositories.GameRepository
+	mapPoolRepo  repositories.MapPoolRepository
+	teamRepo     repositories.TeamRepository
+	logicService *veto.VetoLogicService
 }
 
 type CreateRoomInput struct {
@@ -25,6 +28,8 @@ type CreateRoomInput struct {
 	VetoType        *entities.VetoType // Тип вето (bo1, bo3, bo5)
 	MaxParticipants int
 	Password        *string // Пароль для приватных комнат (опционально)
+	TeamAID         *uint   // Команды, между которыми проходит вето (опционально)
+	TeamBID         *uint
 }
 
 type CreateRoomOutput struct {
@@ -35,11 +40,15 @@ func NewCreateRoomUseCase(
 	roomRepo repositories.RoomRepository,
 	gameRepo repositories.GameRepository,
 	mapPoolRepo repositories.MapPoolRepository,
+	teamRepo repositories.TeamRepository,
+	logicService *veto.VetoLogicService,
 ) *CreateRoomUseCase {
 	return &CreateRoomUseCase{
-		roomRepo:    roomRepo,
-		gameRepo:    gameRepo,
-		mapPoolRepo: mapPoolRepo,
+		roomRepo:     roomRepo,
+		gameRepo:     gameRepo,
+		mapPoolRepo:  mapPoolRepo,
+		teamRepo:     teamRepo,
+		logicService: logicService,
 	}
 }
 
@@ -103,7 +112,18 @@ func (uc *CreateRoomUseCase) Execute(input CreateRoomInput) (*CreateRoomOutput,
 			return nil, ErrInvalidRoom
 		}
 	}
+
+	// Проверяем доступ к пулу и что его хватит для выбранного формата вето
+	if err := checkRoomPool(uc.mapPoolRepo, uc.logicService, input.OwnerID, input.MapPoolID, input.VetoType); err != nil {
+		return nil, err
+	}
 	
+	// Владелец должен состоять хотя бы в одной из команд
+	teamA, teamB, err := checkRoomTeams(uc.teamRepo, input.OwnerID, input.TeamAID, input.TeamBID)
+	if err != nil {
+		return nil, err
+	}
+
 	// Создаем комнату
 	room := &entities.Room{
 		OwnerID:         input.OwnerID,
@@ -115,6 +135,8 @@ func (uc *CreateRoomUseCase) Execute(input CreateRoomInput) (*CreateRoomOutput,
 		GameID:          input.GameID,
 		MapPoolID:       input.MapPoolID,
 		VetoType:        input.VetoType,
+		TeamAID:         input.TeamAID,
+		TeamBID:         input.TeamBID,
 		MaxParticipants: maxParticipants,
 	}
 
@@ -133,6 +155,7 @@ func (uc *CreateRoomUseCase) Execute(input CreateRoomInput) (*CreateRoomOutput,
 		RoomID:   room.ID,
 		UserID:   input.OwnerID,
 		Role:     entities.ParticipantRoleOwner,
+		TeamID:   participantTeamID(input.OwnerID, teamA, teamB),
 		JoinedAt: room.CreatedAt,
 	}
 
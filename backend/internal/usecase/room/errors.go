@@ -3,13 +3,18 @@ package room
 import "errors"
 
 var (
-	ErrRoomNotFound      = errors.New("room not found")
-	ErrGameNotFound      = errors.New("game not found")
-	ErrMapPoolNotFound   = errors.New("map pool not found")
-	ErrInvalidRoom       = errors.New("invalid room")
-	ErrUnauthorized      = errors.New("unauthorized")
-	ErrRoomFull          = errors.New("room is full")
-	ErrAlreadyInRoom     = errors.New("user is already in a room")
-	ErrInvalidCode       = errors.New("invalid room code")
-	ErrCannotJoinPrivate = errors.New("cannot join private room without code")
+	ErrRoomNotFound       = errors.New("room not found")
+	ErrGameNotFound       = errors.New("game not found")
+	ErrMapPoolNotFound    = errors.New("map pool not found")
+	ErrInvalidRoom        = errors.New("invalid room")
+	ErrUnauthorized       = errors.New("unauthorized")
+	ErrRoomFull           = errors.New("room is full")
+	ErrAlreadyInRoom      = errors.New("user is already in a room")
+	ErrInvalidCode        = errors.New("invalid room code")
+	ErrCannotJoinPrivate  = errors.New("cannot join private room without code")
+	ErrPoolTooSmall       = errors.New("map pool is too small for the veto type")
+	ErrTeamNotFound       = errors.New("team not found")
+	ErrNotTeamMember      = errors.New("user is not a member of the team")
+	ErrTeamNotInRoom      = errors.New("team does not play in this room")
+	ErrVetoAlreadyStarted = errors.New("veto is already in progress in this room")
 )
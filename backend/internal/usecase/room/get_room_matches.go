@@ -0,0 +1,52 @@
+package room
+
+import (
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+)
+
+type GetRoomMatchesUseCase struct {
+	roomRepo    repositories.RoomRepository
+	sessionRepo repositories.VetoSessionRepository
+}
+
+type GetRoomMatchesOutput struct {
+	Room    *entities.Room
+	Matches []entities.RoomMatch // По порядку, с загруженными сессиями
+}
+
+func NewGetRoomMatchesUseCase(
+	roomRepo repositories.RoomRepository,
+	sessionRepo repositories.VetoSessionRepository,
+) *GetRoomMatchesUseCase {
+	return &GetRoomMatchesUseCase{
+		roomRepo:    roomRepo,
+		sessionRepo: sessionRepo,
+	}
+}
+
+func (uc *GetRoomMatchesUseCase) Execute(roomID uint) (*GetRoomMatchesOutput, error) {
+	room, err := uc.roomRepo.GetByID(roomID)
+	if err != nil {
+		return nil, err
+	}
+	if room == nil {
+		return nil, ErrRoomNotFound
+	}
+
+	matches := make([]entities.RoomMatch, len(room.Matches))
+	copy(matches, room.Matches)
+	for i := range matches {
+		// Удаленная сессия остается в истории матчем без данных вето
+		session, err := uc.sessionRepo.GetByID(matches[i].VetoSessionID)
+		if err != nil {
+			return nil, err
+		}
+		matches[i].Session = session
+	}
+
+	return &GetRoomMatchesOutput{
+		Room:    room,
+		Matches: matches,
+	}, nil
+}
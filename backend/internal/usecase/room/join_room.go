@@ -10,12 +10,14 @@ import (
 
 type JoinRoomUseCase struct {
 	roomRepo repositories.RoomRepository
+	teamRepo repositories.TeamRepository
 }
 
 type JoinRoomInput struct {
 	RoomID   *uint  // ID комнаты
 	UserID   uint
 	Password string // Пароль для приватных комнат
+	TeamID   *uint  // Команда комнаты, за которую играет участник (опционально)
 }
 
 type JoinRoomOutput struct {
@@ -24,9 +26,11 @@ type JoinRoomOutput struct {
 
 func NewJoinRoomUseCase(
 	roomRepo repositories.RoomRepository,
+	teamRepo repositories.TeamRepository,
 ) *JoinRoomUseCase {
 	return &JoinRoomUseCase{
 		roomRepo: roomRepo,
+		teamRepo: teamRepo,
 	}
 }
 
@@ -83,11 +87,26 @@ func (uc *JoinRoomUseCase) Execute(input JoinRoomInput) (*JoinRoomOutput, error)
 		return nil, ErrRoomFull
 	}
 
+	// Играть за команду может только её участник
+	if input.TeamID != nil {
+		if !room.HasTeam(*input.TeamID) {
+			return nil, ErrTeamNotInRoom
+		}
+		member, err := uc.teamRepo.GetMember(*input.TeamID, input.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if member == nil {
+			return nil, ErrNotTeamMember
+		}
+	}
+
 	// Добавляем участника
 	participant := &entities.RoomParticipant{
 		RoomID:   room.ID,
 		UserID:   input.UserID,
 		Role:     entities.ParticipantRoleMember,
+		TeamID:   input.TeamID,
 		JoinedAt: time.Now(),
 	}
 
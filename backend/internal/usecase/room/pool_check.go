@@ -0,0 +1,39 @@
+package room
+
+import (
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+	"github.com/bbp/backend/internal/usecase/veto"
+)
+
+// checkRoomPool проверяет, что владелец комнаты может использовать пул
+// и что пула хватает для выбранного формата вето
+func checkRoomPool(
+	mapPoolRepo repositories.MapPoolRepository,
+	logicService *veto.VetoLogicService,
+	ownerID uint,
+	mapPoolID *uint,
+	vetoType *entities.VetoType,
+) error {
+	if mapPoolID == nil {
+		return nil
+	}
+
+	mapPool, err := mapPoolRepo.GetByID(*mapPoolID)
+	if err != nil {
+		return err
+	}
+	if mapPool == nil || !mapPool.IsAccessibleBy(&ownerID) {
+		return ErrMapPoolNotFound
+	}
+
+	// Если формат не задан, проверять размер не с чем
+	if vetoType == nil {
+		return nil
+	}
+
+	if err := logicService.CheckPoolSize(*vetoType, len(mapPool.Maps)); err != nil {
+		return ErrPoolTooSmall
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+package room
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+	"github.com/bbp/backend/internal/usecase/veto"
+)
+
+// Таймер хода по умолчанию для сессий, создаваемых из комнаты
+const roomVetoTimerSeconds = 60
+
+type StartVetoUseCase struct {
+	roomRepo             repositories.RoomRepository
+	sessionRepo          repositories.VetoSessionRepository
+	createSessionUseCase *veto.CreateSessionUseCase
+	startSessionUseCase  *veto.StartSessionUseCase
+}
+
+type StartVetoInput struct {
+	RoomID    uint
+	UserID    uint // Для проверки прав
+	SwapSides bool // Поменять стороны A и B относительно предыдущего матча серии
+}
+
+type StartVetoOutput struct {
+	Room    *entities.Room
+	Session *entities.VetoSession
+	Match   *entities.RoomMatch
+}
+
+func NewStartVetoUseCase(
+	roomRepo repositories.RoomRepository,
+	sessionRepo repositories.VetoSessionRepository,
+	createSessionUseCase *veto.CreateSessionUseCase,
+	startSessionUseCase *veto.StartSessionUseCase,
+) *StartVetoUseCase {
+	return &StartVetoUseCase{
+		roomRepo:             roomRepo,
+		sessionRepo:          sessionRepo,
+		createSessionUseCase: createSessionUseCase,
+		startSessionUseCase:  startSessionUseCase,
+	}
+}
+
+func (uc *StartVetoUseCase) Execute(input StartVetoInput) (*StartVetoOutput, error) {
+	room, err := uc.roomRepo.GetByID(input.RoomID)
+	if err != nil {
+		return nil, err
+	}
+	if room == nil {
+		return nil, ErrRoomNotFound
+	}
+
+	// Запускать вето может только владелец комнаты
+	if room.OwnerID != input.UserID {
+		return nil, ErrUnauthorized
+	}
+
+	// Сессия создается только из настроек комнаты
+	if room.MapPoolID == nil || room.VetoType == nil {
+		return nil, ErrInvalidRoom
+	}
+
+	// Новую сессию можно начать, только если предыдущая завершена
+	if room.VetoSessionID != nil {
+		current, err := uc.sessionRepo.GetByID(*room.VetoSessionID)
+		if err != nil {
+			return nil, err
+		}
+		if current != nil && current.Status != entities.VetoStatusFinished {
+			return nil, ErrVetoAlreadyStarted
+		}
+	}
+
+	// Стороны следующего матча сохраняются с предыдущего или меняются местами
+	swapped := input.SwapSides
+	if current := room.CurrentMatch(); current != nil {
+		swapped = current.SidesSwapped != input.SwapSides
+	}
+
+	// Имена сторон берем из команд комнаты, иначе из участников по порядку входа
+	teamAID, teamBID := room.TeamAID, room.TeamBID
+	teamAName, teamBName := "", ""
+	if teamAID == nil {
+		teamAName = participantName(room.Participants, 0, "Team A")
+	}
+	if teamBID == nil {
+		teamBName = participantName(room.Participants, 1, "Team B")
+	}
+	if swapped {
+		teamAID, teamBID = teamBID, teamAID
+		teamAName, teamBName = teamBName, teamAName
+	}
+
+	ownerID := room.OwnerID
+	created, err := uc.createSessionUseCase.Execute(veto.CreateSessionInput{
+		UserID:       &ownerID,
+		GameID:       room.GameID,
+		MapPoolID:    *room.MapPoolID,
+		Type:         *room.VetoType,
+		TeamAName:    teamAName,
+		TeamBName:    teamBName,
+		TeamAID:      teamAID,
+		TeamBID:      teamBID,
+		TimerSeconds: roomVetoTimerSeconds,
+	})
+	if err != nil {
+		return nil, mapSessionError(err)
+	}
+
+	started, err := uc.startSessionUseCase.Execute(veto.StartSessionInput{
+		SessionID: created.Session.ID,
+	})
+	if err != nil {
+		discardSession(uc.sessionRepo, created.Session.ID)
+		return nil, err
+	}
+
+	// Добавляем матч в серию комнаты; при ошибке сессия не должна остаться висеть.
+	// Если параллельный запрос уже запустил вето, текущая сессия комнаты отличается от прочитанной
+	match := &entities.RoomMatch{
+		RoomID:        room.ID,
+		VetoSessionID: started.Session.ID,
+		SidesSwapped:  swapped,
+	}
+	added, err := uc.roomRepo.AddMatch(match, room.VetoSessionID)
+	if err != nil {
+		discardSession(uc.sessionRepo, started.Session.ID)
+		return nil, err
+	}
+	if !added {
+		discardSession(uc.sessionRepo, started.Session.ID)
+		return nil, ErrVetoAlreadyStarted
+	}
+
+	room.VetoSessionID = &started.Session.ID
+	room.Status = entities.RoomStatusActive
+	room.Matches, err = uc.roomRepo.GetMatches(room.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StartVetoOutput{
+		Room:    room,
+		Session: started.Session,
+		Match:   match,
+	}, nil
+}
+
+// discardSession удаляет сессию, которую не удалось привязать к комнате
+func discardSession(sessionRepo repositories.VetoSessionRepository, sessionID uint) {
+	if err := sessionRepo.Delete(sessionID); err != nil {
+		log.Printf("Failed to delete orphaned veto session %d: %v", sessionID, err)
+	}
+}
+
+// participantName возвращает никнейм участника комнаты по позиции
+func participantName(participants []entities.RoomParticipant, index int, fallback string) string {
+	if index >= len(participants) {
+		return fallback
+	}
+	participant := participants[index]
+	if participant.Username != nil && *participant.Username != "" {
+		return *participant.Username
+	}
+	return fmt.Sprintf("Team %d", participant.UserID)
+}
+
+// mapSessionError переводит ошибки создания сессии в ошибки комнаты
+func mapSessionError(err error) error {
+	switch err {
+	case veto.ErrGameNotFound:
+		return ErrGameNotFound
+	case veto.ErrMapPoolNotFound:
+		return ErrMapPoolNotFound
+	case veto.ErrInvalidMapPool, veto.ErrInvalidTeam:
+		return ErrInvalidRoom
+	case veto.ErrPoolTooSmall:
+		return ErrPoolTooSmall
+	case veto.ErrTeamNotFound:
+		return ErrTeamNotFound
+	case veto.ErrNotTeamMember:
+		return ErrNotTeamMember
+	default:
+		return err
+	}
+}
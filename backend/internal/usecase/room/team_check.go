@@ -0,0 +1,38 @@
+package room
+
+import (
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+	"github.com/bbp/backend/internal/usecase/veto"
+)
+
+// checkRoomTeams проверяет команды комнаты так же, как при создании сессии вето
+func checkRoomTeams(
+	teamRepo repositories.TeamRepository,
+	ownerID uint,
+	teamAID, teamBID *uint,
+) (*entities.Team, *entities.Team, error) {
+	teamA, teamB, err := veto.LoadMatchTeams(teamRepo, &ownerID, teamAID, teamBID)
+	switch err {
+	case nil:
+		return teamA, teamB, nil
+	case veto.ErrTeamNotFound:
+		return nil, nil, ErrTeamNotFound
+	case veto.ErrNotTeamMember:
+		return nil, nil, ErrNotTeamMember
+	case veto.ErrInvalidTeam:
+		return nil, nil, ErrInvalidRoom
+	default:
+		return nil, nil, err
+	}
+}
+
+// participantTeamID выбирает команду комнаты, за которую играет пользователь
+func participantTeamID(userID uint, teams ...*entities.Team) *uint {
+	for _, team := range teams {
+		if team != nil && team.GetMember(userID) != nil {
+			return &team.ID
+		}
+	}
+	return nil
+}
@@ -3,19 +3,24 @@ package room
 import (
 	"github.com/bbp/backend/internal/domain/entities"
 	"github.com/bbp/backend/internal/domain/repositories"
+	"github.com/bbp/backend/internal/usecase/veto"
 )
 
 type UpdateRoomUseCase struct {
-	roomRepo repositories.RoomRepository
+	roomRepo     repositories.RoomRepository
+	mapPoolRepo  repositories.MapPoolRepository
+	teamRepo     repositories.TeamRepository
+	logicService *veto.VetoLogicService
 }
 
 type UpdateRoomInput struct {
-	RoomID        uint
-	UserID        uint // Для проверки прав
-	MapPoolID     *uint
-	VetoType      *entities.VetoType
-	VetoSessionID *uint
-	Status        *entities.RoomStatus
+	RoomID    uint
+	UserID    uint // Для проверки прав
+	MapPoolID *uint
+	VetoType  *entities.VetoType
+	Status    *entities.RoomStatus
+	TeamAID   *uint
+	TeamBID   *uint
 }
 
 type UpdateRoomOutput struct {
@@ -24,9 +29,15 @@ type UpdateRoomOutput struct {
 
 func NewUpdateRoomUseCase(
 	roomRepo repositories.RoomRepository,
+	mapPoolRepo repositories.MapPoolRepository,
+	teamRepo repositories.TeamRepository,
+	logicService *veto.VetoLogicService,
 ) *UpdateRoomUseCase {
 	return &UpdateRoomUseCase{
-		roomRepo: roomRepo,
+		roomRepo:     roomRepo,
+		mapPoolRepo:  mapPoolRepo,
+		teamRepo:     teamRepo,
+		logicService: logicService,
 	}
 }
 
@@ -68,12 +79,29 @@ func (uc *UpdateRoomUseCase) Execute(input UpdateRoomInput) (*UpdateRoomOutput,
 	if input.VetoType != nil {
 		room.VetoType = input.VetoType
 	}
-	if input.VetoSessionID != nil {
-		room.VetoSessionID = input.VetoSessionID
-	}
 	if input.Status != nil {
 		room.Status = *input.Status
 	}
+	if input.TeamAID != nil {
+		room.TeamAID = input.TeamAID
+	}
+	if input.TeamBID != nil {
+		room.TeamBID = input.TeamBID
+	}
+
+	// Проверяем команды после применения изменений
+	if input.TeamAID != nil || input.TeamBID != nil {
+		if _, _, err := checkRoomTeams(uc.teamRepo, room.OwnerID, room.TeamAID, room.TeamBID); err != nil {
+			return nil, err
+		}
+	}
+
+	// Проверяем совместимость пула и формата вето после применения изменений
+	if input.VetoType != nil || input.MapPoolID != nil {
+		if err := checkRoomPool(uc.mapPoolRepo, uc.logicService, room.OwnerID, room.MapPoolID, room.VetoType); err != nil {
+			return nil, err
+		}
+	}
 
 	// Сохраняем изменения
 	if err := uc.roomRepo.Update(room); err != nil {
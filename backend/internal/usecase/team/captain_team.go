@@ -0,0 +1,35 @@
+package team
+
+import (
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+)
+
+// loadCaptainTeam загружает команду и проверяет, что пользователь - её капитан
+func loadCaptainTeam(teamRepo repositories.TeamRepository, teamID, userID uint) (*entities.Team, error) {
+	team, err := teamRepo.GetByID(teamID)
+	if err != nil {
+		return nil, err
+	}
+	if team == nil {
+		return nil, ErrTeamNotFound
+	}
+
+	if !team.IsCaptain(userID) {
+		return nil, ErrUnauthorized
+	}
+
+	return team, nil
+}
+
+// checkNameAvailable проверяет, что имя не занято другой командой
+func checkNameAvailable(teamRepo repositories.TeamRepository, name string, teamID uint) error {
+	existing, err := teamRepo.GetByName(name)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.ID != teamID {
+		return ErrTeamNameTaken
+	}
+	return nil
+}
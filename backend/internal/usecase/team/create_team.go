@@ -0,0 +1,72 @@
+package team
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+)
+
+type CreateTeamUseCase struct {
+	teamRepo repositories.TeamRepository
+}
+
+type CreateTeamInput struct {
+	OwnerID uint // Создатель становится капитаном
+	Name    string
+	Tag     string
+	LogoURL string
+}
+
+type CreateTeamOutput struct {
+	Team *entities.Team
+}
+
+func NewCreateTeamUseCase(teamRepo repositories.TeamRepository) *CreateTeamUseCase {
+	return &CreateTeamUseCase{
+		teamRepo: teamRepo,
+	}
+}
+
+func (uc *CreateTeamUseCase) Execute(input CreateTeamInput) (*CreateTeamOutput, error) {
+	team := &entities.Team{
+		Name:    strings.TrimSpace(input.Name),
+		Tag:     strings.TrimSpace(input.Tag),
+		LogoURL: strings.TrimSpace(input.LogoURL),
+	}
+	if err := team.Validate(); err != nil {
+		return nil, ErrInvalidTeam
+	}
+
+	if err := checkNameAvailable(uc.teamRepo, team.Name, 0); err != nil {
+		return nil, err
+	}
+
+	if err := uc.teamRepo.Create(team); err != nil {
+		return nil, err
+	}
+
+	captain := &entities.TeamMember{
+		TeamID:   team.ID,
+		UserID:   input.OwnerID,
+		Role:     entities.TeamRoleCaptain,
+		JoinedAt: time.Now(),
+	}
+	if err := uc.teamRepo.AddMember(captain); err != nil {
+		// Команда без капитана недоступна для управления, удаляем её
+		_ = uc.teamRepo.Delete(team.ID)
+		return nil, fmt.Errorf("failed to add captain: %w", err)
+	}
+
+	// Загружаем команду с составом
+	team, err := uc.teamRepo.GetByID(team.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CreateTeamOutput{
+		Team: team,
+	}, nil
+}
@@ -0,0 +1,29 @@
+package team
+
+import (
+	"github.com/bbp/backend/internal/domain/repositories"
+)
+
+type DeleteTeamUseCase struct {
+	teamRepo repositories.TeamRepository
+}
+
+type DeleteTeamInput struct {
+	TeamID uint
+	UserID uint // Для проверки прав
+}
+
+func NewDeleteTeamUseCase(teamRepo repositories.TeamRepository) *DeleteTeamUseCase {
+	return &DeleteTeamUseCase{
+		teamRepo: teamRepo,
+	}
+}
+
+// Execute удаляет команду; сессии и комнаты сохраняют ссылку на неё в истории
+func (uc *DeleteTeamUseCase) Execute(input DeleteTeamInput) error {
+	if _, err := loadCaptainTeam(uc.teamRepo, input.TeamID, input.UserID); err != nil {
+		return err
+	}
+
+	return uc.teamRepo.Delete(input.TeamID)
+}
@@ -0,0 +1,17 @@
+package team
+
+import "errors"
+
+var (
+	ErrTeamNotFound   = errors.New("team not found")
+	ErrInvalidTeam    = errors.New("invalid team")
+	ErrTeamNameTaken  = errors.New("team name already taken")
+	ErrUnauthorized   = errors.New("unauthorized")
+	ErrUserNotFound   = errors.New("user not found")
+	ErrMemberNotFound = errors.New("team member not found")
+	ErrAlreadyMember  = errors.New("user is already a team member")
+	ErrInviteExists   = errors.New("user already has a pending invite")
+	ErrInviteNotFound = errors.New("team invite not found")
+	ErrInvalidRole    = errors.New("invalid team role")
+	ErrLastCaptain    = errors.New("team must have at least one captain")
+)
@@ -0,0 +1,32 @@
+package team
+
+import (
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+)
+
+type GetInvitesUseCase struct {
+	inviteRepo repositories.TeamInviteRepository
+}
+
+type GetInvitesOutput struct {
+	Invites []entities.TeamInvite
+}
+
+func NewGetInvitesUseCase(inviteRepo repositories.TeamInviteRepository) *GetInvitesUseCase {
+	return &GetInvitesUseCase{
+		inviteRepo: inviteRepo,
+	}
+}
+
+// Execute возвращает приглашения пользователя, ожидающие ответа
+func (uc *GetInvitesUseCase) Execute(userID uint) (*GetInvitesOutput, error) {
+	invites, err := uc.inviteRepo.GetPendingByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetInvitesOutput{
+		Invites: invites,
+	}, nil
+}
@@ -0,0 +1,34 @@
+package team
+
+import (
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+)
+
+type GetTeamUseCase struct {
+	teamRepo repositories.TeamRepository
+}
+
+type GetTeamOutput struct {
+	Team *entities.Team
+}
+
+func NewGetTeamUseCase(teamRepo repositories.TeamRepository) *GetTeamUseCase {
+	return &GetTeamUseCase{
+		teamRepo: teamRepo,
+	}
+}
+
+func (uc *GetTeamUseCase) Execute(teamID uint) (*GetTeamOutput, error) {
+	team, err := uc.teamRepo.GetByID(teamID)
+	if err != nil {
+		return nil, err
+	}
+	if team == nil {
+		return nil, ErrTeamNotFound
+	}
+
+	return &GetTeamOutput{
+		Team: team,
+	}, nil
+}
@@ -0,0 +1,45 @@
+package team
+
+import (
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+)
+
+type GetTeamSessionsUseCase struct {
+	teamRepo    repositories.TeamRepository
+	sessionRepo repositories.VetoSessionRepository
+}
+
+type GetTeamSessionsOutput struct {
+	Sessions []entities.VetoSession
+}
+
+func NewGetTeamSessionsUseCase(
+	teamRepo repositories.TeamRepository,
+	sessionRepo repositories.VetoSessionRepository,
+) *GetTeamSessionsUseCase {
+	return &GetTeamSessionsUseCase{
+		teamRepo:    teamRepo,
+		sessionRepo: sessionRepo,
+	}
+}
+
+// Execute возвращает историю вето команды, новые сессии первыми
+func (uc *GetTeamSessionsUseCase) Execute(teamID uint) (*GetTeamSessionsOutput, error) {
+	team, err := uc.teamRepo.GetByID(teamID)
+	if err != nil {
+		return nil, err
+	}
+	if team == nil {
+		return nil, ErrTeamNotFound
+	}
+
+	sessions, err := uc.sessionRepo.GetByTeamID(team.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetTeamSessionsOutput{
+		Sessions: sessions,
+	}, nil
+}
@@ -0,0 +1,49 @@
+package team
+
+import (
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+)
+
+// teamStatsMapLimit количество карт в топах банов и пиков
+const teamStatsMapLimit = 5
+
+type GetTeamStatsUseCase struct {
+	teamRepo  repositories.TeamRepository
+	statsRepo repositories.VetoStatsRepository
+}
+
+type GetTeamStatsOutput struct {
+	Team  *entities.Team
+	Stats *entities.UserVetoStats
+}
+
+func NewGetTeamStatsUseCase(
+	teamRepo repositories.TeamRepository,
+	statsRepo repositories.VetoStatsRepository,
+) *GetTeamStatsUseCase {
+	return &GetTeamStatsUseCase{
+		teamRepo:  teamRepo,
+		statsRepo: statsRepo,
+	}
+}
+
+func (uc *GetTeamStatsUseCase) Execute(teamID uint) (*GetTeamStatsOutput, error) {
+	team, err := uc.teamRepo.GetByID(teamID)
+	if err != nil {
+		return nil, err
+	}
+	if team == nil {
+		return nil, ErrTeamNotFound
+	}
+
+	stats, err := uc.statsRepo.GetTeamStats(team.ID, teamStatsMapLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetTeamStatsOutput{
+		Team:  team,
+		Stats: stats,
+	}, nil
+}
@@ -0,0 +1,31 @@
+package team
+
+import (
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+)
+
+type GetUserTeamsUseCase struct {
+	teamRepo repositories.TeamRepository
+}
+
+type GetUserTeamsOutput struct {
+	Teams []entities.Team
+}
+
+func NewGetUserTeamsUseCase(teamRepo repositories.TeamRepository) *GetUserTeamsUseCase {
+	return &GetUserTeamsUseCase{
+		teamRepo: teamRepo,
+	}
+}
+
+func (uc *GetUserTeamsUseCase) Execute(userID uint) (*GetUserTeamsOutput, error) {
+	teams, err := uc.teamRepo.GetByMemberID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetUserTeamsOutput{
+		Teams: teams,
+	}, nil
+}
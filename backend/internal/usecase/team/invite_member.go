@@ -0,0 +1,82 @@
+package team
+
+import (
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+)
+
+type InviteMemberUseCase struct {
+	teamRepo   repositories.TeamRepository
+	inviteRepo repositories.TeamInviteRepository
+	userRepo   repositories.UserRepository
+}
+
+type InviteMemberInput struct {
+	TeamID   uint
+	UserID   uint // Капитан, отправляющий приглашение
+	Username string
+	Role     entities.TeamRole
+}
+
+type InviteMemberOutput struct {
+	Invite *entities.TeamInvite
+}
+
+func NewInviteMemberUseCase(
+	teamRepo repositories.TeamRepository,
+	inviteRepo repositories.TeamInviteRepository,
+	userRepo repositories.UserRepository,
+) *InviteMemberUseCase {
+	return &InviteMemberUseCase{
+		teamRepo:   teamRepo,
+		inviteRepo: inviteRepo,
+		userRepo:   userRepo,
+	}
+}
+
+func (uc *InviteMemberUseCase) Execute(input InviteMemberInput) (*InviteMemberOutput, error) {
+	if !input.Role.IsValid() {
+		return nil, ErrInvalidRole
+	}
+
+	team, err := loadCaptainTeam(uc.teamRepo, input.TeamID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Гостевые аккаунты временные, в состав их не приглашаем
+	invitee, err := uc.userRepo.GetByUsername(input.Username)
+	if err != nil {
+		return nil, err
+	}
+	if invitee == nil || invitee.IsGuest {
+		return nil, ErrUserNotFound
+	}
+
+	if team.GetMember(invitee.ID) != nil {
+		return nil, ErrAlreadyMember
+	}
+
+	pending, err := uc.inviteRepo.GetPending(team.ID, invitee.ID)
+	if err != nil {
+		return nil, err
+	}
+	if pending != nil {
+		return nil, ErrInviteExists
+	}
+
+	invite := &entities.TeamInvite{
+		TeamID:      team.ID,
+		UserID:      invitee.ID,
+		InvitedByID: input.UserID,
+		Role:        input.Role,
+		Status:      entities.TeamInviteStatusPending,
+	}
+	if err := uc.inviteRepo.Create(invite); err != nil {
+		return nil, err
+	}
+
+	return &InviteMemberOutput{
+		Invite: invite,
+	}, nil
+}
@@ -0,0 +1,82 @@
+package team
+
+import (
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+)
+
+type RemoveMemberUseCase struct {
+	teamRepo repositories.TeamRepository
+}
+
+type RemoveMemberInput struct {
+	TeamID   uint
+	UserID   uint // Кто удаляет: капитан или сам участник
+	MemberID uint // Кого удаляют
+}
+
+type RemoveMemberOutput struct {
+	Team *entities.Team // nil, если команда удалена вместе с последним участником
+}
+
+func NewRemoveMemberUseCase(teamRepo repositories.TeamRepository) *RemoveMemberUseCase {
+	return &RemoveMemberUseCase{
+		teamRepo: teamRepo,
+	}
+}
+
+func (uc *RemoveMemberUseCase) Execute(input RemoveMemberInput) (*RemoveMemberOutput, error) {
+	team, err := uc.teamRepo.GetByID(input.TeamID)
+	if err != nil {
+		return nil, err
+	}
+	if team == nil {
+		return nil, ErrTeamNotFound
+	}
+
+	// Выйти может любой участник, исключить другого - только капитан
+	if input.UserID != input.MemberID && !team.IsCaptain(input.UserID) {
+		return nil, ErrUnauthorized
+	}
+	if team.GetMember(input.MemberID) == nil {
+		return nil, ErrMemberNotFound
+	}
+
+	team, err = LeaveTeam(uc.teamRepo, team, input.MemberID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RemoveMemberOutput{
+		Team: team,
+	}, nil
+}
+
+// LeaveTeam убирает участника из состава
+// Если уходит последний капитан, капитаном становится участник, вступивший раньше всех;
+// команда без участников удаляется. Возвращает обновленную команду или nil, если она удалена
+func LeaveTeam(teamRepo repositories.TeamRepository, team *entities.Team, userID uint) (*entities.Team, error) {
+	if len(team.Members) <= 1 {
+		return nil, teamRepo.Delete(team.ID)
+	}
+
+	// Капитанство передается до выхода: если выход не удался, повтор найдет команду и завершит его
+	if team.IsCaptain(userID) && team.CountCaptains() == 1 {
+		// Состав отсортирован по времени вступления
+		for _, member := range team.Members {
+			if member.UserID == userID {
+				continue
+			}
+			if err := teamRepo.UpdateMemberRole(team.ID, member.UserID, entities.TeamRoleCaptain); err != nil {
+				return nil, err
+			}
+			break
+		}
+	}
+
+	if err := teamRepo.RemoveMember(team.ID, userID); err != nil {
+		return nil, err
+	}
+
+	return teamRepo.GetByID(team.ID)
+}
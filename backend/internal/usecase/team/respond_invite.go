@@ -0,0 +1,86 @@
+package team
+
+import (
+	"time"
+
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+)
+
+type RespondInviteUseCase struct {
+	teamRepo   repositories.TeamRepository
+	inviteRepo repositories.TeamInviteRepository
+}
+
+type RespondInviteInput struct {
+	InviteID uint
+	UserID   uint // Приглашенный пользователь
+	Accept   bool
+}
+
+type RespondInviteOutput struct {
+	Invite *entities.TeamInvite
+	Team   *entities.Team // Команда с обновленным составом
+}
+
+func NewRespondInviteUseCase(
+	teamRepo repositories.TeamRepository,
+	inviteRepo repositories.TeamInviteRepository,
+) *RespondInviteUseCase {
+	return &RespondInviteUseCase{
+		teamRepo:   teamRepo,
+		inviteRepo: inviteRepo,
+	}
+}
+
+func (uc *RespondInviteUseCase) Execute(input RespondInviteInput) (*RespondInviteOutput, error) {
+	// Чужие приглашения не раскрываем
+	invite, err := uc.inviteRepo.GetByID(input.InviteID)
+	if err != nil {
+		return nil, err
+	}
+	if invite == nil || invite.UserID != input.UserID || !invite.IsPending() {
+		return nil, ErrInviteNotFound
+	}
+
+	team, err := uc.teamRepo.GetByID(invite.TeamID)
+	if err != nil {
+		return nil, err
+	}
+	if team == nil {
+		return nil, ErrTeamNotFound
+	}
+
+	now := time.Now()
+	invite.RespondedAt = &now
+	invite.Status = entities.TeamInviteStatusDeclined
+	if input.Accept {
+		invite.Status = entities.TeamInviteStatusAccepted
+
+		if team.GetMember(input.UserID) == nil {
+			member := &entities.TeamMember{
+				TeamID:   team.ID,
+				UserID:   input.UserID,
+				Role:     invite.Role,
+				JoinedAt: now,
+			}
+			if err := uc.teamRepo.AddMember(member); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := uc.inviteRepo.Update(invite); err != nil {
+		return nil, err
+	}
+
+	team, err = uc.teamRepo.GetByID(team.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RespondInviteOutput{
+		Invite: invite,
+		Team:   team,
+	}, nil
+}
@@ -0,0 +1,61 @@
+package team
+
+import (
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+)
+
+type UpdateMemberUseCase struct {
+	teamRepo repositories.TeamRepository
+}
+
+type UpdateMemberInput struct {
+	TeamID   uint
+	UserID   uint // Капитан, меняющий роль
+	MemberID uint
+	Role     entities.TeamRole
+}
+
+type UpdateMemberOutput struct {
+	Team *entities.Team
+}
+
+func NewUpdateMemberUseCase(teamRepo repositories.TeamRepository) *UpdateMemberUseCase {
+	return &UpdateMemberUseCase{
+		teamRepo: teamRepo,
+	}
+}
+
+func (uc *UpdateMemberUseCase) Execute(input UpdateMemberInput) (*UpdateMemberOutput, error) {
+	if !input.Role.IsValid() {
+		return nil, ErrInvalidRole
+	}
+
+	team, err := loadCaptainTeam(uc.teamRepo, input.TeamID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	member := team.GetMember(input.MemberID)
+	if member == nil {
+		return nil, ErrMemberNotFound
+	}
+
+	// Команда не может остаться без капитана
+	if member.Role == entities.TeamRoleCaptain && input.Role != entities.TeamRoleCaptain && team.CountCaptains() == 1 {
+		return nil, ErrLastCaptain
+	}
+
+	if err := uc.teamRepo.UpdateMemberRole(team.ID, member.UserID, input.Role); err != nil {
+		return nil, err
+	}
+
+	team, err = uc.teamRepo.GetByID(team.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UpdateMemberOutput{
+		Team: team,
+	}, nil
+}
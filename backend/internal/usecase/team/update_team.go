@@ -0,0 +1,64 @@
+package team
+
+import (
+	"strings"
+
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+)
+
+type UpdateTeamUseCase struct {
+	teamRepo repositories.TeamRepository
+}
+
+type UpdateTeamInput struct {
+	TeamID  uint
+	UserID  uint // Для проверки прав
+	Name    *string
+	Tag     *string
+	LogoURL *string
+}
+
+type UpdateTeamOutput struct {
+	Team *entities.Team
+}
+
+func NewUpdateTeamUseCase(teamRepo repositories.TeamRepository) *UpdateTeamUseCase {
+	return &UpdateTeamUseCase{
+		teamRepo: teamRepo,
+	}
+}
+
+func (uc *UpdateTeamUseCase) Execute(input UpdateTeamInput) (*UpdateTeamOutput, error) {
+	team, err := loadCaptainTeam(uc.teamRepo, input.TeamID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Name != nil {
+		team.Name = strings.TrimSpace(*input.Name)
+	}
+	if input.Tag != nil {
+		team.Tag = strings.TrimSpace(*input.Tag)
+	}
+	if input.LogoURL != nil {
+		team.LogoURL = strings.TrimSpace(*input.LogoURL)
+	}
+	if err := team.Validate(); err != nil {
+		return nil, ErrInvalidTeam
+	}
+
+	if input.Name != nil {
+		if err := checkNameAvailable(uc.teamRepo, team.Name, team.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.teamRepo.Update(team); err != nil {
+		return nil, err
+	}
+
+	return &UpdateTeamOutput{
+		Team: team,
+	}, nil
+}
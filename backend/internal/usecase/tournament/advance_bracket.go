@@ -0,0 +1,119 @@
+package tournament
+
+import (
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+	"github.com/bbp/backend/internal/usecase/room"
+	"github.com/bbp/backend/internal/usecase/veto"
+)
+
+// AdvanceBracketUseCase продвигает сетку по подтвержденным результатам матчей (veto.ResultListener)
+type AdvanceBracketUseCase struct {
+	tournamentRepo repositories.TournamentRepository
+	progress       *bracketProgress
+}
+
+func NewAdvanceBracketUseCase(
+	tournamentRepo repositories.TournamentRepository,
+	createMatchRoomUseCase *room.CreateMatchRoomUseCase,
+	stageConstraints *StageConstraintsService,
+) *AdvanceBracketUseCase {
+	return &AdvanceBracketUseCase{
+		tournamentRepo: tournamentRepo,
+		progress: &bracketProgress{
+			tournamentRepo:         tournamentRepo,
+			createMatchRoomUseCase: createMatchRoomUseCase,
+			stageConstraints:       stageConstraints,
+		},
+	}
+}
+
+// CheckResult запрещает менять победителя матча, если его следующий матч уже создан или сыгран
+func (uc *AdvanceBracketUseCase) CheckResult(session *entities.VetoSession, result *entities.MatchResult) error {
+	tournament, match, err := uc.loadMatch(session.ID)
+	if err != nil || match == nil || !match.IsFinished() {
+		return err
+	}
+	if sameTeam(match.WinnerTeamID, resultWinner(match, result)) {
+		return nil
+	}
+
+	for _, next := range tournament.Matches {
+		switch {
+		case match.Bracket == entities.TournamentBracketSwiss:
+			// Пары следующего раунда составлены по таблице с этим результатом
+			if next.Bracket == entities.TournamentBracketSwiss && next.Round > match.Round {
+				return veto.ErrResultLocked
+			}
+		case isNextMatch(match, next.ID) && (next.VetoSessionID != nil || next.IsFinished()):
+			return veto.ErrResultLocked
+		}
+	}
+	return nil
+}
+
+// ResultConfirmed завершает матч сетки и продвигает турнир
+func (uc *AdvanceBracketUseCase) ResultConfirmed(session *entities.VetoSession, result *entities.MatchResult) error {
+	tournament, match, err := uc.loadMatch(session.ID)
+	if err != nil || match == nil {
+		return err
+	}
+
+	winner := resultWinner(match, result)
+	switch {
+	case !match.IsFinished():
+		if err := uc.progress.finish(tournament, match, winner); err != nil {
+			return err
+		}
+	case !sameTeam(match.WinnerTeamID, winner):
+		// Исправленный результат: победитель и проигравший меняются местами в следующих матчах
+		match.WinnerTeamID = winner
+		if err := uc.tournamentRepo.UpdateMatch(match); err != nil {
+			return err
+		}
+		if err := uc.progress.moveTeam(tournament, match.NextMatchID, match.NextSlot, winner); err != nil {
+			return err
+		}
+		if err := uc.progress.moveTeam(tournament, match.LoserNextMatchID, match.LoserNextSlot, match.LoserTeamID()); err != nil {
+			return err
+		}
+	default:
+		return nil
+	}
+
+	return uc.progress.advance(tournament)
+}
+
+// loadMatch загружает турнир и матч сетки, для которого создана сессия
+func (uc *AdvanceBracketUseCase) loadMatch(sessionID uint) (*entities.Tournament, *entities.TournamentMatch, error) {
+	found, err := uc.tournamentRepo.GetMatchBySessionID(sessionID)
+	if err != nil || found == nil {
+		return nil, nil, err
+	}
+	tournament, err := loadTournament(uc.tournamentRepo, found.TournamentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	match := tournament.GetMatch(found.ID)
+	if match == nil {
+		return nil, nil, ErrMatchNotFound
+	}
+	return tournament, match, nil
+}
+
+// resultWinner возвращает команду-победителя: стороны сессии совпадают со слотами матча
+func resultWinner(match *entities.TournamentMatch, result *entities.MatchResult) *uint {
+	if result.WinnerTeam == "A" {
+		return match.TeamAID
+	}
+	return match.TeamBID
+}
+
+func isNextMatch(match *entities.TournamentMatch, matchID uint) bool {
+	return (match.NextMatchID != nil && *match.NextMatchID == matchID) ||
+		(match.LoserNextMatchID != nil && *match.LoserNextMatchID == matchID)
+}
+
+func sameTeam(a, b *uint) bool {
+	return a != nil && b != nil && *a == *b
+}
@@ -0,0 +1,258 @@
+package tournament
+
+import (
+	"github.com/bbp/backend/internal/domain/entities"
+)
+
+// stageVetoType возвращает формат матча этапа: Bo1 в группах, Bo3 в плей-офф,
+// в финале - формат, выбранный организатором (Bo5 для турниров без него)
+func stageVetoType(t *entities.Tournament, final bool) entities.VetoType {
+	switch {
+	case t.Format == entities.TournamentFormatSwiss:
+		return entities.VetoTypeBo1
+	case final && t.FinalVetoType != "":
+		return t.FinalVetoType
+	case final:
+		return entities.VetoTypeBo5
+	default:
+		return entities.VetoTypeBo3
+	}
+}
+
+// stageRuleVetoType возвращает самый длинный формат матчей стадии, под который проверяются ее правила пула;
+// финал плей-офф не короче остальных его матчей
+func stageRuleVetoType(t *entities.Tournament, stage entities.TournamentStage) entities.VetoType {
+	if stage == entities.TournamentStageSwiss {
+		return entities.VetoTypeBo1
+	}
+	return stageVetoType(t, true)
+}
+
+// hasStage проверяет, что в турнире формата format есть стадия stage
+func hasStage(format entities.TournamentFormat, stage entities.TournamentStage) bool {
+	if format == entities.TournamentFormatSwiss {
+		return stage == entities.TournamentStageSwiss
+	}
+	return stage == entities.TournamentStagePlayoffs
+}
+
+// minTeams возвращает минимальное количество команд для старта турнира
+func minTeams(format entities.TournamentFormat) int {
+	if format == entities.TournamentFormatDoubleElimination {
+		return 3
+	}
+	return 2
+}
+
+// bracketSize возвращает размер сетки на выбывание: ближайшую степень двойки
+func bracketSize(teams int) int {
+	size := 2
+	for size < teams {
+		size *= 2
+	}
+	return size
+}
+
+// seedOrder возвращает посевы по позициям первого раунда сетки размера size,
+// чтобы сильнейшие посевы встречались как можно позже (1-8, 4-5, 2-7, 3-6)
+func seedOrder(size int) []int {
+	order := []int{1, 2}
+	for len(order) < size {
+		next := make([]int, 0, len(order)*2)
+		sum := len(order)*2 + 1
+		for _, seed := range order {
+			next = append(next, seed, sum-seed)
+		}
+		order = next
+	}
+	return order
+}
+
+// newMatch создает матч сетки без команд
+func newMatch(t *entities.Tournament, bracket entities.TournamentBracket, round, position int, vetoType entities.VetoType) *entities.TournamentMatch {
+	return &entities.TournamentMatch{
+		TournamentID: t.ID,
+		Bracket:      bracket,
+		Round:        round,
+		Position:     position,
+		Status:       entities.TournamentMatchStatusPending,
+		VetoType:     vetoType,
+	}
+}
+
+// linkWinner направляет победителя матча в слот следующего
+func linkWinner(from, to *entities.TournamentMatch, slot string) {
+	from.NextMatch = to
+	from.NextSlot = slot
+}
+
+// linkLoser направляет проигравшего матча в слот сетки проигравших
+func linkLoser(from, to *entities.TournamentMatch, slot string) {
+	from.LoserNextMatch = to
+	from.LoserNextSlot = slot
+}
+
+// slotByParity возвращает слот следующего матча для пары соседних матчей
+func slotByParity(position int) string {
+	if position%2 == 0 {
+		return "A"
+	}
+	return "B"
+}
+
+// upperBracket строит основную сетку на выбывание и расставляет команды первого раунда по посеву
+// Пустые слоты первого раунда остаются без команды: соперник проходит дальше без игры
+func upperBracket(t *entities.Tournament, teams []entities.TournamentTeam, finalIsLast bool) [][]*entities.TournamentMatch {
+	size := bracketSize(len(teams))
+	var rounds [][]*entities.TournamentMatch
+	for count, round := size/2, 1; count >= 1; count, round = count/2, round+1 {
+		final := finalIsLast && count == 1
+		matches := make([]*entities.TournamentMatch, count)
+		for i := range matches {
+			matches[i] = newMatch(t, entities.TournamentBracketUpper, round, i+1, stageVetoType(t, final))
+		}
+		if round > 1 {
+			for i, prev := range rounds[round-2] {
+				linkWinner(prev, matches[i/2], slotByParity(i))
+			}
+		}
+		rounds = append(rounds, matches)
+	}
+
+	order := seedOrder(size)
+	for i, match := range rounds[0] {
+		match.TeamAID = seededTeam(teams, order[2*i])
+		match.TeamBID = seededTeam(teams, order[2*i+1])
+	}
+	return rounds
+}
+
+// seededTeam возвращает команду с посевом seed или nil, если столько команд нет
+func seededTeam(teams []entities.TournamentTeam, seed int) *uint {
+	if seed > len(teams) {
+		return nil
+	}
+	teamID := teams[seed-1].TeamID
+	return &teamID
+}
+
+// singleElimination строит сетку single elimination
+func singleElimination(t *entities.Tournament, teams []entities.TournamentTeam) []*entities.TournamentMatch {
+	return flatten(upperBracket(t, teams, true))
+}
+
+// doubleElimination строит основную сетку, сетку проигравших и гранд-финал
+// Проигравшие первого раунда встречаются между собой, проигравшие следующих раундов
+// встречают победителей сетки проигравших (в обратном порядке, чтобы отложить повторные встречи)
+func doubleElimination(t *entities.Tournament, teams []entities.TournamentTeam) []*entities.TournamentMatch {
+	upper := upperBracket(t, teams, false)
+	size := bracketSize(len(teams))
+
+	var lower [][]*entities.TournamentMatch
+	for round := 1; round <= 2*(len(upper)-1); round++ {
+		count := size >> (round/2 + 1)
+		if round%2 == 1 {
+			count = size >> ((round-1)/2 + 2)
+		}
+		matches := make([]*entities.TournamentMatch, count)
+		for i := range matches {
+			matches[i] = newMatch(t, entities.TournamentBracketLower, round, i+1, stageVetoType(t, false))
+		}
+
+		switch {
+		case round == 1:
+			for i, from := range upper[0] {
+				linkLoser(from, matches[i/2], slotByParity(i))
+			}
+		case round%2 == 0:
+			// Победители предыдущего раунда сетки проигравших против выбывших из основной сетки
+			dropped := upper[round/2]
+			for i, from := range lower[round-2] {
+				linkWinner(from, matches[i], "A")
+			}
+			for i, from := range dropped {
+				linkLoser(from, matches[len(dropped)-1-i], "B")
+			}
+		default:
+			for i, from := range lower[round-2] {
+				linkWinner(from, matches[i/2], slotByParity(i))
+			}
+		}
+		lower = append(lower, matches)
+	}
+
+	grandFinal := newMatch(t, entities.TournamentBracketGrandFinal, 1, 1, stageVetoType(t, true))
+	linkWinner(upper[len(upper)-1][0], grandFinal, "A")
+	linkWinner(lower[len(lower)-1][0], grandFinal, "B")
+
+	matches := append(flatten(upper), flatten(lower)...)
+	return append(matches, grandFinal)
+}
+
+// swissFirstRound строит первый раунд швейцарской системы: верхняя половина посева против нижней
+// При нечетном количестве команд последний посев проходит раунд без игры
+func swissFirstRound(t *entities.Tournament, teams []entities.TournamentTeam) []*entities.TournamentMatch {
+	ids := make([]uint, len(teams))
+	for i, team := range teams {
+		ids[i] = team.TeamID
+	}
+
+	var bye *uint
+	if len(ids)%2 == 1 {
+		bye = &ids[len(ids)-1]
+		ids = ids[:len(ids)-1]
+	}
+
+	half := len(ids) / 2
+	pairs := make([][2]uint, half)
+	for i := 0; i < half; i++ {
+		pairs[i] = [2]uint{ids[i], ids[i+half]}
+	}
+	return swissRound(t, 1, pairs, bye)
+}
+
+// swissRound создает матчи раунда швейцарской системы; матч с одной командой - пропуск раунда
+func swissRound(t *entities.Tournament, round int, pairs [][2]uint, bye *uint) []*entities.TournamentMatch {
+	vetoType := stageVetoType(t, false)
+	matches := make([]*entities.TournamentMatch, 0, len(pairs)+1)
+	for i := range pairs {
+		match := newMatch(t, entities.TournamentBracketSwiss, round, i+1, vetoType)
+		match.TeamAID = &pairs[i][0]
+		match.TeamBID = &pairs[i][1]
+		matches = append(matches, match)
+	}
+	if bye != nil {
+		match := newMatch(t, entities.TournamentBracketSwiss, round, len(pairs)+1, vetoType)
+		match.TeamAID = bye
+		matches = append(matches, match)
+	}
+	return matches
+}
+
+// swissRoundCount возвращает количество раундов швейцарской системы:
+// заданное организатором или достаточное, чтобы выявить единственного непобежденного
+func swissRoundCount(t *entities.Tournament) int {
+	teams := len(t.Teams)
+	rounds := t.SwissRounds
+	if rounds == 0 {
+		for size := 1; size < teams; size *= 2 {
+			rounds++
+		}
+	}
+	// Больше раундов, чем соперников, не сыграть без повторных встреч
+	if rounds > teams-1 {
+		rounds = teams - 1
+	}
+	if rounds < 1 {
+		rounds = 1
+	}
+	return rounds
+}
+
+func flatten(rounds [][]*entities.TournamentMatch) []*entities.TournamentMatch {
+	var matches []*entities.TournamentMatch
+	for _, round := range rounds {
+		matches = append(matches, round...)
+	}
+	return matches
+}
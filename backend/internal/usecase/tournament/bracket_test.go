@@ -0,0 +1,138 @@
+package tournament
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/bbp/backend/internal/domain/entities"
+)
+
+func testTeams(count int) []entities.TournamentTeam {
+	teams := make([]entities.TournamentTeam, count)
+	for i := range teams {
+		teams[i] = entities.TournamentTeam{TeamID: uint(i + 1), Seed: i + 1}
+	}
+	return teams
+}
+
+func TestSeedOrder(t *testing.T) {
+	tests := []struct {
+		size int
+		want []int
+	}{
+		{size: 2, want: []int{1, 2}},
+		{size: 4, want: []int{1, 4, 2, 3}},
+		{size: 8, want: []int{1, 8, 4, 5, 2, 7, 3, 6}},
+	}
+
+	for _, tt := range tests {
+		if got := seedOrder(tt.size); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("seedOrder(%d) = %v, want %v", tt.size, got, tt.want)
+		}
+	}
+}
+
+func TestSingleElimination_Byes(t *testing.T) {
+	tournament := &entities.Tournament{Format: entities.TournamentFormatSingleElimination}
+	matches := singleElimination(tournament, testTeams(3))
+
+	if len(matches) != 3 {
+		t.Fatalf("expected 3 matches, got %d", len(matches))
+	}
+	// Первый посев без соперника
+	if matches[0].TeamAID == nil || *matches[0].TeamAID != 1 || matches[0].TeamBID != nil {
+		t.Errorf("expected seed 1 to have a bye, got %v vs %v", matches[0].TeamAID, matches[0].TeamBID)
+	}
+	if matches[0].NextMatch != matches[2] || matches[0].NextSlot != "A" {
+		t.Errorf("expected first match winner to go to final slot A")
+	}
+	if matches[1].NextMatch != matches[2] || matches[1].NextSlot != "B" {
+		t.Errorf("expected second match winner to go to final slot B")
+	}
+	if matches[1].VetoType != entities.VetoTypeBo3 || matches[2].VetoType != entities.VetoTypeBo5 {
+		t.Errorf("expected bo3 semi-final and bo5 final, got %s and %s", matches[1].VetoType, matches[2].VetoType)
+	}
+}
+
+func TestDoubleElimination_Structure(t *testing.T) {
+	tournament := &entities.Tournament{Format: entities.TournamentFormatDoubleElimination}
+	matches := doubleElimination(tournament, testTeams(4))
+
+	// Основная сетка 2+1, сетка проигравших 1+1, гранд-финал
+	if len(matches) != 6 {
+		t.Fatalf("expected 6 matches, got %d", len(matches))
+	}
+	upperSemi1, upperSemi2, upperFinal := matches[0], matches[1], matches[2]
+	lower1, lower2, grandFinal := matches[3], matches[4], matches[5]
+
+	if upperSemi1.LoserNextMatch != lower1 || upperSemi2.LoserNextMatch != lower1 {
+		t.Errorf("expected first round losers to meet in lower round 1")
+	}
+	if lower1.NextMatch != lower2 || lower1.NextSlot != "A" {
+		t.Errorf("expected lower round 1 winner to go to lower round 2 slot A")
+	}
+	if upperFinal.LoserNextMatch != lower2 || upperFinal.LoserNextSlot != "B" {
+		t.Errorf("expected upper final loser to drop to lower round 2 slot B")
+	}
+	if upperFinal.NextMatch != grandFinal || upperFinal.NextSlot != "A" {
+		t.Errorf("expected upper final winner in grand final slot A")
+	}
+	if lower2.NextMatch != grandFinal || lower2.NextSlot != "B" {
+		t.Errorf("expected lower final winner in grand final slot B")
+	}
+	if grandFinal.Bracket != entities.TournamentBracketGrandFinal || grandFinal.VetoType != entities.VetoTypeBo5 {
+		t.Errorf("expected bo5 grand final, got %s %s", grandFinal.Bracket, grandFinal.VetoType)
+	}
+}
+
+func TestSwissNextRound_AvoidsRematches(t *testing.T) {
+	tournament := &entities.Tournament{Format: entities.TournamentFormatSwiss, Teams: testTeams(4)}
+	play := func(round int, results [][2]uint) {
+		for i, result := range results {
+			winner, loser := result[0], result[1]
+			tournament.Matches = append(tournament.Matches, entities.TournamentMatch{
+				Bracket:       entities.TournamentBracketSwiss,
+				Round:         round,
+				Position:      i + 1,
+				TeamAID:       &winner,
+				TeamBID:       &loser,
+				WinnerTeamID:  &winner,
+				Status:        entities.TournamentMatchStatusFinished,
+				VetoSessionID: &winner,
+			})
+		}
+	}
+
+	first := swissFirstRound(tournament, tournament.Teams)
+	if len(first) != 2 || *first[0].TeamAID != 1 || *first[0].TeamBID != 3 || *first[1].TeamAID != 2 || *first[1].TeamBID != 4 {
+		t.Fatalf("expected 1-3 and 2-4 in the first round")
+	}
+	play(1, [][2]uint{{1, 3}, {2, 4}})
+
+	second := swissNextRound(tournament, 2)
+	if len(second) != 2 || *second[0].TeamAID != 1 || *second[0].TeamBID != 2 {
+		t.Fatalf("expected leaders 1-2 to meet in the second round")
+	}
+	play(2, [][2]uint{{1, 2}, {3, 4}})
+
+	// Соседи по таблице уже встречались: 1 играет с 4, 2 с 3
+	third := swissNextRound(tournament, 3)
+	if len(third) != 2 || *third[0].TeamAID != 1 || *third[0].TeamBID != 4 || *third[1].TeamAID != 2 || *third[1].TeamBID != 3 {
+		t.Fatalf("expected 1-4 and 2-3 in the third round, got %v-%v and %v-%v",
+			*third[0].TeamAID, *third[0].TeamBID, *third[1].TeamAID, *third[1].TeamBID)
+	}
+
+	standings := swissStandings(tournament)
+	if standings[0].TeamID != 1 || standings[0].Wins != 2 {
+		t.Errorf("expected team 1 to lead with 2 wins, got %+v", standings[0])
+	}
+}
+
+func TestSwissNextRound_Bye(t *testing.T) {
+	tournament := &entities.Tournament{Format: entities.TournamentFormatSwiss, Teams: testTeams(3)}
+
+	first := swissFirstRound(tournament, tournament.Teams)
+	if len(first) != 2 || first[1].TeamBID != nil || *first[1].TeamAID != 3 {
+		t.Fatalf("expected the last seed to get a bye in the first round")
+	}
+}
@@ -0,0 +1,140 @@
+package tournament
+
+import (
+	"strings"
+
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+	"github.com/bbp/backend/internal/usecase/veto"
+)
+
+type CreateTournamentUseCase struct {
+	tournamentRepo repositories.TournamentRepository
+	gameRepo       repositories.GameRepository
+	mapPoolRepo    repositories.MapPoolRepository
+	logicService   *veto.VetoLogicService
+}
+
+type CreateTournamentInput struct {
+	OwnerID       uint
+	Name          string
+	GameID        uint
+	MapPoolID     uint
+	Format        entities.TournamentFormat
+	MaxTeams      int
+	SwissRounds   int                            // Только для швейцарской системы; 0 - по количеству команд
+	FinalVetoType entities.VetoType              // Только для сетки на выбывание; по умолчанию Bo5
+	StageRules    []entities.TournamentStageRule // Ограничения пула карт по стадиям (опционально)
+}
+
+type CreateTournamentOutput struct {
+	Tournament *entities.Tournament
+}
+
+func NewCreateTournamentUseCase(
+	tournamentRepo repositories.TournamentRepository,
+	gameRepo repositories.GameRepository,
+	mapPoolRepo repositories.MapPoolRepository,
+	logicService *veto.VetoLogicService,
+) *CreateTournamentUseCase {
+	return &CreateTournamentUseCase{
+		tournamentRepo: tournamentRepo,
+		gameRepo:       gameRepo,
+		mapPoolRepo:    mapPoolRepo,
+		logicService:   logicService,
+	}
+}
+
+func (uc *CreateTournamentUseCase) Execute(input CreateTournamentInput) (*CreateTournamentOutput, error) {
+	tournament := &entities.Tournament{
+		OwnerID:    input.OwnerID,
+		Name:       strings.TrimSpace(input.Name),
+		GameID:     input.GameID,
+		MapPoolID:  input.MapPoolID,
+		Format:     input.Format,
+		Status:     entities.TournamentStatusRegistration,
+		MaxTeams:   input.MaxTeams,
+		StageRules: input.StageRules,
+	}
+	if input.Format == entities.TournamentFormatSwiss {
+		tournament.SwissRounds = input.SwissRounds
+	} else {
+		tournament.FinalVetoType = input.FinalVetoType
+		if tournament.FinalVetoType == "" {
+			tournament.FinalVetoType = entities.VetoTypeBo5
+		}
+	}
+	if tournament.MaxTeams == 0 {
+		tournament.MaxTeams = 16 // По умолчанию
+	}
+	if err := tournament.Validate(); err != nil {
+		return nil, ErrInvalidTournament
+	}
+
+	game, err := uc.gameRepo.GetByID(input.GameID)
+	if err != nil {
+		return nil, err
+	}
+	if game == nil {
+		return nil, ErrGameNotFound
+	}
+
+	// Матчи создаются от имени организатора, поэтому пул должен быть ему доступен
+	mapPool, err := uc.mapPoolRepo.GetByID(input.MapPoolID)
+	if err != nil {
+		return nil, err
+	}
+	if mapPool == nil || !mapPool.IsAccessibleBy(&input.OwnerID) {
+		return nil, ErrMapPoolNotFound
+	}
+	if mapPool.GameID != input.GameID {
+		return nil, ErrInvalidTournament
+	}
+
+	// Пула должно хватать на матчи каждой стадии; финал проверяется отдельно,
+	// чтобы организатор знал, что достаточно выбрать более короткий формат финала
+	if err := uc.logicService.CheckPoolSize(stageVetoType(tournament, false), len(mapPool.Maps)); err != nil {
+		return nil, ErrPoolTooSmall
+	}
+	if err := uc.logicService.CheckPoolSize(stageVetoType(tournament, true), len(mapPool.Maps)); err != nil {
+		return nil, ErrPoolTooSmallForFinal
+	}
+	if err := uc.checkStageRules(tournament, mapPool); err != nil {
+		return nil, err
+	}
+
+	if err := uc.tournamentRepo.Create(tournament); err != nil {
+		return nil, err
+	}
+
+	return &CreateTournamentOutput{
+		Tournament: tournament,
+	}, nil
+}
+
+// checkStageRules проверяет, что правила заданы для стадий формата турнира не больше одного раза
+// и что исключенных карт и десайдера пул выдерживает в формате матчей стадии
+func (uc *CreateTournamentUseCase) checkStageRules(tournament *entities.Tournament, mapPool *entities.MapPool) error {
+	seen := make(map[entities.TournamentStage]bool)
+	for _, rule := range tournament.StageRules {
+		if !hasStage(tournament.Format, rule.Stage) || seen[rule.Stage] {
+			return ErrInvalidStageRules
+		}
+		seen[rule.Stage] = true
+
+		err := uc.logicService.CheckConstraints(stageRuleVetoType(tournament, rule.Stage), mapPool, &entities.VetoConstraints{
+			ExcludedMapIDs: rule.ExcludedMapIDs,
+			DeciderMapID:   rule.DeciderMapID,
+		})
+		switch err {
+		case nil:
+		case veto.ErrPoolTooSmall:
+			return ErrPoolTooSmall
+		case veto.ErrInvalidConstraints:
+			return ErrInvalidStageRules
+		default:
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package tournament
+
+import (
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+)
+
+type DeleteTournamentUseCase struct {
+	tournamentRepo repositories.TournamentRepository
+}
+
+type DeleteTournamentInput struct {
+	TournamentID uint
+	UserID       uint
+}
+
+func NewDeleteTournamentUseCase(tournamentRepo repositories.TournamentRepository) *DeleteTournamentUseCase {
+	return &DeleteTournamentUseCase{
+		tournamentRepo: tournamentRepo,
+	}
+}
+
+func (uc *DeleteTournamentUseCase) Execute(input DeleteTournamentInput) error {
+	tournament, err := loadOwnTournament(uc.tournamentRepo, input.TournamentID, input.UserID)
+	if err != nil {
+		return err
+	}
+
+	// Начатый турнир уже создал комнаты и сессии матчей, его сетка остается в истории
+	if tournament.Status != entities.TournamentStatusRegistration {
+		return ErrRegistrationClosed
+	}
+
+	return uc.tournamentRepo.Delete(tournament.ID)
+}
@@ -0,0 +1,23 @@
+package tournament
+
+import "errors"
+
+var (
+	ErrTournamentNotFound   = errors.New("tournament not found")
+	ErrInvalidTournament    = errors.New("invalid tournament")
+	ErrUnauthorized         = errors.New("unauthorized")
+	ErrGameNotFound         = errors.New("game not found")
+	ErrMapPoolNotFound      = errors.New("map pool not found")
+	ErrPoolTooSmall         = errors.New("map pool is too small for the tournament formats")
+	ErrPoolTooSmallForFinal = errors.New("map pool is too small for the final format")
+	ErrTeamNotFound         = errors.New("team not found")
+	ErrRegistrationClosed   = errors.New("tournament registration is closed")
+	ErrTournamentFull       = errors.New("tournament is full")
+	ErrAlreadyRegistered    = errors.New("team is already registered")
+	ErrTeamNotRegistered    = errors.New("team is not registered")
+	ErrInvalidSeeds         = errors.New("seeds must list every registered team once")
+	ErrNotEnoughTeams       = errors.New("not enough teams to start the tournament")
+	ErrMatchNotFound        = errors.New("tournament match not found")
+	ErrMatchFinished        = errors.New("tournament match is already finished")
+	ErrInvalidStageRules    = errors.New("invalid stage map rules")
+)
@@ -0,0 +1,58 @@
+package tournament
+
+import (
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+)
+
+type GetTournamentUseCase struct {
+	tournamentRepo repositories.TournamentRepository
+}
+
+type GetTournamentOutput struct {
+	Tournament *entities.Tournament
+	Standings  []entities.TournamentStanding // Таблица швейцарской системы
+}
+
+func NewGetTournamentUseCase(tournamentRepo repositories.TournamentRepository) *GetTournamentUseCase {
+	return &GetTournamentUseCase{
+		tournamentRepo: tournamentRepo,
+	}
+}
+
+func (uc *GetTournamentUseCase) Execute(tournamentID uint) (*GetTournamentOutput, error) {
+	tournament, err := loadTournament(uc.tournamentRepo, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &GetTournamentOutput{Tournament: tournament}
+	if tournament.Format == entities.TournamentFormatSwiss && tournament.Status != entities.TournamentStatusRegistration {
+		output.Standings = swissStandings(tournament)
+	}
+	return output, nil
+}
+
+// loadTournament загружает турнир с командами и матчами
+func loadTournament(tournamentRepo repositories.TournamentRepository, tournamentID uint) (*entities.Tournament, error) {
+	tournament, err := tournamentRepo.GetByID(tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	if tournament == nil {
+		return nil, ErrTournamentNotFound
+	}
+	return tournament, nil
+}
+
+// loadOwnTournament загружает турнир и проверяет, что пользователь - его организатор
+func loadOwnTournament(tournamentRepo repositories.TournamentRepository, tournamentID, userID uint) (*entities.Tournament, error) {
+	tournament, err := loadTournament(tournamentRepo, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	if !tournament.IsOwner(userID) {
+		return nil, ErrUnauthorized
+	}
+	return tournament, nil
+}
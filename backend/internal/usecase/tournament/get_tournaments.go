@@ -0,0 +1,55 @@
+package tournament
+
+import (
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+)
+
+type GetTournamentsUseCase struct {
+	tournamentRepo repositories.TournamentRepository
+}
+
+type GetTournamentsInput struct {
+	Status *entities.TournamentStatus // Опционально
+	Limit  int
+	Offset int
+}
+
+type GetTournamentsOutput struct {
+	Tournaments []entities.Tournament
+	Total       int64
+}
+
+func NewGetTournamentsUseCase(tournamentRepo repositories.TournamentRepository) *GetTournamentsUseCase {
+	return &GetTournamentsUseCase{
+		tournamentRepo: tournamentRepo,
+	}
+}
+
+func (uc *GetTournamentsUseCase) Execute(input GetTournamentsInput) (*GetTournamentsOutput, error) {
+	limit := input.Limit
+	if limit <= 0 {
+		limit = 20 // По умолчанию
+	}
+	if limit > 100 {
+		limit = 100 // Максимум
+	}
+	offset := input.Offset
+	if offset < 0 {
+		offset = 0
+	}
+
+	tournaments, err := uc.tournamentRepo.GetList(input.Status, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	total, err := uc.tournamentRepo.Count(input.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetTournamentsOutput{
+		Tournaments: tournaments,
+		Total:       total,
+	}, nil
+}
@@ -0,0 +1,213 @@
+package tournament
+
+import (
+	"fmt"
+
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+	"github.com/bbp/backend/internal/usecase/room"
+)
+
+// bracketProgress продвигает сетку: проводит команды без соперника дальше,
+// создает комнаты и сессии вето для матчей с известными командами,
+// добавляет раунды швейцарской системы и определяет победителя турнира
+type bracketProgress struct {
+	tournamentRepo         repositories.TournamentRepository
+	createMatchRoomUseCase *room.CreateMatchRoomUseCase
+	stageConstraints       *StageConstraintsService
+}
+
+// advance доводит сетку до состояния, в котором каждый незавершенный матч ждет игры
+func (p *bracketProgress) advance(t *entities.Tournament) error {
+	for {
+		changed := false
+		for i := range t.Matches {
+			match := &t.Matches[i]
+			if match.Status != entities.TournamentMatchStatusPending || awaitsTeams(t, match) {
+				continue
+			}
+
+			if match.TeamAID != nil && match.TeamBID != nil {
+				if err := p.provision(t, match); err != nil {
+					return err
+				}
+				continue
+			}
+
+			// Соперника не будет: команда проходит дальше без игры, пустой матч просто закрывается
+			winner := match.TeamAID
+			if winner == nil {
+				winner = match.TeamBID
+			}
+			if err := p.finish(t, match, winner); err != nil {
+				return err
+			}
+			changed = true
+		}
+
+		if !changed {
+			added, err := p.nextSwissRound(t)
+			if err != nil {
+				return err
+			}
+			if !added {
+				break
+			}
+		}
+	}
+
+	return p.updateWinner(t)
+}
+
+// finish завершает матч и переводит победителя и проигравшего в следующие матчи
+func (p *bracketProgress) finish(t *entities.Tournament, match *entities.TournamentMatch, winner *uint) error {
+	match.WinnerTeamID = winner
+	match.Status = entities.TournamentMatchStatusFinished
+	if err := p.tournamentRepo.UpdateMatch(match); err != nil {
+		return err
+	}
+
+	if err := p.moveTeam(t, match.NextMatchID, match.NextSlot, winner); err != nil {
+		return err
+	}
+	return p.moveTeam(t, match.LoserNextMatchID, match.LoserNextSlot, match.LoserTeamID())
+}
+
+// moveTeam ставит команду в слот следующего матча
+func (p *bracketProgress) moveTeam(t *entities.Tournament, matchID *uint, slot string, teamID *uint) error {
+	if matchID == nil || teamID == nil {
+		return nil
+	}
+	next := t.GetMatch(*matchID)
+	if next == nil {
+		return ErrMatchNotFound
+	}
+	next.SetSlot(slot, teamID)
+	return p.tournamentRepo.UpdateMatch(next)
+}
+
+// provision создает комнату и сессию вето матча с ограничениями пула его стадии
+func (p *bracketProgress) provision(t *entities.Tournament, match *entities.TournamentMatch) error {
+	constraints, err := p.stageConstraints.Build(t, match)
+	if err != nil {
+		return fmt.Errorf("failed to build map constraints for match %d: %w", match.ID, err)
+	}
+
+	output, err := p.createMatchRoomUseCase.Execute(room.CreateMatchRoomInput{
+		OwnerID:     t.OwnerID,
+		Name:        fmt.Sprintf("%s: %s", t.Name, matchLabel(match)),
+		GameID:      t.GameID,
+		MapPoolID:   t.MapPoolID,
+		VetoType:    match.VetoType,
+		TeamAID:     *match.TeamAID,
+		TeamBID:     *match.TeamBID,
+		Constraints: constraints,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to provision match %d: %w", match.ID, err)
+	}
+
+	match.RoomID = &output.Room.ID
+	match.VetoSessionID = &output.Session.ID
+	match.Status = entities.TournamentMatchStatusReady
+	return p.tournamentRepo.UpdateMatch(match)
+}
+
+// nextSwissRound добавляет следующий раунд швейцарской системы, когда текущий полностью сыгран
+func (p *bracketProgress) nextSwissRound(t *entities.Tournament) (bool, error) {
+	if t.Format != entities.TournamentFormatSwiss {
+		return false, nil
+	}
+
+	round := 0
+	for _, match := range t.Matches {
+		if !match.IsFinished() {
+			return false, nil
+		}
+		if match.Round > round {
+			round = match.Round
+		}
+	}
+	if round >= swissRoundCount(t) {
+		return false, nil
+	}
+
+	matches := swissNextRound(t, round+1)
+	if err := p.tournamentRepo.CreateMatches(matches); err != nil {
+		return false, err
+	}
+	for _, match := range matches {
+		t.Matches = append(t.Matches, *match)
+	}
+	return true, nil
+}
+
+// updateWinner завершает турнир, когда сыгран финал или последний раунд швейцарской системы
+// Победитель пересчитывается и при исправлении результата уже завершенного турнира
+func (p *bracketProgress) updateWinner(t *entities.Tournament) error {
+	var winner *uint
+	switch t.Format {
+	case entities.TournamentFormatSwiss:
+		for _, match := range t.Matches {
+			if !match.IsFinished() {
+				return nil
+			}
+		}
+		standings := swissStandings(t)
+		winner = &standings[0].TeamID
+	default:
+		final := finalMatch(t)
+		if final == nil || !final.IsFinished() {
+			return nil
+		}
+		winner = final.WinnerTeamID
+	}
+
+	if t.Status == entities.TournamentStatusFinished && t.WinnerTeamID != nil && winner != nil && *t.WinnerTeamID == *winner {
+		return nil
+	}
+	t.Status = entities.TournamentStatusFinished
+	t.WinnerTeamID = winner
+	return p.tournamentRepo.Update(t)
+}
+
+// awaitsTeams проверяет, ждет ли матч команд из еще не сыгранных матчей
+func awaitsTeams(t *entities.Tournament, match *entities.TournamentMatch) bool {
+	for _, feeder := range t.Matches {
+		if feeder.IsFinished() {
+			continue
+		}
+		if (feeder.NextMatchID != nil && *feeder.NextMatchID == match.ID) ||
+			(feeder.LoserNextMatchID != nil && *feeder.LoserNextMatchID == match.ID) {
+			return true
+		}
+	}
+	return false
+}
+
+// finalMatch возвращает последний матч сетки на выбывание: финал или гранд-финал
+func finalMatch(t *entities.Tournament) *entities.TournamentMatch {
+	for i := range t.Matches {
+		if t.Matches[i].NextMatchID == nil && t.Matches[i].Bracket != entities.TournamentBracketSwiss {
+			return &t.Matches[i]
+		}
+	}
+	return nil
+}
+
+// matchLabel возвращает название матча для комнаты
+func matchLabel(match *entities.TournamentMatch) string {
+	switch match.Bracket {
+	case entities.TournamentBracketGrandFinal:
+		return "Grand final"
+	case entities.TournamentBracketLower:
+		return fmt.Sprintf("Lower bracket round %d, match %d", match.Round, match.Position)
+	case entities.TournamentBracketSwiss:
+		return fmt.Sprintf("Swiss round %d, match %d", match.Round, match.Position)
+	default:
+		if match.NextMatchID == nil {
+			return "Final"
+		}
+		return fmt.Sprintf("Round %d, match %d", match.Round, match.Position)
+	}
+}
@@ -0,0 +1,81 @@
+package tournament
+
+import (
+	"time"
+
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+)
+
+type RegisterTeamUseCase struct {
+	tournamentRepo repositories.TournamentRepository
+	teamRepo       repositories.TeamRepository
+}
+
+type RegisterTeamInput struct {
+	TournamentID uint
+	TeamID       uint
+	UserID       uint // Регистрирует капитан команды
+}
+
+type RegisterTeamOutput struct {
+	Tournament *entities.Tournament
+}
+
+func NewRegisterTeamUseCase(
+	tournamentRepo repositories.TournamentRepository,
+	teamRepo repositories.TeamRepository,
+) *RegisterTeamUseCase {
+	return &RegisterTeamUseCase{
+		tournamentRepo: tournamentRepo,
+		teamRepo:       teamRepo,
+	}
+}
+
+func (uc *RegisterTeamUseCase) Execute(input RegisterTeamInput) (*RegisterTeamOutput, error) {
+	tournament, err := loadTournament(uc.tournamentRepo, input.TournamentID)
+	if err != nil {
+		return nil, err
+	}
+	if tournament.Status != entities.TournamentStatusRegistration {
+		return nil, ErrRegistrationClosed
+	}
+
+	team, err := uc.teamRepo.GetByID(input.TeamID)
+	if err != nil {
+		return nil, err
+	}
+	if team == nil {
+		return nil, ErrTeamNotFound
+	}
+	if !team.IsCaptain(input.UserID) {
+		return nil, ErrUnauthorized
+	}
+
+	if tournament.GetTeam(team.ID) != nil {
+		return nil, ErrAlreadyRegistered
+	}
+	if len(tournament.Teams) >= tournament.MaxTeams {
+		return nil, ErrTournamentFull
+	}
+
+	// Посев по умолчанию - порядок регистрации, организатор может изменить его до старта
+	registration := &entities.TournamentTeam{
+		TournamentID: tournament.ID,
+		TeamID:       team.ID,
+		Seed:         len(tournament.Teams) + 1,
+		RegisteredAt: time.Now(),
+	}
+	if err := uc.tournamentRepo.AddTeam(registration); err != nil {
+		return nil, err
+	}
+
+	tournament, err = loadTournament(uc.tournamentRepo, tournament.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RegisterTeamOutput{
+		Tournament: tournament,
+	}, nil
+}
@@ -0,0 +1,53 @@
+package tournament
+
+import (
+	"time"
+
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+)
+
+type ScheduleMatchUseCase struct {
+	tournamentRepo repositories.TournamentRepository
+}
+
+type ScheduleMatchInput struct {
+	TournamentID uint
+	MatchID      uint
+	UserID       uint
+	ScheduledAt  *time.Time // nil снимает время матча
+}
+
+type ScheduleMatchOutput struct {
+	Match *entities.TournamentMatch
+}
+
+func NewScheduleMatchUseCase(tournamentRepo repositories.TournamentRepository) *ScheduleMatchUseCase {
+	return &ScheduleMatchUseCase{
+		tournamentRepo: tournamentRepo,
+	}
+}
+
+func (uc *ScheduleMatchUseCase) Execute(input ScheduleMatchInput) (*ScheduleMatchOutput, error) {
+	tournament, err := loadOwnTournament(uc.tournamentRepo, input.TournamentID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	match := tournament.GetMatch(input.MatchID)
+	if match == nil {
+		return nil, ErrMatchNotFound
+	}
+	if match.IsFinished() {
+		return nil, ErrMatchFinished
+	}
+
+	match.ScheduledAt = input.ScheduledAt
+	if err := uc.tournamentRepo.UpdateMatch(match); err != nil {
+		return nil, err
+	}
+
+	return &ScheduleMatchOutput{
+		Match: match,
+	}, nil
+}
@@ -0,0 +1,64 @@
+package tournament
+
+import (
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+)
+
+type SeedTeamsUseCase struct {
+	tournamentRepo repositories.TournamentRepository
+}
+
+type SeedTeamsInput struct {
+	TournamentID uint
+	UserID       uint
+	TeamIDs      []uint // Все зарегистрированные команды от первого посева к последнему
+}
+
+type SeedTeamsOutput struct {
+	Tournament *entities.Tournament
+}
+
+func NewSeedTeamsUseCase(tournamentRepo repositories.TournamentRepository) *SeedTeamsUseCase {
+	return &SeedTeamsUseCase{
+		tournamentRepo: tournamentRepo,
+	}
+}
+
+func (uc *SeedTeamsUseCase) Execute(input SeedTeamsInput) (*SeedTeamsOutput, error) {
+	tournament, err := loadOwnTournament(uc.tournamentRepo, input.TournamentID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if tournament.Status != entities.TournamentStatusRegistration {
+		return nil, ErrRegistrationClosed
+	}
+
+	if len(input.TeamIDs) != len(tournament.Teams) {
+		return nil, ErrInvalidSeeds
+	}
+	seeded := make(map[uint]bool, len(input.TeamIDs))
+	teams := make([]entities.TournamentTeam, len(input.TeamIDs))
+	for i, teamID := range input.TeamIDs {
+		registered := tournament.GetTeam(teamID)
+		if registered == nil || seeded[teamID] {
+			return nil, ErrInvalidSeeds
+		}
+		seeded[teamID] = true
+		teams[i] = *registered
+		teams[i].Seed = i + 1
+	}
+
+	if err := uc.tournamentRepo.UpdateSeeds(tournament.ID, teams); err != nil {
+		return nil, err
+	}
+
+	tournament, err = loadTournament(uc.tournamentRepo, tournament.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SeedTeamsOutput{
+		Tournament: tournament,
+	}, nil
+}
@@ -0,0 +1,175 @@
+package tournament
+
+import (
+	"sort"
+	"time"
+
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+	"github.com/bbp/backend/internal/usecase/veto"
+)
+
+// StageConstraintsService собирает ограничения пула карт матча по правилам его стадии
+// и по сериям, которые команды уже сыграли на этой стадии
+type StageConstraintsService struct {
+	sessionRepo     repositories.VetoSessionRepository
+	matchResultRepo repositories.MatchResultRepository
+	mapPoolRepo     repositories.MapPoolRepository
+	logicService    *veto.VetoLogicService
+}
+
+func NewStageConstraintsService(
+	sessionRepo repositories.VetoSessionRepository,
+	matchResultRepo repositories.MatchResultRepository,
+	mapPoolRepo repositories.MapPoolRepository,
+	logicService *veto.VetoLogicService,
+) *StageConstraintsService {
+	return &StageConstraintsService{
+		sessionRepo:     sessionRepo,
+		matchResultRepo: matchResultRepo,
+		mapPoolRepo:     mapPoolRepo,
+		logicService:    logicService,
+	}
+}
+
+// playedSeries карты сыгранной командой серии
+type playedSeries struct {
+	finishedAt time.Time
+	played     []uint // Карты с результатом, а без результата - пики и десайдер
+	banned     []uint
+}
+
+// Build возвращает ограничения для матча с известными командами или nil, если у стадии нет правил
+// Перенесенные баны, которым не хватает места в пуле, отбрасываются начиная с последних
+func (s *StageConstraintsService) Build(t *entities.Tournament, match *entities.TournamentMatch) (*entities.VetoConstraints, error) {
+	rule := t.StageRule(match.Stage())
+	if rule == nil {
+		return nil, nil
+	}
+
+	constraints := &entities.VetoConstraints{
+		ExcludedMapIDs: append([]uint{}, rule.ExcludedMapIDs...),
+		DeciderMapID:   rule.DeciderMapID,
+	}
+	if !rule.NoRepeatPicks && !rule.CarryOverBans {
+		return constraints, nil
+	}
+
+	mapPool, err := s.mapPoolRepo.GetByID(t.MapPoolID)
+	if err != nil {
+		return nil, err
+	}
+	if mapPool == nil {
+		return nil, ErrMapPoolNotFound
+	}
+	inPool := make(map[uint]bool, len(mapPool.Maps))
+	for _, m := range mapPool.Maps {
+		inPool[m.ID] = true
+	}
+
+	var carried []uint
+	for _, slot := range []string{"A", "B"} {
+		teamID := match.Slot(slot)
+		if teamID == nil {
+			continue
+		}
+		history, err := s.stageHistory(t, match, *teamID)
+		if err != nil {
+			return nil, err
+		}
+
+		if rule.NoRepeatPicks {
+			var excluded []uint
+			for _, series := range history {
+				for _, mapID := range series.played {
+					if inPool[mapID] {
+						excluded = appendUnique(excluded, mapID)
+					}
+				}
+			}
+			if slot == "A" {
+				constraints.TeamAExcludedMapIDs = excluded
+			} else {
+				constraints.TeamBExcludedMapIDs = excluded
+			}
+		}
+
+		if rule.CarryOverBans && len(history) > 0 {
+			for _, mapID := range history[len(history)-1].banned {
+				if inPool[mapID] && !constraints.IsExcluded(mapID) {
+					carried = appendUnique(carried, mapID)
+				}
+			}
+		}
+	}
+
+	base := constraints.ExcludedMapIDs
+	for {
+		constraints.ExcludedMapIDs = append(append([]uint{}, base...), carried...)
+		err := s.logicService.CheckConstraints(match.VetoType, mapPool, constraints)
+		if err != veto.ErrPoolTooSmall || len(carried) == 0 {
+			return constraints, err
+		}
+		carried = carried[:len(carried)-1]
+	}
+}
+
+// stageHistory возвращает сыгранные командой серии стадии матча от первой к последней
+func (s *StageConstraintsService) stageHistory(t *entities.Tournament, match *entities.TournamentMatch, teamID uint) ([]playedSeries, error) {
+	var history []playedSeries
+	for _, previous := range t.Matches {
+		if previous.ID == match.ID || previous.Stage() != match.Stage() ||
+			previous.IsBye() || !previous.IsFinished() || !previous.HasTeam(teamID) {
+			continue
+		}
+
+		session, err := s.sessionRepo.GetByID(*previous.VetoSessionID)
+		if err != nil {
+			return nil, err
+		}
+		if session == nil {
+			continue
+		}
+		series := playedSeries{finishedAt: session.CreatedAt}
+		if session.FinishedAt != nil {
+			series.finishedAt = *session.FinishedAt
+		}
+
+		result, err := s.matchResultRepo.GetBySessionID(session.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, action := range session.Actions {
+			switch action.ActionType {
+			case entities.VetoActionTypeBan:
+				series.banned = append(series.banned, action.MapID)
+			case entities.VetoActionTypePick:
+				if result == nil {
+					series.played = append(series.played, action.MapID)
+				}
+			}
+		}
+		if result != nil {
+			for _, m := range result.Maps {
+				series.played = append(series.played, m.MapID)
+			}
+		} else if session.SelectedMapID != nil {
+			series.played = append(series.played, *session.SelectedMapID)
+		}
+		history = append(history, series)
+	}
+
+	sort.SliceStable(history, func(i, j int) bool {
+		return history[i].finishedAt.Before(history[j].finishedAt)
+	})
+	return history, nil
+}
+
+func appendUnique(values []uint, value uint) []uint {
+	for _, v := range values {
+		if v == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
@@ -0,0 +1,89 @@
+package tournament
+
+import (
+	"time"
+
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+	"github.com/bbp/backend/internal/usecase/room"
+)
+
+type StartTournamentUseCase struct {
+	tournamentRepo repositories.TournamentRepository
+	progress       *bracketProgress
+}
+
+type StartTournamentInput struct {
+	TournamentID uint
+	UserID       uint
+}
+
+type StartTournamentOutput struct {
+	Tournament *entities.Tournament
+}
+
+func NewStartTournamentUseCase(
+	tournamentRepo repositories.TournamentRepository,
+	createMatchRoomUseCase *room.CreateMatchRoomUseCase,
+	stageConstraints *StageConstraintsService,
+) *StartTournamentUseCase {
+	return &StartTournamentUseCase{
+		tournamentRepo: tournamentRepo,
+		progress: &bracketProgress{
+			tournamentRepo:         tournamentRepo,
+			createMatchRoomUseCase: createMatchRoomUseCase,
+			stageConstraints:       stageConstraints,
+		},
+	}
+}
+
+func (uc *StartTournamentUseCase) Execute(input StartTournamentInput) (*StartTournamentOutput, error) {
+	tournament, err := loadOwnTournament(uc.tournamentRepo, input.TournamentID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if tournament.Status != entities.TournamentStatusRegistration {
+		return nil, ErrRegistrationClosed
+	}
+	if len(tournament.Teams) < minTeams(tournament.Format) {
+		return nil, ErrNotEnoughTeams
+	}
+
+	// Регистрация закрывается, сетка строится по посеву
+	var matches []*entities.TournamentMatch
+	switch tournament.Format {
+	case entities.TournamentFormatSingleElimination:
+		matches = singleElimination(tournament, tournament.Teams)
+	case entities.TournamentFormatDoubleElimination:
+		matches = doubleElimination(tournament, tournament.Teams)
+	default:
+		matches = swissFirstRound(tournament, tournament.Teams)
+	}
+	if err := uc.tournamentRepo.CreateMatches(matches); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	tournament.Status = entities.TournamentStatusInProgress
+	tournament.StartedAt = &now
+	if err := uc.tournamentRepo.Update(tournament); err != nil {
+		return nil, err
+	}
+
+	// Команды без соперника проходят дальше, для остальных матчей создаются комнаты
+	if tournament, err = loadTournament(uc.tournamentRepo, tournament.ID); err != nil {
+		return nil, err
+	}
+	if err := uc.progress.advance(tournament); err != nil {
+		return nil, err
+	}
+
+	tournament, err = loadTournament(uc.tournamentRepo, tournament.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StartTournamentOutput{
+		Tournament: tournament,
+	}, nil
+}
@@ -0,0 +1,111 @@
+package tournament
+
+import (
+	"sort"
+
+	"github.com/bbp/backend/internal/domain/entities"
+)
+
+// swissStandings считает таблицу по завершенным матчам швейцарской системы
+// Порядок: победы, затем коэффициент Бухгольца (сумма побед соперников), затем посев
+func swissStandings(t *entities.Tournament) []entities.TournamentStanding {
+	standings := make([]entities.TournamentStanding, len(t.Teams))
+	index := make(map[uint]int, len(t.Teams))
+	for i, team := range t.Teams {
+		standings[i] = entities.TournamentStanding{TeamID: team.TeamID, Seed: team.Seed}
+		index[team.TeamID] = i
+	}
+
+	opponents := make(map[uint][]uint)
+	for _, match := range t.Matches {
+		if match.Bracket != entities.TournamentBracketSwiss || !match.IsFinished() || match.WinnerTeamID == nil {
+			continue
+		}
+		if i, ok := index[*match.WinnerTeamID]; ok {
+			standings[i].Wins++
+			if match.IsBye() {
+				standings[i].Byes++
+			}
+		}
+		if loser := match.LoserTeamID(); loser != nil {
+			if i, ok := index[*loser]; ok {
+				standings[i].Losses++
+			}
+			opponents[*match.WinnerTeamID] = append(opponents[*match.WinnerTeamID], *loser)
+			opponents[*loser] = append(opponents[*loser], *match.WinnerTeamID)
+		}
+	}
+
+	for i := range standings {
+		for _, opponent := range opponents[standings[i].TeamID] {
+			if j, ok := index[opponent]; ok {
+				standings[i].Buchholz += standings[j].Wins
+			}
+		}
+	}
+
+	sort.SliceStable(standings, func(i, j int) bool {
+		a, b := standings[i], standings[j]
+		if a.Wins != b.Wins {
+			return a.Wins > b.Wins
+		}
+		if a.Buchholz != b.Buchholz {
+			return a.Buchholz > b.Buchholz
+		}
+		return a.Seed < b.Seed
+	})
+	return standings
+}
+
+// swissNextRound строит следующий раунд: команды играют с соседями по таблице, с кем еще не встречались
+// Пропуск раунда получает команда с конца таблицы, у которой его еще не было
+func swissNextRound(t *entities.Tournament, round int) []*entities.TournamentMatch {
+	standings := swissStandings(t)
+
+	played := make(map[[2]uint]bool)
+	for _, match := range t.Matches {
+		if match.Bracket == entities.TournamentBracketSwiss && match.TeamAID != nil && match.TeamBID != nil {
+			played[[2]uint{*match.TeamAID, *match.TeamBID}] = true
+			played[[2]uint{*match.TeamBID, *match.TeamAID}] = true
+		}
+	}
+
+	var bye *uint
+	if len(standings)%2 == 1 {
+		pick := len(standings) - 1
+		for i := len(standings) - 1; i >= 0; i-- {
+			if standings[i].Byes == 0 {
+				pick = i
+				break
+			}
+		}
+		teamID := standings[pick].TeamID
+		bye = &teamID
+		standings = append(standings[:pick:pick], standings[pick+1:]...)
+	}
+
+	paired := make([]bool, len(standings))
+	var pairs [][2]uint
+	for i := range standings {
+		if paired[i] {
+			continue
+		}
+		opponent := -1
+		for j := i + 1; j < len(standings); j++ {
+			if paired[j] {
+				continue
+			}
+			if opponent == -1 {
+				opponent = j // Повторная встреча, если других соперников не осталось
+			}
+			if !played[[2]uint{standings[i].TeamID, standings[j].TeamID}] {
+				opponent = j
+				break
+			}
+		}
+		paired[i], paired[opponent] = true, true
+		pairs = append(pairs, [2]uint{standings[i].TeamID, standings[opponent].TeamID})
+	}
+
+	return swissRound(t, round, pairs, bye)
+}
@@ -0,0 +1,80 @@
+package tournament
+
+import (
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+)
+
+type WithdrawTeamUseCase struct {
+	tournamentRepo repositories.TournamentRepository
+	teamRepo       repositories.TeamRepository
+}
+
+type WithdrawTeamInput struct {
+	TournamentID uint
+	TeamID       uint
+	UserID       uint // Капитан команды или организатор
+}
+
+type WithdrawTeamOutput struct {
+	Tournament *entities.Tournament
+}
+
+func NewWithdrawTeamUseCase(
+	tournamentRepo repositories.TournamentRepository,
+	teamRepo repositories.TeamRepository,
+) *WithdrawTeamUseCase {
+	return &WithdrawTeamUseCase{
+		tournamentRepo: tournamentRepo,
+		teamRepo:       teamRepo,
+	}
+}
+
+func (uc *WithdrawTeamUseCase) Execute(input WithdrawTeamInput) (*WithdrawTeamOutput, error) {
+	tournament, err := loadTournament(uc.tournamentRepo, input.TournamentID)
+	if err != nil {
+		return nil, err
+	}
+	if tournament.Status != entities.TournamentStatusRegistration {
+		return nil, ErrRegistrationClosed
+	}
+	if tournament.GetTeam(input.TeamID) == nil {
+		return nil, ErrTeamNotRegistered
+	}
+
+	if !tournament.IsOwner(input.UserID) {
+		team, err := uc.teamRepo.GetByID(input.TeamID)
+		if err != nil {
+			return nil, err
+		}
+		if team == nil || !team.IsCaptain(input.UserID) {
+			return nil, ErrUnauthorized
+		}
+	}
+
+	if err := uc.tournamentRepo.RemoveTeam(tournament.ID, input.TeamID); err != nil {
+		return nil, err
+	}
+
+	// Оставшиеся команды сохраняют порядок посева без пропусков
+	remaining := make([]entities.TournamentTeam, 0, len(tournament.Teams))
+	for _, team := range tournament.Teams {
+		if team.TeamID == input.TeamID {
+			continue
+		}
+		team.Seed = len(remaining) + 1
+		remaining = append(remaining, team)
+	}
+	if err := uc.tournamentRepo.UpdateSeeds(tournament.ID, remaining); err != nil {
+		return nil, err
+	}
+
+	tournament, err = loadTournament(uc.tournamentRepo, tournament.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &WithdrawTeamOutput{
+		Tournament: tournament,
+	}, nil
+}
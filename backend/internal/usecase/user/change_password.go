@@ -0,0 +1,45 @@
+package user
+
+import (
+	"github.com/bbp/backend/internal/domain/repositories"
+	"github.com/bbp/backend/pkg/password"
+)
+
+type ChangePasswordUseCase struct {
+	userRepo repositories.UserRepository
+}
+
+type ChangePasswordInput struct {
+	UserID          uint
+	CurrentPassword string
+	NewPassword     string
+}
+
+func NewChangePasswordUseCase(userRepo repositories.UserRepository) *ChangePasswordUseCase {
+	return &ChangePasswordUseCase{
+		userRepo: userRepo,
+	}
+}
+
+func (uc *ChangePasswordUseCase) Execute(input ChangePasswordInput) error {
+	user, err := uc.userRepo.GetByID(input.UserID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return ErrUserNotFound
+	}
+
+	// Смена пароля требует подтверждения текущим паролем
+	if !password.CheckPassword(user.Password, input.CurrentPassword) {
+		return ErrInvalidPassword
+	}
+
+	hashedPassword, err := password.HashPassword(input.NewPassword)
+	if err != nil {
+		return err
+	}
+	user.Password = hashedPassword
+
+	return uc.userRepo.Update(user)
+}
@@ -0,0 +1,54 @@
+package user
+
+import (
+	"time"
+
+	"github.com/bbp/backend/internal/domain/repositories"
+)
+
+// CleanupGuestsUseCase удаляет гостевые аккаунты, сессия которых истекла
+type CleanupGuestsUseCase struct {
+	userRepo             repositories.UserRepository
+	deleteAccountUseCase *DeleteAccountUseCase
+	sessionTTL           time.Duration
+}
+
+type CleanupGuestsInput struct {
+	Now time.Time
+}
+
+type CleanupGuestsOutput struct {
+	Deleted int // Количество удаленных гостей
+}
+
+func NewCleanupGuestsUseCase(
+	userRepo repositories.UserRepository,
+	deleteAccountUseCase *DeleteAccountUseCase,
+	sessionTTL time.Duration,
+) *CleanupGuestsUseCase {
+	return &CleanupGuestsUseCase{
+		userRepo:             userRepo,
+		deleteAccountUseCase: deleteAccountUseCase,
+		sessionTTL:           sessionTTL,
+	}
+}
+
+// Execute удаляет гостей, созданных раньше, чем sessionTTL назад: сессия гостя не продлевается,
+// поэтому войти в такой аккаунт уже нельзя. Комнаты гостя передаются другим участникам
+// или закрываются, как при удалении аккаунта; удаление идемпотентно, поэтому после ошибки
+// оставшиеся гости удаляются при следующем запуске
+func (uc *CleanupGuestsUseCase) Execute(input CleanupGuestsInput) (*CleanupGuestsOutput, error) {
+	guests, err := uc.userRepo.GetGuestsCreatedBefore(input.Now.Add(-uc.sessionTTL))
+	if err != nil {
+		return nil, err
+	}
+
+	output := &CleanupGuestsOutput{}
+	for _, guest := range guests {
+		if err := uc.deleteAccountUseCase.Execute(DeleteAccountInput{UserID: guest.ID}); err != nil {
+			return output, err
+		}
+		output.Deleted++
+	}
+	return output, nil
+}
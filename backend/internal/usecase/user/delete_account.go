@@ -0,0 +1,147 @@
+package user
+
+import (
+	"sort"
+	"time"
+
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+	"github.com/bbp/backend/internal/usecase/auth"
+	"github.com/bbp/backend/internal/usecase/team"
+	"github.com/bbp/backend/pkg/password"
+)
+
+type DeleteAccountUseCase struct {
+	userRepo         repositories.UserRepository
+	roomRepo         repositories.RoomRepository
+	mapPoolRepo      repositories.MapPoolRepository
+	vetoSessionRepo  repositories.VetoSessionRepository
+	teamRepo         repositories.TeamRepository
+	logoutAllUseCase *auth.LogoutAllUseCase
+}
+
+type DeleteAccountInput struct {
+	UserID          uint
+	Password        string
+	AccessJTI       string    // jti текущего access токена
+	AccessExpiresAt time.Time // Срок действия текущего access токена
+}
+
+func NewDeleteAccountUseCase(
+	userRepo repositories.UserRepository,
+	roomRepo repositories.RoomRepository,
+	mapPoolRepo repositories.MapPoolRepository,
+	vetoSessionRepo repositories.VetoSessionRepository,
+	teamRepo repositories.TeamRepository,
+	logoutAllUseCase *auth.LogoutAllUseCase,
+) *DeleteAccountUseCase {
+	return &DeleteAccountUseCase{
+		userRepo:         userRepo,
+		roomRepo:         roomRepo,
+		mapPoolRepo:      mapPoolRepo,
+		vetoSessionRepo:  vetoSessionRepo,
+		teamRepo:         teamRepo,
+		logoutAllUseCase: logoutAllUseCase,
+	}
+}
+
+// Execute удаляет аккаунт пользователя
+// Сессии вето остаются в истории без привязки к пользователю, свои пулы карт удаляются.
+// Шаги затрагивают разные репозитории и выполняются отдельными записями, поэтому каждый из них
+// идемпотентен: после ошибки повторный запрос продолжает с шага, на котором удаление остановилось.
+// Токены отзываются последними, чтобы повторить запрос можно было с тем же токеном.
+func (uc *DeleteAccountUseCase) Execute(input DeleteAccountInput) error {
+	user, err := uc.userRepo.GetByID(input.UserID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return ErrUserNotFound
+	}
+
+	// Пароль гостя сгенерирован сервером, подтвердить его гость не может
+	if !user.IsGuest && !password.CheckPassword(user.Password, input.Password) {
+		return ErrInvalidPassword
+	}
+
+	rooms, err := uc.roomRepo.GetByParticipantID(user.ID)
+	if err != nil {
+		return err
+	}
+	for i := range rooms {
+		if err := uc.leaveRoom(&rooms[i], user.ID); err != nil {
+			return err
+		}
+	}
+
+	// Капитанство переходит к другому участнику, команда без участников удаляется
+	teams, err := uc.teamRepo.GetByMemberID(user.ID)
+	if err != nil {
+		return err
+	}
+	for i := range teams {
+		if _, err := team.LeaveTeam(uc.teamRepo, &teams[i], user.ID); err != nil {
+			return err
+		}
+	}
+
+	pools, err := uc.mapPoolRepo.GetByUserID(user.ID)
+	if err != nil {
+		return err
+	}
+	for _, pool := range pools {
+		if err := uc.mapPoolRepo.Delete(pool.ID); err != nil {
+			return err
+		}
+	}
+
+	if err := uc.vetoSessionRepo.AnonymizeByUserID(user.ID); err != nil {
+		return err
+	}
+
+	// Завершаем сессии до удаления, чтобы выданные токены перестали действовать
+	if err := uc.logoutAllUseCase.Execute(auth.LogoutAllInput{
+		UserID:          user.ID,
+		AccessJTI:       input.AccessJTI,
+		AccessExpiresAt: input.AccessExpiresAt,
+	}); err != nil {
+		return err
+	}
+
+	return uc.userRepo.Delete(user.ID)
+}
+
+// leaveRoom убирает пользователя из комнаты
+// Свою комнату передает участнику, вошедшему раньше всех, если для игры остается минимум 2 участника,
+// иначе закрывает ее, как при выходе владельца.
+// Пользователь удаляется из участников последним: пока он в комнате, повторный вызов
+// находит ее и доводит передачу до конца тому же участнику
+func (uc *DeleteAccountUseCase) leaveRoom(room *entities.Room, userID uint) error {
+	remaining := make([]entities.RoomParticipant, 0, len(room.Participants))
+	for _, participant := range room.Participants {
+		if participant.UserID != userID {
+			remaining = append(remaining, participant)
+		}
+	}
+
+	if len(remaining) <= 1 {
+		return uc.roomRepo.Delete(room.ID)
+	}
+
+	if room.IsOwner(userID) {
+		sort.Slice(remaining, func(i, j int) bool {
+			return remaining[i].JoinedAt.Before(remaining[j].JoinedAt)
+		})
+		newOwner := remaining[0]
+
+		if err := uc.roomRepo.UpdateParticipantRole(room.ID, newOwner.UserID, entities.ParticipantRoleOwner); err != nil {
+			return err
+		}
+		room.OwnerID = newOwner.UserID
+		if err := uc.roomRepo.Update(room); err != nil {
+			return err
+		}
+	}
+
+	return uc.roomRepo.RemoveParticipant(room.ID, userID)
+}
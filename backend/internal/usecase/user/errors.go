@@ -6,4 +6,5 @@ var (
 	ErrUserNotFound          = errors.New("user not found")
 	ErrEmailAlreadyExists    = errors.New("email already exists")
 	ErrUsernameAlreadyExists = errors.New("username already exists")
+	ErrInvalidPassword       = errors.New("current password is incorrect")
 )
\ No newline at end of file
@@ -0,0 +1,91 @@
+package user
+
+import (
+	"time"
+
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+)
+
+type ExportDataUseCase struct {
+	userRepo        repositories.UserRepository
+	vetoSessionRepo repositories.VetoSessionRepository
+	roomRepo        repositories.RoomRepository
+	mapPoolRepo     repositories.MapPoolRepository
+	identityRepo    repositories.UserIdentityRepository
+	teamRepo        repositories.TeamRepository
+}
+
+// ExportDataOutput все данные, которые сервис хранит о пользователе
+type ExportDataOutput struct {
+	ExportedAt time.Time
+	User       *entities.User
+	Identities []entities.UserIdentity
+	Sessions   []entities.VetoSession
+	Rooms      []entities.Room
+	MapPools   []entities.MapPool
+	Teams      []entities.Team
+}
+
+func NewExportDataUseCase(
+	userRepo repositories.UserRepository,
+	vetoSessionRepo repositories.VetoSessionRepository,
+	roomRepo repositories.RoomRepository,
+	mapPoolRepo repositories.MapPoolRepository,
+	identityRepo repositories.UserIdentityRepository,
+	teamRepo repositories.TeamRepository,
+) *ExportDataUseCase {
+	return &ExportDataUseCase{
+		userRepo:        userRepo,
+		vetoSessionRepo: vetoSessionRepo,
+		roomRepo:        roomRepo,
+		mapPoolRepo:     mapPoolRepo,
+		identityRepo:    identityRepo,
+		teamRepo:        teamRepo,
+	}
+}
+
+func (uc *ExportDataUseCase) Execute(userID uint) (*ExportDataOutput, error) {
+	user, err := uc.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	identities, err := uc.identityRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := uc.vetoSessionRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	rooms, err := uc.roomRepo.GetByParticipantID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	pools, err := uc.mapPoolRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	teams, err := uc.teamRepo.GetByMemberID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ExportDataOutput{
+		ExportedAt: time.Now(),
+		User:       user,
+		Identities: identities,
+		Sessions:   sessions,
+		Rooms:      rooms,
+		MapPools:   pools,
+		Teams:      teams,
+	}, nil
+}
@@ -0,0 +1,58 @@
+package user
+
+import (
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+)
+
+// publicProfileMapLimit количество карт в топах банов и пиков
+const publicProfileMapLimit = 5
+
+type GetPublicProfileUseCase struct {
+	userRepo  repositories.UserRepository
+	statsRepo repositories.VetoStatsRepository
+}
+
+type GetPublicProfileInput struct {
+	Username string
+	ViewerID *uint // Авторизованный пользователь, если есть
+}
+
+type GetPublicProfileOutput struct {
+	User  *entities.User
+	Stats *entities.UserVetoStats // nil, если профиль скрыт
+}
+
+func NewGetPublicProfileUseCase(userRepo repositories.UserRepository, statsRepo repositories.VetoStatsRepository) *GetPublicProfileUseCase {
+	return &GetPublicProfileUseCase{
+		userRepo:  userRepo,
+		statsRepo: statsRepo,
+	}
+}
+
+func (uc *GetPublicProfileUseCase) Execute(input GetPublicProfileInput) (*GetPublicProfileOutput, error) {
+	user, err := uc.userRepo.GetByUsername(input.Username)
+	if err != nil {
+		return nil, err
+	}
+	// Гостевые аккаунты временные, публичного профиля у них нет
+	if user == nil || user.IsGuest {
+		return nil, ErrUserNotFound
+	}
+
+	// Скрытый профиль показывает статистику только владельцу
+	isOwner := input.ViewerID != nil && *input.ViewerID == user.ID
+	if !user.IsProfilePublic() && !isOwner {
+		return &GetPublicProfileOutput{User: user}, nil
+	}
+
+	stats, err := uc.statsRepo.GetUserStats(user.ID, publicProfileMapLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetPublicProfileOutput{
+		User:  user,
+		Stats: stats,
+	}, nil
+}
@@ -1,27 +1,41 @@
 package user
 
 import (
+	"log"
+
 	"github.com/bbp/backend/internal/domain/entities"
 	"github.com/bbp/backend/internal/domain/repositories"
+	"github.com/bbp/backend/internal/usecase/auth"
+	"github.com/bbp/backend/pkg/password"
 )
 
 type UpdateProfileUseCase struct {
-	userRepo repositories.UserRepository
+	userRepo       repositories.UserRepository
+	resetTokenRepo repositories.PasswordResetTokenRepository
+	emailVerifier  *auth.EmailVerifier
 }
 
 type UpdateProfileInput struct {
-	UserID   uint
-	Email    *string
-	Username *string
+	UserID            uint
+	Email             *string
+	Username          *string
+	ProfileVisibility *string
+	CurrentPassword   string // Обязателен при смене email
 }
 
 type UpdateProfileOutput struct {
 	User *entities.User
 }
 
-func NewUpdateProfileUseCase(userRepo repositories.UserRepository) *UpdateProfileUseCase {
+func NewUpdateProfileUseCase(
+	userRepo repositories.UserRepository,
+	resetTokenRepo repositories.PasswordResetTokenRepository,
+	emailVerifier *auth.EmailVerifier,
+) *UpdateProfileUseCase {
 	return &UpdateProfileUseCase{
-		userRepo: userRepo,
+		userRepo:       userRepo,
+		resetTokenRepo: resetTokenRepo,
+		emailVerifier:  emailVerifier,
 	}
 }
 
@@ -36,9 +50,15 @@ func (uc *UpdateProfileUseCase) Execute(input UpdateProfileInput) (*UpdateProfil
 	}
 
 	// Обновляем поля, если они указаны
+	emailChanged := false
 	if input.Email != nil {
 		// Проверяем уникальность email (если изменился)
 		if *input.Email != user.Email {
+			// Через email можно сбросить пароль, поэтому смена email, как и смена пароля,
+			// требует текущего пароля: одного украденного access токена для захвата аккаунта мало
+			if !password.CheckPassword(user.Password, input.CurrentPassword) {
+				return nil, ErrInvalidPassword
+			}
 			existingUser, err := uc.userRepo.GetByEmail(*input.Email)
 			if err != nil {
 				return nil, err
@@ -46,6 +66,9 @@ func (uc *UpdateProfileUseCase) Execute(input UpdateProfileInput) (*UpdateProfil
 			if existingUser != nil {
 				return nil, ErrEmailAlreadyExists
 			}
+			// Новый адрес требует повторного подтверждения
+			user.EmailVerified = false
+			emailChanged = true
 		}
 		user.Email = *input.Email
 	}
@@ -64,6 +87,10 @@ func (uc *UpdateProfileUseCase) Execute(input UpdateProfileInput) (*UpdateProfil
 		user.Username = *input.Username
 	}
 
+	if input.ProfileVisibility != nil {
+		user.ProfileVisibility = entities.ProfileVisibility(*input.ProfileVisibility)
+	}
+
 	// Валидируем пользователя
 	if err := user.Validate(); err != nil {
 		return nil, err
@@ -74,6 +101,16 @@ func (uc *UpdateProfileUseCase) Execute(input UpdateProfileInput) (*UpdateProfil
 		return nil, err
 	}
 
+	if emailChanged {
+		// Ссылки сброса пароля, отправленные на старый адрес, больше не действуют
+		if err := uc.resetTokenRepo.InvalidateByUserID(user.ID); err != nil {
+			return nil, err
+		}
+		if err := uc.emailVerifier.Send(user); err != nil {
+			log.Printf("Failed to send verification email to user %d: %v", user.ID, err)
+		}
+	}
+
 	return &UpdateProfileOutput{
 		User: user,
 	}, nil
@@ -57,14 +57,11 @@ func (uc *BanMapUseCase) Execute(input BanMapInput) (*BanMapOutput, error) {
 		return nil, ErrSessionFinished
 	}
 
-	// Получаем пул карт
-	mapPool, err := uc.mapPoolRepo.GetByID(session.MapPoolID)
+	// Получаем пул карт сессии
+	mapPool, err := loadSessionMapPool(session, uc.mapPoolRepo)
 	if err != nil {
 		return nil, err
 	}
-	if mapPool == nil {
-		return nil, ErrMapPoolNotFound
-	}
 
 	// Проверяем, что карта существует
 	mapEntity, err := uc.mapRepo.GetByID(input.MapID)
@@ -96,8 +93,13 @@ func (uc *BanMapUseCase) Execute(input BanMapInput) (*BanMapOutput, error) {
 		}
 	}
 
+	// Карты, исключенные ограничениями сессии, банить нельзя
+	if session.Constraints.IsExcluded(input.MapID) {
+		return nil, ErrMapExcluded
+	}
+
 	// Получаем доступные карты
-	availableMaps := uc.logicService.GetAvailableMaps(mapPool, session.Actions)
+	availableMaps := uc.logicService.GetAvailableMaps(mapPool, session.Actions, session.Constraints)
 
 	// Проверяем, что карта доступна (не забанена и не выбрана)
 	mapAvailable := false
@@ -145,7 +147,7 @@ func (uc *BanMapUseCase) Execute(input BanMapInput) (*BanMapOutput, error) {
 	}
 
 	// Проверяем, завершена ли сессия
-	availableMapsAfterBan := uc.logicService.GetAvailableMaps(mapPool, append(session.Actions, *action))
+	availableMapsAfterBan := uc.logicService.GetAvailableMaps(mapPool, append(session.Actions, *action), session.Constraints)
 	if uc.logicService.IsVetoFinished(session, append(session.Actions, *action), availableMapsAfterBan) {
 		// Для Bo1 автоматически выбираем последнюю карту
 		if session.Type == entities.VetoTypeBo1 && len(availableMapsAfterBan) == 1 {
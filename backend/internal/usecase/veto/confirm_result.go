@@ -0,0 +1,109 @@
+package veto
+
+import (
+	"time"
+
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+)
+
+type ConfirmResultUseCase struct {
+	sessionRepo repositories.VetoSessionRepository
+	resultRepo  repositories.MatchResultRepository
+	teamRepo    repositories.TeamRepository
+	listener    ResultListener
+}
+
+type ConfirmResultInput struct {
+	SessionID uint
+	UserID    uint
+}
+
+type ConfirmResultOutput struct {
+	Session *entities.VetoSession
+	Result  *entities.MatchResult
+}
+
+func NewConfirmResultUseCase(
+	sessionRepo repositories.VetoSessionRepository,
+	resultRepo repositories.MatchResultRepository,
+	teamRepo repositories.TeamRepository,
+) *ConfirmResultUseCase {
+	return &ConfirmResultUseCase{
+		sessionRepo: sessionRepo,
+		resultRepo:  resultRepo,
+		teamRepo:    teamRepo,
+	}
+}
+
+// SetResultListener подключает получателя подтвержденных результатов
+func (uc *ConfirmResultUseCase) SetResultListener(listener ResultListener) {
+	uc.listener = listener
+}
+
+func (uc *ConfirmResultUseCase) Execute(input ConfirmResultInput) (*ConfirmResultOutput, error) {
+	session, result, err := loadPendingResult(uc.sessionRepo, uc.resultRepo, input.SessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Подтверждает капитан соперника того, кто сообщил результат
+	side, err := captainSide(uc.teamRepo, session, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if side == result.ReportedByTeam || input.UserID == result.ReportedByID {
+		return nil, ErrOwnResult
+	}
+
+	now := time.Now()
+	result.Status = entities.MatchResultStatusConfirmed
+	result.ConfirmedByID = &input.UserID
+	result.ConfirmedAt = &now
+	if result.Players, err = resultPlayers(uc.teamRepo, session); err != nil {
+		return nil, err
+	}
+
+	if uc.listener != nil {
+		if err := uc.listener.CheckResult(session, result); err != nil {
+			return nil, err
+		}
+	}
+	if err := uc.resultRepo.Update(result); err != nil {
+		return nil, err
+	}
+	if uc.listener != nil {
+		if err := uc.listener.ResultConfirmed(session, result); err != nil {
+			return nil, err
+		}
+	}
+
+	return &ConfirmResultOutput{
+		Session: session,
+		Result:  result,
+	}, nil
+}
+
+// loadPendingResult загружает результат сессии, ожидающий ответа соперника
+func loadPendingResult(
+	sessionRepo repositories.VetoSessionRepository,
+	resultRepo repositories.MatchResultRepository,
+	sessionID uint,
+) (*entities.VetoSession, *entities.MatchResult, error) {
+	session, err := loadFinishedSession(sessionRepo, sessionID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result, err := resultRepo.GetBySessionID(session.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if result == nil {
+		return nil, nil, ErrResultNotFound
+	}
+	if result.Status != entities.MatchResultStatusPending {
+		return nil, nil, ErrResultNotPending
+	}
+	return session, result, nil
+}
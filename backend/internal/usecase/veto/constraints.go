@@ -0,0 +1,98 @@
+package veto
+
+import "github.com/bbp/backend/internal/domain/entities"
+
+// CheckConstraints проверяет, что ограничения относятся к картам пула и что
+// после исключения карт пула все еще хватает для формата вето
+func (s *VetoLogicService) CheckConstraints(
+	vetoType entities.VetoType,
+	mapPool *entities.MapPool,
+	constraints *entities.VetoConstraints,
+) error {
+	if constraints.IsEmpty() {
+		return s.CheckPoolSize(vetoType, len(mapPool.Maps))
+	}
+
+	inPool := make(map[uint]bool, len(mapPool.Maps))
+	for _, m := range mapPool.Maps {
+		inPool[m.ID] = true
+	}
+	mapIDs := append([]uint{}, constraints.ExcludedMapIDs...)
+	mapIDs = append(mapIDs, constraints.TeamAExcludedMapIDs...)
+	mapIDs = append(mapIDs, constraints.TeamBExcludedMapIDs...)
+	for _, mapID := range mapIDs {
+		if !inPool[mapID] {
+			return ErrInvalidConstraints
+		}
+	}
+
+	if constraints.DeciderMapID != nil {
+		// В Bo1 единственная карта и есть десайдер - назначать ее заранее бессмысленно
+		if vetoType == entities.VetoTypeBo1 || !inPool[*constraints.DeciderMapID] {
+			return ErrInvalidConstraints
+		}
+		for _, mapID := range constraints.ExcludedMapIDs {
+			if mapID == *constraints.DeciderMapID {
+				return ErrInvalidConstraints
+			}
+		}
+	}
+
+	// Назначенный десайдер занимает место десайдера в требованиях к размеру пула
+	mapCount := 0
+	for _, m := range mapPool.Maps {
+		if !containsUint(constraints.ExcludedMapIDs, m.ID) {
+			mapCount++
+		}
+	}
+	return s.CheckPoolSize(vetoType, mapCount)
+}
+
+// GetPickableMaps возвращает карты, которые команда может пикнуть
+// Если все доступные карты исключены для команды, ограничение снимается, чтобы вето не зашло в тупик
+func (s *VetoLogicService) GetPickableMaps(
+	mapPool *entities.MapPool,
+	actions []entities.VetoAction,
+	constraints *entities.VetoConstraints,
+	team string,
+) []entities.Map {
+	availableMaps := s.GetAvailableMaps(mapPool, actions, constraints)
+
+	pickable := []entities.Map{}
+	for _, m := range availableMaps {
+		if !constraints.IsExcludedForTeam(m.ID, team) {
+			pickable = append(pickable, m)
+		}
+	}
+	if len(pickable) == 0 {
+		return availableMaps
+	}
+	return pickable
+}
+
+// GetDeciderCandidates возвращает карты, из которых случайно выбирается десайдер:
+// доступные карты, которые не исключены ни для одной из команд (если такие есть)
+func (s *VetoLogicService) GetDeciderCandidates(
+	availableMaps []entities.Map,
+	constraints *entities.VetoConstraints,
+) []entities.Map {
+	candidates := []entities.Map{}
+	for _, m := range availableMaps {
+		if !constraints.IsExcludedForTeam(m.ID, "A") && !constraints.IsExcludedForTeam(m.ID, "B") {
+			candidates = append(candidates, m)
+		}
+	}
+	if len(candidates) == 0 {
+		return availableMaps
+	}
+	return candidates
+}
+
+func containsUint(values []uint, value uint) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
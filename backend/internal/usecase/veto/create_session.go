@@ -3,6 +3,7 @@ package veto
 import (
 	"crypto/rand"
 	"encoding/hex"
+	"time"
 
 	"github.com/bbp/backend/internal/domain/entities"
 	"github.com/bbp/backend/internal/domain/repositories"
@@ -12,6 +13,8 @@ type CreateSessionUseCase struct {
 	sessionRepo  repositories.VetoSessionRepository
 	mapPoolRepo  repositories.MapPoolRepository
 	gameRepo     repositories.GameRepository
+	rotationRepo repositories.MapRotationRepository
+	teamRepo     repositories.TeamRepository
 	logicService *VetoLogicService
 }
 
@@ -22,7 +25,11 @@ type CreateSessionInput struct {
 	Type        entities.VetoType
 	TeamAName   string
 	TeamBName   string
+	TeamAID     *uint // Зарегистрированные команды (опционально), имя по умолчанию берется из команды
+	TeamBID     *uint
+	TeamsAssigned bool // Команды назначены организатором (турнир), членство создателя не проверяется
 	TimerSeconds int
+	Constraints  *entities.VetoConstraints // Ограничения пула из предыдущих серий (опционально)
 }
 
 type CreateSessionOutput struct {
@@ -33,12 +40,16 @@ func NewCreateSessionUseCase(
 	sessionRepo repositories.VetoSessionRepository,
 	mapPoolRepo repositories.MapPoolRepository,
 	gameRepo repositories.GameRepository,
+	rotationRepo repositories.MapRotationRepository,
+	teamRepo repositories.TeamRepository,
 	logicService *VetoLogicService,
 ) *CreateSessionUseCase {
 	return &CreateSessionUseCase{
 		sessionRepo:  sessionRepo,
 		mapPoolRepo:  mapPoolRepo,
 		gameRepo:     gameRepo,
+		rotationRepo: rotationRepo,
+		teamRepo:     teamRepo,
 		logicService: logicService,
 	}
 }
@@ -67,6 +78,48 @@ func (uc *CreateSessionUseCase) Execute(input CreateSessionInput) (*CreateSessio
 		return nil, ErrInvalidMapPool
 	}
 
+	// Чужие приватные пулы использовать нельзя, публичные и по ссылке - можно
+	if !mapPool.IsAccessibleBy(input.UserID) {
+		return nil, ErrMapPoolNotFound
+	}
+
+	// Проверяем ограничения и что карт в пуле без исключенных хватит для завершения вето
+	if err := uc.logicService.CheckConstraints(input.Type, mapPool, input.Constraints); err != nil {
+		return nil, err
+	}
+	var constraints *entities.VetoConstraints
+	if !input.Constraints.IsEmpty() {
+		constraints = input.Constraints
+	}
+
+	// Сессия команд попадает в их историю и статистику
+	var teamA, teamB *entities.Team
+	if input.TeamsAssigned {
+		teamA, teamB, err = LoadTeams(uc.teamRepo, input.TeamAID, input.TeamBID)
+	} else {
+		teamA, teamB, err = LoadMatchTeams(uc.teamRepo, input.UserID, input.TeamAID, input.TeamBID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	teamAName, teamBName := input.TeamAName, input.TeamBName
+	if teamA != nil && teamAName == "" {
+		teamAName = teamA.Name
+	}
+	if teamB != nil && teamBName == "" {
+		teamBName = teamB.Name
+	}
+
+	// Запоминаем ротацию, действующую на момент создания сессии
+	rotation, err := uc.rotationRepo.GetActive(input.GameID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	var rotationID *uint
+	if rotation != nil {
+		rotationID = &rotation.ID
+	}
+
 	// Генерируем уникальный share token
 	shareToken, err := generateShareToken()
 	if err != nil {
@@ -78,14 +131,19 @@ func (uc *CreateSessionUseCase) Execute(input CreateSessionInput) (*CreateSessio
 		UserID:        input.UserID,
 		GameID:        input.GameID,
 		MapPoolID:     input.MapPoolID,
+		MapRotationID: rotationID,
 		Type:          input.Type,
 		Status:        entities.VetoStatusNotStarted,
-		TeamAName:     input.TeamAName,
-		TeamBName:     input.TeamBName,
+		TeamAName:     teamAName,
+		TeamBName:     teamBName,
+		TeamAID:       input.TeamAID,
+		TeamBID:       input.TeamBID,
 		CurrentTeam:   "A", // Команда A начинает первой
 		TimerSeconds:  input.TimerSeconds,
 		ShareToken:    shareToken,
 		Actions:       []entities.VetoAction{},
+		MapSnapshot:   mapPool.Maps, // Снимок карт пула на момент создания
+		Constraints:   constraints,
 	}
 
 	// Валидируем сессию
@@ -0,0 +1,65 @@
+package veto
+
+import (
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+)
+
+type DisputeResultUseCase struct {
+	sessionRepo repositories.VetoSessionRepository
+	resultRepo  repositories.MatchResultRepository
+	teamRepo    repositories.TeamRepository
+}
+
+type DisputeResultInput struct {
+	SessionID uint
+	UserID    uint
+	Reason    string
+}
+
+type DisputeResultOutput struct {
+	Session *entities.VetoSession
+	Result  *entities.MatchResult
+}
+
+func NewDisputeResultUseCase(
+	sessionRepo repositories.VetoSessionRepository,
+	resultRepo repositories.MatchResultRepository,
+	teamRepo repositories.TeamRepository,
+) *DisputeResultUseCase {
+	return &DisputeResultUseCase{
+		sessionRepo: sessionRepo,
+		resultRepo:  resultRepo,
+		teamRepo:    teamRepo,
+	}
+}
+
+func (uc *DisputeResultUseCase) Execute(input DisputeResultInput) (*DisputeResultOutput, error) {
+	session, result, err := loadPendingResult(uc.sessionRepo, uc.resultRepo, input.SessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Оспорить результат может только капитан соперника
+	side, err := captainSide(uc.teamRepo, session, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if side == result.ReportedByTeam || input.UserID == result.ReportedByID {
+		return nil, ErrOwnResult
+	}
+
+	// Спор разрешается новым отчетом капитана или решением администратора
+	result.Status = entities.MatchResultStatusDisputed
+	result.DisputedByID = &input.UserID
+	result.DisputeReason = &input.Reason
+
+	if err := uc.resultRepo.Update(result); err != nil {
+		return nil, err
+	}
+
+	return &DisputeResultOutput{
+		Session: session,
+		Result:  result,
+	}, nil
+}
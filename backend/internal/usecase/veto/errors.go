@@ -16,4 +16,17 @@ var (
 	ErrInvalidMapPool         = errors.New("invalid map pool")
 	ErrGameNotFound           = errors.New("game not found")
 	ErrInvalidSessionType     = errors.New("invalid session type")
+	ErrPoolTooSmall           = errors.New("map pool is too small for the veto type")
+	ErrTeamNotFound           = errors.New("team not found")
+	ErrNotTeamMember          = errors.New("user is not a member of the team")
+	ErrSessionNotFinished     = errors.New("session is not finished")
+	ErrInvalidResult          = errors.New("invalid match result")
+	ErrResultNotFound         = errors.New("match result not found")
+	ErrNotCaptain             = errors.New("user is not a captain of a session team")
+	ErrResultConfirmed        = errors.New("match result is already confirmed")
+	ErrResultNotPending       = errors.New("match result is not awaiting confirmation")
+	ErrOwnResult              = errors.New("match result must be confirmed by the opposing team")
+	ErrResultLocked           = errors.New("match result can no longer be changed")
+	ErrInvalidConstraints     = errors.New("invalid map constraints")
+	ErrMapExcluded            = errors.New("map is excluded by the session constraints")
 )
\ No newline at end of file
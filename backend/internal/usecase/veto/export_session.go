@@ -0,0 +1,60 @@
+package veto
+
+import (
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+)
+
+// ExportSessionUseCase собирает итог вето для экспорта в файл или карточку
+type ExportSessionUseCase struct {
+	sessionRepo    repositories.VetoSessionRepository
+	gameRepo       repositories.GameRepository
+	overlayUseCase *GetOverlayUseCase
+}
+
+type ExportSessionOutput struct {
+	Session  *entities.VetoSession
+	GameName string
+	// Шаги, ход и десайдер в том же виде, что и для оверлея: с названиями команд, карт и картинками
+	Result *GetOverlayOutput
+}
+
+func NewExportSessionUseCase(
+	sessionRepo repositories.VetoSessionRepository,
+	gameRepo repositories.GameRepository,
+	overlayUseCase *GetOverlayUseCase,
+) *ExportSessionUseCase {
+	return &ExportSessionUseCase{
+		sessionRepo:    sessionRepo,
+		gameRepo:       gameRepo,
+		overlayUseCase: overlayUseCase,
+	}
+}
+
+func (uc *ExportSessionUseCase) Execute(sessionID uint) (*ExportSessionOutput, error) {
+	session, err := uc.sessionRepo.GetByID(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if session == nil {
+		return nil, ErrSessionNotFound
+	}
+
+	result, err := uc.overlayUseCase.build(session)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &ExportSessionOutput{
+		Session: session,
+		Result:  result,
+	}
+	game, err := uc.gameRepo.GetByID(session.GameID)
+	if err != nil {
+		return nil, err
+	}
+	if game != nil {
+		output.GameName = game.Name
+	}
+	return output, nil
+}
@@ -1,6 +1,7 @@
 package veto
 
 import (
+	"github.com/bbp/backend/internal/domain/entities"
 	"github.com/bbp/backend/internal/domain/repositories"
 )
 
@@ -19,6 +20,8 @@ type GetNextActionOutput struct {
 	NeedsSideSelection bool          `json:"needs_side_selection"` // Нужен ли выбор стороны после последнего действия
 	SideSelectionTeam string         `json:"side_selection_team,omitempty"` // Какая команда должна выбрать сторону
 	Message           string         `json:"message,omitempty"`
+	Constraints       *entities.VetoConstraints `json:"constraints,omitempty"`      // Ограничения пула сессии
+	PickableMapIDs    []uint         `json:"pickable_map_ids,omitempty"` // Карты, которые текущая команда может пикнуть с учетом ограничений
 }
 
 func NewGetNextActionUseCase(
@@ -43,17 +46,14 @@ func (uc *GetNextActionUseCase) Execute(sessionID uint) (*GetNextActionOutput, e
 		return nil, ErrSessionNotFound
 	}
 
-	// Получаем пул карт
-	mapPool, err := uc.mapPoolRepo.GetByID(session.MapPoolID)
+	// Получаем пул карт сессии
+	mapPool, err := loadSessionMapPool(session, uc.mapPoolRepo)
 	if err != nil {
 		return nil, err
 	}
-	if mapPool == nil {
-		return nil, ErrMapPoolNotFound
-	}
 
 	// Получаем доступные карты
-	availableMaps := uc.logicService.GetAvailableMaps(mapPool, session.Actions)
+	availableMaps := uc.logicService.GetAvailableMaps(mapPool, session.Actions, session.Constraints)
 	
 	// Определяем текущий шаг
 	currentStep := uc.logicService.GetCurrentStep(session.Actions)
@@ -87,6 +87,7 @@ func (uc *GetNextActionUseCase) Execute(sessionID uint) (*GetNextActionOutput, e
 			NeedsSideSelection: true,
 			SideSelectionTeam:  sideSelectionTeam,
 			Message:            "Side selection required",
+			Constraints:        session.Constraints,
 		}, nil
 	}
 
@@ -101,12 +102,21 @@ func (uc *GetNextActionUseCase) Execute(sessionID uint) (*GetNextActionOutput, e
 			NeedsSideSelection: false,
 			SideSelectionTeam:  "",
 			Message:            "Veto process is finished",
+			Constraints:        session.Constraints,
 		}, nil
 	}
 
 	canBan := nextActionType == NextActionTypeBan || nextActionType == NextActionTypeBoth
 	canPick := nextActionType == NextActionTypePick || nextActionType == NextActionTypeBoth
 
+	var pickableMapIDs []uint
+	if canPick {
+		pickableMapIDs = []uint{}
+		for _, m := range uc.logicService.GetPickableMaps(mapPool, session.Actions, session.Constraints, currentTeam) {
+			pickableMapIDs = append(pickableMapIDs, m.ID)
+		}
+	}
+
 	return &GetNextActionOutput{
 		ActionType:         nextActionType,
 		CurrentStep:        currentStep,
@@ -115,5 +125,7 @@ func (uc *GetNextActionUseCase) Execute(sessionID uint) (*GetNextActionOutput, e
 		CanPick:            canPick,
 		NeedsSideSelection: false,
 		SideSelectionTeam:  "",
+		Constraints:        session.Constraints,
+		PickableMapIDs:     pickableMapIDs,
 	}, nil
 }
\ No newline at end of file
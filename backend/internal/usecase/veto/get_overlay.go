@@ -0,0 +1,195 @@
+package veto
+
+import (
+	"time"
+
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+)
+
+// Действие текущего хода для оверлея
+const (
+	OverlayTurnBan       = "ban"
+	OverlayTurnPick      = "pick"
+	OverlayTurnBanOrPick = "ban_or_pick"
+	OverlayTurnSide      = "side"
+)
+
+// GetOverlayUseCase собирает компактное состояние сессии для стрим-оверлеев
+type GetOverlayUseCase struct {
+	sessionRepo  repositories.VetoSessionRepository
+	mapPoolRepo  repositories.MapPoolRepository
+	logicService *VetoLogicService
+}
+
+// OverlayMap карта в том виде, в котором ее показывает оверлей
+type OverlayMap struct {
+	ID       uint
+	Name     string
+	ImageURL string
+}
+
+// OverlayStep выполненный шаг вето
+type OverlayStep struct {
+	Step         int
+	Team         string // "A" или "B"
+	TeamName     string
+	Action       entities.VetoActionType
+	Map          OverlayMap
+	Side         *string // Сторона на карте после пика
+	SideTeam     string  // Команда, выбравшая сторону
+	SideTeamName string
+}
+
+// OverlayTurn текущий ход; для отсчета оверлей использует Deadline
+type OverlayTurn struct {
+	Step         int
+	Team         string
+	TeamName     string
+	Action       string
+	TimerSeconds int
+	StartedAt    time.Time
+	Deadline     *time.Time // Только для идущей сессии с таймером
+}
+
+// OverlayDecider десайдер (для Bo1 - оставшаяся карта)
+type OverlayDecider struct {
+	Map  OverlayMap
+	Side *string
+}
+
+type GetOverlayOutput struct {
+	SessionID uint
+	Type      entities.VetoType
+	Status    entities.VetoStatus
+	TeamAName string
+	TeamBName string
+	Steps     []OverlayStep
+	Turn      *OverlayTurn // nil, если сессия завершена
+	Decider   *OverlayDecider
+	UpdatedAt time.Time
+}
+
+func NewGetOverlayUseCase(
+	sessionRepo repositories.VetoSessionRepository,
+	mapPoolRepo repositories.MapPoolRepository,
+	logicService *VetoLogicService,
+) *GetOverlayUseCase {
+	return &GetOverlayUseCase{
+		sessionRepo:  sessionRepo,
+		mapPoolRepo:  mapPoolRepo,
+		logicService: logicService,
+	}
+}
+
+// ExecuteByShareToken возвращает состояние оверлея сессии по share token
+func (uc *GetOverlayUseCase) ExecuteByShareToken(shareToken string) (*GetOverlayOutput, error) {
+	session, err := uc.sessionRepo.GetByShareToken(shareToken)
+	if err != nil {
+		return nil, err
+	}
+	if session == nil {
+		return nil, ErrSessionNotFound
+	}
+	return uc.build(session)
+}
+
+// build собирает состояние оверлея загруженной сессии
+func (uc *GetOverlayUseCase) build(session *entities.VetoSession) (*GetOverlayOutput, error) {
+	mapPool, err := loadSessionMapPool(session, uc.mapPoolRepo)
+	if err != nil {
+		return nil, err
+	}
+	maps := make(map[uint]entities.Map, len(mapPool.Maps))
+	for _, m := range mapPool.Maps {
+		maps[m.ID] = m
+	}
+	overlayMap := func(mapID uint) OverlayMap {
+		m := maps[mapID]
+		return OverlayMap{ID: mapID, Name: m.Name, ImageURL: m.ImageURL}
+	}
+
+	output := &GetOverlayOutput{
+		SessionID: session.ID,
+		Type:      session.Type,
+		Status:    session.Status,
+		TeamAName: session.TeamAName,
+		TeamBName: session.TeamBName,
+		Steps:     make([]OverlayStep, 0, len(session.Actions)),
+		UpdatedAt: session.UpdatedAt,
+	}
+
+	turnStartedAt := session.UpdatedAt
+	for _, action := range session.Actions {
+		step := OverlayStep{
+			Step:     action.StepNumber,
+			Team:     action.Team,
+			TeamName: teamName(session, action.Team),
+			Action:   action.ActionType,
+			Map:      overlayMap(action.MapID),
+			Side:     action.SelectedSide,
+		}
+		if action.SelectedSide != nil {
+			step.SideTeam = uc.logicService.GetSideSelectionTeam(session.Type, action.StepNumber)
+			step.SideTeamName = teamName(session, step.SideTeam)
+		}
+		output.Steps = append(output.Steps, step)
+		if action.CreatedAt.After(turnStartedAt) {
+			turnStartedAt = action.CreatedAt
+		}
+	}
+
+	if session.SelectedMapID != nil {
+		output.Decider = &OverlayDecider{
+			Map:  overlayMap(*session.SelectedMapID),
+			Side: session.SelectedSide,
+		}
+	}
+
+	if !session.IsFinished() {
+		output.Turn = uc.currentTurn(session, mapPool, turnStartedAt)
+	}
+
+	return output, nil
+}
+
+// currentTurn определяет, чей ход и какое действие ожидается
+func (uc *GetOverlayUseCase) currentTurn(session *entities.VetoSession, mapPool *entities.MapPool, startedAt time.Time) *OverlayTurn {
+	turn := &OverlayTurn{
+		Step:         uc.logicService.GetCurrentStep(session.Actions),
+		TimerSeconds: session.TimerSeconds,
+		StartedAt:    startedAt,
+	}
+
+	if uc.logicService.NeedsSideSelection(session, session.Actions) {
+		lastAction := session.Actions[len(session.Actions)-1]
+		turn.Team = uc.logicService.GetSideSelectionTeam(session.Type, lastAction.StepNumber)
+		turn.Action = OverlayTurnSide
+	} else {
+		availableMaps := uc.logicService.GetAvailableMaps(mapPool, session.Actions, session.Constraints)
+		turn.Team = uc.logicService.GetCurrentTeam(session.Type, turn.Step)
+		switch uc.logicService.GetNextActionType(session, session.Actions, len(availableMaps)) {
+		case NextActionTypePick:
+			turn.Action = OverlayTurnPick
+		case NextActionTypeBoth:
+			turn.Action = OverlayTurnBanOrPick
+		default:
+			turn.Action = OverlayTurnBan
+		}
+	}
+	turn.TeamName = teamName(session, turn.Team)
+
+	if session.Status == entities.VetoStatusInProgress && session.TimerSeconds > 0 {
+		deadline := startedAt.Add(time.Duration(session.TimerSeconds) * time.Second)
+		turn.Deadline = &deadline
+	}
+	return turn
+}
+
+// teamName возвращает название команды "A" или "B"
+func teamName(session *entities.VetoSession, team string) string {
+	if team == "B" {
+		return session.TeamBName
+	}
+	return session.TeamAName
+}
@@ -0,0 +1,49 @@
+package veto
+
+import (
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+)
+
+type GetResultUseCase struct {
+	sessionRepo repositories.VetoSessionRepository
+	resultRepo  repositories.MatchResultRepository
+}
+
+type GetResultOutput struct {
+	Session *entities.VetoSession
+	Result  *entities.MatchResult
+}
+
+func NewGetResultUseCase(
+	sessionRepo repositories.VetoSessionRepository,
+	resultRepo repositories.MatchResultRepository,
+) *GetResultUseCase {
+	return &GetResultUseCase{
+		sessionRepo: sessionRepo,
+		resultRepo:  resultRepo,
+	}
+}
+
+func (uc *GetResultUseCase) Execute(sessionID uint) (*GetResultOutput, error) {
+	session, err := uc.sessionRepo.GetByID(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if session == nil {
+		return nil, ErrSessionNotFound
+	}
+
+	result, err := uc.resultRepo.GetBySessionID(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return nil, ErrResultNotFound
+	}
+
+	return &GetResultOutput{
+		Session: session,
+		Result:  result,
+	}, nil
+}
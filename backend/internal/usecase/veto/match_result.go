@@ -0,0 +1,164 @@
+package veto
+
+import (
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+)
+
+// MapScoreInput счет одной карты серии в порядке игры
+type MapScoreInput struct {
+	MapID  uint
+	ScoreA int
+	ScoreB int
+}
+
+// playedMap карта серии: пик команды или десайдер (без действия)
+type playedMap struct {
+	actionID *uint
+	mapID    uint
+}
+
+// playedMaps возвращает карты серии в порядке игры: пики по шагам, затем десайдер
+func playedMaps(session *entities.VetoSession) []playedMap {
+	var maps []playedMap
+	for i := range session.Actions {
+		action := &session.Actions[i]
+		if action.ActionType == entities.VetoActionTypePick {
+			maps = append(maps, playedMap{actionID: &action.ID, mapID: action.MapID})
+		}
+	}
+	if session.SelectedMapID != nil {
+		for _, m := range maps {
+			if m.mapID == *session.SelectedMapID {
+				return maps
+			}
+		}
+		maps = append(maps, playedMap{mapID: *session.SelectedMapID})
+	}
+	return maps
+}
+
+// buildMatchResult проверяет счет карт и считает победителя серии
+// Серия заканчивается, как только одна из сторон выиграла большинство карт,
+// поэтому несыгранные карты в отчете не указываются
+func buildMatchResult(session *entities.VetoSession, scores []MapScoreInput) (*entities.MatchResult, error) {
+	played := playedMaps(session)
+	if len(played) == 0 || len(scores) == 0 || len(scores) > len(played) {
+		return nil, ErrInvalidResult
+	}
+
+	needed := len(played)/2 + 1
+	result := &entities.MatchResult{
+		VetoSessionID: session.ID,
+		Maps:          make([]entities.MapResult, 0, len(scores)),
+	}
+	for i, score := range scores {
+		if result.ScoreA >= needed || result.ScoreB >= needed {
+			return nil, ErrInvalidResult
+		}
+		if score.MapID != played[i].mapID || score.ScoreA < 0 || score.ScoreB < 0 || score.ScoreA == score.ScoreB {
+			return nil, ErrInvalidResult
+		}
+
+		mapResult := entities.MapResult{
+			VetoActionID: played[i].actionID,
+			MapID:        score.MapID,
+			Order:        i + 1,
+			ScoreA:       score.ScoreA,
+			ScoreB:       score.ScoreB,
+		}
+		if mapResult.Winner() == "A" {
+			result.ScoreA++
+		} else {
+			result.ScoreB++
+		}
+		result.Maps = append(result.Maps, mapResult)
+	}
+
+	switch {
+	case result.ScoreA >= needed:
+		result.WinnerTeam = "A"
+	case result.ScoreB >= needed:
+		result.WinnerTeam = "B"
+	default:
+		return nil, ErrInvalidResult
+	}
+	return result, nil
+}
+
+// sessionSide сторона сессии и играющая за нее команда
+type sessionSide struct {
+	side   string
+	teamID *uint
+}
+
+// sessionSides возвращает стороны сессии по порядку
+func sessionSides(session *entities.VetoSession) []sessionSide {
+	return []sessionSide{{"A", session.TeamAID}, {"B", session.TeamBID}}
+}
+
+// captainSide возвращает сторону сессии, капитаном команды которой является пользователь
+func captainSide(teamRepo repositories.TeamRepository, session *entities.VetoSession, userID uint) (string, error) {
+	for _, s := range sessionSides(session) {
+		if s.teamID == nil {
+			continue
+		}
+		team, err := teamRepo.GetByID(*s.teamID)
+		if err != nil {
+			return "", err
+		}
+		if team != nil && team.IsCaptain(userID) {
+			return s.side, nil
+		}
+	}
+	return "", ErrNotCaptain
+}
+
+// resultPlayers фиксирует составы команд сессии, чтобы результат попал в статистику игроков
+func resultPlayers(teamRepo repositories.TeamRepository, session *entities.VetoSession) ([]entities.MatchResultPlayer, error) {
+	var players []entities.MatchResultPlayer
+	seen := make(map[uint]bool)
+	for _, s := range sessionSides(session) {
+		if s.teamID == nil {
+			continue
+		}
+		team, err := teamRepo.GetByID(*s.teamID)
+		if err != nil {
+			return nil, err
+		}
+		if team == nil {
+			continue
+		}
+		for _, member := range team.Members {
+			if seen[member.UserID] {
+				continue
+			}
+			seen[member.UserID] = true
+			players = append(players, entities.MatchResultPlayer{UserID: member.UserID, Team: s.side})
+		}
+	}
+	return players, nil
+}
+
+// loadFinishedSession загружает сессию, по итогам которой сообщается результат
+func loadFinishedSession(sessionRepo repositories.VetoSessionRepository, sessionID uint) (*entities.VetoSession, error) {
+	session, err := sessionRepo.GetByID(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if session == nil {
+		return nil, ErrSessionNotFound
+	}
+	if session.Status != entities.VetoStatusFinished {
+		return nil, ErrSessionNotFinished
+	}
+	return session, nil
+}
+
+// ResultListener получает подтвержденные результаты матчей, например чтобы продвинуть сетку турнира
+type ResultListener interface {
+	// CheckResult вызывается до сохранения подтвержденного результата и может его запретить
+	CheckResult(session *entities.VetoSession, result *entities.MatchResult) error
+	// ResultConfirmed вызывается после сохранения подтвержденного результата
+	ResultConfirmed(session *entities.VetoSession, result *entities.MatchResult) error
+}
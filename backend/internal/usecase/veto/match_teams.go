@@ -0,0 +1,70 @@
+package veto
+
+import (
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+)
+
+// LoadMatchTeams загружает команды матча, указанные по ID
+// Команды должны различаться, а пользователь - состоять хотя бы в одной из них,
+// чтобы посторонние не добавляли сессии в историю и статистику чужих команд
+func LoadMatchTeams(
+	teamRepo repositories.TeamRepository,
+	userID *uint,
+	teamAID, teamBID *uint,
+) (*entities.Team, *entities.Team, error) {
+	teamA, teamB, err := LoadTeams(teamRepo, teamAID, teamBID)
+	if err != nil || (teamA == nil && teamB == nil) {
+		return teamA, teamB, err
+	}
+
+	if userID == nil {
+		return nil, nil, ErrNotTeamMember
+	}
+	isMember := (teamA != nil && teamA.GetMember(*userID) != nil) ||
+		(teamB != nil && teamB.GetMember(*userID) != nil)
+	if !isMember {
+		return nil, nil, ErrNotTeamMember
+	}
+
+	return teamA, teamB, nil
+}
+
+// LoadTeams загружает команды матча без проверки членства,
+// например когда команды назначает организатор турнира
+func LoadTeams(
+	teamRepo repositories.TeamRepository,
+	teamAID, teamBID *uint,
+) (*entities.Team, *entities.Team, error) {
+	if teamAID == nil && teamBID == nil {
+		return nil, nil, nil
+	}
+	if teamAID != nil && teamBID != nil && *teamAID == *teamBID {
+		return nil, nil, ErrInvalidTeam
+	}
+
+	load := func(teamID *uint) (*entities.Team, error) {
+		if teamID == nil {
+			return nil, nil
+		}
+		team, err := teamRepo.GetByID(*teamID)
+		if err != nil {
+			return nil, err
+		}
+		if team == nil {
+			return nil, ErrTeamNotFound
+		}
+		return team, nil
+	}
+
+	teamA, err := load(teamAID)
+	if err != nil {
+		return nil, nil, err
+	}
+	teamB, err := load(teamBID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return teamA, teamB, nil
+}
@@ -62,14 +62,11 @@ func (uc *PickMapUseCase) Execute(input PickMapInput) (*PickMapOutput, error) {
 		return nil, ErrInvalidAction
 	}
 
-	// Получаем пул карт
-	mapPool, err := uc.mapPoolRepo.GetByID(session.MapPoolID)
+	// Получаем пул карт сессии
+	mapPool, err := loadSessionMapPool(session, uc.mapPoolRepo)
 	if err != nil {
 		return nil, err
 	}
-	if mapPool == nil {
-		return nil, ErrMapPoolNotFound
-	}
 
 	// Проверяем, что карта существует
 	mapEntity, err := uc.mapRepo.GetByID(input.MapID)
@@ -101,8 +98,13 @@ func (uc *PickMapUseCase) Execute(input PickMapInput) (*PickMapOutput, error) {
 		}
 	}
 
+	// Карты, исключенные ограничениями сессии, пикать нельзя
+	if session.Constraints.IsExcluded(input.MapID) {
+		return nil, ErrMapExcluded
+	}
+
 	// Получаем доступные карты
-	availableMaps := uc.logicService.GetAvailableMaps(mapPool, session.Actions)
+	availableMaps := uc.logicService.GetAvailableMaps(mapPool, session.Actions, session.Constraints)
 
 	// Проверяем, что карта доступна
 	mapAvailable := false
@@ -116,6 +118,18 @@ func (uc *PickMapUseCase) Execute(input PickMapInput) (*PickMapOutput, error) {
 		return nil, ErrMapAlreadyPicked
 	}
 
+	// Проверяем ограничения команды (например, карта уже сыграна ею в предыдущей серии)
+	mapPickable := false
+	for _, m := range uc.logicService.GetPickableMaps(mapPool, session.Actions, session.Constraints, input.Team) {
+		if m.ID == input.MapID {
+			mapPickable = true
+			break
+		}
+	}
+	if !mapPickable {
+		return nil, ErrMapExcluded
+	}
+
 	// Определяем текущий шаг
 	currentStep := uc.logicService.GetCurrentStep(session.Actions)
 
@@ -151,7 +165,7 @@ func (uc *PickMapUseCase) Execute(input PickMapInput) (*PickMapOutput, error) {
 
 	// Добавляем действие в список для проверок (временно, для проверки логики)
 	actionsWithNewPick := append(session.Actions, *action)
-	availableMapsAfterPick := uc.logicService.GetAvailableMaps(mapPool, actionsWithNewPick)
+	availableMapsAfterPick := uc.logicService.GetAvailableMaps(mapPool, actionsWithNewPick, session.Constraints)
 	
 	// ВАЖНО: Проверяем, нужен ли выбор стороны ПЕРЕД проверкой завершения сессии
 	// Если нужен выбор стороны, НЕ устанавливаем статус finished
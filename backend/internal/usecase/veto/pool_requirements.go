@@ -0,0 +1,80 @@
+package veto
+
+import "github.com/bbp/backend/internal/domain/entities"
+
+// PoolSizeRequirement требования формата вето к размеру пула карт
+type PoolSizeRequirement struct {
+	MinSize   int // Минимальный размер пула, при котором вето можно завершить
+	ExactSize int // Размер, при котором десидер определяется вето, а не рандомом (0 - любой размер)
+}
+
+// PoolCompatibility совместимость пула с форматом вето
+type PoolCompatibility struct {
+	VetoType   entities.VetoType
+	MinSize    int
+	ExactSize  int
+	Compatible bool // Пула хватает, чтобы завершить вето
+	Exact      bool // Размер пула совпадает с ExactSize
+}
+
+// SupportedVetoTypes форматы вето в порядке отображения
+var SupportedVetoTypes = []entities.VetoType{
+	entities.VetoTypeBo1,
+	entities.VetoTypeBo3,
+	entities.VetoTypeBo5,
+}
+
+// GetPoolSizeRequirement вычисляет требования к размеру пула, проигрывая
+// последовательность действий формата до завершения вето
+func (s *VetoLogicService) GetPoolSizeRequirement(vetoType entities.VetoType) PoolSizeRequirement {
+	// Bo1 завершается, когда остается 1 карта, - нужен хотя бы один бан
+	if vetoType == entities.VetoTypeBo1 {
+		return PoolSizeRequirement{MinSize: 2}
+	}
+
+	session := &entities.VetoSession{Type: vetoType}
+	actions := []entities.VetoAction{}
+	for !s.IsVetoFinished(session, actions, nil) {
+		actionType := entities.VetoActionTypeBan
+		if s.GetNextActionType(session, actions, 0) == NextActionTypePick {
+			actionType = entities.VetoActionTypePick
+		}
+		actions = append(actions, entities.VetoAction{
+			StepNumber: s.GetCurrentStep(actions),
+			ActionType: actionType,
+		})
+
+		// Защита от бесконечного цикла для неизвестных форматов
+		if len(actions) > 64 {
+			return PoolSizeRequirement{}
+		}
+	}
+
+	// Каждое действие забирает одну карту, плюс одна карта на десидер
+	size := len(actions) + 1
+	return PoolSizeRequirement{MinSize: size, ExactSize: size}
+}
+
+// CheckPoolSize проверяет, что пула из mapCount карт хватает для формата вето
+func (s *VetoLogicService) CheckPoolSize(vetoType entities.VetoType, mapCount int) error {
+	if mapCount < s.GetPoolSizeRequirement(vetoType).MinSize {
+		return ErrPoolTooSmall
+	}
+	return nil
+}
+
+// GetPoolCompatibility возвращает совместимость пула из mapCount карт со всеми форматами вето
+func (s *VetoLogicService) GetPoolCompatibility(mapCount int) []PoolCompatibility {
+	result := make([]PoolCompatibility, len(SupportedVetoTypes))
+	for i, vetoType := range SupportedVetoTypes {
+		req := s.GetPoolSizeRequirement(vetoType)
+		result[i] = PoolCompatibility{
+			VetoType:   vetoType,
+			MinSize:    req.MinSize,
+			ExactSize:  req.ExactSize,
+			Compatible: mapCount >= req.MinSize,
+			Exact:      req.ExactSize == 0 || mapCount == req.ExactSize,
+		}
+	}
+	return result
+}
@@ -0,0 +1,113 @@
+package veto
+
+import (
+	"time"
+
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+)
+
+type ReportResultUseCase struct {
+	sessionRepo repositories.VetoSessionRepository
+	resultRepo  repositories.MatchResultRepository
+	teamRepo    repositories.TeamRepository
+	listener    ResultListener
+}
+
+type ReportResultInput struct {
+	SessionID uint
+	UserID    uint
+	Maps      []MapScoreInput
+	AsAdmin   bool // Администратор выставляет результат сразу подтвержденным, в том числе при споре
+}
+
+type ReportResultOutput struct {
+	Session *entities.VetoSession
+	Result  *entities.MatchResult
+}
+
+func NewReportResultUseCase(
+	sessionRepo repositories.VetoSessionRepository,
+	resultRepo repositories.MatchResultRepository,
+	teamRepo repositories.TeamRepository,
+) *ReportResultUseCase {
+	return &ReportResultUseCase{
+		sessionRepo: sessionRepo,
+		resultRepo:  resultRepo,
+		teamRepo:    teamRepo,
+	}
+}
+
+// SetResultListener подключает получателя подтвержденных результатов
+func (uc *ReportResultUseCase) SetResultListener(listener ResultListener) {
+	uc.listener = listener
+}
+
+func (uc *ReportResultUseCase) Execute(input ReportResultInput) (*ReportResultOutput, error) {
+	session, err := loadFinishedSession(uc.sessionRepo, input.SessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Сообщить результат может капитан одной из команд сессии
+	side := ""
+	if !input.AsAdmin {
+		if side, err = captainSide(uc.teamRepo, session, input.UserID); err != nil {
+			return nil, err
+		}
+	}
+
+	existing, err := uc.resultRepo.GetBySessionID(session.ID)
+	if err != nil {
+		return nil, err
+	}
+	// Подтвержденный результат меняет только администратор
+	if existing != nil && existing.IsConfirmed() && !input.AsAdmin {
+		return nil, ErrResultConfirmed
+	}
+
+	result, err := buildMatchResult(session, input.Maps)
+	if err != nil {
+		return nil, err
+	}
+	result.ReportedByID = input.UserID
+	result.ReportedByTeam = side
+	result.Status = entities.MatchResultStatusPending
+
+	// Повторный отчет капитана (в том числе после спора) снова ждет подтверждения соперника
+	if input.AsAdmin {
+		now := time.Now()
+		result.Status = entities.MatchResultStatusConfirmed
+		result.ConfirmedByID = &input.UserID
+		result.ConfirmedAt = &now
+		if result.Players, err = resultPlayers(uc.teamRepo, session); err != nil {
+			return nil, err
+		}
+		if uc.listener != nil {
+			if err := uc.listener.CheckResult(session, result); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if existing == nil {
+		err = uc.resultRepo.Create(result)
+	} else {
+		result.ID = existing.ID
+		result.CreatedAt = existing.CreatedAt
+		err = uc.resultRepo.Update(result)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if result.IsConfirmed() && uc.listener != nil {
+		if err := uc.listener.ResultConfirmed(session, result); err != nil {
+			return nil, err
+		}
+	}
+
+	return &ReportResultOutput{
+		Session: session,
+		Result:  result,
+	}, nil
+}
@@ -8,6 +8,7 @@ import (
 type ResetSessionUseCase struct {
 	sessionRepo repositories.VetoSessionRepository
 	actionRepo  repositories.VetoActionRepository
+	resultRepo  repositories.MatchResultRepository
 }
 
 type ResetSessionInput struct {
@@ -21,10 +22,12 @@ type ResetSessionOutput struct {
 func NewResetSessionUseCase(
 	sessionRepo repositories.VetoSessionRepository,
 	actionRepo repositories.VetoActionRepository,
+	resultRepo repositories.MatchResultRepository,
 ) *ResetSessionUseCase {
 	return &ResetSessionUseCase{
 		sessionRepo: sessionRepo,
 		actionRepo:  actionRepo,
+		resultRepo:  resultRepo,
 	}
 }
 
@@ -43,6 +46,11 @@ func (uc *ResetSessionUseCase) Execute(input ResetSessionInput) (*ResetSessionOu
 		return nil, err
 	}
 
+	// Результат серии относился к удаленным пикам
+	if err := uc.resultRepo.DeleteBySessionID(input.SessionID); err != nil {
+		return nil, err
+	}
+
 	// Сбрасываем состояние сессии
 	session.Status = entities.VetoStatusNotStarted
 	session.CurrentTeam = "A"
@@ -92,6 +92,11 @@ func (uc *SelectSideUseCase) Execute(input SelectSideInput) (*SelectSideOutput,
 		return nil, err
 	}
 
+	// Выбор стороны завершает ход: обновление сессии отмечает начало следующего хода для таймера
+	if err := uc.sessionRepo.Update(session); err != nil {
+		return nil, err
+	}
+
 	// Получаем обновленную сессию с действиями (чтобы получить обновленное действие с selected_side)
 	updatedSession, err := uc.sessionRepo.GetByID(session.ID)
 	if err != nil {
@@ -102,9 +107,9 @@ func (uc *SelectSideUseCase) Execute(input SelectSideInput) (*SelectSideOutput,
 	// Если выбор стороны был последним шагом перед завершением, нужно проверить и установить finished
 	if updatedSession.Status != entities.VetoStatusFinished {
 		// Получаем пул карт для проверки завершения
-		mapPool, err := uc.mapPoolRepo.GetByID(updatedSession.MapPoolID)
-		if err == nil && mapPool != nil {
-			availableMaps := uc.logicService.GetAvailableMaps(mapPool, updatedSession.Actions)
+		mapPool, err := loadSessionMapPool(updatedSession, uc.mapPoolRepo)
+		if err == nil {
+			availableMaps := uc.logicService.GetAvailableMaps(mapPool, updatedSession.Actions, updatedSession.Constraints)
 			
 			// Проверяем, завершена ли сессия после выбора стороны
 			if uc.logicService.IsVetoFinished(updatedSession, updatedSession.Actions, availableMaps) {
@@ -116,12 +121,19 @@ func (uc *SelectSideUseCase) Execute(input SelectSideInput) (*SelectSideOutput,
 						updatedSession.SelectedMapID = &availableMaps[0].ID
 					}
 				} else if updatedSession.Type == entities.VetoTypeBo3 || updatedSession.Type == entities.VetoTypeBo5 {
-					// BO3/BO5: выбираем десидер случайно из оставшихся карт
-					if len(availableMaps) > 0 {
+					// BO3/BO5: десидер назначен ограничениями сессии или выбирается случайно из оставшихся карт
+					candidates := uc.logicService.GetDeciderCandidates(availableMaps, updatedSession.Constraints)
+					if updatedSession.Constraints != nil && updatedSession.Constraints.DeciderMapID != nil {
+						deciderMapID := *updatedSession.Constraints.DeciderMapID
+						updatedSession.SelectedMapID = &deciderMapID
+
+						randomSide := uc.logicService.RandomizeDeciderSide()
+						updatedSession.SelectedSide = &randomSide
+					} else if len(candidates) > 0 {
 						// Рандомим индекс карты для десидера
 						rand.Seed(time.Now().UnixNano())
-						randomIndex := rand.Intn(len(availableMaps))
-						deciderMap := availableMaps[randomIndex]
+						randomIndex := rand.Intn(len(candidates))
+						deciderMap := candidates[randomIndex]
 						updatedSession.SelectedMapID = &deciderMap.ID
 						
 						// ВАЖНО: Рандомим сторону для десидера (третья карта в BO3, пятая в BO5)
@@ -0,0 +1,61 @@
+package veto
+
+import (
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+)
+
+// loadSessionMapPool возвращает пул карт, с которым работает сессия
+// Для сессий со снимком карт используется снимок, поэтому удаление карты из пула
+// или изменение каталога не влияет на уже созданные сессии.
+// Для старых сессий без снимка загружается текущее состояние пула.
+func loadSessionMapPool(
+	session *entities.VetoSession,
+	mapPoolRepo repositories.MapPoolRepository,
+) (*entities.MapPool, error) {
+	if len(session.MapSnapshot) > 0 {
+		return &entities.MapPool{
+			ID:     session.MapPoolID,
+			GameID: session.GameID,
+			Maps:   session.MapSnapshot,
+		}, nil
+	}
+
+	mapPool, err := mapPoolRepo.GetByID(session.MapPoolID)
+	if err != nil {
+		return nil, err
+	}
+	if mapPool == nil {
+		return nil, ErrMapPoolNotFound
+	}
+	return mapPool, nil
+}
+
+// LoadSessionMapPool возвращает пул сессии для ответов API: название и настройки
+// берутся из пула, а карты - из снимка сессии. Если пул уже удален, пул собирается
+// из одного снимка, поэтому сессия не теряет список карт.
+func LoadSessionMapPool(
+	session *entities.VetoSession,
+	mapPoolRepo repositories.MapPoolRepository,
+) (*entities.MapPool, error) {
+	mapPool, err := mapPoolRepo.GetByID(session.MapPoolID)
+	if err != nil {
+		return nil, err
+	}
+	if len(session.MapSnapshot) == 0 {
+		if mapPool == nil {
+			return nil, ErrMapPoolNotFound
+		}
+		return mapPool, nil
+	}
+
+	snapshot := entities.MapPool{
+		ID:     session.MapPoolID,
+		GameID: session.GameID,
+	}
+	if mapPool != nil {
+		snapshot = *mapPool
+	}
+	snapshot.Maps = session.MapSnapshot
+	return &snapshot, nil
+}
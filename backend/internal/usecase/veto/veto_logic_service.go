@@ -154,9 +154,12 @@ func (s *VetoLogicService) CanPerformAction(
 }
 
 // GetAvailableMaps возвращает доступные карты (не забаненные и не выбранные)
+// Карты, исключенные ограничениями сессии, и назначенный десайдер недоступны обеим командам;
+// ограничения отдельных команд учитывает GetPickableMaps
 func (s *VetoLogicService) GetAvailableMaps(
 	mapPool *entities.MapPool,
 	actions []entities.VetoAction,
+	constraints *entities.VetoConstraints,
 ) []entities.Map {
 	// Собираем ID забаненных и выбранных карт
 	bannedMapIDs := make(map[uint]bool)
@@ -173,7 +176,7 @@ func (s *VetoLogicService) GetAvailableMaps(
 	// Фильтруем карты
 	availableMaps := []entities.Map{}
 	for _, m := range mapPool.Maps {
-		if !bannedMapIDs[m.ID] && !pickedMapIDs[m.ID] {
+		if !bannedMapIDs[m.ID] && !pickedMapIDs[m.ID] && !constraints.IsExcluded(m.ID) {
 			availableMaps = append(availableMaps, m)
 		}
 	}
@@ -1,6 +1,7 @@
 package veto
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/bbp/backend/internal/domain/entities"
@@ -450,3 +451,107 @@ func TestIsVetoFinished_Bo5(t *testing.T) {
 		})
 	}
 }
+
+func TestGetPoolSizeRequirement(t *testing.T) {
+	service := NewVetoLogicService()
+
+	tests := []struct {
+		vetoType entities.VetoType
+		want     PoolSizeRequirement
+	}{
+		{entities.VetoTypeBo1, PoolSizeRequirement{MinSize: 2}},
+		{entities.VetoTypeBo3, PoolSizeRequirement{MinSize: 7, ExactSize: 7}},
+		{entities.VetoTypeBo5, PoolSizeRequirement{MinSize: 13, ExactSize: 13}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.vetoType), func(t *testing.T) {
+			got := service.GetPoolSizeRequirement(tt.vetoType)
+			if got != tt.want {
+				t.Errorf("GetPoolSizeRequirement() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	if err := service.CheckPoolSize(entities.VetoTypeBo5, 3); err != ErrPoolTooSmall {
+		t.Errorf("CheckPoolSize(bo5, 3) = %v, want ErrPoolTooSmall", err)
+	}
+	if err := service.CheckPoolSize(entities.VetoTypeBo3, 7); err != nil {
+		t.Errorf("CheckPoolSize(bo3, 7) = %v, want nil", err)
+	}
+}
+
+func testMapPool(count int) *entities.MapPool {
+	pool := &entities.MapPool{ID: 1, GameID: 1}
+	for i := 1; i <= count; i++ {
+		pool.Maps = append(pool.Maps, entities.Map{ID: uint(i)})
+	}
+	return pool
+}
+
+func mapIDs(maps []entities.Map) []uint {
+	ids := []uint{}
+	for _, m := range maps {
+		ids = append(ids, m.ID)
+	}
+	return ids
+}
+
+func TestGetAvailableMaps_Constraints(t *testing.T) {
+	service := NewVetoLogicService()
+	pool := testMapPool(5)
+	decider := uint(5)
+	constraints := &entities.VetoConstraints{
+		ExcludedMapIDs:      []uint{1},
+		TeamAExcludedMapIDs: []uint{2, 3},
+		DeciderMapID:        &decider,
+	}
+	actions := []entities.VetoAction{{MapID: 4, ActionType: entities.VetoActionTypeBan}}
+
+	if got := mapIDs(service.GetAvailableMaps(pool, actions, constraints)); !reflect.DeepEqual(got, []uint{2, 3}) {
+		t.Errorf("GetAvailableMaps() = %v, want [2 3]", got)
+	}
+	if got := mapIDs(service.GetPickableMaps(pool, nil, constraints, "B")); !reflect.DeepEqual(got, []uint{2, 3, 4}) {
+		t.Errorf("GetPickableMaps(B) = %v, want [2 3 4]", got)
+	}
+	if got := mapIDs(service.GetPickableMaps(pool, nil, constraints, "A")); !reflect.DeepEqual(got, []uint{4}) {
+		t.Errorf("GetPickableMaps(A) = %v, want [4]", got)
+	}
+	// Все доступные карты исключены для команды A - ограничение снимается
+	if got := mapIDs(service.GetPickableMaps(pool, actions, constraints, "A")); !reflect.DeepEqual(got, []uint{2, 3}) {
+		t.Errorf("GetPickableMaps(A) without pickable maps = %v, want [2 3]", got)
+	}
+	if got := mapIDs(service.GetAvailableMaps(pool, actions, nil)); !reflect.DeepEqual(got, []uint{1, 2, 3, 5}) {
+		t.Errorf("GetAvailableMaps() without constraints = %v, want [1 2 3 5]", got)
+	}
+}
+
+func TestCheckConstraints(t *testing.T) {
+	service := NewVetoLogicService()
+	decider := uint(7)
+	outside := uint(42)
+
+	tests := []struct {
+		name        string
+		vetoType    entities.VetoType
+		poolSize    int
+		constraints *entities.VetoConstraints
+		want        error
+	}{
+		{"no constraints", entities.VetoTypeBo3, 7, nil, nil},
+		{"forced decider keeps its slot", entities.VetoTypeBo3, 7, &entities.VetoConstraints{DeciderMapID: &decider}, nil},
+		{"excluded maps shrink the pool", entities.VetoTypeBo3, 8, &entities.VetoConstraints{ExcludedMapIDs: []uint{1, 2}}, ErrPoolTooSmall},
+		{"team exclusions keep the pool", entities.VetoTypeBo3, 7, &entities.VetoConstraints{TeamAExcludedMapIDs: []uint{1, 2, 3}}, nil},
+		{"map outside the pool", entities.VetoTypeBo3, 7, &entities.VetoConstraints{ExcludedMapIDs: []uint{outside}}, ErrInvalidConstraints},
+		{"decider in bo1", entities.VetoTypeBo1, 7, &entities.VetoConstraints{DeciderMapID: &decider}, ErrInvalidConstraints},
+		{"excluded decider", entities.VetoTypeBo3, 9, &entities.VetoConstraints{ExcludedMapIDs: []uint{decider}, DeciderMapID: &decider}, ErrInvalidConstraints},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := service.CheckConstraints(tt.vetoType, testMapPool(tt.poolSize), tt.constraints); got != tt.want {
+				t.Errorf("CheckConstraints() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
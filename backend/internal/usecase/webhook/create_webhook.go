@@ -0,0 +1,123 @@
+package webhook
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/url"
+	"strings"
+
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+)
+
+// maxWebhooksPerUser ограничение подписок пользователя вместе с подписками его комнат
+const maxWebhooksPerUser = 20
+
+type CreateWebhookUseCase struct {
+	webhookRepo repositories.WebhookRepository
+	roomRepo    repositories.RoomRepository
+}
+
+type CreateWebhookInput struct {
+	UserID uint
+	RoomID *uint // Подписка комнаты, которой владеет пользователь (опционально)
+	URL    string
+	Events []entities.WebhookEvent // Пустой список - все события
+}
+
+type CreateWebhookOutput struct {
+	Webhook *entities.Webhook
+}
+
+func NewCreateWebhookUseCase(webhookRepo repositories.WebhookRepository, roomRepo repositories.RoomRepository) *CreateWebhookUseCase {
+	return &CreateWebhookUseCase{
+		webhookRepo: webhookRepo,
+		roomRepo:    roomRepo,
+	}
+}
+
+func (uc *CreateWebhookUseCase) Execute(input CreateWebhookInput) (*CreateWebhookOutput, error) {
+	webhookURL, err := normalizeURL(input.URL)
+	if err != nil {
+		return nil, err
+	}
+	events, err := normalizeEvents(input.Events)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.RoomID != nil {
+		room, err := uc.roomRepo.GetByID(*input.RoomID)
+		if err != nil {
+			return nil, err
+		}
+		if room == nil {
+			return nil, ErrRoomNotFound
+		}
+		if room.OwnerID != input.UserID {
+			return nil, ErrUnauthorized
+		}
+	}
+
+	existing, err := uc.webhookRepo.GetByUserID(input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) >= maxWebhooksPerUser {
+		return nil, ErrTooManyWebhooks
+	}
+
+	secret, err := generateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	webhook := &entities.Webhook{
+		UserID:   input.UserID,
+		RoomID:   input.RoomID,
+		URL:      webhookURL,
+		Secret:   secret,
+		Events:   events,
+		IsActive: true,
+	}
+	if err := uc.webhookRepo.Create(webhook); err != nil {
+		return nil, err
+	}
+
+	return &CreateWebhookOutput{Webhook: webhook}, nil
+}
+
+// normalizeURL проверяет, что адрес подписки - абсолютный http(s) URL
+func normalizeURL(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	parsed, err := url.Parse(raw)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return "", ErrInvalidURL
+	}
+	return raw, nil
+}
+
+// normalizeEvents проверяет фильтр событий и убирает повторы
+func normalizeEvents(events []entities.WebhookEvent) ([]entities.WebhookEvent, error) {
+	normalized := []entities.WebhookEvent{}
+	seen := make(map[entities.WebhookEvent]bool, len(events))
+	for _, event := range events {
+		if !event.IsValid() {
+			return nil, ErrInvalidEvent
+		}
+		if !seen[event] {
+			seen[event] = true
+			normalized = append(normalized, event)
+		}
+	}
+	return normalized, nil
+}
+
+// generateSecret создает ключ подписи доставок
+func generateSecret() (string, error) {
+	bytes := make([]byte, 24) // 24 байта = 48 hex символов
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(bytes), nil
+}
@@ -0,0 +1,27 @@
+package webhook
+
+import "github.com/bbp/backend/internal/domain/repositories"
+
+type DeleteWebhookUseCase struct {
+	webhookRepo repositories.WebhookRepository
+}
+
+type DeleteWebhookInput struct {
+	WebhookID uint
+	UserID    uint
+}
+
+func NewDeleteWebhookUseCase(webhookRepo repositories.WebhookRepository) *DeleteWebhookUseCase {
+	return &DeleteWebhookUseCase{
+		webhookRepo: webhookRepo,
+	}
+}
+
+func (uc *DeleteWebhookUseCase) Execute(input DeleteWebhookInput) error {
+	webhook, err := loadOwnWebhook(uc.webhookRepo, input.WebhookID, input.UserID)
+	if err != nil {
+		return err
+	}
+
+	return uc.webhookRepo.Delete(webhook.ID)
+}
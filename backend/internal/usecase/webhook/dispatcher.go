@@ -0,0 +1,340 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+)
+
+const (
+	// Заголовки запроса доставки
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+
+	maxResponseBody = 1024 // Сколько байт ответа получателя сохраняется в журнале
+	dueBatchSize    = 50
+)
+
+// DeliveryPolicy повторные попытки и таймауты доставок
+type DeliveryPolicy struct {
+	MaxAttempts  int           // Попыток на доставку, после чего она помечается failed
+	BaseBackoff  time.Duration // Пауза после первой неудачи, каждая следующая вдвое дольше
+	MaxBackoff   time.Duration
+	Timeout      time.Duration // Таймаут запроса к получателю
+	PollInterval time.Duration // Как часто проверяются доставки, время повтора которых наступило
+	// Разрешить доставку на loopback, частные и link-local адреса (локальная разработка и тесты)
+	AllowPrivateNetworks bool
+}
+
+// DefaultDeliveryPolicy политика по умолчанию: 6 попыток в течение ~5 минут
+func DefaultDeliveryPolicy() DeliveryPolicy {
+	return DeliveryPolicy{
+		MaxAttempts:  6,
+		BaseBackoff:  10 * time.Second,
+		MaxBackoff:   time.Hour,
+		Timeout:      10 * time.Second,
+		PollInterval: 5 * time.Second,
+	}
+}
+
+// Event событие для подписок: подписки комнаты RoomID и подписки пользователя OwnerID
+type Event struct {
+	Type      entities.WebhookEvent
+	RoomID    *uint
+	OwnerID   *uint
+	SessionID *uint
+	Data      interface{}
+}
+
+// eventPayload тело запроса доставки
+type eventPayload struct {
+	Event     entities.WebhookEvent `json:"event"`
+	CreatedAt time.Time             `json:"created_at"`
+	RoomID    *uint                 `json:"room_id,omitempty"`
+	SessionID *uint                 `json:"session_id,omitempty"`
+	Data      interface{}           `json:"data"`
+}
+
+// Dispatcher сохраняет доставки событий в журнал и асинхронно отправляет их подписчикам
+// Доставка подписывается HMAC-SHA256 от "<timestamp>.<body>" ключом подписки
+type Dispatcher struct {
+	webhookRepo  repositories.WebhookRepository
+	deliveryRepo repositories.WebhookDeliveryRepository
+	policy       DeliveryPolicy
+	client       *http.Client
+	wake         chan struct{}
+}
+
+func NewDispatcher(
+	webhookRepo repositories.WebhookRepository,
+	deliveryRepo repositories.WebhookDeliveryRepository,
+	policy DeliveryPolicy,
+) *Dispatcher {
+	return &Dispatcher{
+		webhookRepo:  webhookRepo,
+		deliveryRepo: deliveryRepo,
+		policy:       policy,
+		client:       newDeliveryClient(policy),
+		wake:         make(chan struct{}, 1),
+	}
+}
+
+// NewVetoEvent событие сессии вето; владелец - владелец комнаты или создатель сессии без комнаты
+func NewVetoEvent(eventType entities.WebhookEvent, session *entities.VetoSession, room *entities.Room, data interface{}) Event {
+	event := Event{Type: eventType, SessionID: &session.ID, Data: data}
+	if room != nil {
+		event.RoomID = &room.ID
+		event.OwnerID = &room.OwnerID
+	} else {
+		event.OwnerID = session.UserID
+	}
+	return event
+}
+
+// PublishVetoEvent публикует событие действия в сессии,
+// а если действие завершило вето - еще и veto.finished с теми же данными
+func (d *Dispatcher) PublishVetoEvent(eventType entities.WebhookEvent, session *entities.VetoSession, room *entities.Room, data interface{}) {
+	if d == nil || session == nil {
+		return
+	}
+
+	d.Publish(NewVetoEvent(eventType, session, room, data))
+	switch eventType {
+	case entities.WebhookEventMapBanned, entities.WebhookEventMapPicked, entities.WebhookEventSideSelected:
+		if session.Status == entities.VetoStatusFinished {
+			d.Publish(NewVetoEvent(entities.WebhookEventVetoFinished, session, room, data))
+		}
+	}
+}
+
+// Publish создает доставки события для подходящих подписок и будит отправку
+// Ошибки только пишутся в лог: подписки не должны ломать действие, которое породило событие
+func (d *Dispatcher) Publish(event Event) {
+	if d == nil {
+		return
+	}
+
+	webhooks, err := d.subscribers(event)
+	if err != nil {
+		log.Printf("Failed to load webhooks for %s: %v", event.Type, err)
+		return
+	}
+	if len(webhooks) == 0 {
+		return
+	}
+
+	payload, err := json.Marshal(eventPayload{
+		Event:     event.Type,
+		CreatedAt: time.Now().UTC(),
+		RoomID:    event.RoomID,
+		SessionID: event.SessionID,
+		Data:      event.Data,
+	})
+	if err != nil {
+		log.Printf("Failed to encode webhook payload for %s: %v", event.Type, err)
+		return
+	}
+
+	for _, webhook := range webhooks {
+		if _, err := d.enqueue(webhook.ID, event.Type, string(payload), nil); err != nil {
+			log.Printf("Failed to enqueue webhook %d delivery for %s: %v", webhook.ID, event.Type, err)
+		}
+	}
+}
+
+// subscribers возвращает активные подписки комнаты и владельца, которые получают событие
+func (d *Dispatcher) subscribers(event Event) ([]entities.Webhook, error) {
+	var candidates []entities.Webhook
+	if event.RoomID != nil {
+		webhooks, err := d.webhookRepo.GetActiveByRoomID(*event.RoomID)
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, webhooks...)
+	}
+	if event.OwnerID != nil {
+		webhooks, err := d.webhookRepo.GetActiveByUser(*event.OwnerID)
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, webhooks...)
+	}
+
+	var webhooks []entities.Webhook
+	for _, webhook := range candidates {
+		if webhook.Subscribes(event.Type) {
+			webhooks = append(webhooks, webhook)
+		}
+	}
+	return webhooks, nil
+}
+
+// enqueue сохраняет доставку, готовую к немедленной отправке
+func (d *Dispatcher) enqueue(webhookID uint, event entities.WebhookEvent, payload string, redeliveryOf *uint) (*entities.WebhookDelivery, error) {
+	now := time.Now()
+	delivery := &entities.WebhookDelivery{
+		WebhookID:     webhookID,
+		Event:         event,
+		Payload:       payload,
+		Status:        entities.WebhookDeliveryStatusPending,
+		NextAttemptAt: &now,
+		RedeliveryOf:  redeliveryOf,
+	}
+	if err := d.deliveryRepo.Create(delivery); err != nil {
+		return nil, err
+	}
+
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+	return delivery, nil
+}
+
+// Run отправляет доставки, пока не отменен ctx
+// Доставки хранятся в базе, поэтому после перезапуска неотправленные продолжают отправляться
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.policy.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		d.DeliverDue()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-d.wake:
+		}
+	}
+}
+
+// DeliverDue отправляет доставки, время попытки которых наступило, и ждет их завершения
+func (d *Dispatcher) DeliverDue() {
+	deliveries, err := d.deliveryRepo.GetDue(time.Now(), dueBatchSize)
+	if err != nil {
+		log.Printf("Failed to load due webhook deliveries: %v", err)
+		return
+	}
+
+	var wg sync.WaitGroup
+	for i := range deliveries {
+		wg.Add(1)
+		go func(delivery *entities.WebhookDelivery) {
+			defer wg.Done()
+			if err := d.deliver(delivery); err != nil {
+				log.Printf("Failed to save webhook delivery %d: %v", delivery.ID, err)
+			}
+		}(&deliveries[i])
+	}
+	wg.Wait()
+}
+
+// deliver делает одну попытку доставки и сохраняет ее результат
+func (d *Dispatcher) deliver(delivery *entities.WebhookDelivery) error {
+	webhook, err := d.webhookRepo.GetByID(delivery.WebhookID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	delivery.Attempts++
+	delivery.ResponseStatus = nil
+	delivery.ResponseBody = ""
+	delivery.LastError = ""
+
+	switch {
+	case webhook == nil:
+		delivery.LastError = ErrWebhookNotFound.Error()
+		delivery.Attempts = d.policy.MaxAttempts
+	case !webhook.IsActive:
+		delivery.LastError = "webhook is disabled"
+		delivery.Attempts = d.policy.MaxAttempts
+	default:
+		status, body, err := d.send(webhook, delivery, now)
+		if err != nil {
+			delivery.LastError = err.Error()
+		} else {
+			delivery.ResponseStatus = &status
+			delivery.ResponseBody = body
+			if status < 200 || status >= 300 {
+				delivery.LastError = fmt.Sprintf("unexpected response status %d", status)
+			}
+		}
+	}
+
+	switch {
+	case delivery.LastError == "":
+		delivery.Status = entities.WebhookDeliveryStatusSucceeded
+		delivery.DeliveredAt = &now
+		delivery.NextAttemptAt = nil
+	case delivery.Attempts >= d.policy.MaxAttempts:
+		delivery.Status = entities.WebhookDeliveryStatusFailed
+		delivery.NextAttemptAt = nil
+	default:
+		next := now.Add(d.backoff(delivery.Attempts))
+		delivery.NextAttemptAt = &next
+	}
+	return d.deliveryRepo.Update(delivery)
+}
+
+// send отправляет тело доставки и возвращает статус и начало ответа получателя
+func (d *Dispatcher) send(webhook *entities.Webhook, delivery *entities.WebhookDelivery, now time.Time) (int, string, error) {
+	req, err := http.NewRequest(http.MethodPost, webhook.URL, bytes.NewBufferString(delivery.Payload))
+	if err != nil {
+		return 0, "", err
+	}
+
+	timestamp := now.Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "BBP-Webhooks/1.0")
+	req.Header.Set(HeaderEvent, string(delivery.Event))
+	req.Header.Set(HeaderDelivery, strconv.FormatUint(uint64(delivery.ID), 10))
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(webhook.Secret, timestamp, []byte(delivery.Payload)))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	return resp.StatusCode, string(body), nil
+}
+
+// backoff пауза после attempts неудачных попыток
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	delay := d.policy.BaseBackoff
+	for i := 1; i < attempts && delay < d.policy.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > d.policy.MaxBackoff {
+		delay = d.policy.MaxBackoff
+	}
+	return delay
+}
+
+// Sign возвращает значение заголовка X-Webhook-Signature:
+// "sha256=" и hex HMAC-SHA256 строки "<timestamp>.<body>" ключом подписки
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,14 @@
+package webhook
+
+import "errors"
+
+var (
+	ErrWebhookNotFound   = errors.New("webhook not found")
+	ErrDeliveryNotFound  = errors.New("webhook delivery not found")
+	ErrUnauthorized      = errors.New("unauthorized")
+	ErrRoomNotFound      = errors.New("room not found")
+	ErrInvalidURL        = errors.New("webhook url must be an absolute http or https url")
+	ErrInvalidEvent      = errors.New("unknown webhook event")
+	ErrTooManyWebhooks   = errors.New("webhook limit reached")
+	ErrAddressNotAllowed = errors.New("webhook address is not allowed")
+)
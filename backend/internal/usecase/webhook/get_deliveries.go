@@ -0,0 +1,46 @@
+package webhook
+
+import (
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+)
+
+type GetDeliveriesUseCase struct {
+	webhookRepo  repositories.WebhookRepository
+	deliveryRepo repositories.WebhookDeliveryRepository
+}
+
+type GetDeliveriesInput struct {
+	WebhookID uint
+	UserID    uint
+	Limit     int
+	Offset    int
+}
+
+type GetDeliveriesOutput struct {
+	Deliveries []entities.WebhookDelivery
+}
+
+func NewGetDeliveriesUseCase(
+	webhookRepo repositories.WebhookRepository,
+	deliveryRepo repositories.WebhookDeliveryRepository,
+) *GetDeliveriesUseCase {
+	return &GetDeliveriesUseCase{
+		webhookRepo:  webhookRepo,
+		deliveryRepo: deliveryRepo,
+	}
+}
+
+func (uc *GetDeliveriesUseCase) Execute(input GetDeliveriesInput) (*GetDeliveriesOutput, error) {
+	webhook, err := loadOwnWebhook(uc.webhookRepo, input.WebhookID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries, err := uc.deliveryRepo.GetByWebhookID(webhook.ID, input.Limit, input.Offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetDeliveriesOutput{Deliveries: deliveries}, nil
+}
@@ -0,0 +1,44 @@
+package webhook
+
+import (
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+)
+
+type GetWebhooksUseCase struct {
+	webhookRepo repositories.WebhookRepository
+}
+
+type GetWebhooksOutput struct {
+	Webhooks []entities.Webhook
+}
+
+func NewGetWebhooksUseCase(webhookRepo repositories.WebhookRepository) *GetWebhooksUseCase {
+	return &GetWebhooksUseCase{
+		webhookRepo: webhookRepo,
+	}
+}
+
+func (uc *GetWebhooksUseCase) Execute(userID uint) (*GetWebhooksOutput, error) {
+	webhooks, err := uc.webhookRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetWebhooksOutput{Webhooks: webhooks}, nil
+}
+
+// loadOwnWebhook загружает подписку и проверяет, что она принадлежит пользователю
+func loadOwnWebhook(webhookRepo repositories.WebhookRepository, webhookID, userID uint) (*entities.Webhook, error) {
+	webhook, err := webhookRepo.GetByID(webhookID)
+	if err != nil {
+		return nil, err
+	}
+	if webhook == nil {
+		return nil, ErrWebhookNotFound
+	}
+	if webhook.UserID != userID {
+		return nil, ErrUnauthorized
+	}
+	return webhook, nil
+}
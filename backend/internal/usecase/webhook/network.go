@@ -0,0 +1,81 @@
+package webhook
+
+import (
+	"net"
+	"net/http"
+	"syscall"
+)
+
+// Диапазоны, не покрытые методами net.IP: служебные и зарезервированные сети,
+// CGNAT и NAT64, через который IPv6 адрес может указывать на внутренний IPv4
+var reservedNetworks = mustParseCIDRs(
+	"0.0.0.0/8",
+	"100.64.0.0/10",
+	"192.0.0.0/24",
+	"198.18.0.0/15",
+	"240.0.0.0/4",
+	"64:ff9b::/96",
+)
+
+// newDeliveryClient HTTP клиент доставок. Адрес получателя проверяется при подключении,
+// уже после разрешения DNS, поэтому подменить его через DNS rebinding не получится.
+// Редиректы не выполняются: ответ 3xx сохраняется как неудачная доставка
+func newDeliveryClient(policy DeliveryPolicy) *http.Client {
+	dialer := &net.Dialer{Timeout: policy.Timeout}
+	if !policy.AllowPrivateNetworks {
+		dialer.Control = checkDialAddress
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+	// Через прокси подключение шло бы к адресу прокси, а не получателя
+	transport.Proxy = nil
+
+	return &http.Client{
+		Timeout:   policy.Timeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// checkDialAddress запрещает подключения к локальным и внутренним адресам
+func checkDialAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !isPublicIP(ip) {
+		return ErrAddressNotAllowed
+	}
+	return nil
+}
+
+// isPublicIP сообщает, что адрес не loopback, не link-local, не частный и не служебный
+func isPublicIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return false
+	}
+	for _, network := range reservedNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}
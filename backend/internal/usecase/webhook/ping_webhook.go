@@ -0,0 +1,51 @@
+package webhook
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+)
+
+// PingWebhookUseCase отправляет подписке тестовое событие ping, например чтобы проверить получателя
+type PingWebhookUseCase struct {
+	webhookRepo repositories.WebhookRepository
+	dispatcher  *Dispatcher
+}
+
+type PingWebhookInput struct {
+	WebhookID uint
+	UserID    uint
+}
+
+func NewPingWebhookUseCase(webhookRepo repositories.WebhookRepository, dispatcher *Dispatcher) *PingWebhookUseCase {
+	return &PingWebhookUseCase{
+		webhookRepo: webhookRepo,
+		dispatcher:  dispatcher,
+	}
+}
+
+func (uc *PingWebhookUseCase) Execute(input PingWebhookInput) (*DeliveryOutput, error) {
+	webhook, err := loadOwnWebhook(uc.webhookRepo, input.WebhookID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := json.Marshal(eventPayload{
+		Event:     entities.WebhookEventPing,
+		CreatedAt: time.Now().UTC(),
+		RoomID:    webhook.RoomID,
+		Data:      map[string]interface{}{"webhook_id": webhook.ID},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	delivery, err := uc.dispatcher.enqueue(webhook.ID, entities.WebhookEventPing, string(payload), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DeliveryOutput{Delivery: delivery}, nil
+}
@@ -0,0 +1,57 @@
+package webhook
+
+import (
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+)
+
+// RedeliverUseCase повторяет доставку вручную: создает новую доставку с тем же телом
+type RedeliverUseCase struct {
+	webhookRepo  repositories.WebhookRepository
+	deliveryRepo repositories.WebhookDeliveryRepository
+	dispatcher   *Dispatcher
+}
+
+type RedeliverInput struct {
+	WebhookID  uint
+	DeliveryID uint
+	UserID     uint
+}
+
+type DeliveryOutput struct {
+	Delivery *entities.WebhookDelivery
+}
+
+func NewRedeliverUseCase(
+	webhookRepo repositories.WebhookRepository,
+	deliveryRepo repositories.WebhookDeliveryRepository,
+	dispatcher *Dispatcher,
+) *RedeliverUseCase {
+	return &RedeliverUseCase{
+		webhookRepo:  webhookRepo,
+		deliveryRepo: deliveryRepo,
+		dispatcher:   dispatcher,
+	}
+}
+
+func (uc *RedeliverUseCase) Execute(input RedeliverInput) (*DeliveryOutput, error) {
+	webhook, err := loadOwnWebhook(uc.webhookRepo, input.WebhookID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	original, err := uc.deliveryRepo.GetByID(input.DeliveryID)
+	if err != nil {
+		return nil, err
+	}
+	if original == nil || original.WebhookID != webhook.ID {
+		return nil, ErrDeliveryNotFound
+	}
+
+	delivery, err := uc.dispatcher.enqueue(webhook.ID, original.Event, original.Payload, &original.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DeliveryOutput{Delivery: delivery}, nil
+}
@@ -0,0 +1,69 @@
+package webhook
+
+import (
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/domain/repositories"
+)
+
+type UpdateWebhookUseCase struct {
+	webhookRepo repositories.WebhookRepository
+}
+
+// UpdateWebhookInput изменяет только заданные поля
+type UpdateWebhookInput struct {
+	WebhookID    uint
+	UserID       uint
+	URL          *string
+	Events       *[]entities.WebhookEvent
+	IsActive     *bool
+	RotateSecret bool // Выдать новый ключ подписи; старый перестает действовать сразу
+}
+
+type UpdateWebhookOutput struct {
+	Webhook       *entities.Webhook
+	SecretRotated bool
+}
+
+func NewUpdateWebhookUseCase(webhookRepo repositories.WebhookRepository) *UpdateWebhookUseCase {
+	return &UpdateWebhookUseCase{
+		webhookRepo: webhookRepo,
+	}
+}
+
+func (uc *UpdateWebhookUseCase) Execute(input UpdateWebhookInput) (*UpdateWebhookOutput, error) {
+	webhook, err := loadOwnWebhook(uc.webhookRepo, input.WebhookID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.URL != nil {
+		webhookURL, err := normalizeURL(*input.URL)
+		if err != nil {
+			return nil, err
+		}
+		webhook.URL = webhookURL
+	}
+	if input.Events != nil {
+		events, err := normalizeEvents(*input.Events)
+		if err != nil {
+			return nil, err
+		}
+		webhook.Events = events
+	}
+	if input.IsActive != nil {
+		webhook.IsActive = *input.IsActive
+	}
+	if input.RotateSecret {
+		secret, err := generateSecret()
+		if err != nil {
+			return nil, err
+		}
+		webhook.Secret = secret
+	}
+
+	if err := uc.webhookRepo.Update(webhook); err != nil {
+		return nil, err
+	}
+
+	return &UpdateWebhookOutput{Webhook: webhook, SecretRotated: input.RotateSecret}, nil
+}
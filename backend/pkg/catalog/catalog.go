@@ -0,0 +1,235 @@
+package catalog
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SchemaVersion текущая версия формата файлов каталога
+const SchemaVersion = 1
+
+// defaultFiles содержит каталоги, поставляемые вместе с бинарником
+//
+//go:embed data/*.yaml
+var defaultFiles embed.FS
+
+// Catalog описывает игры, их карты и системные пулы
+type Catalog struct {
+	Games []Game
+}
+
+// File соответствует одному файлу каталога
+type File struct {
+	Version int    `json:"version" yaml:"version"`
+	Games   []Game `json:"games" yaml:"games"`
+}
+
+// Game описывает игру в каталоге
+type Game struct {
+	Slug     string `json:"slug" yaml:"slug"`
+	Name     string `json:"name" yaml:"name"`
+	IsActive *bool  `json:"is_active,omitempty" yaml:"is_active,omitempty"` // По умолчанию true
+	Maps     []Map  `json:"maps" yaml:"maps"`
+	Pools    []Pool `json:"pools,omitempty" yaml:"pools,omitempty"`
+}
+
+// Map описывает карту в каталоге
+type Map struct {
+	Slug          string `json:"slug" yaml:"slug"`
+	Name          string `json:"name" yaml:"name"`
+	ImageURL      string `json:"image_url,omitempty" yaml:"image_url,omitempty"`
+	IsCompetitive bool   `json:"is_competitive" yaml:"is_competitive"`
+	IsActive      *bool  `json:"is_active,omitempty" yaml:"is_active,omitempty"` // По умолчанию true
+}
+
+// Pool описывает системный пул карт
+// Если Maps не указан, состав пула вычисляется по типу:
+// all - все активные карты, competitive - активные соревновательные карты
+type Pool struct {
+	Type string   `json:"type" yaml:"type"` // all или competitive
+	Name string   `json:"name" yaml:"name"`
+	Maps []string `json:"maps,omitempty" yaml:"maps,omitempty"` // Slug'и карт
+}
+
+// Active возвращает флаг активности игры с учетом значения по умолчанию
+func (g *Game) Active() bool {
+	return g.IsActive == nil || *g.IsActive
+}
+
+// Active возвращает флаг активности карты с учетом значения по умолчанию
+func (m *Map) Active() bool {
+	return m.IsActive == nil || *m.IsActive
+}
+
+// PoolMaps возвращает slug'и карт, входящих в пул
+func (g *Game) PoolMaps(pool Pool) []string {
+	if len(pool.Maps) > 0 {
+		return pool.Maps
+	}
+
+	slugs := make([]string, 0, len(g.Maps))
+	for _, m := range g.Maps {
+		if !m.Active() {
+			continue
+		}
+		if pool.Type == "competitive" && !m.IsCompetitive {
+			continue
+		}
+		slugs = append(slugs, m.Slug)
+	}
+	return slugs
+}
+
+// LoadDefault загружает встроенные каталоги
+func LoadDefault() (*Catalog, error) {
+	return loadFS(defaultFiles, "data")
+}
+
+// Load загружает каталог из файла или из всех .yaml/.yml/.json файлов директории
+func Load(path string) (*Catalog, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return loadFS(os.DirFS(path), ".")
+	}
+
+	file, err := readFile(os.DirFS(filepath.Dir(path)), filepath.Base(path))
+	if err != nil {
+		return nil, err
+	}
+	return merge([]*File{file})
+}
+
+func loadFS(fsys fs.FS, dir string) (*Catalog, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !isCatalogFile(entry.Name()) {
+			continue
+		}
+		names = append(names, entry.Name())
+	}
+	// Файлы читаются по имени (01-valorant.yaml, 02-cs2.yaml, ...),
+	// чтобы ID новых игр не зависели от порядка в ФС
+	sort.Strings(names)
+
+	files := make([]*File, 0, len(names))
+	for _, name := range names {
+		file, err := readFile(fsys, filepath.ToSlash(filepath.Join(dir, name)))
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+
+	return merge(files)
+}
+
+func isCatalogFile(name string) bool {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".yaml", ".yml", ".json":
+		return true
+	}
+	return false
+}
+
+func readFile(fsys fs.FS, name string) (*File, error) {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+
+	file := &File{}
+	if strings.ToLower(filepath.Ext(name)) == ".json" {
+		err = json.Unmarshal(data, file)
+	} else {
+		err = yaml.Unmarshal(data, file)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	if file.Version != SchemaVersion {
+		return nil, fmt.Errorf("%s: unsupported catalog version %d (expected %d)", name, file.Version, SchemaVersion)
+	}
+	return file, nil
+}
+
+func merge(files []*File) (*Catalog, error) {
+	catalog := &Catalog{}
+	seen := make(map[string]bool)
+	for _, file := range files {
+		for _, game := range file.Games {
+			if seen[game.Slug] {
+				return nil, fmt.Errorf("game %q is defined more than once", game.Slug)
+			}
+			seen[game.Slug] = true
+			catalog.Games = append(catalog.Games, game)
+		}
+	}
+
+	if err := catalog.Validate(); err != nil {
+		return nil, err
+	}
+	return catalog, nil
+}
+
+// Validate проверяет целостность каталога
+func (c *Catalog) Validate() error {
+	if len(c.Games) == 0 {
+		return errors.New("catalog has no games")
+	}
+
+	for _, game := range c.Games {
+		if game.Slug == "" || game.Name == "" {
+			return errors.New("game slug and name are required")
+		}
+
+		mapSlugs := make(map[string]bool, len(game.Maps))
+		for _, m := range game.Maps {
+			if m.Slug == "" || m.Name == "" {
+				return fmt.Errorf("game %s: map slug and name are required", game.Slug)
+			}
+			if mapSlugs[m.Slug] {
+				return fmt.Errorf("game %s: map %q is defined more than once", game.Slug, m.Slug)
+			}
+			mapSlugs[m.Slug] = true
+		}
+
+		poolTypes := make(map[string]bool, len(game.Pools))
+		for _, pool := range game.Pools {
+			if pool.Type != "all" && pool.Type != "competitive" {
+				return fmt.Errorf("game %s: invalid pool type %q", game.Slug, pool.Type)
+			}
+			if pool.Name == "" {
+				return fmt.Errorf("game %s: pool name is required", game.Slug)
+			}
+			if poolTypes[pool.Type] {
+				return fmt.Errorf("game %s: pool %q is defined more than once", game.Slug, pool.Type)
+			}
+			poolTypes[pool.Type] = true
+			for _, slug := range pool.Maps {
+				if !mapSlugs[slug] {
+					return fmt.Errorf("game %s: pool %s references unknown map %q", game.Slug, pool.Type, slug)
+				}
+			}
+		}
+	}
+
+	return nil
+}
@@ -1,6 +1,8 @@
 package jwt
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"time"
 
@@ -11,13 +13,23 @@ import (
 type Claims struct {
 	UserID   uint   `json:"user_id"`
 	Username string `json:"username"`
+	Guest    bool   `json:"guest,omitempty"` // Гостевой аккаунт с ограниченными правами
 	jwt.RegisteredClaims
 }
 
+// ErrTokenRevoked возвращается для отозванных токенов (logout)
+var ErrTokenRevoked = errors.New("token has been revoked")
+
+// Denylist проверяет, отозван ли токен по его идентификатору (jti)
+type Denylist interface {
+	IsRevoked(jti string) (bool, error)
+}
+
 // JWTService предоставляет методы для работы с JWT токенами
 type JWTService struct {
 	secret     []byte
 	expiration time.Duration
+	denylist   Denylist
 }
 
 // NewJWTService создает новый сервис для работы с JWT
@@ -28,13 +40,36 @@ func NewJWTService(secret string, expiration time.Duration) *JWTService {
 	}
 }
 
+// SetDenylist подключает список отозванных токенов, который проверяется в ValidateToken
+func (s *JWTService) SetDenylist(denylist Denylist) {
+	s.denylist = denylist
+}
+
+// Expiration возвращает время жизни access токена
+func (s *JWTService) Expiration() time.Duration {
+	return s.expiration
+}
+
 // GenerateToken генерирует JWT токен для пользователя
 func (s *JWTService) GenerateToken(userID uint, username string) (string, error) {
+	tokenString, _, err := s.IssueToken(userID, username, false)
+	return tokenString, err
+}
+
+// IssueToken генерирует JWT токен и возвращает его claims (jti и срок действия)
+func (s *JWTService) IssueToken(userID uint, username string, guest bool) (string, *Claims, error) {
+	jti, err := generateID()
+	if err != nil {
+		return "", nil, err
+	}
+
 	expirationTime := time.Now().Add(s.expiration)
 	claims := &Claims{
 		UserID:   userID,
 		Username: username,
+		Guest:    guest,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        jti,
 			ExpiresAt: jwt.NewNumericDate(expirationTime),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
@@ -43,10 +78,10 @@ func (s *JWTService) GenerateToken(userID uint, username string) (string, error)
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	tokenString, err := token.SignedString(s.secret)
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
 
-	return tokenString, nil
+	return tokenString, claims, nil
 }
 
 // ValidateToken проверяет и парсит JWT токен
@@ -69,5 +104,25 @@ func (s *JWTService) ValidateToken(tokenString string) (*Claims, error) {
 		return nil, errors.New("invalid token")
 	}
 
+	// Проверяем, не отозван ли токен
+	if s.denylist != nil && claims.ID != "" {
+		revoked, err := s.denylist.IsRevoked(claims.ID)
+		if err != nil {
+			return nil, err
+		}
+		if revoked {
+			return nil, ErrTokenRevoked
+		}
+	}
+
 	return claims, nil
-}
\ No newline at end of file
+}
+
+// generateID генерирует случайный идентификатор токена
+func generateID() (string, error) {
+	bytes := make([]byte, 16)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
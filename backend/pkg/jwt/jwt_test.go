@@ -146,3 +146,32 @@ func TestTokenWithDifferentUsers(t *testing.T) {
 		t.Errorf("Token2 claims = %+v, want UserID=2, Username=user2", claims2)
 	}
 }
+
+type mapDenylist map[string]bool
+
+func (d mapDenylist) IsRevoked(jti string) (bool, error) {
+	return d[jti], nil
+}
+
+func TestValidateToken_Denylist(t *testing.T) {
+	service := NewJWTService("test-secret-key", time.Hour)
+	denylist := mapDenylist{}
+	service.SetDenylist(denylist)
+
+	token, claims, err := service.IssueToken(1, "user1", false)
+	if err != nil {
+		t.Fatalf("IssueToken() error = %v", err)
+	}
+	if claims.ID == "" {
+		t.Fatal("IssueToken() should set jti")
+	}
+
+	if _, err := service.ValidateToken(token); err != nil {
+		t.Fatalf("ValidateToken() error = %v", err)
+	}
+
+	denylist[claims.ID] = true
+	if _, err := service.ValidateToken(token); err != ErrTokenRevoked {
+		t.Errorf("ValidateToken() error = %v, want ErrTokenRevoked", err)
+	}
+}
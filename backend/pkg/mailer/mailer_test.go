@@ -0,0 +1,38 @@
+package mailer
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestFileMailer_Send(t *testing.T) {
+	dir := t.TempDir()
+	m := NewFileMailer(dir)
+
+	if err := m.Send(Message{To: "user@example.com", Subject: "Hello", Body: "link"}); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir() error = %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 file, got %d", len(entries))
+	}
+
+	data, err := os.ReadFile(dir + "/" + entries[0].Name())
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if !strings.Contains(string(data), "Subject: Hello") || !strings.Contains(string(data), "link") {
+		t.Errorf("unexpected file content: %s", data)
+	}
+}
+
+func TestNew_UnknownDriver(t *testing.T) {
+	if _, err := New("smtp-unknown", ""); err == nil {
+		t.Error("expected error for unknown driver")
+	}
+}
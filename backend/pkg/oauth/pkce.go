@@ -0,0 +1,31 @@
+package oauth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+)
+
+// GenerateCodeVerifier генерирует code_verifier для PKCE (RFC 7636)
+func GenerateCodeVerifier() (string, error) {
+	return randomURLSafe(32)
+}
+
+// GenerateState генерирует случайный параметр state для защиты от CSRF
+func GenerateState() (string, error) {
+	return randomURLSafe(24)
+}
+
+// CodeChallengeS256 вычисляет code_challenge по методу S256
+func CodeChallengeS256(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func randomURLSafe(n int) (string, error) {
+	bytes := make([]byte, n)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(bytes), nil
+}
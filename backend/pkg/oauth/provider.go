@@ -0,0 +1,206 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+var (
+	ErrExchangeFailed = errors.New("oauth code exchange failed")
+	ErrUserInfoFailed = errors.New("oauth user info request failed")
+)
+
+// UserInfo данные пользователя, полученные от провайдера
+type UserInfo struct {
+	Subject       string // Стабильный идентификатор пользователя у провайдера
+	Email         string
+	EmailVerified bool
+	Username      string // Предпочитаемое имя пользователя (может быть пустым)
+}
+
+// Provider провайдер OAuth2/OIDC авторизации
+type Provider interface {
+	Name() string
+	// AuthCodeURL возвращает URL страницы авторизации провайдера
+	AuthCodeURL(state, codeChallenge string) string
+	// Exchange обменивает код авторизации на данные пользователя
+	Exchange(ctx context.Context, code, codeVerifier string) (*UserInfo, error)
+}
+
+// UserInfoMapper преобразует ответ userinfo endpoint провайдера в UserInfo
+type UserInfoMapper func(claims map[string]interface{}) *UserInfo
+
+// Config настройки OAuth2 провайдера
+type Config struct {
+	Name         string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	AuthURL      string
+	TokenURL     string
+	UserInfoURL  string
+	Scopes       []string
+	// MapUserInfo по умолчанию разбирает стандартные OIDC claims
+	MapUserInfo UserInfoMapper
+	HTTPClient  *http.Client
+}
+
+// OAuth2Provider реализация Provider по схеме authorization code + PKCE (S256)
+// Данные пользователя берутся из userinfo endpoint по access токену,
+// поэтому подпись id_token не проверяется
+type OAuth2Provider struct {
+	cfg Config
+}
+
+func NewProvider(cfg Config) *OAuth2Provider {
+	if cfg.MapUserInfo == nil {
+		cfg.MapUserInfo = MapOIDCUserInfo
+	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &OAuth2Provider{cfg: cfg}
+}
+
+func (p *OAuth2Provider) Name() string {
+	return p.cfg.Name
+}
+
+func (p *OAuth2Provider) AuthCodeURL(state, codeChallenge string) string {
+	params := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {p.cfg.ClientID},
+		"redirect_uri":          {p.cfg.RedirectURL},
+		"scope":                 {strings.Join(p.cfg.Scopes, " ")},
+		"state":                 {state},
+		"code_challenge":        {codeChallenge},
+		"code_challenge_method": {"S256"},
+	}
+
+	separator := "?"
+	if strings.Contains(p.cfg.AuthURL, "?") {
+		separator = "&"
+	}
+	return p.cfg.AuthURL + separator + params.Encode()
+}
+
+func (p *OAuth2Provider) Exchange(ctx context.Context, code, codeVerifier string) (*UserInfo, error) {
+	accessToken, err := p.exchangeCode(ctx, code, codeVerifier)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, err := p.fetchUserInfo(ctx, accessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	info := p.cfg.MapUserInfo(claims)
+	if info == nil || info.Subject == "" {
+		return nil, fmt.Errorf("%w: subject is missing", ErrUserInfoFailed)
+	}
+	return info, nil
+}
+
+func (p *OAuth2Provider) exchangeCode(ctx context.Context, code, codeVerifier string) (string, error) {
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {p.cfg.RedirectURL},
+		"client_id":     {p.cfg.ClientID},
+		"client_secret": {p.cfg.ClientSecret},
+		"code_verifier": {codeVerifier},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.cfg.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	var tokenResponse struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := p.doJSON(req, &tokenResponse); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrExchangeFailed, err)
+	}
+	if tokenResponse.AccessToken == "" {
+		return "", fmt.Errorf("%w: access_token is missing", ErrExchangeFailed)
+	}
+
+	return tokenResponse.AccessToken, nil
+}
+
+func (p *OAuth2Provider) fetchUserInfo(ctx context.Context, accessToken string) (map[string]interface{}, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.cfg.UserInfoURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Accept", "application/json")
+
+	var claims map[string]interface{}
+	if err := p.doJSON(req, &claims); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUserInfoFailed, err)
+	}
+	return claims, nil
+}
+
+func (p *OAuth2Provider) doJSON(req *http.Request, target interface{}) error {
+	resp, err := p.cfg.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	return json.Unmarshal(body, target)
+}
+
+// MapOIDCUserInfo разбирает стандартные OIDC claims (sub, email, email_verified, preferred_username, name)
+func MapOIDCUserInfo(claims map[string]interface{}) *UserInfo {
+	info := &UserInfo{
+		Subject:       stringClaim(claims, "sub"),
+		Email:         stringClaim(claims, "email"),
+		EmailVerified: boolClaim(claims, "email_verified"),
+		Username:      stringClaim(claims, "preferred_username"),
+	}
+	if info.Username == "" {
+		info.Username = stringClaim(claims, "name")
+	}
+	return info
+}
+
+func stringClaim(claims map[string]interface{}, key string) string {
+	if value, ok := claims[key].(string); ok {
+		return value
+	}
+	return ""
+}
+
+// boolClaim учитывает, что некоторые провайдеры отдают булевы claims строкой
+func boolClaim(claims map[string]interface{}, key string) bool {
+	switch value := claims[key].(type) {
+	case bool:
+		return value
+	case string:
+		return value == "true"
+	default:
+		return false
+	}
+}
@@ -0,0 +1,65 @@
+package oauth
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/bbp/backend/pkg/oauth/oauthtest"
+)
+
+func TestOIDCProvider_AuthorizationCodeFlow(t *testing.T) {
+	fake := oauthtest.NewProvider("client", "secret")
+	defer fake.Close()
+	fake.SetUser(oauthtest.User{Subject: "42", Email: "player@example.com", EmailVerified: true, Username: "player"})
+
+	provider, err := DiscoverOIDCProvider(context.Background(), "test", fake.Issuer(), "client", "secret", "http://localhost/callback")
+	if err != nil {
+		t.Fatalf("DiscoverOIDCProvider() error = %v", err)
+	}
+
+	verifier, err := GenerateCodeVerifier()
+	if err != nil {
+		t.Fatalf("GenerateCodeVerifier() error = %v", err)
+	}
+
+	// Проходим страницу авторизации без следования редиректу
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	resp, err := client.Get(provider.AuthCodeURL("state-1", CodeChallengeS256(verifier)))
+	if err != nil {
+		t.Fatalf("authorize request error = %v", err)
+	}
+	resp.Body.Close()
+
+	location, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		t.Fatalf("invalid redirect: %v", err)
+	}
+	if location.Query().Get("state") != "state-1" {
+		t.Fatalf("state was not passed back: %s", location)
+	}
+	code := location.Query().Get("code")
+
+	// Неверный code_verifier отклоняется
+	if _, err := provider.Exchange(context.Background(), code, "wrong-verifier"); err == nil {
+		t.Fatal("expected PKCE verification error")
+	}
+
+	resp, err = client.Get(provider.AuthCodeURL("state-2", CodeChallengeS256(verifier)))
+	if err != nil {
+		t.Fatalf("authorize request error = %v", err)
+	}
+	resp.Body.Close()
+	location, _ = url.Parse(resp.Header.Get("Location"))
+
+	info, err := provider.Exchange(context.Background(), location.Query().Get("code"), verifier)
+	if err != nil {
+		t.Fatalf("Exchange() error = %v", err)
+	}
+	if info.Subject != "42" || info.Email != "player@example.com" || !info.EmailVerified || info.Username != "player" {
+		t.Errorf("unexpected user info: %+v", info)
+	}
+}
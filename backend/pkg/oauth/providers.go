@@ -0,0 +1,84 @@
+package oauth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// NewDiscordProvider создает провайдер Discord (OAuth2, не OIDC)
+func NewDiscordProvider(clientID, clientSecret, redirectURL string) *OAuth2Provider {
+	return NewProvider(Config{
+		Name:         "discord",
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		RedirectURL:  redirectURL,
+		AuthURL:      "https://discord.com/oauth2/authorize",
+		TokenURL:     "https://discord.com/api/oauth2/token",
+		UserInfoURL:  "https://discord.com/api/users/@me",
+		Scopes:       []string{"identify", "email"},
+		MapUserInfo:  mapDiscordUserInfo,
+	})
+}
+
+// NewGoogleProvider создает провайдер Google (OIDC)
+func NewGoogleProvider(clientID, clientSecret, redirectURL string) *OAuth2Provider {
+	return NewProvider(Config{
+		Name:         "google",
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		RedirectURL:  redirectURL,
+		AuthURL:      "https://accounts.google.com/o/oauth2/v2/auth",
+		TokenURL:     "https://oauth2.googleapis.com/token",
+		UserInfoURL:  "https://openidconnect.googleapis.com/v1/userinfo",
+		Scopes:       []string{"openid", "email", "profile"},
+	})
+}
+
+// discoveryDocument поля OpenID Provider Metadata, нужные для авторизации
+type discoveryDocument struct {
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	UserInfoEndpoint      string `json:"userinfo_endpoint"`
+}
+
+// DiscoverOIDCProvider создает провайдер для произвольного OIDC issuer через discovery
+func DiscoverOIDCProvider(ctx context.Context, name, issuer, clientID, clientSecret, redirectURL string) (*OAuth2Provider, error) {
+	discoveryURL := strings.TrimRight(issuer, "/") + "/.well-known/openid-configuration"
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, discoveryURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	provider := NewProvider(Config{Name: name})
+	var doc discoveryDocument
+	if err := provider.doJSON(req, &doc); err != nil {
+		return nil, fmt.Errorf("oidc discovery failed: %w", err)
+	}
+	if doc.AuthorizationEndpoint == "" || doc.TokenEndpoint == "" || doc.UserInfoEndpoint == "" {
+		return nil, fmt.Errorf("oidc discovery failed: incomplete provider metadata")
+	}
+
+	return NewProvider(Config{
+		Name:         name,
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		RedirectURL:  redirectURL,
+		AuthURL:      doc.AuthorizationEndpoint,
+		TokenURL:     doc.TokenEndpoint,
+		UserInfoURL:  doc.UserInfoEndpoint,
+		Scopes:       []string{"openid", "email", "profile"},
+	}), nil
+}
+
+// mapDiscordUserInfo разбирает ответ /users/@me
+func mapDiscordUserInfo(claims map[string]interface{}) *UserInfo {
+	return &UserInfo{
+		Subject:       stringClaim(claims, "id"),
+		Email:         stringClaim(claims, "email"),
+		EmailVerified: boolClaim(claims, "verified"),
+		Username:      stringClaim(claims, "username"),
+	}
+}
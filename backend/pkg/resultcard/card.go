@@ -0,0 +1,318 @@
+package resultcard
+
+import (
+	"image"
+	"image/color"
+	"strings"
+	"sync"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+// Card карточка с итогом вето: команды, пики со сторонами, десайдер и баны
+type Card struct {
+	TeamA    string
+	TeamB    string
+	Subtitle string // Например, "CS2 · BO3"
+	Picks    []Tile
+	Decider  *Tile
+	Bans     []Tile
+}
+
+// Tile карта на карточке
+type Tile struct {
+	MapName string
+	Team    string      // "A", "B" или пусто для десайдера; определяет цвет подписи
+	Label   string      // Например, "Pick · Alpha"
+	Detail  string      // Например, сторона: "Bravo · CT"
+	Image   image.Image // nil - плитка без картинки
+}
+
+const (
+	cardWidth   = 1200
+	cardPadding = 40
+	tileGap     = 20
+	maxPickW    = 360
+	bansPerRow  = 6
+)
+
+var (
+	colorBackground = color.RGBA{0x10, 0x15, 0x1c, 0xff}
+	colorTile       = color.RGBA{0x1c, 0x23, 0x30, 0xff}
+	colorText       = color.RGBA{0xff, 0xff, 0xff, 0xff}
+	colorMuted      = color.RGBA{0x9a, 0xa4, 0xb2, 0xff}
+	colorTeamA      = color.RGBA{0x4f, 0x8c, 0xff, 0xff}
+	colorTeamB      = color.RGBA{0xff, 0x6b, 0x4f, 0xff}
+	colorDecider    = color.RGBA{0xf5, 0xc5, 0x42, 0xff}
+	colorBanShade   = color.RGBA{0x00, 0x00, 0x00, 0x80}
+)
+
+// Элементы разметки, которые одинаково рисуются в SVG и PNG
+type element interface{}
+
+type rectElement struct {
+	Rect  image.Rectangle
+	Color color.RGBA
+}
+
+type imageElement struct {
+	Rect      image.Rectangle
+	Image     image.Image
+	Grayscale bool
+}
+
+type textElement struct {
+	X, Y   int // Y - базовая линия
+	Text   string
+	Size   float64
+	Bold   bool
+	Color  color.RGBA
+	Center bool
+	// Строка из частей разного цвета; если задана, Text и Color не используются
+	Spans []textSpan
+}
+
+type textSpan struct {
+	Text  string
+	Color color.RGBA
+}
+
+func (t textElement) spans() []textSpan {
+	if t.Spans != nil {
+		return t.Spans
+	}
+	return []textSpan{{Text: t.Text, Color: t.Color}}
+}
+
+var (
+	fontsOnce   sync.Once
+	fontsErr    error
+	fontRegular *opentype.Font
+	fontBold    *opentype.Font
+)
+
+func loadFonts() error {
+	fontsOnce.Do(func() {
+		if fontRegular, fontsErr = opentype.Parse(goregular.TTF); fontsErr != nil {
+			return
+		}
+		fontBold, fontsErr = opentype.Parse(gobold.TTF)
+	})
+	return fontsErr
+}
+
+// faces шрифты нужных размеров; font.Face нельзя использовать из нескольких горутин,
+// поэтому набор создается на каждую отрисовку
+type faces struct {
+	cache map[faceKey]font.Face
+}
+
+type faceKey struct {
+	size float64
+	bold bool
+}
+
+func newFaces() (*faces, error) {
+	if err := loadFonts(); err != nil {
+		return nil, err
+	}
+	return &faces{cache: make(map[faceKey]font.Face)}, nil
+}
+
+func (f *faces) face(size float64, bold bool) font.Face {
+	key := faceKey{size: size, bold: bold}
+	if face, ok := f.cache[key]; ok {
+		return face
+	}
+	src := fontRegular
+	if bold {
+		src = fontBold
+	}
+	face, err := opentype.NewFace(src, &opentype.FaceOptions{Size: size, DPI: 72, Hinting: font.HintingFull})
+	if err != nil {
+		// Размеры фиксированы, ошибка возможна только при поврежденном шрифте
+		panic(err)
+	}
+	f.cache[key] = face
+	return face
+}
+
+func (f *faces) measure(text string, size float64, bold bool) int {
+	return font.MeasureString(f.face(size, bold), text).Ceil()
+}
+
+func (f *faces) close() {
+	for _, face := range f.cache {
+		face.Close()
+	}
+}
+
+// fit обрезает текст с многоточием, чтобы он поместился в ширину
+func (f *faces) fit(text string, size float64, bold bool, width int) string {
+	if f.measure(text, size, bold) <= width {
+		return text
+	}
+	runes := []rune(text)
+	for len(runes) > 0 {
+		runes = runes[:len(runes)-1]
+		candidate := strings.TrimSpace(string(runes)) + "…"
+		if f.measure(candidate, size, bold) <= width {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// layout раскладывает карточку на элементы и возвращает их вместе с размером изображения
+func layout(card Card, f *faces) ([]element, image.Point) {
+	var elements []element
+	y := cardPadding
+
+	// Заголовок "Команда A vs Команда B" с цветами команд
+	const titleSize = 40
+	maxTeamWidth := (cardWidth - 2*cardPadding - f.measure(" vs ", titleSize, true)) / 2
+	y += titleSize
+	elements = append(elements, textElement{
+		X: cardWidth / 2, Y: y, Size: titleSize, Bold: true, Center: true,
+		Spans: []textSpan{
+			{Text: f.fit(card.TeamA, titleSize, true, maxTeamWidth), Color: colorTeamA},
+			{Text: " vs ", Color: colorMuted},
+			{Text: f.fit(card.TeamB, titleSize, true, maxTeamWidth), Color: colorTeamB},
+		},
+	})
+	if card.Subtitle != "" {
+		y += 34
+		elements = append(elements, textElement{
+			X: cardWidth / 2, Y: y, Text: f.fit(card.Subtitle, 20, false, cardWidth-2*cardPadding),
+			Size: 20, Color: colorMuted, Center: true,
+		})
+	}
+	y += 30
+
+	// Сыгранные карты: пики и десайдер
+	played := append([]Tile{}, card.Picks...)
+	if card.Decider != nil {
+		played = append(played, *card.Decider)
+	}
+	if len(played) > 0 {
+		y = sectionTitle(&elements, "MAPS", y)
+		n := len(played)
+		width := (cardWidth - 2*cardPadding - (n-1)*tileGap) / n
+		if width > maxPickW {
+			width = maxPickW
+		}
+		x := (cardWidth - n*width - (n-1)*tileGap) / 2
+		height := 0
+		for _, tile := range played {
+			height = pickTile(&elements, f, tile, image.Rect(x, y, x+width, y))
+			x += width + tileGap
+		}
+		y += height + 30
+	}
+
+	// Баны
+	if len(card.Bans) > 0 {
+		y = sectionTitle(&elements, "BANS", y)
+		width := (cardWidth - 2*cardPadding - (bansPerRow-1)*tileGap) / bansPerRow
+		for start := 0; start < len(card.Bans); start += bansPerRow {
+			row := card.Bans[start:min(start+bansPerRow, len(card.Bans))]
+			x := (cardWidth - len(row)*width - (len(row)-1)*tileGap) / 2
+			height := 0
+			for _, tile := range row {
+				height = banTile(&elements, f, tile, image.Rect(x, y, x+width, y))
+				x += width + tileGap
+			}
+			y += height + tileGap
+		}
+		y -= tileGap
+	}
+
+	size := image.Pt(cardWidth, y+cardPadding)
+	elements = append([]element{rectElement{Rect: image.Rectangle{Max: size}, Color: colorBackground}}, elements...)
+	return elements, size
+}
+
+func sectionTitle(elements *[]element, title string, y int) int {
+	y += 16
+	*elements = append(*elements, textElement{X: cardPadding, Y: y, Text: title, Size: 16, Bold: true, Color: colorMuted})
+	return y + 14
+}
+
+// pickTile рисует плитку сыгранной карты и возвращает ее высоту
+func pickTile(elements *[]element, f *faces, tile Tile, r image.Rectangle) int {
+	accent := teamColor(tile.Team)
+	width := r.Dx()
+	imageHeight := width * 9 / 16
+	height := imageHeight + 100
+	r.Max.Y = r.Min.Y + height
+
+	*elements = append(*elements, rectElement{Rect: r, Color: colorTile})
+	imageRect := image.Rect(r.Min.X, r.Min.Y, r.Max.X, r.Min.Y+imageHeight)
+	if tile.Image != nil {
+		*elements = append(*elements, imageElement{Rect: imageRect, Image: tile.Image})
+	}
+	*elements = append(*elements, rectElement{Rect: image.Rect(r.Min.X, imageRect.Max.Y, r.Max.X, imageRect.Max.Y+4), Color: accent})
+
+	textWidth := width - 24
+	x := r.Min.X + 12
+	y := imageRect.Max.Y + 34
+	*elements = append(*elements, textElement{X: x, Y: y, Text: f.fit(tile.MapName, 24, true, textWidth), Size: 24, Bold: true, Color: colorText})
+	y += 26
+	*elements = append(*elements, textElement{X: x, Y: y, Text: f.fit(tile.Label, 17, true, textWidth), Size: 17, Bold: true, Color: accent})
+	if tile.Detail != "" {
+		y += 24
+		*elements = append(*elements, textElement{X: x, Y: y, Text: f.fit(tile.Detail, 16, false, textWidth), Size: 16, Color: colorMuted})
+	}
+	return height
+}
+
+// banTile рисует плитку забаненной карты (картинка в оттенках серого) и возвращает ее высоту
+func banTile(elements *[]element, f *faces, tile Tile, r image.Rectangle) int {
+	width := r.Dx()
+	imageHeight := width * 9 / 16
+	height := imageHeight + 58
+	r.Max.Y = r.Min.Y + height
+
+	*elements = append(*elements, rectElement{Rect: r, Color: colorTile})
+	imageRect := image.Rect(r.Min.X, r.Min.Y, r.Max.X, r.Min.Y+imageHeight)
+	if tile.Image != nil {
+		*elements = append(*elements,
+			imageElement{Rect: imageRect, Image: tile.Image, Grayscale: true},
+			rectElement{Rect: imageRect, Color: colorBanShade},
+		)
+	}
+
+	textWidth := width - 20
+	x := r.Min.X + 10
+	y := imageRect.Max.Y + 24
+	*elements = append(*elements, textElement{X: x, Y: y, Text: f.fit(tile.MapName, 17, true, textWidth), Size: 17, Bold: true, Color: colorText})
+	y += 22
+	*elements = append(*elements, textElement{X: x, Y: y, Text: f.fit(tile.Label, 14, false, textWidth), Size: 14, Color: teamColor(tile.Team)})
+	return height
+}
+
+func teamColor(team string) color.RGBA {
+	switch team {
+	case "A":
+		return colorTeamA
+	case "B":
+		return colorTeamB
+	default:
+		return colorDecider
+	}
+}
+
+// textStart начало текста по горизонтали с учетом выравнивания по центру
+func textStart(f *faces, t textElement) fixed.Int26_6 {
+	x := fixed.I(t.X)
+	if t.Center {
+		for _, span := range t.spans() {
+			x -= font.MeasureString(f.face(t.Size, t.Bold), span.Text) / 2
+		}
+	}
+	return x
+}
@@ -0,0 +1,118 @@
+package resultcard
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	// Максимальный размер картинки карты
+	maxImageSize = 5 << 20
+
+	// Неудачная загрузка повторяется не раньше, чем через этот интервал
+	failedImageTTL = 5 * time.Minute
+)
+
+var ErrUnsupportedImageURL = errors.New("unsupported image url")
+
+// ImageLoader загружает картинки карт по Map.ImageURL и кэширует их в памяти.
+// Относительные URL (например, /images/mirage.png) отдает фронтенд, поэтому они
+// разрешаются относительно baseURL.
+type ImageLoader struct {
+	baseURL *url.URL
+	client  *http.Client
+
+	mu    sync.Mutex
+	cache map[string]cachedImage
+}
+
+type cachedImage struct {
+	image    image.Image
+	failedAt time.Time
+}
+
+func NewImageLoader(baseURL string) (*ImageLoader, error) {
+	base, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, err
+	}
+	return &ImageLoader{
+		baseURL: base,
+		client:  &http.Client{Timeout: 5 * time.Second},
+		cache:   make(map[string]cachedImage),
+	}, nil
+}
+
+// Load возвращает картинку по URL; картинки, которые не удалось загрузить, кэшируются как ошибки
+func (l *ImageLoader) Load(rawURL string) (image.Image, error) {
+	l.mu.Lock()
+	cached, ok := l.cache[rawURL]
+	l.mu.Unlock()
+	if ok {
+		if cached.image != nil {
+			return cached.image, nil
+		}
+		if time.Since(cached.failedAt) < failedImageTTL {
+			return nil, fmt.Errorf("image %s failed to load recently", rawURL)
+		}
+	}
+
+	img, err := l.fetch(rawURL)
+
+	l.mu.Lock()
+	if err != nil {
+		log.Printf("[resultcard] failed to load map image %s: %v", rawURL, err)
+		l.cache[rawURL] = cachedImage{failedAt: time.Now()}
+	} else {
+		l.cache[rawURL] = cachedImage{image: img}
+	}
+	l.mu.Unlock()
+	return img, err
+}
+
+func (l *ImageLoader) fetch(rawURL string) (image.Image, error) {
+	ref, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	target := l.baseURL.ResolveReference(ref)
+	if target.Scheme != "http" && target.Scheme != "https" {
+		return nil, ErrUnsupportedImageURL
+	}
+
+	resp, err := l.client.Get(target.String())
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("image %s: unexpected status %d", target, resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxImageSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxImageSize {
+		return nil, fmt.Errorf("image %s is too large", target)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("image %s: %w", target, err)
+	}
+	return img, nil
+}
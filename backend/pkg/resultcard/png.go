@@ -0,0 +1,83 @@
+package resultcard
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+
+	"golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+// RenderPNG рисует карточку в PNG
+func RenderPNG(card Card) ([]byte, error) {
+	f, err := newFaces()
+	if err != nil {
+		return nil, err
+	}
+	defer f.close()
+
+	elements, size := layout(card, f)
+	dst := image.NewRGBA(image.Rectangle{Max: size})
+	for _, e := range elements {
+		switch e := e.(type) {
+		case rectElement:
+			draw.Draw(dst, e.Rect, image.NewUniform(e.Color), image.Point{}, draw.Over)
+		case imageElement:
+			drawCover(dst, e.Rect, e.Image)
+			if e.Grayscale {
+				grayscale(dst, e.Rect)
+			}
+		case textElement:
+			d := font.Drawer{
+				Dst:  dst,
+				Face: f.face(e.Size, e.Bold),
+				Dot:  fixed.Point26_6{X: textStart(f, e), Y: fixed.I(e.Y)},
+			}
+			for _, span := range e.spans() {
+				d.Src = image.NewUniform(span.Color)
+				d.DrawString(span.Text)
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, dst); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// drawCover масштабирует картинку так, чтобы она заполнила область, обрезая лишнее по краям
+func drawCover(dst draw.Image, r image.Rectangle, src image.Image) {
+	b := src.Bounds()
+	if b.Empty() || r.Empty() {
+		return
+	}
+	// Сравниваем пропорции без деления: b.Dx/b.Dy против r.Dx/r.Dy
+	crop := b
+	if b.Dx()*r.Dy() > r.Dx()*b.Dy() {
+		width := b.Dy() * r.Dx() / r.Dy()
+		crop.Min.X = b.Min.X + (b.Dx()-width)/2
+		crop.Max.X = crop.Min.X + width
+	} else {
+		height := b.Dx() * r.Dy() / r.Dx()
+		crop.Min.Y = b.Min.Y + (b.Dy()-height)/2
+		crop.Max.Y = crop.Min.Y + height
+	}
+	draw.CatmullRom.Scale(dst, r, src, crop, draw.Over, nil)
+}
+
+// grayscale переводит область изображения в оттенки серого
+func grayscale(img *image.RGBA, r image.Rectangle) {
+	r = r.Intersect(img.Bounds())
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			c := img.RGBAAt(x, y)
+			gray := color.GrayModel.Convert(c).(color.Gray)
+			img.SetRGBA(x, y, color.RGBA{gray.Y, gray.Y, gray.Y, c.A})
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package resultcard
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRenderCard(t *testing.T) {
+	// Фронтенд отдает картинку карты - сплошной красный прямоугольник
+	src := image.NewRGBA(image.Rect(0, 0, 64, 36))
+	for y := 0; y < 36; y++ {
+		for x := 0; x < 64; x++ {
+			src.SetRGBA(x, y, color.RGBA{0xff, 0, 0, 0xff})
+		}
+	}
+	var encoded bytes.Buffer
+	if err := png.Encode(&encoded, src); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/images/mirage.png" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(encoded.Bytes())
+	}))
+	defer server.Close()
+
+	loader, err := NewImageLoader(server.URL)
+	if err != nil {
+		t.Fatalf("NewImageLoader() error = %v", err)
+	}
+	img, err := loader.Load("/images/mirage.png")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if img.Bounds().Dx() != 64 {
+		t.Fatalf("unexpected image size: %v", img.Bounds())
+	}
+	if _, err := loader.Load("/images/missing.png"); err == nil {
+		t.Fatal("expected error for missing image")
+	}
+	// Повторные загрузки берутся из кэша, включая неудачные
+	loader.Load("/images/mirage.png")
+	loader.Load("/images/missing.png")
+	if requests != 2 {
+		t.Errorf("expected 2 requests, got %d", requests)
+	}
+
+	card := Card{
+		TeamA:    "Alpha & Co",
+		TeamB:    "Bravo",
+		Subtitle: "CS2 · BO3",
+		Picks: []Tile{
+			{MapName: "Mirage", Team: "A", Label: "Pick · Alpha & Co", Detail: "Bravo · CT", Image: img},
+			{MapName: "Inferno", Team: "B", Label: "Pick · Bravo"},
+		},
+		Decider: &Tile{MapName: "Nuke", Label: "Decider"},
+		Bans: []Tile{
+			{MapName: "Mirage", Team: "B", Label: "Ban · Bravo", Image: img},
+		},
+	}
+
+	svg, err := RenderSVG(card)
+	if err != nil {
+		t.Fatalf("RenderSVG() error = %v", err)
+	}
+	for _, want := range []string{"Alpha &amp; Co", "Bravo · CT", "Decider", "data:image/jpeg;base64,"} {
+		if !strings.Contains(string(svg), want) {
+			t.Errorf("SVG does not contain %q", want)
+		}
+	}
+
+	data, err := RenderPNG(card)
+	if err != nil {
+		t.Fatalf("RenderPNG() error = %v", err)
+	}
+	rendered, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if rendered.Bounds().Dx() != cardWidth {
+		t.Errorf("expected width %d, got %d", cardWidth, rendered.Bounds().Dx())
+	}
+
+	// Картинка пика рисуется в цвете, картинка бана - в оттенках серого
+	f, _ := newFaces()
+	defer f.close()
+	elements, _ := layout(card, f)
+	var images []imageElement
+	for _, e := range elements {
+		if e, ok := e.(imageElement); ok {
+			images = append(images, e)
+		}
+	}
+	if len(images) != 2 {
+		t.Fatalf("expected 2 images, got %d", len(images))
+	}
+	center := func(r image.Rectangle) (uint32, uint32, uint32) {
+		c, g, b, _ := rendered.At((r.Min.X+r.Max.X)/2, (r.Min.Y+r.Max.Y)/2).RGBA()
+		return c, g, b
+	}
+	if r, g, b := center(images[0].Rect); r>>8 != 0xff || g != 0 || b != 0 {
+		t.Errorf("expected red pick image, got %d %d %d", r>>8, g>>8, b>>8)
+	}
+	if r, g, b := center(images[1].Rect); r != g || g != b {
+		t.Errorf("expected gray ban image, got %d %d %d", r>>8, g>>8, b>>8)
+	}
+}
@@ -0,0 +1,78 @@
+package resultcard
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+)
+
+// Картинки в SVG встраиваются с запасом по разрешению для экранов с высокой плотностью пикселей
+const svgImageScale = 2
+
+// RenderSVG рисует карточку в SVG; картинки карт, уменьшенные до размера плиток,
+// встраиваются как data URI, поэтому файл открывается без доступа к серверу
+func RenderSVG(card Card) ([]byte, error) {
+	f, err := newFaces()
+	if err != nil {
+		return nil, err
+	}
+	defer f.close()
+
+	elements, size := layout(card, f)
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" xmlns:xlink="http://www.w3.org/1999/xlink" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n", size.X, size.Y, size.X, size.Y)
+	for _, e := range elements {
+		switch e := e.(type) {
+		case rectElement:
+			fmt.Fprintf(&buf, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s"%s/>`+"\n",
+				e.Rect.Min.X, e.Rect.Min.Y, e.Rect.Dx(), e.Rect.Dy(), svgColor(e.Color), svgOpacity(e.Color))
+		case imageElement:
+			tile := image.NewRGBA(image.Rect(0, 0, e.Rect.Dx()*svgImageScale, e.Rect.Dy()*svgImageScale))
+			drawCover(tile, tile.Bounds(), e.Image)
+			if e.Grayscale {
+				grayscale(tile, tile.Bounds())
+			}
+			var encoded bytes.Buffer
+			if err := jpeg.Encode(&encoded, tile, &jpeg.Options{Quality: 85}); err != nil {
+				return nil, err
+			}
+			fmt.Fprintf(&buf, `<image x="%d" y="%d" width="%d" height="%d" xlink:href="data:image/jpeg;base64,%s"/>`+"\n",
+				e.Rect.Min.X, e.Rect.Min.Y, e.Rect.Dx(), e.Rect.Dy(), base64.StdEncoding.EncodeToString(encoded.Bytes()))
+		case textElement:
+			weight, anchor := "normal", "start"
+			if e.Bold {
+				weight = "bold"
+			}
+			if e.Center {
+				anchor = "middle"
+			}
+			fmt.Fprintf(&buf, `<text x="%d" y="%d" font-family="Go, Helvetica, Arial, sans-serif" font-size="%g" font-weight="%s" text-anchor="%s" xml:space="preserve">`,
+				e.X, e.Y, e.Size, weight, anchor)
+			for _, span := range e.spans() {
+				fmt.Fprintf(&buf, `<tspan fill="%s">`, svgColor(span.Color))
+				if err := xml.EscapeText(&buf, []byte(span.Text)); err != nil {
+					return nil, err
+				}
+				buf.WriteString("</tspan>")
+			}
+			buf.WriteString("</text>\n")
+		}
+	}
+	buf.WriteString("</svg>\n")
+	return buf.Bytes(), nil
+}
+
+func svgColor(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+func svgOpacity(c color.RGBA) string {
+	if c.A == 0xff {
+		return ""
+	}
+	return fmt.Sprintf(` fill-opacity="%.2f"`, float64(c.A)/0xff)
+}
@@ -3,161 +3,432 @@ package seed
 import (
 	"fmt"
 	"log"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/bbp/backend/internal/domain/entities"
 	"github.com/bbp/backend/internal/domain/repositories"
+	"github.com/bbp/backend/pkg/catalog"
 )
 
-// Seed заполняет базу данных начальными данными
-// Функция идемпотентна - можно вызывать несколько раз без дублирования данных
-func Seed(
-	gameRepo repositories.GameRepository,
-	mapRepo repositories.MapRepository,
-	mapPoolRepo repositories.MapPoolRepository,
-) error {
-	log.Println("Starting database seeding...")
+// ChangeKind тип изменения, вносимого импортом каталога
+type ChangeKind string
+
+const (
+	ChangeCreate     ChangeKind = "create"
+	ChangeUpdate     ChangeKind = "update"
+	ChangeDeactivate ChangeKind = "deactivate"
+)
+
+// Change описывает одно изменение в БД
+type Change struct {
+	Kind    ChangeKind
+	Entity  string   // game, map или pool
+	Key     string   // Например "valorant/bind"
+	Details []string // Список изменившихся полей
+}
+
+// String форматирует изменение в виде строки diff'а
+func (c Change) String() string {
+	prefix := map[ChangeKind]string{
+		ChangeCreate:     "+",
+		ChangeUpdate:     "~",
+		ChangeDeactivate: "-",
+	}[c.Kind]
+
+	line := fmt.Sprintf("%s %s %s", prefix, c.Entity, c.Key)
+	if len(c.Details) > 0 {
+		line += " (" + strings.Join(c.Details, ", ") + ")"
+	}
+	return line
+}
+
+// Options настройки импорта
+type Options struct {
+	DryRun bool // Только вычислить изменения, ничего не записывая
+}
+
+// Report результат импорта
+type Report struct {
+	DryRun  bool
+	Changes []Change
+}
+
+// Repositories набор репозиториев, необходимых для импорта
+type Repositories struct {
+	Games     repositories.GameRepository
+	Maps      repositories.MapRepository
+	MapPools  repositories.MapPoolRepository
+	Rotations repositories.MapRotationRepository
+}
+
+// Import приводит игры, карты и системные пулы в БД к состоянию каталога
+// Записи сопоставляются по slug (пулы - по типу), поэтому импорт идемпотентен.
+// Карты, отсутствующие в каталоге, не удаляются, а деактивируются.
+// Игры, отсутствующие в каталоге, не затрагиваются.
+// При изменении набора соревновательных карт создаётся новая версия ротации.
+func Import(cat *catalog.Catalog, repos Repositories, opts Options) (*Report, error) {
+	importer := &importer{repos: repos, opts: opts, report: &Report{DryRun: opts.DryRun}}
 
-	// 1. Создаем игру Valorant
-	valorantGame := &entities.Game{
-		ID:       1,
-		Name:     "Valorant",
-		Slug:     "valorant",
-		IsActive: true,
+	for _, game := range cat.Games {
+		if err := importer.importGame(game); err != nil {
+			return nil, fmt.Errorf("game %s: %w", game.Slug, err)
+		}
 	}
 
-	existingGame, err := gameRepo.GetByID(1)
+	return importer.report, nil
+}
+
+type importer struct {
+	repos  Repositories
+	opts   Options
+	report *Report
+}
+
+func (im *importer) record(kind ChangeKind, entity, key string, details ...string) {
+	im.report.Changes = append(im.report.Changes, Change{
+		Kind:    kind,
+		Entity:  entity,
+		Key:     key,
+		Details: details,
+	})
+}
+
+func (im *importer) importGame(spec catalog.Game) error {
+	game, err := im.repos.Games.GetBySlug(spec.Slug)
 	if err != nil {
-		return fmt.Errorf("failed to check existing game: %w", err)
+		return err
 	}
 
-	if existingGame == nil {
-		if err := gameRepo.Create(valorantGame); err != nil {
-			return fmt.Errorf("failed to create Valorant game: %w", err)
+	if game == nil {
+		game = &entities.Game{
+			Name:     spec.Name,
+			Slug:     spec.Slug,
+			IsActive: spec.Active(),
+		}
+		im.record(ChangeCreate, "game", spec.Slug)
+		if !im.opts.DryRun {
+			if err := game.Validate(); err != nil {
+				return err
+			}
+			if err := im.repos.Games.Create(game); err != nil {
+				return err
+			}
 		}
-		log.Println("✓ Created Valorant game")
 	} else {
-		log.Println("✓ Valorant game already exists")
-	}
-
-	// 2. Создаем карты Valorant
-	maps := []struct {
-		ID            uint
-		Name          string
-		Slug          string
-		ImageURL      string
-		IsCompetitive bool
-		IsActive      bool
-	}{
-		{1, "Abyss", "abyss", "/images/abyss.png", true, true},
-		{2, "Ascent", "ascent", "/images/ascent.png", false, true},
-		{3, "Bind", "bind", "/images/bind.png", true, true},
-		{4, "Breeze", "breeze", "/images/breeze.png", false, true},
-		{5, "Corrode", "corrode", "/images/corrode.png", true, true},
-		{6, "Fracture", "fracture", "/images/fracture.png", false, true},
-		{7, "Haven", "haven", "/images/haven.png", true, true},
-		{8, "Icebox", "icebox", "/images/icebox.png", false, true},
-		{9, "Lotus", "lotus", "/images/lotus.png", false, true},
-		{10, "Pearl", "pearl", "/images/pearl.png", true, true},
-		{11, "Split", "split", "/images/split.png", true, true},
-		{12, "Sunset", "sunset", "/images/sunset.png", true, true},
-	}
-
-	createdMaps := 0
-	for _, mapData := range maps {
-		existingMap, err := mapRepo.GetByID(mapData.ID)
+		var details []string
+		details = diffString(details, "name", game.Name, spec.Name)
+		details = diffBool(details, "is_active", game.IsActive, spec.Active())
+		if len(details) > 0 {
+			game.Name = spec.Name
+			game.IsActive = spec.Active()
+			im.record(ChangeUpdate, "game", spec.Slug, details...)
+			if !im.opts.DryRun {
+				if err := im.repos.Games.Update(game); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	maps, err := im.importMaps(game, spec)
+	if err != nil {
+		return err
+	}
+
+	if err := im.importPools(game, spec, maps); err != nil {
+		return err
+	}
+
+	return im.importRotation(game, spec, maps)
+}
+
+// importMaps синхронизирует карты игры и возвращает актуальные карты по slug
+func (im *importer) importMaps(game *entities.Game, spec catalog.Game) (map[string]*entities.Map, error) {
+	existing := make(map[string]*entities.Map)
+	if game.ID != 0 {
+		maps, err := im.repos.Maps.GetByGameID(game.ID)
 		if err != nil {
-			return fmt.Errorf("failed to check existing map %s: %w", mapData.Name, err)
+			return nil, err
 		}
+		for i := range maps {
+			existing[maps[i].Slug] = &maps[i]
+		}
+	}
 
-		if existingMap == nil {
-			mapEntity := &entities.Map{
-				ID:            mapData.ID,
-				GameID:        1,
-				Name:          mapData.Name,
-				Slug:          mapData.Slug,
-				ImageURL:      mapData.ImageURL,
-				IsActive:      mapData.IsActive,
-				IsCompetitive: mapData.IsCompetitive,
+	result := make(map[string]*entities.Map, len(spec.Maps))
+	for _, mapSpec := range spec.Maps {
+		key := spec.Slug + "/" + mapSpec.Slug
+		m, ok := existing[mapSpec.Slug]
+		if !ok {
+			m = &entities.Map{
+				GameID:        game.ID,
+				Name:          mapSpec.Name,
+				Slug:          mapSpec.Slug,
+				ImageURL:      mapSpec.ImageURL,
+				IsActive:      mapSpec.Active(),
+				IsCompetitive: mapSpec.IsCompetitive,
+			}
+			im.record(ChangeCreate, "map", key)
+			if !im.opts.DryRun {
+				if err := m.Validate(); err != nil {
+					return nil, err
+				}
+				if err := im.repos.Maps.Create(m); err != nil {
+					return nil, err
+				}
 			}
+			result[mapSpec.Slug] = m
+			continue
+		}
 
-			if err := mapRepo.Create(mapEntity); err != nil {
-				return fmt.Errorf("failed to create map %s: %w", mapData.Name, err)
+		var details []string
+		details = diffString(details, "name", m.Name, mapSpec.Name)
+		details = diffString(details, "image_url", m.ImageURL, mapSpec.ImageURL)
+		details = diffBool(details, "is_active", m.IsActive, mapSpec.Active())
+		details = diffBool(details, "is_competitive", m.IsCompetitive, mapSpec.IsCompetitive)
+		if len(details) > 0 {
+			m.Name = mapSpec.Name
+			m.ImageURL = mapSpec.ImageURL
+			m.IsActive = mapSpec.Active()
+			m.IsCompetitive = mapSpec.IsCompetitive
+			im.record(ChangeUpdate, "map", key, details...)
+			if !im.opts.DryRun {
+				if err := im.repos.Maps.Update(m); err != nil {
+					return nil, err
+				}
 			}
-			createdMaps++
 		}
+		result[mapSpec.Slug] = m
 	}
 
-	if createdMaps > 0 {
-		log.Printf("✓ Created %d maps", createdMaps)
-	} else {
-		log.Println("✓ All maps already exist")
+	// Карты, которых нет в каталоге, деактивируем (история сессий ссылается на них)
+	for _, slug := range sortedKeys(existing) {
+		m := existing[slug]
+		if _, ok := result[slug]; ok || !m.IsActive {
+			continue
+		}
+		m.IsActive = false
+		im.record(ChangeDeactivate, "map", spec.Slug+"/"+slug)
+		if !im.opts.DryRun {
+			if err := im.repos.Maps.Update(m); err != nil {
+				return nil, err
+			}
+		}
 	}
 
-	// 3. Создаем системные пулы карт (All Maps и Competitive Maps)
-	// Системные пулы доступны всем пользователям (user_id = NULL)
-	allMaps, err := mapRepo.GetByGameID(1)
-	if err != nil {
-		return fmt.Errorf("failed to get maps for system pools: %w", err)
-	}
+	return result, nil
+}
 
-	// Проверяем и создаем пул "All Maps"
-	existingAllMapsPool, err := mapPoolRepo.GetByID(1)
-	if err != nil {
-		return fmt.Errorf("failed to check existing All Maps pool: %w", err)
+// importPools синхронизирует системные пулы игры
+func (im *importer) importPools(game *entities.Game, spec catalog.Game, maps map[string]*entities.Map) error {
+	existing := make(map[entities.MapPoolType]*entities.MapPool)
+	if game.ID != 0 {
+		pools, err := im.repos.MapPools.GetSystemPools(game.ID)
+		if err != nil {
+			return err
+		}
+		for i := range pools {
+			existing[pools[i].Type] = &pools[i]
+		}
 	}
 
-	if existingAllMapsPool == nil {
-		allMapsPool := &entities.MapPool{
-			ID:       1,
-			GameID:   1,
-			UserID:   nil, // Системный пул - без привязки к пользователю
-			Name:     "All Maps",
-			Type:     entities.MapPoolTypeAll,
-			IsSystem: true,
-			Maps:     allMaps,
+	for _, poolSpec := range spec.Pools {
+		key := spec.Slug + "/" + poolSpec.Type
+
+		// В системные пулы попадают только активные карты
+		wanted := make(map[string]*entities.Map)
+		for _, slug := range spec.PoolMaps(poolSpec) {
+			if m := maps[slug]; m != nil && m.IsActive {
+				wanted[slug] = m
+			}
+		}
+
+		pool, ok := existing[entities.MapPoolType(poolSpec.Type)]
+		if !ok {
+			pool = &entities.MapPool{
+				GameID:   game.ID,
+				UserID:   nil, // Системный пул - без привязки к пользователю
+				Name:     poolSpec.Name,
+				Type:     entities.MapPoolType(poolSpec.Type),
+				IsSystem: true,
+			}
+			for _, slug := range sortedKeys(wanted) {
+				pool.Maps = append(pool.Maps, *wanted[slug])
+			}
+			im.record(ChangeCreate, "pool", key, fmt.Sprintf("%d maps", len(pool.Maps)))
+			if !im.opts.DryRun {
+				if err := pool.Validate(); err != nil {
+					return err
+				}
+				if err := im.repos.MapPools.Create(pool); err != nil {
+					return err
+				}
+			}
+			continue
 		}
 
-		if err := mapPoolRepo.Create(allMapsPool); err != nil {
-			return fmt.Errorf("failed to create All Maps pool: %w", err)
+		var details []string
+		details = diffString(details, "name", pool.Name, poolSpec.Name)
+		if pool.Name != poolSpec.Name {
+			pool.Name = poolSpec.Name
+			if !im.opts.DryRun {
+				if err := im.repos.MapPools.Update(pool); err != nil {
+					return err
+				}
+			}
 		}
-		log.Println("✓ Created All Maps system pool")
-	} else {
-		log.Println("✓ All Maps system pool already exists")
-	}
 
-	// Проверяем и создаем пул "Competitive Maps"
-	existingCompetitivePool, err := mapPoolRepo.GetByID(2)
-	if err != nil {
-		return fmt.Errorf("failed to check existing Competitive Maps pool: %w", err)
+		current := make(map[string]entities.Map, len(pool.Maps))
+		for _, m := range pool.Maps {
+			current[m.Slug] = m
+		}
+		for _, slug := range sortedKeys(wanted) {
+			if _, ok := current[slug]; ok {
+				continue
+			}
+			details = append(details, "+"+slug)
+			if !im.opts.DryRun {
+				if err := im.repos.MapPools.AddMap(pool.ID, wanted[slug].ID); err != nil {
+					return err
+				}
+			}
+		}
+		for _, slug := range sortedKeys(current) {
+			if _, ok := wanted[slug]; ok {
+				continue
+			}
+			details = append(details, "-"+slug)
+			if !im.opts.DryRun {
+				if err := im.repos.MapPools.RemoveMap(pool.ID, current[slug].ID); err != nil {
+					return err
+				}
+			}
+		}
+
+		if len(details) > 0 {
+			im.record(ChangeUpdate, "pool", key, details...)
+		}
 	}
 
-	if existingCompetitivePool == nil {
-		// Фильтруем только соревновательные карты
-		competitiveMaps := make([]entities.Map, 0)
-		for _, m := range allMaps {
-			if m.IsCompetitive {
-				competitiveMaps = append(competitiveMaps, m)
-			}
+	return nil
+}
+
+// importRotation создаёт новую версию ротации, если набор активных
+// соревновательных карт отличается от последней сохранённой версии
+func (im *importer) importRotation(game *entities.Game, spec catalog.Game, maps map[string]*entities.Map) error {
+	wanted := make(map[string]*entities.Map)
+	for slug, m := range maps {
+		if m.IsActive && m.IsCompetitive {
+			wanted[slug] = m
 		}
+	}
+	if len(wanted) == 0 {
+		return nil
+	}
 
-		competitivePool := &entities.MapPool{
-			ID:       2,
-			GameID:   1,
-			UserID:   nil, // Системный пул - без привязки к пользователю
-			Name:     "Competitive Maps",
-			Type:     entities.MapPoolTypeCompetitive,
-			IsSystem: true,
-			Maps:     competitiveMaps,
+	var latest *entities.MapRotation
+	if game.ID != 0 {
+		rotations, err := im.repos.Rotations.GetByGameID(game.ID)
+		if err != nil {
+			return err
+		}
+		if len(rotations) > 0 {
+			latest = &rotations[0]
 		}
+	}
 
-		if err := mapPoolRepo.Create(competitivePool); err != nil {
-			return fmt.Errorf("failed to create Competitive Maps pool: %w", err)
+	var details []string
+	if latest != nil {
+		current := make(map[string]bool, len(latest.Maps))
+		for _, m := range latest.Maps {
+			current[m.Slug] = true
+		}
+		for _, slug := range sortedKeys(wanted) {
+			if !current[slug] {
+				details = append(details, "+"+slug)
+			}
+		}
+		for _, slug := range sortedKeys(current) {
+			if _, ok := wanted[slug]; !ok {
+				details = append(details, "-"+slug)
+			}
+		}
+		if len(details) == 0 {
+			return nil
 		}
-		log.Println("✓ Created Competitive Maps system pool")
 	} else {
-		log.Println("✓ Competitive Maps system pool already exists")
+		details = append(details, fmt.Sprintf("%d maps", len(wanted)))
+	}
+
+	im.record(ChangeCreate, "rotation", spec.Slug, details...)
+	if im.opts.DryRun {
+		return nil
+	}
+
+	rotation := &entities.MapRotation{
+		GameID:        game.ID,
+		EffectiveFrom: time.Now(),
+	}
+	for _, slug := range sortedKeys(wanted) {
+		rotation.MapIDs = append(rotation.MapIDs, wanted[slug].ID)
+	}
+	if err := rotation.Validate(); err != nil {
+		return err
+	}
+	return im.repos.Rotations.Create(rotation)
+}
+
+// Seed заполняет базу данных встроенным каталогом игр
+// Функция идемпотентна - можно вызывать несколько раз без дублирования данных
+func Seed(
+	gameRepo repositories.GameRepository,
+	mapRepo repositories.MapRepository,
+	mapPoolRepo repositories.MapPoolRepository,
+	rotationRepo repositories.MapRotationRepository,
+) error {
+	cat, err := catalog.LoadDefault()
+	if err != nil {
+		return fmt.Errorf("failed to load default catalog: %w", err)
+	}
+
+	report, err := Import(cat, Repositories{
+		Games:     gameRepo,
+		Maps:      mapRepo,
+		MapPools:  mapPoolRepo,
+		Rotations: rotationRepo,
+	}, Options{})
+	if err != nil {
+		return err
 	}
 
-	log.Println("Database seeding completed successfully!")
+	for _, change := range report.Changes {
+		log.Println(change.String())
+	}
+	log.Printf("Database seeding completed: %d changes", len(report.Changes))
 	return nil
 }
+
+func diffString(details []string, field, from, to string) []string {
+	if from == to {
+		return details
+	}
+	return append(details, fmt.Sprintf("%s: %q -> %q", field, from, to))
+}
+
+func diffBool(details []string, field string, from, to bool) []string {
+	if from == to {
+		return details
+	}
+	return append(details, fmt.Sprintf("%s: %t -> %t", field, from, to))
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
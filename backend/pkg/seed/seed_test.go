@@ -0,0 +1,176 @@
+package seed
+
+import (
+	"testing"
+
+	"github.com/bbp/backend/internal/domain/entities"
+	"github.com/bbp/backend/internal/repository/models"
+	"github.com/bbp/backend/internal/repository/sqlite"
+	"github.com/bbp/backend/pkg/catalog"
+	"github.com/bbp/backend/pkg/database"
+)
+
+func setupRepos(t *testing.T) (Repositories, func()) {
+	db, err := database.NewDatabase(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+
+	if err := database.Migrate(db,
+		&models.GameModel{},
+		&models.MapModel{},
+		&models.MapPoolModel{},
+		&models.RoomParticipantModel{},
+		&models.MapRotationModel{},
+	); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	repos := Repositories{
+		Games:     sqlite.NewGameRepository(db),
+		Maps:      sqlite.NewMapRepository(db),
+		MapPools:  sqlite.NewMapPoolRepository(db),
+		Rotations: sqlite.NewMapRotationRepository(db),
+	}
+	return repos, func() { database.Close(db) }
+}
+
+func testCatalog() *catalog.Catalog {
+	return &catalog.Catalog{
+		Games: []catalog.Game{
+			{
+				Slug: "valorant",
+				Name: "Valorant",
+				Maps: []catalog.Map{
+					{Slug: "bind", Name: "Bind", IsCompetitive: true},
+					{Slug: "haven", Name: "Haven", IsCompetitive: true},
+					{Slug: "lotus", Name: "Lotus"},
+				},
+				Pools: []catalog.Pool{
+					{Type: "all", Name: "All Maps"},
+					{Type: "competitive", Name: "Competitive Maps"},
+				},
+			},
+		},
+	}
+}
+
+func TestImport_Idempotent(t *testing.T) {
+	repos, cleanup := setupRepos(t)
+	defer cleanup()
+
+	report, err := Import(testCatalog(), repos, Options{})
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	// 1 игра + 3 карты + 2 пула + ротация
+	if len(report.Changes) != 7 {
+		t.Errorf("first import: got %d changes, want 7: %v", len(report.Changes), report.Changes)
+	}
+
+	report, err = Import(testCatalog(), repos, Options{})
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	if len(report.Changes) != 0 {
+		t.Errorf("second import: got %d changes, want 0: %v", len(report.Changes), report.Changes)
+	}
+}
+
+func TestImport_DeactivatesMissingMaps(t *testing.T) {
+	repos, cleanup := setupRepos(t)
+	defer cleanup()
+
+	if _, err := Import(testCatalog(), repos, Options{}); err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+
+	cat := testCatalog()
+	cat.Games[0].Maps = cat.Games[0].Maps[1:] // Убираем bind
+	if _, err := Import(cat, repos, Options{}); err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+
+	game, _ := repos.Games.GetBySlug("valorant")
+	bind, err := repos.Maps.GetByGameIDAndSlug(game.ID, "bind")
+	if err != nil || bind == nil {
+		t.Fatalf("bind should not be deleted: %v", err)
+	}
+	if bind.IsActive {
+		t.Error("bind should be deactivated")
+	}
+
+	pools, _ := repos.MapPools.GetSystemPools(game.ID)
+	for _, pool := range pools {
+		for _, m := range pool.Maps {
+			if m.Slug == "bind" {
+				t.Errorf("pool %s still contains deactivated map", pool.Type)
+			}
+		}
+		if pool.Type == entities.MapPoolTypeCompetitive && len(pool.Maps) != 1 {
+			t.Errorf("competitive pool: got %d maps, want 1", len(pool.Maps))
+		}
+	}
+}
+
+func TestImport_CreatesRotationVersion(t *testing.T) {
+	repos, cleanup := setupRepos(t)
+	defer cleanup()
+
+	if _, err := Import(testCatalog(), repos, Options{}); err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+
+	cat := testCatalog()
+	cat.Games[0].Maps[2].IsCompetitive = true // lotus входит в ротацию
+	if _, err := Import(cat, repos, Options{}); err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+
+	game, _ := repos.Games.GetBySlug("valorant")
+	rotations, err := repos.Rotations.GetByGameID(game.ID)
+	if err != nil {
+		t.Fatalf("GetByGameID() error = %v", err)
+	}
+	if len(rotations) != 2 {
+		t.Fatalf("got %d rotations, want 2", len(rotations))
+	}
+	if len(rotations[0].MapIDs) != 3 || len(rotations[1].MapIDs) != 2 {
+		t.Errorf("unexpected rotation sizes: newest %d, oldest %d", len(rotations[0].MapIDs), len(rotations[1].MapIDs))
+	}
+}
+
+func TestImport_DryRun(t *testing.T) {
+	repos, cleanup := setupRepos(t)
+	defer cleanup()
+
+	report, err := Import(testCatalog(), repos, Options{DryRun: true})
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	if len(report.Changes) == 0 {
+		t.Error("dry run should report changes")
+	}
+
+	games, _ := repos.Games.GetAll()
+	if len(games) != 0 {
+		t.Errorf("dry run should not write, got %d games", len(games))
+	}
+}
+
+func TestDefaultCatalog(t *testing.T) {
+	cat, err := catalog.LoadDefault()
+	if err != nil {
+		t.Fatalf("LoadDefault() error = %v", err)
+	}
+
+	slugs := make(map[string]bool)
+	for _, game := range cat.Games {
+		slugs[game.Slug] = true
+	}
+	for _, slug := range []string{"valorant", "cs2", "overwatch", "rainbow-six"} {
+		if !slugs[slug] {
+			t.Errorf("default catalog is missing %s", slug)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package totp
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Тестовые векторы RFC 6238 (SHA1, секрет "12345678901234567890"), последние 6 цифр
+func TestCode_RFC6238Vectors(t *testing.T) {
+	secret := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+
+	for _, tt := range tests {
+		got, err := Code(secret, Step(time.Unix(tt.unix, 0)))
+		if err != nil {
+			t.Fatalf("Code() error = %v", err)
+		}
+		if got != tt.want {
+			t.Errorf("Code(%d) = %s, want %s", tt.unix, got, tt.want)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	secret, err := GenerateSecret()
+	if err != nil {
+		t.Fatalf("GenerateSecret() error = %v", err)
+	}
+
+	now := time.Now()
+	code, _ := Code(secret, Step(now))
+	if step, ok := Validate(secret, code, now); !ok || step != Step(now) {
+		t.Errorf("Validate() should accept current code")
+	}
+
+	// Код предыдущего шага принимается из-за отклонения часов
+	previous, _ := Code(secret, Step(now)-1)
+	if _, ok := Validate(secret, previous, now); !ok {
+		t.Errorf("Validate() should accept code within skew")
+	}
+
+	old, _ := Code(secret, Step(now)-5)
+	if _, ok := Validate(secret, old, now); ok {
+		t.Errorf("Validate() should reject old code")
+	}
+
+	if _, ok := Validate(secret, "12345", now); ok {
+		t.Errorf("Validate() should reject malformed code")
+	}
+}
+
+func TestURI(t *testing.T) {
+	uri := URI("Map Ban", "user@example.com", "JBSWY3DPEHPK3PXP")
+	if !strings.HasPrefix(uri, "otpauth://totp/Map%20Ban:user@example.com?") {
+		t.Errorf("unexpected uri: %s", uri)
+	}
+	if !strings.Contains(uri, "secret=JBSWY3DPEHPK3PXP") {
+		t.Errorf("uri should contain secret: %s", uri)
+	}
+}
@@ -0,0 +1,129 @@
+package websocket
+
+import (
+	"time"
+)
+
+const (
+	// Number of recent events kept per room for Last-Event-ID resume
+	eventLogSize = 100
+
+	// Room event logs without subscribers are dropped after this period of inactivity
+	eventLogTTL = 10 * time.Minute
+
+	// Events buffered per subscriber before it is considered too slow and disconnected
+	subscriberBuffer = 64
+)
+
+// Event is a room broadcast recorded for Server-Sent Events subscribers
+type Event struct {
+	ID   uint64
+	Type string
+	Data []byte // JSON-encoded Message, the same payload WebSocket clients receive
+}
+
+// Subscriber receives room broadcasts without a WebSocket connection
+type Subscriber struct {
+	RoomID uint
+	// Events is closed when the subscriber falls behind; it should reconnect with its last event ID
+	Events chan Event
+}
+
+// roomEventLog keeps recent events and subscribers of a room
+type roomEventLog struct {
+	events      []Event
+	subscribers map[*Subscriber]bool
+	// Events with IDs up to this one are no longer available for replay
+	droppedThrough uint64
+	lastActivity   time.Time
+}
+
+// Subscribe registers a subscriber for room broadcasts.
+// Events recorded after lastEventID are returned for replay; resumed is false when some of them
+// are no longer available (or lastEventID is zero) and the client has to reload the room state.
+func (m *Manager) Subscribe(roomID uint, lastEventID uint64) (sub *Subscriber, missed []Event, resumed bool) {
+	m.eventsMu.Lock()
+	defer m.eventsMu.Unlock()
+
+	log := m.eventLog(roomID)
+	log.lastActivity = time.Now()
+
+	sub = &Subscriber{
+		RoomID: roomID,
+		Events: make(chan Event, subscriberBuffer),
+	}
+	log.subscribers[sub] = true
+
+	if lastEventID == 0 || lastEventID < log.droppedThrough || lastEventID > m.lastEventID {
+		return sub, nil, false
+	}
+	for _, event := range log.events {
+		if event.ID > lastEventID {
+			missed = append(missed, event)
+		}
+	}
+	return sub, missed, true
+}
+
+// Unsubscribe removes a subscriber; safe to call after it was disconnected
+func (m *Manager) Unsubscribe(sub *Subscriber) {
+	m.eventsMu.Lock()
+	defer m.eventsMu.Unlock()
+
+	if log, ok := m.eventLogs[sub.RoomID]; ok && log.subscribers[sub] {
+		delete(log.subscribers, sub)
+		close(sub.Events)
+	}
+}
+
+// recordEvent stores a room broadcast and delivers it to subscribers
+func (m *Manager) recordEvent(roomID uint, eventType string, data []byte) {
+	m.eventsMu.Lock()
+	defer m.eventsMu.Unlock()
+
+	m.lastEventID++
+	event := Event{ID: m.lastEventID, Type: eventType, Data: data}
+
+	log := m.eventLog(roomID)
+	log.lastActivity = time.Now()
+	log.events = append(log.events, event)
+	if len(log.events) > eventLogSize {
+		log.droppedThrough = log.events[0].ID
+		log.events = log.events[1:]
+	}
+
+	for sub := range log.subscribers {
+		select {
+		case sub.Events <- event:
+		default:
+			delete(log.subscribers, sub)
+			close(sub.Events)
+		}
+	}
+}
+
+// eventLog returns the room event log, creating it if needed (eventsMu must be held)
+func (m *Manager) eventLog(roomID uint) *roomEventLog {
+	log, ok := m.eventLogs[roomID]
+	if !ok {
+		// Earlier events of the room (if any) were dropped together with its previous log
+		log = &roomEventLog{
+			subscribers:    make(map[*Subscriber]bool),
+			droppedThrough: m.lastEventID,
+		}
+		m.eventLogs[roomID] = log
+	}
+	return log
+}
+
+// cleanupEventLogs drops logs of rooms without subscribers and recent events
+func (m *Manager) cleanupEventLogs(now time.Time) {
+	m.eventsMu.Lock()
+	defer m.eventsMu.Unlock()
+
+	for roomID, log := range m.eventLogs {
+		if len(log.subscribers) == 0 && now.Sub(log.lastActivity) > eventLogTTL {
+			delete(m.eventLogs, roomID)
+		}
+	}
+}
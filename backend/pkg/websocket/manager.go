@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"log"
 	"sync"
+	"time"
 )
 
 // Manager manages WebSocket connections
@@ -25,6 +26,11 @@ type Manager struct {
 
 	// Message handler
 	MessageHandler func(*Client, *Message)
+
+	// Recent room events and Server-Sent Events subscribers
+	eventsMu    sync.Mutex
+	eventLogs   map[uint]*roomEventLog
+	lastEventID uint64
 }
 
 // RoomMessage represents a message to be broadcast to a room
@@ -40,6 +46,9 @@ func NewManager() *Manager {
 		Register:   make(chan *Client),
 		Unregister: make(chan *Client),
 		Broadcast:  make(chan *RoomMessage),
+		eventLogs:  make(map[uint]*roomEventLog),
+		// Event IDs start from the current time so IDs issued before a restart are always older
+		lastEventID: uint64(time.Now().UnixNano()),
 	}
 }
 
@@ -50,6 +59,9 @@ func (m *Manager) SetMessageHandler(handler func(*Client, *Message)) {
 
 // Run starts the manager
 func (m *Manager) Run() {
+	cleanup := time.NewTicker(time.Minute)
+	defer cleanup.Stop()
+
 	for {
 		select {
 		case client := <-m.Register:
@@ -97,6 +109,9 @@ func (m *Manager) Run() {
 
 		case roomMsg := <-m.Broadcast:
 			m.broadcastToRoom(roomMsg.RoomID, roomMsg.Message, nil)
+
+		case now := <-cleanup.C:
+			m.cleanupEventLogs(now)
 		}
 	}
 }
@@ -118,6 +133,15 @@ func (m *Manager) BroadcastToRoom(roomID uint, msg Message) {
 
 // broadcastToRoom broadcasts a message to all clients in a room (internal)
 func (m *Manager) broadcastToRoom(roomID uint, msg Message, exclude *Client) {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		log.Printf("Error marshaling message: %v", err)
+		return
+	}
+
+	// Server-Sent Events subscribers receive every room broadcast
+	m.recordEvent(roomID, msg.Type, data)
+
 	m.mu.RLock()
 	room, ok := m.Rooms[roomID]
 	if !ok {
@@ -135,12 +159,6 @@ func (m *Manager) broadcastToRoom(roomID uint, msg Message, exclude *Client) {
 	m.mu.RUnlock()
 
 	// Send message to all clients
-	data, err := json.Marshal(msg)
-	if err != nil {
-		log.Printf("Error marshaling message: %v", err)
-		return
-	}
-
 	for _, client := range clients {
 		select {
 		case client.Send <- data:
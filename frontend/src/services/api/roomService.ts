@@ -162,6 +162,39 @@ export async function updateRoom(
   }
 }
 
+/**
+ * Запуск вето в комнате: сервер создает сессию из настроек комнаты и привязывает ее
+ */
+export async function startVeto(id: number, swapSides = false): Promise<RoomResponse> {
+  try {
+    const response = await apiClient.post<RoomResponse>(`/api/rooms/${id}/veto`, {
+      swap_sides: swapSides,
+    });
+    // Инвалидируем кеш комнаты и списка комнат
+    apiCache.delete(generateCacheKey('room', { id }));
+    apiCache.invalidate('rooms-list:*');
+    return response.data;
+  } catch (error) {
+    throw handleApiError(error);
+  }
+}
+
+/**
+ * История матчей комнаты
+ */
+export async function getRoomMatches(
+  id: number
+): Promise<import('./types').RoomMatchesResponse> {
+  try {
+    const response = await apiClient.get<import('./types').RoomMatchesResponse>(
+      `/api/rooms/${id}/matches`
+    );
+    return response.data;
+  } catch (error) {
+    throw handleApiError(error);
+  }
+}
+
 /**
  * Преобразование RoomResponse в Room (для совместимости)
  */
@@ -179,6 +212,7 @@ export function roomResponseToRoom(response: RoomResponse): Room {
     mapPoolId: response.map_pool_id || undefined,
     vetoType: response.veto_type || undefined,
     vetoSessionId: response.veto_session_id || undefined,
+    currentMatch: response.current_match || undefined,
     maxParticipants: response.max_participants,
     createdAt: response.created_at,
     updatedAt: response.updated_at,
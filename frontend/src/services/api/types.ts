@@ -51,6 +51,15 @@ export interface CreateVetoSessionRequest {
   team_a_name: string;
   team_b_name: string;
   timer_seconds?: number;
+  constraints?: VetoConstraints;
+}
+
+// Ограничения пула карт сессии из предыдущих серий
+export interface VetoConstraints {
+  excluded_map_ids?: number[]; // Недоступны обеим командам
+  team_a_excluded_map_ids?: number[]; // Команда A не может их пикнуть
+  team_b_excluded_map_ids?: number[];
+  decider_map_id?: number; // Десайдер назначен заранее
 }
 
 export interface VetoSessionResponse {
@@ -71,6 +80,7 @@ export interface VetoSessionResponse {
   finished_at?: string;
   map_pool?: MapPoolResponse;
   actions?: VetoActionResponse[];
+  constraints?: VetoConstraints;
 }
 
 export interface VetoActionResponse {
@@ -93,6 +103,8 @@ export interface NextActionResponse {
   needs_side_selection: boolean;
   side_selection_team?: string;
   message?: string;
+  constraints?: VetoConstraints;
+  pickable_map_ids?: number[]; // Карты, которые текущая команда может пикнуть
 }
 
 // Типы для Map Pools
@@ -135,6 +147,8 @@ export interface RoomResponse {
   veto_type?: 'bo1' | 'bo3' | 'bo5'; // Тип вето
   veto_session_id?: number;
   veto_session?: VetoSessionResponse;
+  current_match?: number; // Номер текущего матча серии
+  matches?: RoomMatchResponse[];
   max_participants: number;
   participants_count: number;
   participants: ParticipantResponse[];
@@ -142,6 +156,59 @@ export interface RoomResponse {
   updated_at: string;
 }
 
+export interface RoomMatchResponse {
+  id: number;
+  number: number;
+  veto_session_id: number;
+  sides_swapped: boolean;
+  created_at: string;
+  session?: VetoSessionResponse;
+}
+
+export interface RoomMatchesResponse {
+  room_id: number;
+  current_match?: number;
+  matches: RoomMatchResponse[];
+}
+
+export interface MapScoreRequest {
+  map_id: number;
+  score_a: number;
+  score_b: number;
+}
+
+export interface ReportMatchResultRequest {
+  maps: MapScoreRequest[];
+}
+
+export interface MapResultResponse {
+  veto_action_id?: number;
+  map_id: number;
+  order: number;
+  score_a: number;
+  score_b: number;
+  winner: 'A' | 'B';
+}
+
+export interface MatchResultResponse {
+  id: number;
+  veto_session_id: number;
+  status: 'pending' | 'confirmed' | 'disputed';
+  reported_by_id: number;
+  reported_by_team?: 'A' | 'B';
+  confirmed_by_id?: number;
+  disputed_by_id?: number;
+  dispute_reason?: string;
+  score_a: number;
+  score_b: number;
+  winner_team: 'A' | 'B';
+  winner_name?: string;
+  maps: MapResultResponse[];
+  created_at: string;
+  updated_at: string;
+  confirmed_at?: string;
+}
+
 export interface CreateRoomRequest {
   name: string;
   type: 'public' | 'private';
@@ -155,7 +222,6 @@ export interface CreateRoomRequest {
 export interface UpdateRoomRequest {
   map_pool_id?: number;
   veto_type?: 'bo1' | 'bo3' | 'bo5'; // Тип вето
-  veto_session_id?: number;
   status?: 'waiting' | 'active' | 'finished';
 }
 
@@ -164,7 +230,6 @@ export interface JoinRoomRequest {
 }
 
 export interface UpdateRoomRequest {
-  veto_session_id?: number; // ID сессии вето
   status?: 'waiting' | 'active' | 'finished'; // Статус комнаты
 }
 
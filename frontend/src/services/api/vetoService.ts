@@ -3,6 +3,8 @@ import type {
   CreateVetoSessionRequest,
   VetoSessionResponse,
   NextActionResponse,
+  ReportMatchResultRequest,
+  MatchResultResponse,
 } from './types';
 
 /**
@@ -165,3 +167,71 @@ export async function resetSession(
     throw handleApiError(error);
   }
 }
+
+/**
+ * Получение результата матча
+ */
+export async function getResult(
+  sessionId: number
+): Promise<MatchResultResponse> {
+  try {
+    const response = await apiClient.get<MatchResultResponse>(
+      `/api/veto/sessions/${sessionId}/result`
+    );
+    return response.data;
+  } catch (error) {
+    throw handleApiError(error);
+  }
+}
+
+/**
+ * Отчет о результате матча (капитан команды)
+ */
+export async function reportResult(
+  sessionId: number,
+  data: ReportMatchResultRequest
+): Promise<MatchResultResponse> {
+  try {
+    const response = await apiClient.post<MatchResultResponse>(
+      `/api/veto/sessions/${sessionId}/result`,
+      data
+    );
+    return response.data;
+  } catch (error) {
+    throw handleApiError(error);
+  }
+}
+
+/**
+ * Подтверждение результата капитаном соперника
+ */
+export async function confirmResult(
+  sessionId: number
+): Promise<MatchResultResponse> {
+  try {
+    const response = await apiClient.post<MatchResultResponse>(
+      `/api/veto/sessions/${sessionId}/result/confirm`
+    );
+    return response.data;
+  } catch (error) {
+    throw handleApiError(error);
+  }
+}
+
+/**
+ * Оспаривание результата капитаном соперника
+ */
+export async function disputeResult(
+  sessionId: number,
+  reason: string
+): Promise<MatchResultResponse> {
+  try {
+    const response = await apiClient.post<MatchResultResponse>(
+      `/api/veto/sessions/${sessionId}/result/dispute`,
+      { reason }
+    );
+    return response.data;
+  } catch (error) {
+    throw handleApiError(error);
+  }
+}
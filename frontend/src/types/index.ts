@@ -44,6 +44,7 @@ export interface Room {
   mapPoolId?: number;
   vetoType?: 'bo1' | 'bo3' | 'bo5'; // Тип вето
   vetoSessionId?: number;
+  currentMatch?: number; // Номер текущего матча серии
   maxParticipants: number;
   password?: string; // Пароль для приватных комнат (опционально)
   createdAt: string;